@@ -0,0 +1,482 @@
+// Package app builds the service graph from the configuration and the repositories
+// of a backend, runs its background workers and reports the health of its dependencies.
+//
+//	repos, err := app.OpenBackend(cfg.Storage)
+//	a, err := app.New(ctx, cfg, repos, logger)
+//	err = a.Run(ctx) // until ctx is done, then shuts down gracefully
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/cache"
+	"github.com/Mx1q/ppo_services/config"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/telemetry"
+	"os"
+	"sync"
+	"time"
+)
+
+// Services are the services of the app with all decorators applied. Optional
+// services are nil when the backend has no repositories for them.
+type Services struct {
+	Users             domain.IUserService
+	Keywords          domain.IKeywordValidatorService
+	Salads            domain.ISaladService
+	SaladInteractor   domain.ISaladInteractor
+	Recipes           domain.IRecipeService
+	RecipeSteps       domain.IRecipeStepService
+	StepInteractor    domain.IRecipeStepInteractor
+	RecipeIngredients domain.IRecipeIngredientService
+	RecipeAggregates  domain.IRecipeAggregateService
+	Ingredients       domain.IIngredientService
+	IngredientTypes   domain.IIngredientTypeService
+	Measurements      domain.IMeasurementService
+	SaladTypes        domain.ISaladTypeService
+	Comments          domain.ICommentService
+	Details           domain.ISaladDetailsService
+	Exports           domain.IRecipeExportService
+	Diets             domain.IDietService
+	Moderation        domain.IModerationService
+	Ratings           domain.IRatingService
+	Catalog           domain.ICatalogService
+	Crypto            services.IHashCrypto
+	Events            domain.IEventBus
+
+	// Auth also needs a JWT key in the configuration
+	Auth          domain.IAuthService
+	Audit         domain.IAuditService
+	Substitutions domain.ISubstitutionService
+	Translations  domain.ITranslationService
+	Media         domain.IMediaService
+	Webhooks      domain.IWebhookService
+}
+
+type lifecycle int
+
+const (
+	created lifecycle = iota
+	started
+	stopped
+)
+
+// App owns the repositories it was built on, Close releases them
+type App struct {
+	Services
+
+	config  *config.Config
+	logger  logger.ILogger
+	repos   *Repositories
+	workers []*worker
+	checks  []healthCheck
+	metrics *telemetry.Registry
+
+	mu     sync.Mutex
+	state  lifecycle
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New builds the services over repos as cfg configures them
+func New(ctx context.Context, cfg *config.Config, repos *Repositories, logger logger.ILogger) (*App, error) {
+	if err := repos.verify(); err != nil {
+		return nil, err
+	}
+
+	a := &App{
+		config: cfg,
+		logger: logger,
+		repos:  repos,
+	}
+	if err := a.build(ctx); err != nil {
+		a.Events.Close()
+		return nil, err
+	}
+	a.addChecks()
+	return a, nil
+}
+
+// Metrics returns the registry of call metrics, nil unless telemetry is enabled
+func (a *App) Metrics() *telemetry.Registry {
+	return a.metrics
+}
+
+// build wires repositories, wrapped with cache and telemetry, to services, then
+// wraps the services, innermost first, with media cleanup, audit, events,
+// localization and telemetry
+func (a *App) build(ctx context.Context) error {
+	cfg, logger := a.config, a.logger
+
+	var tel *telemetry.Telemetry
+	if cfg.Telemetry.Enabled {
+		var exporter telemetry.SpanExporter
+		if cfg.Telemetry.Traces {
+			exporter = telemetry.NewStdoutExporter(os.Stderr)
+		}
+		tracer := telemetry.NewTracer(exporter).OnError(func(err error) {
+			logger.Errorf("exporting span error: %s", err.Error())
+		})
+		a.metrics = telemetry.NewRegistry()
+		tel = telemetry.New(a.metrics, tracer)
+	}
+	repos := decorateRepositories(a.repos, cfg.Cache, tel)
+
+	a.Events = domain.NewEventBus(domain.EventBusConfig{
+		Mode:    domain.AsyncDelivery,
+		Workers: cfg.Workers.EventWorkers,
+		OnError: func(event domain.IEvent, err error) {
+			logger.Errorf("handling event %s error: %s", event.EventName(), err.Error())
+		},
+	})
+	var publisher domain.IEventPublisher = a.Events
+	if repos.Outbox != nil {
+		publisher = services.NewOutboxPublisher(repos.Outbox)
+	}
+
+	keywords, err := services.NewKeywordValidatorService(ctx, repos.Keywords, logger)
+	if err != nil {
+		return fmt.Errorf("building keyword service: %w", err)
+	}
+	users := services.NewUserService(repos.Users, logger, cfg.Pages)
+	salads := services.NewSaladService(repos.Salads, logger, cfg.Rating, cfg.Pages)
+	recipes := services.NewRecipeService(repos.Recipes, logger, cfg.Rating, cfg.Pages)
+	recipeSteps := services.NewRecipeStepService(repos.RecipeSteps, repos.TxManager, logger)
+	recipeIngredients := services.NewRecipeIngredientService(repos.RecipeIngredients, repos.TxManager, logger)
+	ingredients := services.NewIngredientService(repos.Ingredients, logger, cfg.Pages)
+	ingredientTypes := services.NewIngredientTypeService(repos.IngredientTypes, repos.TxManager, logger)
+	measurements := services.NewMeasurementService(repos.Measurements, logger)
+	comments := services.NewCommentService(repos.Comments, logger, cfg.Rating, cfg.Pages)
+	a.Diets = services.NewDietService(repos.Recipes, repos.Ingredients, repos.IngredientTypes, repos.SaladTypes, logger)
+	saladTypes := services.NewDietCheckedSaladTypeService(
+		services.NewSaladTypeService(repos.SaladTypes, repos.TxManager, logger, cfg.Pages), a.Diets, logger)
+	validators := []domain.IValidatorService{keywords, services.NewUrlValidatorService(logger)}
+	aggregates := services.NewRecipeAggregateService(
+		repos.TxManager,
+		repos.Salads,
+		repos.Recipes,
+		repos.RecipeSteps,
+		repos.RecipeIngredients,
+		repos.SaladTypes,
+		validators,
+		logger,
+	)
+
+	a.Crypto = services.NewHashCryptoWithCost(cfg.Auth.BcryptCost)
+	if repos.Auth != nil && cfg.Auth.JWTKey != "" {
+		a.Auth = services.NewAuthService(repos.Auth, logger, a.Crypto, cfg.Auth)
+	}
+
+	if repos.Audit != nil {
+		a.Audit = services.NewAuditService(repos.Audit, logger)
+	}
+
+	if repos.Media != nil {
+		a.Media = services.NewMediaService(
+			repos.Media, repos.Salads, repos.RecipeSteps, repos.Blobs, repos.TxManager, domain.MediaLimits{}, logger)
+		if a.Audit != nil {
+			a.Media = services.NewAuditedMediaService(a.Media, repos.TxManager, a.Audit)
+		}
+		salads = services.NewMediaCleanupSaladService(salads, repos.Recipes, repos.RecipeSteps, a.Media, logger)
+		recipeSteps = services.NewMediaCleanupRecipeStepService(recipeSteps, a.Media)
+		recipes = services.NewMediaCleanupRecipeService(recipes, repos.RecipeSteps, a.Media, logger)
+		aggregates = services.NewMediaCleanupRecipeAggregateService(aggregates, repos.Recipes, repos.RecipeSteps, a.Media, logger)
+	}
+
+	if a.Audit != nil {
+		users = services.NewAuditedUserService(users, repos.TxManager, a.Audit)
+		keywords = services.NewAuditedKeywordValidatorService(keywords, repos.TxManager, a.Audit)
+		salads = services.NewAuditedSaladService(salads, repos.TxManager, a.Audit)
+		recipes = services.NewAuditedRecipeService(recipes, repos.TxManager, a.Audit)
+		recipeSteps = services.NewAuditedRecipeStepService(recipeSteps, repos.TxManager, a.Audit)
+		recipeIngredients = services.NewAuditedRecipeIngredientService(recipeIngredients, repos.TxManager, a.Audit)
+		ingredients = services.NewAuditedIngredientService(ingredients, repos.TxManager, a.Audit)
+		ingredientTypes = services.NewAuditedIngredientTypeService(ingredientTypes, repos.TxManager, a.Audit)
+		measurements = services.NewAuditedMeasurementService(measurements, repos.TxManager, a.Audit)
+		saladTypes = services.NewAuditedSaladTypeService(saladTypes, repos.TxManager, a.Audit)
+		comments = services.NewAuditedCommentService(comments, repos.TxManager, a.Audit)
+		aggregates = services.NewAuditedRecipeAggregateService(aggregates, repos.TxManager, a.Audit)
+		if a.Auth != nil {
+			a.Auth = services.NewAuditedAuthService(a.Auth, repos.TxManager, a.Audit)
+		}
+	}
+
+	keywords = services.NewEventedKeywordValidatorService(keywords, repos.TxManager, publisher)
+	salads = services.NewEventedSaladService(salads, repos.TxManager, publisher)
+	recipes = services.NewEventedRecipeService(recipes, repos.TxManager, publisher)
+	comments = services.NewEventedCommentService(comments, repos.TxManager, publisher)
+	aggregates = services.NewEventedRecipeAggregateService(aggregates, repos.Recipes, repos.TxManager, publisher)
+
+	if repos.Translations != nil {
+		a.Translations = services.NewTranslationService(repos.Translations, repos.TxManager, logger)
+		if a.Audit != nil {
+			a.Translations = services.NewAuditedTranslationService(a.Translations, repos.TxManager, a.Audit)
+		}
+		salads = services.NewLocalizedSaladService(salads, a.Translations)
+		ingredients = services.NewLocalizedIngredientService(ingredients, a.Translations)
+		ingredientTypes = services.NewLocalizedIngredientTypeService(ingredientTypes, a.Translations)
+		measurements = services.NewLocalizedMeasurementService(measurements, a.Translations)
+		saladTypes = services.NewLocalizedSaladTypeService(saladTypes, a.Translations)
+	}
+
+	a.Users = users
+	a.Keywords = keywords
+	a.Salads = salads
+	a.SaladInteractor = services.NewSaladInteractor(salads, validators)
+	a.Recipes = recipes
+	a.RecipeSteps = recipeSteps
+	a.StepInteractor = services.NewRecipeStepInteractor(recipeSteps, validators)
+	a.RecipeIngredients = recipeIngredients
+	a.RecipeAggregates = aggregates
+	a.Ingredients = ingredients
+	a.IngredientTypes = ingredientTypes
+	a.Measurements = measurements
+	a.SaladTypes = saladTypes
+	a.Comments = comments
+	a.Details = services.NewSaladDetailsService(
+		salads,
+		users,
+		recipes,
+		recipeSteps,
+		ingredients,
+		recipeIngredients,
+		measurements,
+		saladTypes,
+		comments,
+		logger,
+	)
+	a.Exports = services.NewRecipeExportService(a.Details, logger, cfg.Rating)
+	a.Moderation = services.NewModerationService(recipes, logger)
+	a.Ratings = services.NewRatingService(recipes, comments, logger)
+	a.Catalog = services.NewCatalogService(ingredientTypes, ingredients, measurements, saladTypes, keywords, logger)
+	if a.Audit != nil {
+		a.Moderation = services.NewAuditedModerationService(a.Moderation, repos.TxManager, a.Audit)
+		a.Ratings = services.NewAuditedRatingService(a.Ratings, repos.TxManager, a.Audit)
+		a.Catalog = services.NewAuditedCatalogService(a.Catalog, a.Audit)
+	}
+
+	if repos.Substitutions != nil {
+		substitutions := services.NewSubstitutionService(
+			repos.Substitutions, repos.Ingredients, repos.RecipeIngredients, a.Details, repos.TxManager, logger)
+		if a.Audit != nil {
+			substitutions = services.NewAuditedSubstitutionService(substitutions, repos.TxManager, a.Audit)
+		}
+		a.Substitutions = substitutions
+	}
+
+	if repos.Outbox != nil {
+		relay := services.NewOutboxRelay(repos.Outbox, a.Events, logger)
+		a.addWorker("outbox-relay", time.Duration(cfg.Workers.OutboxInterval), func(ctx context.Context) error {
+			// drain the outbox, a full batch means more entries may be pending
+			for {
+				relayed, err := relay.Relay(ctx, cfg.Workers.OutboxBatch)
+				if err != nil || relayed < cfg.Workers.OutboxBatch {
+					return err
+				}
+			}
+		})
+	}
+
+	if repos.WebhookSubscriptions != nil {
+		a.Webhooks = services.NewWebhookService(
+			repos.WebhookSubscriptions,
+			repos.WebhookDeliveries,
+			repos.WebhookDeadLetters,
+			nil,
+			services.DefaultWebhookConfig,
+			logger,
+		)
+		if a.Audit != nil {
+			a.Webhooks = services.NewAuditedWebhookService(a.Webhooks, repos.TxManager, a.Audit)
+		}
+		a.Events.Subscribe(domain.AllEvents, a.Webhooks.HandleEvent)
+		a.addWorker("webhook-delivery", time.Duration(cfg.Workers.WebhookInterval), func(ctx context.Context) error {
+			_, err := a.Webhooks.DeliverDue(ctx, cfg.Workers.WebhookBatch)
+			return err
+		})
+	}
+
+	if tel != nil {
+		a.instrument(tel)
+	}
+	return nil
+}
+
+// decorateRepositories returns a copy of repos with the reference data repositories
+// cached and every repository instrumented, as configured. The cache sits over the
+// instrumented repositories, so their metrics count the calls reaching the storage.
+func decorateRepositories(repos *Repositories, cacheConfig config.CacheConfig, tel *telemetry.Telemetry) *Repositories {
+	decorated := *repos
+	if tel != nil {
+		decorated.Users = telemetry.NewInstrumentedUserRepository(decorated.Users, tel)
+		decorated.Salads = telemetry.NewInstrumentedSaladRepository(decorated.Salads, tel)
+		decorated.Recipes = telemetry.NewInstrumentedRecipeRepository(decorated.Recipes, tel)
+		decorated.RecipeSteps = telemetry.NewInstrumentedRecipeStepRepository(decorated.RecipeSteps, tel)
+		decorated.RecipeIngredients = telemetry.NewInstrumentedRecipeIngredientRepository(decorated.RecipeIngredients, tel)
+		decorated.Ingredients = telemetry.NewInstrumentedIngredientRepository(decorated.Ingredients, tel)
+		decorated.IngredientTypes = telemetry.NewInstrumentedIngredientTypeRepository(decorated.IngredientTypes, tel)
+		decorated.Measurements = telemetry.NewInstrumentedMeasurementRepository(decorated.Measurements, tel)
+		decorated.SaladTypes = telemetry.NewInstrumentedSaladTypeRepository(decorated.SaladTypes, tel)
+		decorated.Comments = telemetry.NewInstrumentedCommentRepository(decorated.Comments, tel)
+		decorated.Keywords = telemetry.NewInstrumentedKeywordValidatorRepository(decorated.Keywords, tel)
+		if decorated.Auth != nil {
+			decorated.Auth = telemetry.NewInstrumentedAuthRepository(decorated.Auth, tel)
+		}
+		if decorated.Audit != nil {
+			decorated.Audit = telemetry.NewInstrumentedAuditRepository(decorated.Audit, tel)
+		}
+		if decorated.Substitutions != nil {
+			decorated.Substitutions = telemetry.NewInstrumentedSubstitutionRepository(decorated.Substitutions, tel)
+		}
+		if decorated.Translations != nil {
+			decorated.Translations = telemetry.NewInstrumentedTranslationRepository(decorated.Translations, tel)
+		}
+		if decorated.Media != nil {
+			decorated.Media = telemetry.NewInstrumentedMediaRepository(decorated.Media, tel)
+		}
+		if decorated.WebhookSubscriptions != nil {
+			decorated.WebhookSubscriptions = telemetry.NewInstrumentedWebhookSubscriptionRepository(decorated.WebhookSubscriptions, tel)
+			decorated.WebhookDeliveries = telemetry.NewInstrumentedWebhookDeliveryRepository(decorated.WebhookDeliveries, tel)
+			decorated.WebhookDeadLetters = telemetry.NewInstrumentedWebhookDeadLetterRepository(decorated.WebhookDeadLetters, tel)
+		}
+	}
+
+	if cacheConfig.Enabled {
+		options := cache.Options{Size: cacheConfig.Size, TTL: time.Duration(cacheConfig.TTL)}
+		ingredients := cache.NewCachedIngredientRepository(decorated.Ingredients, options)
+		decorated.Ingredients = ingredients
+		decorated.RecipeIngredients = cache.NewCachedRecipeIngredientRepository(decorated.RecipeIngredients, ingredients)
+		decorated.IngredientTypes = cache.NewCachedIngredientTypeRepository(decorated.IngredientTypes, ingredients, options)
+		decorated.Measurements = cache.NewCachedMeasurementRepository(decorated.Measurements, options)
+		decorated.SaladTypes = cache.NewCachedSaladTypeRepository(decorated.SaladTypes, options)
+	}
+	return &decorated
+}
+
+// instrument wraps every built service, the outermost decorator
+func (a *App) instrument(tel *telemetry.Telemetry) {
+	a.Users = telemetry.NewInstrumentedUserService(a.Users, tel)
+	a.Keywords = telemetry.NewInstrumentedKeywordValidatorService(a.Keywords, tel)
+	a.Salads = telemetry.NewInstrumentedSaladService(a.Salads, tel)
+	a.Recipes = telemetry.NewInstrumentedRecipeService(a.Recipes, tel)
+	a.RecipeSteps = telemetry.NewInstrumentedRecipeStepService(a.RecipeSteps, tel)
+	a.RecipeIngredients = telemetry.NewInstrumentedRecipeIngredientService(a.RecipeIngredients, tel)
+	a.RecipeAggregates = telemetry.NewInstrumentedRecipeAggregateService(a.RecipeAggregates, tel)
+	a.Ingredients = telemetry.NewInstrumentedIngredientService(a.Ingredients, tel)
+	a.IngredientTypes = telemetry.NewInstrumentedIngredientTypeService(a.IngredientTypes, tel)
+	a.Measurements = telemetry.NewInstrumentedMeasurementService(a.Measurements, tel)
+	a.SaladTypes = telemetry.NewInstrumentedSaladTypeService(a.SaladTypes, tel)
+	a.Comments = telemetry.NewInstrumentedCommentService(a.Comments, tel)
+	a.Details = telemetry.NewInstrumentedSaladDetailsService(a.Details, tel)
+	a.Exports = telemetry.NewInstrumentedRecipeExportService(a.Exports, tel)
+	a.Diets = telemetry.NewInstrumentedDietService(a.Diets, tel)
+	a.Moderation = telemetry.NewInstrumentedModerationService(a.Moderation, tel)
+	a.Ratings = telemetry.NewInstrumentedRatingService(a.Ratings, tel)
+	a.Catalog = telemetry.NewInstrumentedCatalogService(a.Catalog, tel)
+	if a.Auth != nil {
+		a.Auth = telemetry.NewInstrumentedAuthService(a.Auth, tel)
+	}
+	if a.Audit != nil {
+		a.Audit = telemetry.NewInstrumentedAuditService(a.Audit, tel)
+	}
+	if a.Substitutions != nil {
+		a.Substitutions = telemetry.NewInstrumentedSubstitutionService(a.Substitutions, tel)
+	}
+	if a.Translations != nil {
+		a.Translations = telemetry.NewInstrumentedTranslationService(a.Translations, tel)
+	}
+	if a.Media != nil {
+		a.Media = telemetry.NewInstrumentedMediaService(a.Media, tel)
+	}
+	if a.Webhooks != nil {
+		a.Webhooks = telemetry.NewInstrumentedWebhookService(a.Webhooks, tel)
+	}
+}
+
+func (a *App) addWorker(name string, interval time.Duration, run func(ctx context.Context) error) {
+	if interval <= 0 {
+		a.logger.Infof("worker %s is disabled", name)
+		return
+	}
+	a.workers = append(a.workers, &worker{name: name, interval: interval, run: run, logger: a.logger})
+}
+
+// Start runs the background workers until Stop, ctx only carries values to them
+func (a *App) Start(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.state != created {
+		return fmt.Errorf("app is already started")
+	}
+
+	ctx, a.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	for _, w := range a.workers {
+		a.wg.Add(1)
+		go func(w *worker) {
+			defer a.wg.Done()
+			w.loop(ctx)
+		}(w)
+	}
+	a.state = started
+	a.logger.Infof("app started with %d workers", len(a.workers))
+	return nil
+}
+
+// Stop stops the workers and delivers queued events. It waits for them until ctx
+// is done and then returns its error, leaving the rest to finish in the background.
+func (a *App) Stop(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.state == stopped {
+		return nil
+	}
+	if a.cancel != nil {
+		a.cancel()
+	}
+	a.state = stopped
+
+	done := make(chan struct{})
+	go func() {
+		a.wg.Wait()
+		a.Events.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		a.logger.Infof("app stopped")
+		return nil
+	case <-ctx.Done():
+		a.logger.Warnf("app stop: %s", ctx.Err().Error())
+		return fmt.Errorf("stopping app: %w", ctx.Err())
+	}
+}
+
+// Close releases the repositories, with save the backend keeps the changes.
+// Call it after Stop.
+func (a *App) Close(save bool) error {
+	if a.repos.Close == nil {
+		return nil
+	}
+	if err := a.repos.Close(save); err != nil {
+		return fmt.Errorf("closing backend: %w", err)
+	}
+	return nil
+}
+
+// Run starts the app and shuts it down when ctx is done, waiting for running work
+// up to the shutdown timeout of the configuration. Cancel ctx on SIGINT and SIGTERM,
+// for example with signal.NotifyContext, for a graceful shutdown.
+func (a *App) Run(ctx context.Context) error {
+	if err := a.Start(ctx); err != nil {
+		return err
+	}
+	<-ctx.Done()
+
+	stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Duration(a.config.Workers.ShutdownTimeout))
+	defer cancel()
+	return errors.Join(a.Stop(stopCtx), a.Close(true))
+}
@@ -0,0 +1,208 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/blobstore"
+	"github.com/Mx1q/ppo_services/config"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/memrepo"
+	"github.com/Mx1q/ppo_services/sqliterepo"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Built-in backends: file keeps the memory store in the JSON file of the DSN,
+// memory loses it on exit, sqlite keeps every repository in the database file of the DSN
+const (
+	BackendFile   = "file"
+	BackendMemory = "memory"
+	BackendSQLite = "sqlite"
+)
+
+// Repositories are the repositories of a backend. The core ones up to TxManager are
+// required, services over a nil optional repository are not built.
+type Repositories struct {
+	Users             domain.IUserRepository
+	Salads            domain.ISaladRepository
+	Recipes           domain.IRecipeRepository
+	RecipeSteps       domain.IRecipeStepRepository
+	RecipeIngredients domain.IRecipeIngredientRepository
+	Ingredients       domain.IIngredientRepository
+	IngredientTypes   domain.IIngredientTypeRepository
+	Measurements      domain.IMeasurementRepository
+	SaladTypes        domain.ISaladTypeRepository
+	Comments          domain.ICommentRepository
+	Keywords          domain.IKeywordValidatorRepository
+	TxManager         domain.ITransactionManager
+
+	Auth                 domain.IAuthRepository
+	Audit                domain.IAuditRepository
+	Substitutions        domain.ISubstitutionRepository
+	Translations         domain.ITranslationRepository
+	Media                domain.IMediaRepository
+	Blobs                domain.IBlobStorage
+	Outbox               domain.IEventOutbox
+	WebhookSubscriptions domain.IWebhookSubscriptionRepository
+	WebhookDeliveries    domain.IWebhookDeliveryRepository
+	WebhookDeadLetters   domain.IWebhookDeadLetterRepository
+
+	// Ping checks that the backend is reachable, nil for backends which always are
+	Ping func(ctx context.Context) error
+	// Close releases the backend, with save it keeps the changes, nil if there is nothing to release
+	Close func(save bool) error
+}
+
+func (r *Repositories) verify() error {
+	if r.Users == nil || r.Salads == nil || r.Recipes == nil || r.RecipeSteps == nil ||
+		r.RecipeIngredients == nil || r.Ingredients == nil || r.IngredientTypes == nil ||
+		r.Measurements == nil || r.SaladTypes == nil || r.Comments == nil || r.Keywords == nil ||
+		r.TxManager == nil {
+		return fmt.Errorf("backend misses core repositories")
+	}
+	if (r.Media == nil) != (r.Blobs == nil) {
+		return fmt.Errorf("media repository and blob storage go together")
+	}
+	if (r.WebhookSubscriptions == nil) != (r.WebhookDeliveries == nil) ||
+		(r.WebhookSubscriptions == nil) != (r.WebhookDeadLetters == nil) {
+		return fmt.Errorf("webhook repositories go together")
+	}
+	return nil
+}
+
+// Backend opens the repositories of a storage
+type Backend func(storage config.StorageConfig) (*Repositories, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]Backend{
+		BackendFile:   openFileBackend,
+		BackendMemory: openMemoryBackend,
+		BackendSQLite: openSQLiteBackend,
+	}
+)
+
+// RegisterBackend makes a backend available to OpenBackend under name, usually from
+// the init of the package implementing it
+func RegisterBackend(name string, backend Backend) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[name] = backend
+}
+
+// Backends returns the names of registered backends
+func Backends() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OpenBackend opens the backend named by storage.Backend
+func OpenBackend(storage config.StorageConfig) (*Repositories, error) {
+	backendsMu.RLock()
+	backend, ok := backends[storage.Backend]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown backend %s, registered: %v", storage.Backend, Backends())
+	}
+
+	repos, err := backend(storage)
+	if err != nil {
+		return nil, fmt.Errorf("opening backend %s: %w", storage.Backend, err)
+	}
+	return repos, nil
+}
+
+func memoryRepositories(store *memrepo.Store) *Repositories {
+	return &Repositories{
+		Users:             memrepo.NewUserRepository(store),
+		Salads:            memrepo.NewSaladRepository(store),
+		Recipes:           memrepo.NewRecipeRepository(store),
+		RecipeSteps:       memrepo.NewRecipeStepRepository(store),
+		RecipeIngredients: memrepo.NewRecipeIngredientRepository(store),
+		Ingredients:       memrepo.NewIngredientRepository(store),
+		IngredientTypes:   memrepo.NewIngredientTypeRepository(store),
+		Measurements:      memrepo.NewMeasurementRepository(store),
+		SaladTypes:        memrepo.NewSaladTypeRepository(store),
+		Comments:          memrepo.NewCommentRepository(store),
+		Keywords:          memrepo.NewKeywordRepository(store),
+		TxManager:         memrepo.NewTransactionManager(store),
+		Auth:              memrepo.NewAuthRepository(store),
+	}
+}
+
+func openMemoryBackend(storage config.StorageConfig) (*Repositories, error) {
+	return memoryRepositories(memrepo.NewStore()), nil
+}
+
+func openFileBackend(storage config.StorageConfig) (*Repositories, error) {
+	if storage.DSN == "" {
+		return nil, fmt.Errorf("empty file name")
+	}
+	store, err := memrepo.OpenStore(storage.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := memoryRepositories(store)
+	repos.Ping = func(ctx context.Context) error {
+		// the store is saved next to the file, so its directory must exist
+		_, err := os.Stat(filepath.Dir(storage.DSN))
+		return err
+	}
+	repos.Close = func(save bool) error {
+		if !save {
+			return nil
+		}
+		return store.Save(storage.DSN)
+	}
+	return repos, nil
+}
+
+func openSQLiteBackend(storage config.StorageConfig) (*Repositories, error) {
+	db, err := sqliterepo.Open(storage.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := &Repositories{
+		Users:                sqliterepo.NewUserRepository(db),
+		Salads:               sqliterepo.NewSaladRepository(db),
+		Recipes:              sqliterepo.NewRecipeRepository(db),
+		RecipeSteps:          sqliterepo.NewRecipeStepRepository(db),
+		RecipeIngredients:    sqliterepo.NewRecipeIngredientRepository(db),
+		Ingredients:          sqliterepo.NewIngredientRepository(db),
+		IngredientTypes:      sqliterepo.NewIngredientTypeRepository(db),
+		Measurements:         sqliterepo.NewMeasurementRepository(db),
+		SaladTypes:           sqliterepo.NewSaladTypeRepository(db),
+		Comments:             sqliterepo.NewCommentRepository(db),
+		Keywords:             sqliterepo.NewKeywordRepository(db),
+		TxManager:            sqliterepo.NewTransactionManager(db),
+		Auth:                 sqliterepo.NewAuthRepository(db),
+		Audit:                sqliterepo.NewAuditRepository(db),
+		Substitutions:        sqliterepo.NewSubstitutionRepository(db),
+		Translations:         sqliterepo.NewTranslationRepository(db),
+		Outbox:               sqliterepo.NewEventOutbox(db),
+		WebhookSubscriptions: sqliterepo.NewWebhookSubscriptionRepository(db),
+		WebhookDeliveries:    sqliterepo.NewWebhookDeliveryRepository(db),
+		WebhookDeadLetters:   sqliterepo.NewWebhookDeadLetterRepository(db),
+		Ping:                 db.Ping,
+		Close: func(save bool) error {
+			// changes are committed as they are made
+			return db.Close()
+		},
+	}
+	if storage.MediaDir != "" {
+		repos.Media = sqliterepo.NewMediaRepository(db)
+		repos.Blobs = blobstore.NewLocalBlobStorage(storage.MediaDir)
+	}
+	return repos, nil
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// HealthStatus is the result of checking one dependency, Err is nil when it is healthy
+type HealthStatus struct {
+	Name string
+	Err  error
+}
+
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// addChecks registers a check for the storage, every optional dependency the app
+// has and every worker, which is healthy while its last run succeeded
+func (a *App) addChecks() {
+	a.checks = append(a.checks, healthCheck{"storage", func(ctx context.Context) error {
+		if a.repos.Ping == nil {
+			return nil
+		}
+		return a.repos.Ping(ctx)
+	}})
+
+	if a.repos.Outbox != nil {
+		a.checks = append(a.checks, healthCheck{"outbox", func(ctx context.Context) error {
+			_, err := a.repos.Outbox.GetPending(ctx, 1)
+			return err
+		}})
+	}
+	if a.repos.WebhookSubscriptions != nil {
+		a.checks = append(a.checks, healthCheck{"webhooks", func(ctx context.Context) error {
+			_, err := a.repos.WebhookSubscriptions.GetAll(ctx)
+			return err
+		}})
+	}
+
+	for _, w := range a.workers {
+		a.checks = append(a.checks, healthCheck{"worker " + w.name, w.check})
+	}
+}
+
+// Health runs every check, in a fixed order
+func (a *App) Health(ctx context.Context) []HealthStatus {
+	statuses := make([]HealthStatus, 0, len(a.checks))
+	for _, c := range a.checks {
+		statuses = append(statuses, HealthStatus{Name: c.name, Err: c.check(ctx)})
+	}
+	return statuses
+}
+
+// Healthy joins the errors of failed checks, nil means every dependency is healthy
+func (a *App) Healthy(ctx context.Context) error {
+	var errs []error
+	for _, status := range a.Health(ctx) {
+		if status.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", status.Name, status.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"github.com/Mx1q/ppo_services/logger"
+	"sync"
+	"time"
+)
+
+// worker runs a job every interval and keeps the result of the last run for health checks
+type worker struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+	logger   logger.ILogger
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+func (w *worker) loop(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.runOnce(ctx)
+		}
+	}
+}
+
+func (w *worker) runOnce(ctx context.Context) {
+	err := w.run(ctx)
+	if err != nil && errors.Is(err, context.Canceled) && ctx.Err() != nil {
+		// interrupted by Stop, not a failure
+		return
+	}
+	if err != nil {
+		w.logger.Errorf("worker %s error: %s", w.name, err.Error())
+	}
+
+	w.mu.Lock()
+	w.lastErr = err
+	w.mu.Unlock()
+}
+
+func (w *worker) check(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.lastErr
+}
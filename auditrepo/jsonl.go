@@ -0,0 +1,92 @@
+package auditrepo
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"os"
+	"sync"
+)
+
+// FileAuditRepository appends records to a JSON Lines file, one record per line.
+// Existing lines are never rewritten, queries scan the whole file.
+type FileAuditRepository struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileAuditRepository(path string) domain.IAuditRepository {
+	return &FileAuditRepository{path: path}
+}
+
+func (r *FileAuditRepository) Create(ctx context.Context, record *domain.AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encoding audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err = file.Write(line); err != nil {
+		return fmt.Errorf("writing audit record: %w", err)
+	}
+	return file.Sync()
+}
+
+func (r *FileAuditRepository) GetByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*domain.AuditRecord, error) {
+	return r.filter(func(record *domain.AuditRecord) bool {
+		return record.EntityType == entityType && record.EntityID == entityId
+	})
+}
+
+func (r *FileAuditRepository) GetByActor(ctx context.Context, actorId uuid.UUID) ([]*domain.AuditRecord, error) {
+	return r.filter(func(record *domain.AuditRecord) bool {
+		return record.ActorID == actorId
+	})
+}
+
+func (r *FileAuditRepository) filter(match func(record *domain.AuditRecord) bool) ([]*domain.AuditRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	records := make([]*domain.AuditRecord, 0)
+	file, err := os.Open(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := new(domain.AuditRecord)
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, fmt.Errorf("decoding audit record at line %d: %w", lineNum, err)
+		}
+		if match(record) {
+			records = append(records, record)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading audit log: %w", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,50 @@
+package auditrepo
+
+import (
+	"context"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"sync"
+)
+
+type MemoryAuditRepository struct {
+	mu      sync.RWMutex
+	records []*domain.AuditRecord
+}
+
+func NewMemoryAuditRepository() domain.IAuditRepository {
+	return &MemoryAuditRepository{}
+}
+
+func (r *MemoryAuditRepository) Create(ctx context.Context, record *domain.AuditRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records = append(r.records, record)
+	return nil
+}
+
+func (r *MemoryAuditRepository) GetByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*domain.AuditRecord, error) {
+	return r.filter(func(record *domain.AuditRecord) bool {
+		return record.EntityType == entityType && record.EntityID == entityId
+	}), nil
+}
+
+func (r *MemoryAuditRepository) GetByActor(ctx context.Context, actorId uuid.UUID) ([]*domain.AuditRecord, error) {
+	return r.filter(func(record *domain.AuditRecord) bool {
+		return record.ActorID == actorId
+	}), nil
+}
+
+func (r *MemoryAuditRepository) filter(match func(record *domain.AuditRecord) bool) []*domain.AuditRecord {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := make([]*domain.AuditRecord, 0)
+	for _, record := range r.records {
+		if match(record) {
+			records = append(records, record)
+		}
+	}
+	return records
+}
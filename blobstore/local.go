@@ -0,0 +1,90 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalBlobStorage keeps each blob in a file under the root directory. Keys are
+// relative slash separated paths, writes go to a temporary file renamed in place.
+type LocalBlobStorage struct {
+	root string
+}
+
+func NewLocalBlobStorage(root string) domain.IBlobStorage {
+	return &LocalBlobStorage{root: root}
+}
+
+func (s *LocalBlobStorage) path(key string) (string, error) {
+	if key == "" || strings.Contains(key, "\\") || filepath.IsAbs(key) || !filepath.IsLocal(key) {
+		return "", fmt.Errorf("%w: %q", domain.ErrInvalidBlobKey, key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+func (s *LocalBlobStorage) Put(ctx context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("creating blob directory: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".blob-*")
+	if err != nil {
+		return fmt.Errorf("creating blob: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("writing blob: %w", err)
+	}
+
+	if err = os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("writing blob: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalBlobStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrBlobNotFound, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading blob: %w", err)
+	}
+	return data, nil
+}
+
+func (s *LocalBlobStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("deleting blob: %w", err)
+	}
+	return nil
+}
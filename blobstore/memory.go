@@ -0,0 +1,48 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"sync"
+)
+
+type MemoryBlobStorage struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+func NewMemoryBlobStorage() domain.IBlobStorage {
+	return &MemoryBlobStorage{blobs: make(map[string][]byte)}
+}
+
+func (s *MemoryBlobStorage) Put(ctx context.Context, key string, data []byte) error {
+	if key == "" {
+		return fmt.Errorf("%w: %q", domain.ErrInvalidBlobKey, key)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.blobs[key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (s *MemoryBlobStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrBlobNotFound, key)
+	}
+	return append([]byte(nil), data...), nil
+}
+
+func (s *MemoryBlobStorage) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.blobs, key)
+	return nil
+}
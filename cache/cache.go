@@ -0,0 +1,232 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var errLoadPanicked = errors.New("cache: load panicked")
+
+type Options struct {
+	// Size bounds the number of entries, the least recently used one is evicted first
+	Size int
+	// TTL is the lifetime of an entry, zero means entries don't expire
+	TTL time.Duration
+}
+
+var DefaultOptions = Options{
+	Size: 1024,
+	TTL:  5 * time.Minute,
+}
+
+type Stats struct {
+	Hits        uint64
+	Misses      uint64
+	Evictions   uint64
+	Expirations uint64
+	Size        int
+}
+
+func (s Stats) Add(other Stats) Stats {
+	return Stats{
+		Hits:        s.Hits + other.Hits,
+		Misses:      s.Misses + other.Misses,
+		Evictions:   s.Evictions + other.Evictions,
+		Expirations: s.Expirations + other.Expirations,
+		Size:        s.Size + other.Size,
+	}
+}
+
+func (s Stats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// IStats is implemented by the cached repositories
+type IStats interface {
+	Stats() Stats
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+type flight[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Cache is a size-bounded LRU cache with TTL. Concurrent GetOrLoad calls of one
+// missing key share a single load.
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	options Options
+	order   *list.List
+	entries map[K]*list.Element
+	flights map[K]*flight[V]
+	// generation changes on every invalidation, so loads started before it aren't stored
+	generation uint64
+	stats      Stats
+	now        func() time.Time
+}
+
+func New[K comparable, V any](options Options) *Cache[K, V] {
+	if options.Size <= 0 {
+		options.Size = DefaultOptions.Size
+	}
+	return &Cache[K, V]{
+		options: options,
+		order:   list.New(),
+		entries: make(map[K]*list.Element),
+		flights: make(map[K]*flight[V]),
+		now:     time.Now,
+	}
+}
+
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value, ok := c.get(key)
+	if ok {
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
+	}
+	return value, ok
+}
+
+func (c *Cache[K, V]) get(key K) (V, bool) {
+	var zero V
+	element, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+
+	e := element.Value.(*entry[K, V])
+	if !e.expiresAt.IsZero() && !c.now().Before(e.expiresAt) {
+		c.remove(element)
+		c.stats.Expirations++
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return e.value, true
+}
+
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(key, value)
+}
+
+func (c *Cache[K, V]) set(key K, value V) {
+	var expiresAt time.Time
+	if c.options.TTL > 0 {
+		expiresAt = c.now().Add(c.options.TTL)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		e := element.Value.(*entry[K, V])
+		e.value = value
+		e.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.options.Size {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *Cache[K, V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*entry[K, V]).key)
+}
+
+// GetOrLoad returns the cached value or calls load once for all concurrent callers
+// of the key. Errors are not cached.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, load func(ctx context.Context) (V, error)) (V, error) {
+	c.mu.Lock()
+	if value, ok := c.get(key); ok {
+		c.stats.Hits++
+		c.mu.Unlock()
+		return value, nil
+	}
+	c.stats.Misses++
+
+	if f, ok := c.flights[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.value, f.err
+		case <-ctx.Done():
+			var zero V
+			return zero, ctx.Err()
+		}
+	}
+
+	f := &flight[V]{done: make(chan struct{})}
+	c.flights[key] = f
+	generation := c.generation
+	c.mu.Unlock()
+
+	completed := false
+	defer func() {
+		c.mu.Lock()
+		if c.flights[key] == f {
+			delete(c.flights, key)
+		}
+		if !completed {
+			f.err = errLoadPanicked
+		} else if f.err == nil && generation == c.generation {
+			c.set(key, f.value)
+		}
+		c.mu.Unlock()
+		close(f.done)
+	}()
+
+	f.value, f.err = load(ctx)
+	completed = true
+	return f.value, f.err
+}
+
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	delete(c.flights, key)
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.flights = make(map[K]*flight[V])
+	c.order.Init()
+	c.entries = make(map[K]*list.Element)
+}
+
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Size = c.order.Len()
+	return stats
+}
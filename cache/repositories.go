@@ -0,0 +1,382 @@
+package cache
+
+import (
+	"context"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+// Cached*Repository decorators serve reads of reference data from memory and drop the
+// affected entries on writes. Entities are copied in and out of the cache, so callers
+// modifying a returned entity don't change the cached one. Reads inside a transaction
+// bypass the cache, so uncommitted data never gets there.
+
+func inTransaction(ctx context.Context) bool {
+	_, ok := domain.TransactionFromContext(ctx)
+	return ok
+}
+
+// invalidateAfterWrite drops entries right after a write and once more when its
+// transaction ends, as reads outside the transaction may load old rows meanwhile
+func invalidateAfterWrite(ctx context.Context, invalidate func()) {
+	invalidate()
+	if inTransaction(ctx) {
+		domain.AfterTransaction(ctx, invalidate)
+	}
+}
+
+func cloneOne[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	switch entity := any(&copied).(type) {
+	case *domain.Ingredient:
+		entity.Contents = append([]string(nil), entity.Contents...)
+	case *domain.IngredientType:
+		entity.Contents = append([]string(nil), entity.Contents...)
+	}
+	return &copied
+}
+
+func cloneAll[T any](values []*T) []*T {
+	if values == nil {
+		return nil
+	}
+	copied := make([]*T, len(values))
+	for i, value := range values {
+		copied[i] = cloneOne(value)
+	}
+	return copied
+}
+
+type page[T any] struct {
+	items []*T
+	info  *domain.PageInfo
+}
+
+func pageKey(request *domain.PageRequest) domain.PageRequest {
+	if request == nil {
+		return domain.PageRequest{}
+	}
+	return *request
+}
+
+type CachedMeasurementRepository struct {
+	domain.IMeasurementRepository
+	byId *Cache[uuid.UUID, *domain.Measurement]
+	all  *Cache[struct{}, []*domain.Measurement]
+}
+
+func NewCachedMeasurementRepository(measurementRepo domain.IMeasurementRepository, options Options) domain.IMeasurementRepository {
+	return &CachedMeasurementRepository{
+		IMeasurementRepository: measurementRepo,
+		byId:                   New[uuid.UUID, *domain.Measurement](options),
+		all:                    New[struct{}, []*domain.Measurement](options),
+	}
+}
+
+func (r *CachedMeasurementRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Measurement, error) {
+	if inTransaction(ctx) {
+		return r.IMeasurementRepository.GetById(ctx, id)
+	}
+	measurement, err := r.byId.GetOrLoad(ctx, id, func(ctx context.Context) (*domain.Measurement, error) {
+		measurement, err := r.IMeasurementRepository.GetById(ctx, id)
+		return cloneOne(measurement), err
+	})
+	return cloneOne(measurement), err
+}
+
+func (r *CachedMeasurementRepository) GetAll(ctx context.Context) ([]*domain.Measurement, error) {
+	if inTransaction(ctx) {
+		return r.IMeasurementRepository.GetAll(ctx)
+	}
+	measurements, err := r.all.GetOrLoad(ctx, struct{}{}, func(ctx context.Context) ([]*domain.Measurement, error) {
+		measurements, err := r.IMeasurementRepository.GetAll(ctx)
+		return cloneAll(measurements), err
+	})
+	return cloneAll(measurements), err
+}
+
+func (r *CachedMeasurementRepository) Create(ctx context.Context, measurement *domain.Measurement) error {
+	defer invalidateAfterWrite(ctx, r.all.Purge)
+	return r.IMeasurementRepository.Create(ctx, measurement)
+}
+
+func (r *CachedMeasurementRepository) Update(ctx context.Context, measurement *domain.Measurement) error {
+	defer invalidateAfterWrite(ctx, func() { r.invalidate(measurement.ID) })
+	return r.IMeasurementRepository.Update(ctx, measurement)
+}
+
+func (r *CachedMeasurementRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	defer invalidateAfterWrite(ctx, func() { r.invalidate(id) })
+	return r.IMeasurementRepository.DeleteById(ctx, id)
+}
+
+func (r *CachedMeasurementRepository) invalidate(id uuid.UUID) {
+	r.byId.Delete(id)
+	r.all.Purge()
+}
+
+func (r *CachedMeasurementRepository) Stats() Stats {
+	return r.byId.Stats().Add(r.all.Stats())
+}
+
+type CachedIngredientTypeRepository struct {
+	domain.IIngredientTypeRepository
+	byId *Cache[uuid.UUID, *domain.IngredientType]
+	all  *Cache[struct{}, []*domain.IngredientType]
+	// ingredients lose the type on its deletion, so their entries are dropped as well
+	ingredients *CachedIngredientRepository
+}
+
+func NewCachedIngredientTypeRepository(ingredientTypeRepo domain.IIngredientTypeRepository, ingredients *CachedIngredientRepository, options Options) domain.IIngredientTypeRepository {
+	return &CachedIngredientTypeRepository{
+		IIngredientTypeRepository: ingredientTypeRepo,
+		byId:                      New[uuid.UUID, *domain.IngredientType](options),
+		all:                       New[struct{}, []*domain.IngredientType](options),
+		ingredients:               ingredients,
+	}
+}
+
+func (r *CachedIngredientTypeRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.IngredientType, error) {
+	if inTransaction(ctx) {
+		return r.IIngredientTypeRepository.GetById(ctx, id)
+	}
+	ingredientType, err := r.byId.GetOrLoad(ctx, id, func(ctx context.Context) (*domain.IngredientType, error) {
+		ingredientType, err := r.IIngredientTypeRepository.GetById(ctx, id)
+		return cloneOne(ingredientType), err
+	})
+	return cloneOne(ingredientType), err
+}
+
+func (r *CachedIngredientTypeRepository) GetAll(ctx context.Context) ([]*domain.IngredientType, error) {
+	if inTransaction(ctx) {
+		return r.IIngredientTypeRepository.GetAll(ctx)
+	}
+	ingredientTypes, err := r.all.GetOrLoad(ctx, struct{}{}, func(ctx context.Context) ([]*domain.IngredientType, error) {
+		ingredientTypes, err := r.IIngredientTypeRepository.GetAll(ctx)
+		return cloneAll(ingredientTypes), err
+	})
+	return cloneAll(ingredientTypes), err
+}
+
+func (r *CachedIngredientTypeRepository) Create(ctx context.Context, ingredientType *domain.IngredientType) error {
+	defer invalidateAfterWrite(ctx, r.all.Purge)
+	return r.IIngredientTypeRepository.Create(ctx, ingredientType)
+}
+
+func (r *CachedIngredientTypeRepository) Update(ctx context.Context, ingredientType *domain.IngredientType) error {
+	defer invalidateAfterWrite(ctx, func() { r.invalidate(ingredientType.ID) })
+	return r.IIngredientTypeRepository.Update(ctx, ingredientType)
+}
+
+func (r *CachedIngredientTypeRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	defer invalidateAfterWrite(ctx, func() {
+		r.invalidate(id)
+		r.ingredients.purge()
+	})
+	return r.IIngredientTypeRepository.DeleteById(ctx, id)
+}
+
+func (r *CachedIngredientTypeRepository) invalidate(id uuid.UUID) {
+	r.byId.Delete(id)
+	r.all.Purge()
+}
+
+func (r *CachedIngredientTypeRepository) Stats() Stats {
+	return r.byId.Stats().Add(r.all.Stats())
+}
+
+type CachedSaladTypeRepository struct {
+	domain.ISaladTypeRepository
+	byId  *Cache[uuid.UUID, *domain.SaladType]
+	pages *Cache[domain.PageRequest, page[domain.SaladType]]
+}
+
+func NewCachedSaladTypeRepository(saladTypeRepo domain.ISaladTypeRepository, options Options) domain.ISaladTypeRepository {
+	return &CachedSaladTypeRepository{
+		ISaladTypeRepository: saladTypeRepo,
+		byId:                 New[uuid.UUID, *domain.SaladType](options),
+		pages:                New[domain.PageRequest, page[domain.SaladType]](options),
+	}
+}
+
+func (r *CachedSaladTypeRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.SaladType, error) {
+	if inTransaction(ctx) {
+		return r.ISaladTypeRepository.GetById(ctx, id)
+	}
+	saladType, err := r.byId.GetOrLoad(ctx, id, func(ctx context.Context) (*domain.SaladType, error) {
+		saladType, err := r.ISaladTypeRepository.GetById(ctx, id)
+		return cloneOne(saladType), err
+	})
+	return cloneOne(saladType), err
+}
+
+func (r *CachedSaladTypeRepository) GetAll(ctx context.Context, pageReq *domain.PageRequest) ([]*domain.SaladType, *domain.PageInfo, error) {
+	if inTransaction(ctx) {
+		return r.ISaladTypeRepository.GetAll(ctx, pageReq)
+	}
+	loaded, err := r.pages.GetOrLoad(ctx, pageKey(pageReq), func(ctx context.Context) (page[domain.SaladType], error) {
+		saladTypes, info, err := r.ISaladTypeRepository.GetAll(ctx, pageReq)
+		return page[domain.SaladType]{items: cloneAll(saladTypes), info: info}, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return cloneAll(loaded.items), cloneOne(loaded.info), nil
+}
+
+func (r *CachedSaladTypeRepository) Create(ctx context.Context, saladType *domain.SaladType) error {
+	defer invalidateAfterWrite(ctx, r.pages.Purge)
+	return r.ISaladTypeRepository.Create(ctx, saladType)
+}
+
+func (r *CachedSaladTypeRepository) Update(ctx context.Context, saladType *domain.SaladType) error {
+	defer invalidateAfterWrite(ctx, func() { r.invalidate(saladType.ID) })
+	return r.ISaladTypeRepository.Update(ctx, saladType)
+}
+
+func (r *CachedSaladTypeRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	defer invalidateAfterWrite(ctx, func() { r.invalidate(id) })
+	return r.ISaladTypeRepository.DeleteById(ctx, id)
+}
+
+func (r *CachedSaladTypeRepository) invalidate(id uuid.UUID) {
+	r.byId.Delete(id)
+	r.pages.Purge()
+}
+
+func (r *CachedSaladTypeRepository) Stats() Stats {
+	return r.byId.Stats().Add(r.pages.Stats())
+}
+
+type CachedIngredientRepository struct {
+	domain.IIngredientRepository
+	byId     *Cache[uuid.UUID, *domain.Ingredient]
+	pages    *Cache[domain.PageRequest, page[domain.Ingredient]]
+	byRecipe *Cache[uuid.UUID, []*domain.Ingredient]
+}
+
+func NewCachedIngredientRepository(ingredientRepo domain.IIngredientRepository, options Options) *CachedIngredientRepository {
+	return &CachedIngredientRepository{
+		IIngredientRepository: ingredientRepo,
+		byId:                  New[uuid.UUID, *domain.Ingredient](options),
+		pages:                 New[domain.PageRequest, page[domain.Ingredient]](options),
+		byRecipe:              New[uuid.UUID, []*domain.Ingredient](options),
+	}
+}
+
+func (r *CachedIngredientRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Ingredient, error) {
+	if inTransaction(ctx) {
+		return r.IIngredientRepository.GetById(ctx, id)
+	}
+	ingredient, err := r.byId.GetOrLoad(ctx, id, func(ctx context.Context) (*domain.Ingredient, error) {
+		ingredient, err := r.IIngredientRepository.GetById(ctx, id)
+		return cloneOne(ingredient), err
+	})
+	return cloneOne(ingredient), err
+}
+
+func (r *CachedIngredientRepository) GetAll(ctx context.Context, pageReq *domain.PageRequest) ([]*domain.Ingredient, *domain.PageInfo, error) {
+	if inTransaction(ctx) {
+		return r.IIngredientRepository.GetAll(ctx, pageReq)
+	}
+	loaded, err := r.pages.GetOrLoad(ctx, pageKey(pageReq), func(ctx context.Context) (page[domain.Ingredient], error) {
+		ingredients, info, err := r.IIngredientRepository.GetAll(ctx, pageReq)
+		return page[domain.Ingredient]{items: cloneAll(ingredients), info: info}, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return cloneAll(loaded.items), cloneOne(loaded.info), nil
+}
+
+func (r *CachedIngredientRepository) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.Ingredient, error) {
+	if inTransaction(ctx) {
+		return r.IIngredientRepository.GetAllByRecipeId(ctx, recipeId)
+	}
+	ingredients, err := r.byRecipe.GetOrLoad(ctx, recipeId, func(ctx context.Context) ([]*domain.Ingredient, error) {
+		ingredients, err := r.IIngredientRepository.GetAllByRecipeId(ctx, recipeId)
+		return cloneAll(ingredients), err
+	})
+	return cloneAll(ingredients), err
+}
+
+func (r *CachedIngredientRepository) Link(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) (uuid.UUID, error) {
+	defer invalidateAfterWrite(ctx, func() { r.byRecipe.Delete(recipeId) })
+	return r.IIngredientRepository.Link(ctx, recipeId, ingredientId)
+}
+
+func (r *CachedIngredientRepository) Unlink(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) error {
+	defer invalidateAfterWrite(ctx, func() { r.byRecipe.Delete(recipeId) })
+	return r.IIngredientRepository.Unlink(ctx, recipeId, ingredientId)
+}
+
+func (r *CachedIngredientRepository) Create(ctx context.Context, ingredient *domain.Ingredient) error {
+	defer invalidateAfterWrite(ctx, r.pages.Purge)
+	return r.IIngredientRepository.Create(ctx, ingredient)
+}
+
+func (r *CachedIngredientRepository) Update(ctx context.Context, ingredient *domain.Ingredient) error {
+	defer invalidateAfterWrite(ctx, func() { r.invalidate(ingredient.ID) })
+	return r.IIngredientRepository.Update(ctx, ingredient)
+}
+
+func (r *CachedIngredientRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	defer invalidateAfterWrite(ctx, func() { r.invalidate(id) })
+	return r.IIngredientRepository.DeleteById(ctx, id)
+}
+
+// invalidate drops recipe lists too, as any of them may contain the ingredient
+func (r *CachedIngredientRepository) invalidate(id uuid.UUID) {
+	r.byId.Delete(id)
+	r.pages.Purge()
+	r.byRecipe.Purge()
+}
+
+func (r *CachedIngredientRepository) purge() {
+	r.byId.Purge()
+	r.pages.Purge()
+	r.byRecipe.Purge()
+}
+
+func (r *CachedIngredientRepository) Stats() Stats {
+	return r.byId.Stats().Add(r.pages.Stats()).Add(r.byRecipe.Stats())
+}
+
+// CachedRecipeIngredientRepository doesn't cache anything, it drops ingredient lists of
+// recipes cached by the ingredient repository when recipe ingredients change
+type CachedRecipeIngredientRepository struct {
+	domain.IRecipeIngredientRepository
+	ingredients *CachedIngredientRepository
+}
+
+func NewCachedRecipeIngredientRepository(recipeIngredientRepo domain.IRecipeIngredientRepository, ingredients *CachedIngredientRepository) domain.IRecipeIngredientRepository {
+	return &CachedRecipeIngredientRepository{
+		IRecipeIngredientRepository: recipeIngredientRepo,
+		ingredients:                 ingredients,
+	}
+}
+
+func (r *CachedRecipeIngredientRepository) Create(ctx context.Context, recipeIngredient *domain.RecipeIngredient) (uuid.UUID, error) {
+	defer invalidateAfterWrite(ctx, func() { r.ingredients.byRecipe.Delete(recipeIngredient.RecipeID) })
+	return r.IRecipeIngredientRepository.Create(ctx, recipeIngredient)
+}
+
+// Update and DeleteById may move a row to or from any recipe, so all lists are dropped
+
+func (r *CachedRecipeIngredientRepository) Update(ctx context.Context, recipeIngredient *domain.RecipeIngredient) error {
+	defer invalidateAfterWrite(ctx, r.ingredients.byRecipe.Purge)
+	return r.IRecipeIngredientRepository.Update(ctx, recipeIngredient)
+}
+
+func (r *CachedRecipeIngredientRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	defer invalidateAfterWrite(ctx, r.ingredients.byRecipe.Purge)
+	return r.IRecipeIngredientRepository.DeleteById(ctx, id)
+}
+
+func (r *CachedRecipeIngredientRepository) DeleteAllByRecipeId(ctx context.Context, recipeId uuid.UUID) error {
+	defer invalidateAfterWrite(ctx, func() { r.ingredients.byRecipe.Delete(recipeId) })
+	return r.IRecipeIngredientRepository.DeleteAllByRecipeId(ctx, recipeId)
+}
@@ -0,0 +1,141 @@
+// Command catalog imports and exports reference catalogues of a store as CSV or JSON.
+//
+//	catalog [flags] import <catalog> [file]
+//	catalog [flags] export <catalog> [file]
+//
+// Catalogues are ingredient-types, ingredients, measurements, salad-types and keywords.
+// Without a file rows are read from stdin or written to stdout.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/Mx1q/ppo_services/app"
+	"github.com/Mx1q/ppo_services/config"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	storePath := flag.String("store", "catalog.json", "file of the store")
+	format := flag.String("format", "", "csv or json, by default the extension of the file or csv")
+	dryRun := flag.Bool("dry-run", false, "validate rows without saving them")
+	upsert := flag.Bool("upsert", false, "update entities with names of imported rows")
+	logLevel := flag.String("log-level", logger.LoggerWarnLevel, "level of logs written to stderr")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] import|export <catalog> [file]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() < 2 || flag.NArg() > 3 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	command, catalog, file := flag.Arg(0), flag.Arg(1), flag.Arg(2)
+	if *format == "" {
+		*format = formatOf(file)
+	}
+
+	if command != "import" && command != "export" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg := config.Default()
+	cfg.Storage = config.StorageConfig{Backend: app.BackendFile, DSN: *storePath}
+	repos, err := app.OpenBackend(cfg.Storage)
+	if err != nil {
+		fail(err)
+	}
+	ctx := context.Background()
+	a, err := app.New(ctx, cfg, repos, logger.NewLogger(*logLevel, os.Stderr))
+	if err != nil {
+		fail(err)
+	}
+
+	var rejected int
+	save := false
+	if command == "import" {
+		options := &domain.CatalogImportOptions{Format: *format, DryRun: *dryRun, Upsert: *upsert}
+		rejected, err = importCatalog(ctx, a.Catalog, catalog, file, options)
+		save = err == nil && !*dryRun
+	} else {
+		err = exportCatalog(ctx, a.Catalog, catalog, file, *format)
+	}
+	if stopErr := a.Stop(ctx); err == nil {
+		err = stopErr
+	}
+	if closeErr := a.Close(save); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fail(err)
+	}
+	if rejected > 0 {
+		os.Exit(1)
+	}
+}
+
+// importCatalog prints the report and returns the number of rejected rows,
+// they fail the command after the other rows are saved
+func importCatalog(ctx context.Context, catalogService domain.ICatalogService, catalog string, file string, options *domain.CatalogImportOptions) (int, error) {
+	var r io.Reader = os.Stdin
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	report, err := catalogService.Import(ctx, catalog, r, options)
+	if err != nil {
+		return 0, err
+	}
+
+	mode := ""
+	if options.DryRun {
+		mode = " (dry run)"
+	}
+	fmt.Printf("%s%s: %d created, %d updated, %d unchanged, %d rejected\n",
+		report.Catalog, mode, report.Created, report.Updated, report.Unchanged, len(report.Errors))
+	for _, rowErr := range report.Errors {
+		fmt.Println(rowErr.Error())
+	}
+	return len(report.Errors), nil
+}
+
+func exportCatalog(ctx context.Context, catalogService domain.ICatalogService, catalog string, file string, format string) error {
+	if file == "" {
+		return catalogService.Export(ctx, catalog, os.Stdout, format)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	err = catalogService.Export(ctx, catalog, f, format)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func formatOf(file string) string {
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		return domain.CatalogFormatJSON
+	}
+	return domain.CatalogFormatCSV
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err.Error())
+	os.Exit(1)
+}
@@ -0,0 +1,393 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"net/mail"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// userView leaves the password hash out of the output
+type userView struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	Role     string    `json:"role"`
+}
+
+func viewUser(user *domain.User) *userView {
+	return &userView{ID: user.ID, Username: user.Username, Name: user.Name, Email: user.Email.Address, Role: user.Role}
+}
+
+type pageView[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int    `json:"total"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+func newPageView[T any](items []T, info *domain.PageInfo) *pageView[T] {
+	view := &pageView[T]{Items: items}
+	if info != nil {
+		view.Total, view.NextCursor = info.Total, info.NextCursor
+	}
+	return view
+}
+
+// printPage writes the table of a page followed by the cursor of the next one
+func printPage[T any](c *cli, page *pageView[T], header []string, rows [][]string) error {
+	if err := c.out.print(page, header, rows); err != nil {
+		return err
+	}
+	if c.out.format == outputTable && page.NextCursor != "" {
+		return c.out.message("next page: -cursor %s", page.NextCursor)
+	}
+	return nil
+}
+
+func parseIds(args []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(args))
+	for _, arg := range args {
+		id, err := uuid.Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %s", arg)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func createUser(ctx context.Context, c *cli, args []string) (bool, error) {
+	fs := newFlagSet("user create")
+	user := &domain.User{}
+	var email string
+	fs.StringVar(&user.Username, "username", "", "login of the user")
+	fs.StringVar(&user.Name, "name", "", "display name")
+	fs.StringVar(&email, "email", "", "email address")
+	fs.StringVar(&user.Password, "password", "", "password, stored hashed")
+	fs.StringVar(&user.Role, "role", domain.DefaultRole, "role of the user")
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return false, err
+	}
+
+	user.Email = mail.Address{Address: email}
+	if user.Password != "" {
+		hashed, err := c.crypto.GenerateHashPass(user.Password)
+		if err != nil {
+			return false, err
+		}
+		user.Password = hashed
+	}
+
+	if err := c.users.Create(ctx, user); err != nil {
+		return false, err
+	}
+	return true, c.out.print(viewUser(user), []string{"ID", "USERNAME", "ROLE"},
+		[][]string{{user.ID.String(), user.Username, user.Role}})
+}
+
+func assignRole(ctx context.Context, c *cli, args []string) (bool, error) {
+	rest, err := parseFlags(newFlagSet("user role"), args, 2, 2)
+	if err != nil {
+		return false, err
+	}
+	if err = domain.VerifyRole(rest[1]); err != nil {
+		return false, err
+	}
+
+	user, err := c.users.GetByUsername(ctx, rest[0])
+	if err != nil {
+		return false, err
+	}
+	if user.Role == rest[1] {
+		return false, c.out.message("user %s already has role %s", user.Username, user.Role)
+	}
+	user.Role = rest[1]
+	if err = c.users.Update(ctx, user); err != nil {
+		return false, err
+	}
+	return true, c.out.message("user %s has role %s", user.Username, user.Role)
+}
+
+func listUsers(ctx context.Context, c *cli, args []string) (bool, error) {
+	fs := newFlagSet("user list")
+	page := &domain.PageRequest{}
+	fs.StringVar(&page.Sort, "sort", "", "sort field, name or username")
+	fs.IntVar(&page.Size, "size", 0, "page size")
+	fs.StringVar(&page.Cursor, "cursor", "", "cursor of the page")
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return false, err
+	}
+
+	users, info, err := c.users.GetAll(ctx, page)
+	if err != nil {
+		return false, err
+	}
+	views := make([]*userView, 0, len(users))
+	rows := make([][]string, 0, len(users))
+	for _, user := range users {
+		views = append(views, viewUser(user))
+		rows = append(rows, []string{user.ID.String(), user.Username, user.Name, user.Email.Address, user.Role})
+	}
+	return false, printPage(c, newPageView(views, info), []string{"ID", "USERNAME", "NAME", "EMAIL", "ROLE"}, rows)
+}
+
+// addKeywords stores words in lower case, the validator looks them up that way
+func addKeywords(ctx context.Context, c *cli, args []string) (bool, error) {
+	words, err := parseFlags(newFlagSet("keyword add"), args, 1, -1)
+	if err != nil {
+		return false, err
+	}
+
+	known, err := c.keywords.GetAll(ctx)
+	if err != nil {
+		return false, err
+	}
+	added := 0
+	for _, word := range words {
+		keyword := &domain.KeyWord{Word: strings.ToLower(word)}
+		if _, ok := known[keyword.Word]; ok {
+			continue
+		}
+		if err = c.keywords.Create(ctx, keyword); err != nil {
+			return added > 0, err
+		}
+		known[keyword.Word] = keyword.ID
+		added++
+	}
+	return added > 0, c.out.message("%d keywords added", added)
+}
+
+func removeKeywords(ctx context.Context, c *cli, args []string) (bool, error) {
+	words, err := parseFlags(newFlagSet("keyword remove"), args, 1, -1)
+	if err != nil {
+		return false, err
+	}
+
+	known, err := c.keywords.GetAll(ctx)
+	if err != nil {
+		return false, err
+	}
+	removed := 0
+	for _, word := range words {
+		id, ok := known[strings.ToLower(word)]
+		if !ok {
+			return removed > 0, fmt.Errorf("keyword %s not found", word)
+		}
+		if err = c.keywords.DeleteById(ctx, id); err != nil {
+			return removed > 0, err
+		}
+		removed++
+	}
+	return removed > 0, c.out.message("%d keywords removed", removed)
+}
+
+func listKeywords(ctx context.Context, c *cli, args []string) (bool, error) {
+	if _, err := parseFlags(newFlagSet("keyword list"), args, 0, 0); err != nil {
+		return false, err
+	}
+
+	known, err := c.keywords.GetAll(ctx)
+	if err != nil {
+		return false, err
+	}
+	words := make([]string, 0, len(known))
+	for word := range known {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+
+	rows := make([][]string, 0, len(words))
+	for _, word := range words {
+		rows = append(rows, []string{word, known[word].String()})
+	}
+	return false, c.out.print(words, []string{"WORD", "ID"}, rows)
+}
+
+func moderationQueue(ctx context.Context, c *cli, args []string) (bool, error) {
+	fs := newFlagSet("moderation queue")
+	page := &domain.PageRequest{}
+	fs.IntVar(&page.Size, "size", 0, "page size")
+	fs.StringVar(&page.Cursor, "cursor", "", "cursor of the page")
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return false, err
+	}
+
+	recipes, info, err := c.moderation.GetQueue(ctx, page)
+	if err != nil {
+		return false, err
+	}
+	rows := make([][]string, 0, len(recipes))
+	for _, recipe := range recipes {
+		rows = append(rows, []string{
+			recipe.ID.String(),
+			recipe.SaladID.String(),
+			recipe.CreatedAt.Format(time.DateTime),
+			strconv.Itoa(recipe.NumberOfServings),
+			strconv.Itoa(recipe.TimeToCook),
+		})
+	}
+	return false, printPage(c, newPageView(recipes, info), []string{"RECIPE", "SALAD", "CREATED", "SERVINGS", "MINUTES"}, rows)
+}
+
+func approveRecipes(ctx context.Context, c *cli, args []string) (bool, error) {
+	return moderate(ctx, c, "moderation approve", args, c.moderation.Approve, "approved")
+}
+
+func rejectRecipes(ctx context.Context, c *cli, args []string) (bool, error) {
+	return moderate(ctx, c, "moderation reject", args, c.moderation.Reject, "rejected")
+}
+
+func moderate(ctx context.Context, c *cli, name string, args []string, decide func(ctx context.Context, id uuid.UUID) error, decision string) (bool, error) {
+	rest, err := parseFlags(newFlagSet(name), args, 1, -1)
+	if err != nil {
+		return false, err
+	}
+	ids, err := parseIds(rest)
+	if err != nil {
+		return false, err
+	}
+
+	for i, id := range ids {
+		if err = decide(ctx, id); err != nil {
+			return i > 0, fmt.Errorf("recipe %s: %w", id.String(), err)
+		}
+	}
+	return true, c.out.message("%d recipes %s", len(ids), decision)
+}
+
+func recomputeRatings(ctx context.Context, c *cli, args []string) (bool, error) {
+	rest, err := parseFlags(newFlagSet("rating recompute"), args, 0, -1)
+	if err != nil {
+		return false, err
+	}
+	ids, err := parseIds(rest)
+	if err != nil {
+		return false, err
+	}
+
+	if len(ids) == 0 {
+		changed, err := c.ratings.RecomputeAll(ctx)
+		if err != nil {
+			return changed > 0, err
+		}
+		return changed > 0, c.out.message("%d ratings changed", changed)
+	}
+
+	ratings := make(map[string]float32, len(ids))
+	rows := make([][]string, 0, len(ids))
+	for i, id := range ids {
+		rating, err := c.ratings.Recompute(ctx, id)
+		if err != nil {
+			return i > 0, fmt.Errorf("salad %s: %w", id.String(), err)
+		}
+		ratings[id.String()] = rating
+		rows = append(rows, []string{id.String(), strconv.FormatFloat(float64(rating), 'f', 2, 32)})
+	}
+	return true, c.out.print(ratings, []string{"SALAD", "RATING"}, rows)
+}
+
+type saladView struct {
+	*domain.Salad
+	Status int     `json:"status,omitempty"`
+	Rating float32 `json:"rating,omitempty"`
+}
+
+func listSalads(ctx context.Context, c *cli, args []string) (bool, error) {
+	fs := newFlagSet("salad list")
+	filter := &domain.RecipeFilter{}
+	page := &domain.PageRequest{}
+	var author string
+	fs.IntVar(&filter.Status, "status", 0, "recipe status, 0 for any")
+	fs.StringVar(&author, "author", "", "id of the author")
+	fs.StringVar(&page.Sort, "sort", "", "sort field")
+	fs.BoolVar(&page.Desc, "desc", false, "descending order")
+	fs.IntVar(&page.Size, "size", 0, "page size")
+	fs.StringVar(&page.Cursor, "cursor", "", "cursor of the page")
+	if _, err := parseFlags(fs, args, 0, 0); err != nil {
+		return false, err
+	}
+	if author != "" {
+		ids, err := parseIds([]string{author})
+		if err != nil {
+			return false, err
+		}
+		filter.AuthorID = ids[0]
+	}
+
+	salads, info, err := c.salads.GetAll(ctx, filter, page)
+	if err != nil {
+		return false, err
+	}
+	views := make([]*saladView, 0, len(salads))
+	rows := make([][]string, 0, len(salads))
+	for _, salad := range salads {
+		view := &saladView{Salad: salad}
+		if recipe, err := c.recipes.GetBySaladId(ctx, salad.ID); err == nil {
+			view.Status, view.Rating = recipe.Status, recipe.Rating
+		}
+		views = append(views, view)
+		rows = append(rows, []string{
+			salad.ID.String(),
+			salad.Name,
+			salad.AuthorID.String(),
+			strconv.Itoa(view.Status),
+			strconv.FormatFloat(float64(view.Rating), 'f', 2, 32),
+		})
+	}
+	return false, printPage(c, newPageView(views, info), []string{"ID", "NAME", "AUTHOR", "STATUS", "RATING"}, rows)
+}
+
+func showSalad(ctx context.Context, c *cli, args []string) (bool, error) {
+	rest, err := parseFlags(newFlagSet("salad show"), args, 1, 1)
+	if err != nil {
+		return false, err
+	}
+	ids, err := parseIds(rest)
+	if err != nil {
+		return false, err
+	}
+
+	details, err := c.details.GetById(ctx, ids[0], &domain.PageRequest{})
+	if err != nil {
+		return false, err
+	}
+	if c.out.format == outputJSON {
+		// Author of the view hides the one of details with its password hash
+		return false, c.out.print(struct {
+			*domain.SaladDetails
+			Author *userView
+		}{SaladDetails: details, Author: viewUser(details.Author)}, nil, nil)
+	}
+
+	rows := [][]string{
+		{"id", details.Salad.ID.String()},
+		{"name", details.Salad.Name},
+		{"description", details.Salad.Description},
+		{"author", details.Author.Username},
+		{"status", strconv.Itoa(details.Recipe.Status)},
+		{"rating", strconv.FormatFloat(float64(details.Recipe.Rating), 'f', 2, 32)},
+		{"servings", strconv.Itoa(details.Recipe.NumberOfServings)},
+		{"minutes", strconv.Itoa(details.Recipe.TimeToCook)},
+		{"comments", strconv.Itoa(details.CommentsPage.Total)},
+	}
+	for _, saladType := range details.Types {
+		rows = append(rows, []string{"type", saladType.Name})
+	}
+	for _, item := range details.Ingredients {
+		amount := ""
+		if item.Measurement != nil {
+			amount = domain.FormatAmount(item.Amount) + " " + item.Measurement.Name
+		}
+		rows = append(rows, []string{"ingredient", fmt.Sprintf("%s %s", item.Ingredient.Name, amount)})
+	}
+	for _, step := range details.Steps {
+		rows = append(rows, []string{"step", fmt.Sprintf("%d. %s", step.StepNum, step.Name)})
+	}
+	return false, c.out.print(details, []string{"FIELD", "VALUE"}, rows)
+}
@@ -0,0 +1,200 @@
+// Command saladctl operates the service layer: users and roles, the keyword blacklist,
+// moderation of recipes, ratings and inspection of salads.
+//
+//	saladctl [flags] <group> <command> [arguments]
+//
+// Run saladctl without arguments to list the commands.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/Mx1q/ppo_services/app"
+	"github.com/Mx1q/ppo_services/config"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/Mx1q/ppo_services/services"
+	"os"
+	"sort"
+	"strings"
+)
+
+// command runs with the arguments after its name and reports whether it changed data
+type command struct {
+	usage string
+	run   func(ctx context.Context, c *cli, args []string) (bool, error)
+}
+
+var commands = map[string]map[string]command{
+	"user": {
+		"create": {"-username NAME -name NAME -email EMAIL -password PASSWORD [-role ROLE]", createUser},
+		"role":   {"USERNAME ROLE", assignRole},
+		"list":   {"[-sort name|username] [-size N] [-cursor CURSOR]", listUsers},
+	},
+	"keyword": {
+		"add":    {"WORD...", addKeywords},
+		"remove": {"WORD...", removeKeywords},
+		"list":   {"", listKeywords},
+	},
+	"moderation": {
+		"queue":   {"[-size N] [-cursor CURSOR]", moderationQueue},
+		"approve": {"RECIPE_ID...", approveRecipes},
+		"reject":  {"RECIPE_ID...", rejectRecipes},
+	},
+	"rating": {
+		"recompute": {"[SALAD_ID...]", recomputeRatings},
+	},
+	"salad": {
+		"list": {"[-status N] [-author USER_ID] [-sort FIELD] [-desc] [-size N] [-cursor CURSOR]", listSalads},
+		"show": {"SALAD_ID", showSalad},
+	},
+}
+
+// cli holds the services commands use
+type cli struct {
+	users      domain.IUserService
+	keywords   domain.IKeywordValidatorService
+	moderation domain.IModerationService
+	ratings    domain.IRatingService
+	salads     domain.ISaladService
+	recipes    domain.IRecipeService
+	details    domain.ISaladDetailsService
+	crypto     services.IHashCrypto
+	out        *printer
+}
+
+func main() {
+	configPath := flag.String("config", "", "configuration file of the service, its storage, rating, page and bcrypt settings apply")
+	backend := flag.String("backend", app.BackendFile, fmt.Sprintf("repository backend, one of %v", app.Backends()))
+	dsn := flag.String("dsn", "saladctl.json", "data source of the backend, the file of the file backend")
+	output := flag.String("output", outputTable, "output format, table or json")
+	logLevel := flag.String("log-level", logger.LoggerWarnLevel, "level of logs written to stderr")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)][flag.Arg(1)]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	cfg, err := loadConfig(*configPath, *backend, *dsn, *logLevel)
+	if err != nil {
+		fail(err)
+	}
+	out, err := newPrinter(os.Stdout, *output)
+	if err != nil {
+		fail(err)
+	}
+	repos, err := app.OpenBackend(cfg.Storage)
+	if err != nil {
+		fail(err)
+	}
+
+	ctx := context.Background()
+	a, err := app.New(ctx, cfg, repos, logger.NewLogger(cfg.Log.Level, os.Stderr))
+	if err != nil {
+		fail(err)
+	}
+	changed, err := cmd.run(ctx, newCli(a, out), flag.Args()[2:])
+	if stopErr := a.Stop(ctx); err == nil {
+		err = stopErr
+	}
+	if closeErr := a.Close(changed); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+// loadConfig reads the configuration file if given, otherwise starts from the defaults
+// of saladctl. Flags set on the command line override the file.
+func loadConfig(path string, backend string, dsn string, logLevel string) (*config.Config, error) {
+	cfg := config.Default()
+	cfg.Storage = config.StorageConfig{Backend: backend, DSN: dsn}
+	cfg.Log.Level = logLevel
+	if path == "" {
+		return cfg, nil
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "backend":
+			cfg.Storage.Backend = backend
+		case "dsn":
+			cfg.Storage.DSN = dsn
+		case "log-level":
+			cfg.Log.Level = logLevel
+		}
+	})
+	return cfg, nil
+}
+
+func newCli(a *app.App, out *printer) *cli {
+	return &cli{
+		users:      a.Users,
+		keywords:   a.Keywords,
+		moderation: a.Moderation,
+		ratings:    a.Ratings,
+		salads:     a.Salads,
+		recipes:    a.Recipes,
+		details:    a.Details,
+		crypto:     a.Crypto,
+		out:        out,
+	}
+}
+
+func usage() {
+	w := flag.CommandLine.Output()
+	fmt.Fprintf(w, "usage: %s [flags] <group> <command> [arguments]\n\ncommands:\n", os.Args[0])
+
+	groups := make([]string, 0, len(commands))
+	for group := range commands {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		names := make([]string, 0, len(commands[group]))
+		for name := range commands[group] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(w, "  %s %s %s\n", group, name, commands[group][name].usage)
+		}
+	}
+	fmt.Fprintln(w, "\nflags:")
+	flag.PrintDefaults()
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err.Error())
+	os.Exit(1)
+}
+
+// parseFlags parses flags of a command, the rest arguments must number from min to max,
+// a negative max allows any number
+func parseFlags(fs *flag.FlagSet, args []string, min int, max int) ([]string, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	rest := fs.Args()
+	if len(rest) < min || (max >= 0 && len(rest) > max) {
+		return nil, fmt.Errorf("%s: wrong number of arguments", fs.Name())
+	}
+	return rest, nil
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(strings.TrimSpace(name), flag.ContinueOnError)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// printer writes results as a table or as JSON of the value itself
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	if format != outputTable && format != outputJSON {
+		return nil, fmt.Errorf("unknown output %s", format)
+	}
+	return &printer{w: w, format: format}, nil
+}
+
+// print writes value as JSON or the rows under the header as a table
+func (p *printer) print(value any, header []string, rows [][]string) error {
+	if p.format == outputJSON {
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// message writes a confirmation of a command, in JSON as {"result": message}
+func (p *printer) message(format string, args ...any) error {
+	message := fmt.Sprintf(format, args...)
+	if p.format == outputJSON {
+		return p.print(map[string]string{"result": message}, nil, nil)
+	}
+	_, err := fmt.Fprintln(p.w, message)
+	return err
+}
@@ -0,0 +1,223 @@
+// Package config holds the tunables of the service layer. Load reads them from
+// defaults, a YAML or JSON file and environment variables, in that precedence.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"golang.org/x/crypto/bcrypt"
+	"slices"
+	"time"
+)
+
+// minJWTKeyLength is the key size of HS256, shorter keys are easier to brute force
+const minJWTKeyLength = 32
+
+type Config struct {
+	Log     LogConfig     `yaml:"log" json:"log"`
+	Auth    AuthConfig    `yaml:"auth" json:"auth"`
+	Rating  RatingConfig  `yaml:"rating" json:"rating"`
+	Pages   PageConfig    `yaml:"pages" json:"pages"`
+	Storage StorageConfig `yaml:"storage" json:"storage"`
+	Workers WorkerConfig  `yaml:"workers" json:"workers"`
+	// Telemetry and Cache are off by default
+	Telemetry TelemetryConfig `yaml:"telemetry" json:"telemetry"`
+	Cache     CacheConfig     `yaml:"cache" json:"cache"`
+}
+
+type LogConfig struct {
+	// Level is one of logger.Levels
+	Level string `yaml:"level" json:"level"`
+}
+
+type AuthConfig struct {
+	JWTKey Secret `yaml:"jwtKey" json:"jwtKey"`
+	// JWTKeyFile is a file holding the key, like a mounted secret, it excludes JWTKey
+	JWTKeyFile string   `yaml:"jwtKeyFile" json:"jwtKeyFile"`
+	TokenTTL   Duration `yaml:"tokenTTL" json:"tokenTTL"`
+	BcryptCost int      `yaml:"bcryptCost" json:"bcryptCost"`
+}
+
+// RatingConfig bounds rates of comments
+type RatingConfig struct {
+	MinRate int `yaml:"minRate" json:"minRate"`
+	MaxRate int `yaml:"maxRate" json:"maxRate"`
+}
+
+// PageConfig bounds pages of lists, services take it
+type PageConfig struct {
+	DefaultSize int `yaml:"defaultSize" json:"defaultSize"`
+	MaxSize     int `yaml:"maxSize" json:"maxSize"`
+}
+
+func (c PageConfig) Sizes() domain.PageSizes {
+	return domain.PageSizes{Default: c.DefaultSize, Max: c.MaxSize}
+}
+
+// StorageConfig selects the repository backend, DSN is its data source. MediaDir
+// keeps uploaded media of backends storing it, media is disabled without it.
+type StorageConfig struct {
+	Backend  string `yaml:"backend" json:"backend"`
+	DSN      string `yaml:"dsn" json:"dsn"`
+	MediaDir string `yaml:"mediaDir" json:"mediaDir"`
+}
+
+// WorkerConfig paces the background work of the app. A worker runs every interval
+// and handles up to its batch of items, a zero interval disables it.
+type WorkerConfig struct {
+	OutboxInterval  Duration `yaml:"outboxInterval" json:"outboxInterval"`
+	OutboxBatch     int      `yaml:"outboxBatch" json:"outboxBatch"`
+	WebhookInterval Duration `yaml:"webhookInterval" json:"webhookInterval"`
+	WebhookBatch    int      `yaml:"webhookBatch" json:"webhookBatch"`
+	// EventWorkers is the number of goroutines delivering events to subscribers
+	EventWorkers int `yaml:"eventWorkers" json:"eventWorkers"`
+	// ShutdownTimeout bounds the wait for running work on shutdown
+	ShutdownTimeout Duration `yaml:"shutdownTimeout" json:"shutdownTimeout"`
+}
+
+// TelemetryConfig turns on metrics of service and repository calls, Traces
+// writes their spans to stderr as well
+type TelemetryConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	Traces  bool `yaml:"traces" json:"traces"`
+}
+
+// CacheConfig turns on caching of reference data repositories: ingredients,
+// ingredient types, measurements and salad types. A zero TTL keeps entries until evicted.
+type CacheConfig struct {
+	Enabled bool     `yaml:"enabled" json:"enabled"`
+	Size    int      `yaml:"size" json:"size"`
+	TTL     Duration `yaml:"ttl" json:"ttl"`
+}
+
+// Secret is a string kept out of formatted output
+type Secret string
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return "***"
+}
+
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+// Duration reads durations like "24h" and "15m" from text
+type Duration time.Duration
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// Default has every value but the JWT key, which must be configured
+func Default() *Config {
+	return &Config{
+		Log: LogConfig{
+			Level: logger.LoggerInfoLevel,
+		},
+		Auth: AuthConfig{
+			TokenTTL:   Duration(24 * time.Hour),
+			BcryptCost: bcrypt.DefaultCost,
+		},
+		Rating: RatingConfig{
+			MinRate: domain.MinRate,
+			MaxRate: domain.MaxRate,
+		},
+		Pages: PageConfig{
+			DefaultSize: domain.DefaultPageSize,
+			MaxSize:     domain.MaxPageSize,
+		},
+		Storage: StorageConfig{
+			Backend: "file",
+			DSN:     "salads.json",
+		},
+		Workers: WorkerConfig{
+			OutboxInterval:  Duration(time.Second),
+			OutboxBatch:     100,
+			WebhookInterval: Duration(5 * time.Second),
+			WebhookBatch:    50,
+			EventWorkers:    4,
+			ShutdownTimeout: Duration(30 * time.Second),
+		},
+		Cache: CacheConfig{
+			Size: 1024,
+			TTL:  Duration(5 * time.Minute),
+		},
+	}
+}
+
+// Validate reports every invalid value at once
+func (c *Config) Validate() error {
+	var errs []error
+	if !slices.Contains(logger.Levels, c.Log.Level) {
+		errs = append(errs, fmt.Errorf("log.level must be one of %v", logger.Levels))
+	}
+
+	if c.Auth.JWTKey == "" {
+		errs = append(errs, fmt.Errorf("auth.jwtKey or auth.jwtKeyFile is required"))
+	} else if len(c.Auth.JWTKey) < minJWTKeyLength {
+		errs = append(errs, fmt.Errorf("auth.jwtKey must have at least %d bytes", minJWTKeyLength))
+	}
+	if c.Auth.TokenTTL <= 0 {
+		errs = append(errs, fmt.Errorf("auth.tokenTTL must be positive"))
+	}
+	if c.Auth.BcryptCost < bcrypt.MinCost || c.Auth.BcryptCost > bcrypt.MaxCost {
+		errs = append(errs, fmt.Errorf("auth.bcryptCost must be from %d to %d", bcrypt.MinCost, bcrypt.MaxCost))
+	}
+
+	if c.Rating.MinRate < 0 || c.Rating.MinRate >= c.Rating.MaxRate {
+		errs = append(errs, fmt.Errorf("rating.minRate must be from 0 to below rating.maxRate"))
+	}
+
+	if c.Pages.MaxSize <= 0 {
+		errs = append(errs, fmt.Errorf("pages.maxSize must be positive"))
+	}
+	if c.Pages.DefaultSize <= 0 || c.Pages.DefaultSize > c.Pages.MaxSize {
+		errs = append(errs, fmt.Errorf("pages.defaultSize must be from 1 to pages.maxSize"))
+	}
+
+	if c.Storage.Backend == "" {
+		errs = append(errs, fmt.Errorf("storage.backend is required"))
+	}
+
+	if c.Workers.OutboxInterval < 0 || c.Workers.WebhookInterval < 0 {
+		errs = append(errs, fmt.Errorf("workers intervals must not be negative"))
+	}
+	if c.Workers.OutboxBatch <= 0 || c.Workers.WebhookBatch <= 0 {
+		errs = append(errs, fmt.Errorf("workers batches must be positive"))
+	}
+	if c.Workers.EventWorkers <= 0 {
+		errs = append(errs, fmt.Errorf("workers.eventWorkers must be positive"))
+	}
+	if c.Workers.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("workers.shutdownTimeout must be positive"))
+	}
+
+	if c.Telemetry.Traces && !c.Telemetry.Enabled {
+		errs = append(errs, fmt.Errorf("telemetry.traces needs telemetry.enabled"))
+	}
+	if c.Cache.Enabled && c.Cache.Size <= 0 {
+		errs = append(errs, fmt.Errorf("cache.size must be positive"))
+	}
+	if c.Cache.TTL < 0 {
+		errs = append(errs, fmt.Errorf("cache.ttl must not be negative"))
+	}
+	return errors.Join(errs...)
+}
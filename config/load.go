@@ -0,0 +1,162 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EnvPrefix starts the variables overriding the file, auth.jwtKey is SALAD_AUTH_JWT_KEY
+const EnvPrefix = "SALAD_"
+
+// Load reads the file over the defaults, then the environment over the file, resolves
+// secrets from files and validates the result. An empty path skips the file.
+func Load(path string) (*Config, error) {
+	return LoadWithEnv(path, os.LookupEnv)
+}
+
+// LoadWithEnv is Load with variables of lookupEnv instead of the environment
+func LoadWithEnv(path string, lookupEnv func(key string) (string, bool)) (*Config, error) {
+	cfg := Default()
+	if path != "" {
+		if err := readFile(path, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyEnv(reflect.ValueOf(cfg).Elem(), EnvPrefix, lookupEnv); err != nil {
+		return nil, err
+	}
+	if err := cfg.resolveSecrets(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return cfg, nil
+}
+
+// readFile decodes JSON files by their extension and YAML otherwise, unknown keys are errors
+func readFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(cfg)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		// an empty file keeps the defaults
+		if err = decoder.Decode(cfg); errors.Is(err, io.EOF) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("decoding config %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Config) resolveSecrets() error {
+	if c.Auth.JWTKeyFile == "" {
+		return nil
+	}
+	if c.Auth.JWTKey != "" {
+		return fmt.Errorf("auth.jwtKey and auth.jwtKeyFile are both set")
+	}
+
+	data, err := os.ReadFile(c.Auth.JWTKeyFile)
+	if err != nil {
+		return fmt.Errorf("reading auth.jwtKeyFile: %w", err)
+	}
+	c.Auth.JWTKey = Secret(strings.TrimSpace(string(data)))
+	return nil
+}
+
+var textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// applyEnv walks the fields of a struct and sets those with a variable named
+// after the path of their yaml names
+func applyEnv(value reflect.Value, prefix string, lookupEnv func(key string) (string, bool)) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		key := prefix + envName(name)
+		fieldValue := value.Field(i)
+
+		if field.Type.Kind() == reflect.Struct {
+			if err := applyEnv(fieldValue, key+"_", lookupEnv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		raw, ok := lookupEnv(key)
+		if !ok {
+			continue
+		}
+		if err := setValue(fieldValue, raw); err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func setValue(value reflect.Value, raw string) error {
+	if value.Addr().Type().Implements(textUnmarshaler) {
+		return value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Int:
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(parsed))
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(parsed)
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
+
+// envName turns camelCase names into upper snake case, jwtKeyFile into JWT_KEY_FILE
+// and tokenTTL into TOKEN_TTL
+func envName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
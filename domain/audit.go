@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	AuditActionCreate    = "create"
+	AuditActionUpdate    = "update"
+	AuditActionDelete    = "delete"
+	AuditActionLink      = "link"
+	AuditActionUnlink    = "unlink"
+	AuditActionReorder   = "reorder"
+	AuditActionMove      = "move"
+	AuditActionApprove   = "approve"
+	AuditActionReject    = "reject"
+	AuditActionRecompute = "recompute"
+	AuditActionImport    = "import"
+	AuditActionRedeliver = "redeliver"
+)
+
+const (
+	AuditEntitySalad            = "salad"
+	AuditEntityRecipe           = "recipe"
+	AuditEntityRecipeStep       = "recipe_step"
+	AuditEntityComment          = "comment"
+	AuditEntityIngredient       = "ingredient"
+	AuditEntityRecipeIngredient = "recipe_ingredient"
+	AuditEntityIngredientType   = "ingredient_type"
+	AuditEntitySaladType        = "salad_type"
+	AuditEntityMeasurement      = "measurement"
+	AuditEntityKeyword          = "keyword"
+	AuditEntitySubstitution     = "substitution"
+	AuditEntityUser             = "user"
+	AuditEntityMedia            = "media"
+	AuditEntityTranslation      = "translation"
+	AuditEntityWebhook          = "webhook"
+	AuditEntityWebhookDelivery  = "webhook_delivery"
+	// AuditEntityCatalog records imports, the entity ID is nil
+	AuditEntityCatalog = "catalog"
+)
+
+type AuditRecord struct {
+	ID         uuid.UUID
+	ActorID    uuid.UUID
+	ActorRole  string
+	Action     string
+	EntityType string
+	EntityID   uuid.UUID
+	Before     json.RawMessage `json:",omitempty"`
+	After      json.RawMessage `json:",omitempty"`
+	Timestamp  time.Time
+}
+
+type IAuditRepository interface {
+	Create(ctx context.Context, record *AuditRecord) error
+	GetByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*AuditRecord, error)
+	GetByActor(ctx context.Context, actorId uuid.UUID) ([]*AuditRecord, error)
+}
+
+type IAuditService interface {
+	Record(ctx context.Context, action string, entityType string, entityId uuid.UUID, before, after interface{}) error
+	GetByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*AuditRecord, error)
+	GetByActor(ctx context.Context, actorId uuid.UUID) ([]*AuditRecord, error)
+}
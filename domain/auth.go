@@ -2,10 +2,26 @@ package domain
 
 import (
 	"context"
+	"fmt"
 	"github.com/google/uuid"
 )
 
-const DefaultRole = "user"
+const (
+	DefaultRole   = "user"
+	ModeratorRole = "moderator"
+	AdminRole     = "admin"
+)
+
+var Roles = []string{DefaultRole, ModeratorRole, AdminRole}
+
+func VerifyRole(role string) error {
+	for _, known := range Roles {
+		if role == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown role %s", role)
+}
 
 type UserAuth struct {
 	ID         uuid.UUID
@@ -24,3 +40,19 @@ type IAuthService interface {
 	Login(ctx context.Context, authInfo *UserAuth) (string, error)
 	Register(ctx context.Context, authInfo *User) (string, error)
 }
+
+type Actor struct {
+	ID   uuid.UUID
+	Role string
+}
+
+type actorKey struct{}
+
+func ContextWithActor(ctx context.Context, actor *Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFromContext(ctx context.Context) (*Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(*Actor)
+	return actor, ok
+}
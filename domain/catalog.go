@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+const (
+	CatalogFormatCSV  = "csv"
+	CatalogFormatJSON = "json"
+)
+
+// Catalogues of reference data, rows of each refer to other entities by name
+const (
+	CatalogIngredientTypes = "ingredient-types"
+	CatalogIngredients     = "ingredients"
+	CatalogMeasurements    = "measurements"
+	CatalogSaladTypes      = "salad-types"
+	CatalogKeywords        = "keywords"
+)
+
+// CatalogRow is a row of any catalogue, a catalogue uses only some of the fields.
+// Type and Parent are names of an ingredient type or a parent of the same catalogue.
+type CatalogRow struct {
+	Name        string   `json:"name,omitempty"`
+	Word        string   `json:"word,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Parent      string   `json:"parent,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Calories    int      `json:"calories,omitempty"`
+	Density     float64  `json:"density,omitempty"`
+	Contents    []string `json:"contents,omitempty"`
+	Unit        string   `json:"unit,omitempty"`
+	Grams       float64  `json:"grams,omitempty"`
+}
+
+type CatalogImportOptions struct {
+	Format string
+	// DryRun validates and resolves rows without writing them
+	DryRun bool
+	// Upsert updates entities with the name of a row instead of reporting a duplicate
+	Upsert bool
+}
+
+// CatalogRowError is a rejected row, rows are numbered from 1 without the CSV header
+type CatalogRowError struct {
+	Row  int
+	Name string
+	Err  error
+}
+
+func (e *CatalogRowError) Error() string {
+	return fmt.Sprintf("row %d (%s): %s", e.Row, e.Name, e.Err.Error())
+}
+
+func (e *CatalogRowError) Unwrap() error {
+	return e.Err
+}
+
+// CatalogImportReport counts rows by outcome, in a dry run rows are counted
+// as if they were written
+type CatalogImportReport struct {
+	Catalog   string
+	Created   int
+	Updated   int
+	Unchanged int
+	Errors    []*CatalogRowError
+}
+
+type ICatalogService interface {
+	// Import reads rows of the catalogue, a rejected row doesn't stop the import.
+	// The error is returned only when the input can't be read at all.
+	Import(ctx context.Context, catalog string, r io.Reader, options *CatalogImportOptions) (*CatalogImportReport, error)
+	Export(ctx context.Context, catalog string, w io.Writer, format string) error
+}
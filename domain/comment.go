@@ -16,13 +16,14 @@ type Comment struct {
 	SaladID  uuid.UUID
 	Text     string
 	Rating   int
+	Version  int
 }
 
 type ICommentRepository interface {
 	Create(ctx context.Context, comment *Comment) error
 	GetById(ctx context.Context, id uuid.UUID) (*Comment, error)
 	GetBySaladAndUser(ctx context.Context, saladId uuid.UUID, userId uuid.UUID) (*Comment, error)
-	GetAllBySaladID(ctx context.Context, saladId uuid.UUID, page int) ([]*Comment, int, error)
+	GetAllBySaladID(ctx context.Context, saladId uuid.UUID, page *PageRequest) ([]*Comment, *PageInfo, error)
 	Update(ctx context.Context, comment *Comment) error
 	DeleteById(ctx context.Context, id uuid.UUID) error
 }
@@ -31,7 +32,7 @@ type ICommentService interface {
 	Create(ctx context.Context, comment *Comment) error
 	GetById(ctx context.Context, id uuid.UUID) (*Comment, error)
 	GetBySaladAndUser(ctx context.Context, saladId uuid.UUID, userId uuid.UUID) (*Comment, error)
-	GetAllBySaladID(ctx context.Context, saladId uuid.UUID, page int) ([]*Comment, int, error)
+	GetAllBySaladID(ctx context.Context, saladId uuid.UUID, page *PageRequest) ([]*Comment, *PageInfo, error)
 	Update(ctx context.Context, user *Comment) error
 	DeleteById(ctx context.Context, id uuid.UUID) error
 }
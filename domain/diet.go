@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+)
+
+// Contents of ingredients that rule out diets, set on an ingredient or on its type
+const (
+	ContentMeat    = "meat"
+	ContentFish    = "fish"
+	ContentDairy   = "dairy"
+	ContentEgg     = "egg"
+	ContentHoney   = "honey"
+	ContentGluten  = "gluten"
+	ContentLactose = "lactose"
+	ContentNuts    = "nuts"
+)
+
+const (
+	DietVegan       = "vegan"
+	DietVegetarian  = "vegetarian"
+	DietGlutenFree  = "gluten-free"
+	DietLactoseFree = "lactose-free"
+	DietNutFree     = "nut-free"
+)
+
+var AllDiets = []string{DietVegan, DietVegetarian, DietGlutenFree, DietLactoseFree, DietNutFree}
+
+var dietExclusions = map[string][]string{
+	DietVegan:       {ContentMeat, ContentFish, ContentDairy, ContentEgg, ContentHoney},
+	DietVegetarian:  {ContentMeat, ContentFish},
+	DietGlutenFree:  {ContentGluten},
+	DietLactoseFree: {ContentLactose},
+	DietNutFree:     {ContentNuts},
+}
+
+var allContents = []string{
+	ContentMeat, ContentFish, ContentDairy, ContentEgg,
+	ContentHoney, ContentGluten, ContentLactose, ContentNuts,
+}
+
+func IsDiet(diet string) bool {
+	_, ok := dietExclusions[diet]
+	return ok
+}
+
+// DietOfName returns the diet a salad type name stands for, like "Vegan"
+func DietOfName(name string) (string, bool) {
+	diet := strings.ToLower(strings.TrimSpace(name))
+	return diet, IsDiet(diet)
+}
+
+func VerifyContents(contents []string) error {
+	for _, content := range contents {
+		known := false
+		for _, c := range allContents {
+			known = known || c == content
+		}
+		if !known {
+			return fmt.Errorf("unknown content %s", content)
+		}
+	}
+	return nil
+}
+
+func VerifyDiets(diets []string) error {
+	for _, diet := range diets {
+		if !IsDiet(diet) {
+			return fmt.Errorf("unknown diet %s", diet)
+		}
+	}
+	return nil
+}
+
+// IngredientContents merges contents of the ingredient and of its type with the ancestors
+// of the type (see IngredientTypeAncestors), so contents of "Meat" reach "Poultry"
+func IngredientContents(ingredient *Ingredient, types []*IngredientType) []string {
+	contents := append([]string(nil), ingredient.Contents...)
+	for _, ingredientType := range types {
+		contents = append(contents, ingredientType.Contents...)
+	}
+	return contents
+}
+
+// Excludes reports whether the contents rule out the diet
+func Excludes(contents []string, diet string) bool {
+	for _, excluded := range dietExclusions[diet] {
+		for _, content := range contents {
+			if content == excluded {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DietsOf returns the diets none of the contents rule out, in the order of AllDiets
+func DietsOf(contents []string) []string {
+	diets := make([]string, 0, len(AllDiets))
+	for _, diet := range AllDiets {
+		if !Excludes(contents, diet) {
+			diets = append(diets, diet)
+		}
+	}
+	return diets
+}
+
+// DietConflict is a salad type standing for a diet the ingredients of the salad rule out
+type DietConflict struct {
+	SaladID     uuid.UUID
+	SaladTypeID uuid.UUID
+	Diet        string
+	Ingredients []uuid.UUID
+}
+
+func (c *DietConflict) String() string {
+	return fmt.Sprintf("salad type %s marks salad %s as %s, but %d ingredient(s) rule it out",
+		c.SaladTypeID.String(), c.SaladID.String(), c.Diet, len(c.Ingredients))
+}
+
+type IDietService interface {
+	GetRecipeDiets(ctx context.Context, recipeId uuid.UUID) ([]string, error)
+	// CheckSaladType returns the conflict of tagging the salad with the type, nil if there is none
+	CheckSaladType(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) (*DietConflict, error)
+	GetSaladConflicts(ctx context.Context, saladId uuid.UUID) ([]*DietConflict, error)
+}
@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	SaladCreatedEvent        = "salad.created"
+	SaladUpdatedEvent        = "salad.updated"
+	SaladDeletedEvent        = "salad.deleted"
+	RecipeCreatedEvent       = "recipe.created"
+	RecipeUpdatedEvent       = "recipe.updated"
+	RecipeStatusChangedEvent = "recipe.status_changed"
+	RecipeDeletedEvent       = "recipe.deleted"
+	CommentAddedEvent        = "comment.added"
+	CommentUpdatedEvent      = "comment.updated"
+	CommentDeletedEvent      = "comment.deleted"
+	KeywordCreatedEvent      = "keyword.created"
+	KeywordUpdatedEvent      = "keyword.updated"
+	KeywordDeletedEvent      = "keyword.deleted"
+
+	// AllEvents subscribes a handler to every published event
+	AllEvents = "*"
+)
+
+// IEvent is a fact about an entity. Events of the same aggregate are delivered
+// in the order they were published.
+type IEvent interface {
+	EventName() string
+	AggregateID() uuid.UUID
+}
+
+type SaladCreated struct{ Salad *Salad }
+type SaladUpdated struct{ Salad *Salad }
+type SaladDeleted struct{ SaladID uuid.UUID }
+
+func (e *SaladCreated) EventName() string      { return SaladCreatedEvent }
+func (e *SaladCreated) AggregateID() uuid.UUID { return e.Salad.ID }
+func (e *SaladUpdated) EventName() string      { return SaladUpdatedEvent }
+func (e *SaladUpdated) AggregateID() uuid.UUID { return e.Salad.ID }
+func (e *SaladDeleted) EventName() string      { return SaladDeletedEvent }
+func (e *SaladDeleted) AggregateID() uuid.UUID { return e.SaladID }
+
+// Recipe events belong to the salad aggregate, so they are ordered with salad events
+
+type RecipeCreated struct{ Recipe *Recipe }
+type RecipeUpdated struct{ Recipe *Recipe }
+type RecipeStatusChanged struct {
+	RecipeID  uuid.UUID
+	SaladID   uuid.UUID
+	OldStatus int
+	NewStatus int
+}
+type RecipeDeleted struct {
+	RecipeID uuid.UUID
+	SaladID  uuid.UUID
+}
+
+func (e *RecipeCreated) EventName() string            { return RecipeCreatedEvent }
+func (e *RecipeCreated) AggregateID() uuid.UUID       { return e.Recipe.SaladID }
+func (e *RecipeUpdated) EventName() string            { return RecipeUpdatedEvent }
+func (e *RecipeUpdated) AggregateID() uuid.UUID       { return e.Recipe.SaladID }
+func (e *RecipeStatusChanged) EventName() string      { return RecipeStatusChangedEvent }
+func (e *RecipeStatusChanged) AggregateID() uuid.UUID { return e.SaladID }
+func (e *RecipeDeleted) EventName() string            { return RecipeDeletedEvent }
+func (e *RecipeDeleted) AggregateID() uuid.UUID       { return e.SaladID }
+
+type CommentAdded struct{ Comment *Comment }
+type CommentUpdated struct{ Comment *Comment }
+type CommentDeleted struct {
+	CommentID uuid.UUID
+	SaladID   uuid.UUID
+}
+
+func (e *CommentAdded) EventName() string        { return CommentAddedEvent }
+func (e *CommentAdded) AggregateID() uuid.UUID   { return e.Comment.SaladID }
+func (e *CommentUpdated) EventName() string      { return CommentUpdatedEvent }
+func (e *CommentUpdated) AggregateID() uuid.UUID { return e.Comment.SaladID }
+func (e *CommentDeleted) EventName() string      { return CommentDeletedEvent }
+func (e *CommentDeleted) AggregateID() uuid.UUID { return e.SaladID }
+
+type KeywordCreated struct{ KeyWord *KeyWord }
+type KeywordUpdated struct{ KeyWord *KeyWord }
+type KeywordDeleted struct{ KeywordID uuid.UUID }
+
+func (e *KeywordCreated) EventName() string      { return KeywordCreatedEvent }
+func (e *KeywordCreated) AggregateID() uuid.UUID { return e.KeyWord.ID }
+func (e *KeywordUpdated) EventName() string      { return KeywordUpdatedEvent }
+func (e *KeywordUpdated) AggregateID() uuid.UUID { return e.KeyWord.ID }
+func (e *KeywordDeleted) EventName() string      { return KeywordDeletedEvent }
+func (e *KeywordDeleted) AggregateID() uuid.UUID { return e.KeywordID }
+
+var eventFactories = map[string]func() IEvent{
+	SaladCreatedEvent:        func() IEvent { return &SaladCreated{} },
+	SaladUpdatedEvent:        func() IEvent { return &SaladUpdated{} },
+	SaladDeletedEvent:        func() IEvent { return &SaladDeleted{} },
+	RecipeCreatedEvent:       func() IEvent { return &RecipeCreated{} },
+	RecipeUpdatedEvent:       func() IEvent { return &RecipeUpdated{} },
+	RecipeStatusChangedEvent: func() IEvent { return &RecipeStatusChanged{} },
+	RecipeDeletedEvent:       func() IEvent { return &RecipeDeleted{} },
+	CommentAddedEvent:        func() IEvent { return &CommentAdded{} },
+	CommentUpdatedEvent:      func() IEvent { return &CommentUpdated{} },
+	CommentDeletedEvent:      func() IEvent { return &CommentDeleted{} },
+	KeywordCreatedEvent:      func() IEvent { return &KeywordCreated{} },
+	KeywordUpdatedEvent:      func() IEvent { return &KeywordUpdated{} },
+	KeywordDeletedEvent:      func() IEvent { return &KeywordDeleted{} },
+}
+
+// DecodeEvent restores a typed event from its name and JSON payload
+func DecodeEvent(name string, payload json.RawMessage) (IEvent, error) {
+	factory, ok := eventFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown event %s", name)
+	}
+
+	event := factory()
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("decoding event %s: %w", name, err)
+	}
+	return event, nil
+}
+
+type EventHandler func(ctx context.Context, event IEvent) error
+
+type IEventPublisher interface {
+	Publish(ctx context.Context, events ...IEvent) error
+}
+
+type IEventBus interface {
+	IEventPublisher
+	// Subscribe registers handler for events with the name (or AllEvents)
+	// and returns a function removing the subscription
+	Subscribe(eventName string, handler EventHandler) func()
+	// Close stops accepting events and waits until queued ones are delivered
+	Close()
+}
+
+// OutboxEntry is an event persisted in the same transaction as the write that caused it
+type OutboxEntry struct {
+	ID          uuid.UUID
+	EventName   string
+	AggregateID uuid.UUID
+	Payload     json.RawMessage
+	CreatedAt   time.Time
+	PublishedAt *time.Time
+}
+
+func NewOutboxEntry(event IEvent, createdAt time.Time) (*OutboxEntry, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("encoding event %s: %w", event.EventName(), err)
+	}
+	return &OutboxEntry{
+		ID:          uuid.New(),
+		EventName:   event.EventName(),
+		AggregateID: event.AggregateID(),
+		Payload:     payload,
+		CreatedAt:   createdAt,
+	}, nil
+}
+
+func (e *OutboxEntry) Event() (IEvent, error) {
+	return DecodeEvent(e.EventName, e.Payload)
+}
+
+// IEventOutbox stores entries using the transaction from ctx, if there is one.
+// GetPending returns entries neither published nor failed in creation order.
+type IEventOutbox interface {
+	Save(ctx context.Context, entries ...*OutboxEntry) error
+	GetPending(ctx context.Context, limit int) ([]*OutboxEntry, error)
+	MarkPublished(ctx context.Context, ids []uuid.UUID, publishedAt time.Time) error
+	// MarkFailed sets the entry aside with the reason, it is never relayed again
+	MarkFailed(ctx context.Context, id uuid.UUID, reason string, failedAt time.Time) error
+}
+
+type IOutboxRelay interface {
+	// Relay publishes up to limit pending entries and returns how many were published
+	Relay(ctx context.Context, limit int) (int, error)
+}
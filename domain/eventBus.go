@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+type DeliveryMode int
+
+const (
+	// SyncDelivery calls handlers in Publish and returns their errors
+	SyncDelivery DeliveryMode = iota
+	// AsyncDelivery queues events to a bounded worker pool, handler errors go to OnError
+	AsyncDelivery
+)
+
+var ErrEventBusClosed = errors.New("event bus is closed")
+
+type EventBusConfig struct {
+	Mode DeliveryMode
+	// Workers is the number of async workers, events of one aggregate are always
+	// handled by the same worker, so they keep their order
+	Workers int
+	// QueueSize bounds each worker queue, Publish blocks while the queue is full
+	QueueSize int
+	OnError   func(event IEvent, err error)
+}
+
+type subscription struct {
+	id      int
+	handler EventHandler
+}
+
+type queuedEvent struct {
+	ctx   context.Context
+	event IEvent
+}
+
+type EventBus struct {
+	config EventBusConfig
+
+	subsMu   sync.RWMutex
+	subs     map[string][]subscription
+	nextSubs int
+
+	queueMu sync.RWMutex
+	queues  []chan queuedEvent
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+func NewEventBus(config EventBusConfig) IEventBus {
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 64
+	}
+
+	bus := &EventBus{
+		config: config,
+		subs:   make(map[string][]subscription),
+	}
+	if config.Mode == AsyncDelivery {
+		bus.queues = make([]chan queuedEvent, config.Workers)
+		for i := range bus.queues {
+			bus.queues[i] = make(chan queuedEvent, config.QueueSize)
+			bus.wg.Add(1)
+			go bus.work(bus.queues[i])
+		}
+	}
+	return bus
+}
+
+func (b *EventBus) Subscribe(eventName string, handler EventHandler) func() {
+	b.subsMu.Lock()
+	defer b.subsMu.Unlock()
+
+	b.nextSubs++
+	id := b.nextSubs
+	b.subs[eventName] = append(b.subs[eventName], subscription{id: id, handler: handler})
+
+	return func() {
+		b.subsMu.Lock()
+		defer b.subsMu.Unlock()
+
+		subs := b.subs[eventName]
+		for i, sub := range subs {
+			if sub.id == id {
+				b.subs[eventName] = append(subs[:i:i], subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (b *EventBus) handlers(eventName string) []EventHandler {
+	b.subsMu.RLock()
+	defer b.subsMu.RUnlock()
+
+	handlers := make([]EventHandler, 0, len(b.subs[eventName])+len(b.subs[AllEvents]))
+	for _, sub := range b.subs[eventName] {
+		handlers = append(handlers, sub.handler)
+	}
+	for _, sub := range b.subs[AllEvents] {
+		handlers = append(handlers, sub.handler)
+	}
+	return handlers
+}
+
+func (b *EventBus) Publish(ctx context.Context, events ...IEvent) error {
+	b.queueMu.RLock()
+	defer b.queueMu.RUnlock()
+
+	if b.closed {
+		return ErrEventBusClosed
+	}
+
+	if b.config.Mode == SyncDelivery {
+		var errs []error
+		for _, event := range events {
+			if err := b.deliver(ctx, event); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+
+	for _, event := range events {
+		select {
+		case b.queues[b.shard(event)] <- queuedEvent{ctx: context.WithoutCancel(ctx), event: event}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (b *EventBus) Close() {
+	b.queueMu.Lock()
+	if b.closed {
+		b.queueMu.Unlock()
+		return
+	}
+	b.closed = true
+	for _, queue := range b.queues {
+		close(queue)
+	}
+	b.queueMu.Unlock()
+
+	b.wg.Wait()
+}
+
+func (b *EventBus) shard(event IEvent) int {
+	id := event.AggregateID()
+	hash := fnv.New32a()
+	_, _ = hash.Write(id[:])
+	return int(hash.Sum32() % uint32(len(b.queues)))
+}
+
+func (b *EventBus) work(queue chan queuedEvent) {
+	defer b.wg.Done()
+
+	for queued := range queue {
+		if err := b.deliver(queued.ctx, queued.event); err != nil && b.config.OnError != nil {
+			b.config.OnError(queued.event, err)
+		}
+	}
+}
+
+// deliver calls all handlers of the event, a failing handler doesn't stop the others
+func (b *EventBus) deliver(ctx context.Context, event IEvent) error {
+	var errs []error
+	for _, handler := range b.handlers(event.EventName()) {
+		if err := callHandler(ctx, handler, event); err != nil {
+			errs = append(errs, fmt.Errorf("handling %s: %w", event.EventName(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func callHandler(ctx context.Context, handler EventHandler, event IEvent) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("handler panic: %v", p)
+		}
+	}()
+	return handler(ctx, event)
+}
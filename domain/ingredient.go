@@ -10,12 +10,17 @@ type Ingredient struct {
 	TypeID   uuid.UUID
 	Name     string
 	Calories int
+	// Density in grams per milliliter converts volumes to mass, zero when unknown
+	Density float64
+	// Contents are the ContentX constants, the contents of the type apply as well
+	Contents []string
+	Version  int
 }
 
 type IIngredientRepository interface {
 	Create(ctx context.Context, ingredient *Ingredient) error
 	GetById(ctx context.Context, id uuid.UUID) (*Ingredient, error)
-	GetAll(ctx context.Context, page int) ([]*Ingredient, int, error)
+	GetAll(ctx context.Context, page *PageRequest) ([]*Ingredient, *PageInfo, error)
 	GetAllByRecipeId(ctx context.Context, id uuid.UUID) ([]*Ingredient, error)
 	Link(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) (uuid.UUID, error)
 	Unlink(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) error
@@ -26,7 +31,7 @@ type IIngredientRepository interface {
 type IIngredientService interface {
 	Create(ctx context.Context, salad *Ingredient) error
 	GetById(ctx context.Context, id uuid.UUID) (*Ingredient, error)
-	GetAll(ctx context.Context, page int) ([]*Ingredient, int, error)
+	GetAll(ctx context.Context, page *PageRequest) ([]*Ingredient, *PageInfo, error)
 	GetAllByRecipeId(ctx context.Context, id uuid.UUID) ([]*Ingredient, error)
 	Link(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) (uuid.UUID, error)
 	Unlink(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) error
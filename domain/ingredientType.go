@@ -5,16 +5,21 @@ import (
 	"github.com/google/uuid"
 )
 
+// IngredientType is a node of the ingredient taxonomy, types with a nil ParentID are roots
 type IngredientType struct {
 	ID          uuid.UUID
+	ParentID    uuid.UUID
 	Name        string
 	Description string
+	Contents    []string
+	Version     int
 }
 
 type IIngredientTypeRepository interface {
 	Create(ctx context.Context, ingredientType *IngredientType) error
 	GetById(ctx context.Context, id uuid.UUID) (*IngredientType, error)
 	GetAll(ctx context.Context) ([]*IngredientType, error)
+	GetAllByParentId(ctx context.Context, parentId uuid.UUID) ([]*IngredientType, error)
 	Update(ctx context.Context, measurement *IngredientType) error
 	DeleteById(ctx context.Context, id uuid.UUID) error
 }
@@ -23,6 +28,14 @@ type IIngredientTypeService interface {
 	Create(ctx context.Context, measurement *IngredientType) error
 	GetById(ctx context.Context, id uuid.UUID) (*IngredientType, error)
 	GetAll(ctx context.Context) ([]*IngredientType, error)
+	// GetChildren returns direct subtypes, a nil parent gives the roots
+	GetChildren(ctx context.Context, parentId uuid.UUID) ([]*IngredientType, error)
+	// GetSubtree returns the type followed by all of its descendants
+	GetSubtree(ctx context.Context, id uuid.UUID) ([]*IngredientType, error)
+	// Move puts the type under the parent, a nil parent makes it a root
+	Move(ctx context.Context, id uuid.UUID, parentId uuid.UUID) error
 	Update(ctx context.Context, measurement *IngredientType) error
+	// DeleteById fails with ErrTypeHasChildren until subtypes are moved or deleted,
+	// ingredients of the type are left without one
 	DeleteById(ctx context.Context, id uuid.UUID) error
 }
@@ -6,8 +6,9 @@ import (
 )
 
 type KeyWord struct {
-	ID   uuid.UUID
-	Word string
+	ID      uuid.UUID
+	Word    string
+	Version int
 }
 
 type IKeywordValidatorRepository interface {
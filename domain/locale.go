@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"regexp"
+	"strings"
+)
+
+const (
+	TranslationEntityIngredient     = "ingredient"
+	TranslationEntityIngredientType = "ingredient_type"
+	TranslationEntitySaladType      = "salad_type"
+	TranslationEntityMeasurement    = "measurement"
+	TranslationEntitySalad          = "salad"
+)
+
+const (
+	TranslationFieldName        = "name"
+	TranslationFieldDescription = "description"
+)
+
+// TranslatableFields are the fields of each entity type that have translations
+var TranslatableFields = map[string][]string{
+	TranslationEntityIngredient:     {TranslationFieldName},
+	TranslationEntityIngredientType: {TranslationFieldName, TranslationFieldDescription},
+	TranslationEntitySaladType:      {TranslationFieldName, TranslationFieldDescription},
+	TranslationEntityMeasurement:    {TranslationFieldName},
+	TranslationEntitySalad:          {TranslationFieldName, TranslationFieldDescription},
+}
+
+// Translation is the text of an entity field in a locale. Entities keep their own
+// text as the source, it's shown when no locale of the chain has a translation.
+type Translation struct {
+	ID         uuid.UUID
+	EntityType string
+	EntityID   uuid.UUID
+	Locale     string
+	Field      string
+	Text       string
+	Version    int
+}
+
+// MissingTranslation is a field with source text and no translation in the locale
+type MissingTranslation struct {
+	EntityType string
+	EntityID   uuid.UUID
+	Field      string
+	Source     string
+}
+
+// TranslatableText points to a field of a loaded entity, Localize replaces the text
+type TranslatableText struct {
+	EntityID uuid.UUID
+	Field    string
+	Text     *string
+}
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// VerifyLocale accepts language tags like "ru", "en" and "en-GB"
+func VerifyLocale(locale string) error {
+	if !localePattern.MatchString(locale) {
+		return fmt.Errorf("invalid locale %q", locale)
+	}
+	return nil
+}
+
+// LocaleChain follows each locale with its parents, "en-GB" is followed by "en".
+// Locales repeat only once, at their first position.
+func LocaleChain(locales ...string) []string {
+	seen := make(map[string]bool)
+	chain := make([]string, 0, len(locales))
+	for _, locale := range locales {
+		for locale != "" {
+			if !seen[locale] {
+				seen[locale] = true
+				chain = append(chain, locale)
+			}
+			cut := strings.LastIndex(locale, "-")
+			if cut < 0 {
+				break
+			}
+			locale = locale[:cut]
+		}
+	}
+	return chain
+}
+
+type localeKey struct{}
+
+// ContextWithLocale selects the locale for reads with fallbacks tried in order,
+// like ContextWithLocale(ctx, "en-GB", "ru")
+func ContextWithLocale(ctx context.Context, locales ...string) context.Context {
+	return context.WithValue(ctx, localeKey{}, LocaleChain(locales...))
+}
+
+// LocalesFromContext returns the locale chain, false without a selected locale
+func LocalesFromContext(ctx context.Context) ([]string, bool) {
+	locales, ok := ctx.Value(localeKey{}).([]string)
+	return locales, ok && len(locales) != 0
+}
+
+type ITranslationRepository interface {
+	Create(ctx context.Context, translation *Translation) (uuid.UUID, error)
+	GetById(ctx context.Context, id uuid.UUID) (*Translation, error)
+	GetAllByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*Translation, error)
+	// GetAllByEntities returns translations of the entities in any of the locales
+	GetAllByEntities(ctx context.Context, entityType string, entityIds []uuid.UUID, locales []string) ([]*Translation, error)
+	// GetMissing lists translatable fields of all entities of the type with no translation
+	// in the locale, fields with empty source text aren't missing
+	GetMissing(ctx context.Context, entityType string, locale string) ([]*MissingTranslation, error)
+	Update(ctx context.Context, translation *Translation) error
+	DeleteById(ctx context.Context, id uuid.UUID) error
+}
+
+type ITranslationService interface {
+	// Set creates the translation of the field in the locale or replaces its text
+	Set(ctx context.Context, translation *Translation) error
+	GetAllByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*Translation, error)
+	DeleteById(ctx context.Context, id uuid.UUID) error
+	// Localize replaces texts with translations in the first locale of the context chain
+	// that has them, without a locale in the context texts stay as they are
+	Localize(ctx context.Context, entityType string, texts []TranslatableText) error
+	GetMissing(ctx context.Context, entityType string, locale string) ([]*MissingTranslation, error)
+}
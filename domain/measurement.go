@@ -5,16 +5,28 @@ import (
 	"github.com/google/uuid"
 )
 
+// Measurement is a unit recipes measure ingredients in. Unit is the code of a known unit,
+// Grams is the approximate weight of one unit, derived for units of mass. Volumes of
+// ingredients with a density are weighed with it instead (see SaladIngredient.Grams).
 type Measurement struct {
-	ID    uuid.UUID
-	Name  string
-	Grams int
+	ID      uuid.UUID
+	Name    string
+	Unit    string
+	Grams   float64
+	Version int
+}
+
+type RecipeMeasurement struct {
+	IngredientID uuid.UUID
+	Measurement  *Measurement
+	Amount       int
 }
 
 type IMeasurementRepository interface {
 	Create(ctx context.Context, measurement *Measurement) error
 	GetById(ctx context.Context, id uuid.UUID) (*Measurement, error)
 	GetByRecipeId(ctx context.Context, ingredientId uuid.UUID, recipeId uuid.UUID) (*Measurement, int, error)
+	GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*RecipeMeasurement, error)
 	GetAll(ctx context.Context) ([]*Measurement, error)
 	UpdateLink(ctx context.Context, linkId uuid.UUID, measurementId uuid.UUID, amount int) error
 	Update(ctx context.Context, measurement *Measurement) error
@@ -25,8 +37,12 @@ type IMeasurementService interface {
 	Create(ctx context.Context, measurement *Measurement) error
 	GetById(ctx context.Context, id uuid.UUID) (*Measurement, error)
 	GetByRecipeId(ctx context.Context, ingredientId uuid.UUID, recipeId uuid.UUID) (*Measurement, int, error)
+	GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*RecipeMeasurement, error)
 	GetAll(ctx context.Context) ([]*Measurement, error)
 	UpdateLink(ctx context.Context, linkId uuid.UUID, measurementId uuid.UUID, amount int) error
 	Update(ctx context.Context, measurement *Measurement) error
 	DeleteById(ctx context.Context, id uuid.UUID) error
+	Convert(ctx context.Context, quantity Quantity, to string, ingredient *Ingredient) (Quantity, error)
+	// ToSystem returns amount of the recipe ingredient in units of the system
+	ToSystem(ctx context.Context, item *SaladIngredient, system string) (Quantity, error)
 }
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+)
+
+const (
+	MediaOwnerSalad      = "salad"
+	MediaOwnerRecipeStep = "recipe_step"
+)
+
+// MediaTypes are MIME types accepted for upload, the type is sniffed from the content
+var MediaTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+// Media is an image attached to a salad or a recipe step. Key and ThumbnailKey
+// address the original and the thumbnail in the blob storage.
+type Media struct {
+	ID           uuid.UUID
+	OwnerType    string
+	OwnerID      uuid.UUID
+	Key          string
+	ThumbnailKey string
+	MimeType     string
+	Size         int64
+	Width        int
+	Height       int
+	// Order is the display position among media of the owner starting from 1
+	Order   int
+	Version int
+}
+
+type MediaUpload struct {
+	OwnerType string
+	OwnerID   uuid.UUID
+	Data      []byte
+	// Order is the position to insert the media at, zero appends it
+	Order int
+}
+
+// MediaLimits bound uploads, zero fields take default values
+type MediaLimits struct {
+	MaxSize       int64
+	MaxWidth      int
+	MaxHeight     int
+	ThumbnailSize int
+}
+
+var (
+	ErrBlobNotFound   = errors.New("blob not found")
+	ErrInvalidBlobKey = errors.New("invalid blob key")
+	ErrMediaChanged   = errors.New("media were changed concurrently")
+)
+
+// IBlobStorage keeps binary content by keys, deleting a missing key is not an error
+type IBlobStorage interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type IMediaRepository interface {
+	Create(ctx context.Context, media *Media) (uuid.UUID, error)
+	GetById(ctx context.Context, id uuid.UUID) (*Media, error)
+	GetAllByOwner(ctx context.Context, ownerType string, ownerId uuid.UUID) ([]*Media, error)
+	Update(ctx context.Context, media *Media) error
+	DeleteById(ctx context.Context, id uuid.UUID) error
+}
+
+type IMediaService interface {
+	// Upload validates the image, stores it with a thumbnail and attaches it to the owner
+	Upload(ctx context.Context, upload *MediaUpload) (*Media, error)
+	GetById(ctx context.Context, id uuid.UUID) (*Media, error)
+	// GetAllByOwner returns media of the owner in display order
+	GetAllByOwner(ctx context.Context, ownerType string, ownerId uuid.UUID) ([]*Media, error)
+	GetContent(ctx context.Context, id uuid.UUID) ([]byte, error)
+	GetThumbnail(ctx context.Context, id uuid.UUID) ([]byte, error)
+	Reorder(ctx context.Context, ownerType string, ownerId uuid.UUID, mediaIds []uuid.UUID) error
+	DeleteById(ctx context.Context, id uuid.UUID) error
+	DeleteAllByOwner(ctx context.Context, ownerType string, ownerId uuid.UUID) error
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+)
+
+var ErrNotInModeration = errors.New("recipe isn't in moderation")
+
+type IModerationService interface {
+	// GetQueue returns recipes waiting for moderation, the oldest first
+	GetQueue(ctx context.Context, page *PageRequest) ([]*Recipe, *PageInfo, error)
+	// Approve publishes a recipe in moderation
+	Approve(ctx context.Context, recipeId uuid.UUID) error
+	// Reject returns a recipe in moderation to its author
+	Reject(ctx context.Context, recipeId uuid.UUID) error
+}
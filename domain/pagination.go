@@ -0,0 +1,249 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Page sizes of lists without configured ones
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// PageSizes bound page requests: Default is the size of pages without one, Max the largest size
+type PageSizes struct {
+	Default int
+	Max     int
+}
+
+var DefaultPageSizes = PageSizes{Default: DefaultPageSize, Max: MaxPageSize}
+
+// RepositoryPageSizes don't bound the size, services normalize requests before
+// passing them to repositories
+var RepositoryPageSizes = PageSizes{Default: DefaultPageSize, Max: math.MaxInt}
+
+// Sort fields of list methods, the empty sort is the default order of a list
+const (
+	SortByName       = "name"
+	SortByUsername   = "username"
+	SortByRating     = "rating"
+	SortByCalories   = "calories"
+	SortByTimeToCook = "timeToCook"
+	SortByCreatedAt  = "createdAt"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageRequest selects a page of a list. The first page has an empty Cursor, the next
+// ones pass PageInfo.NextCursor of the previous page with the same Sort and Desc.
+// A nil PageRequest is the first page of the default size.
+type PageRequest struct {
+	Size   int
+	Cursor string
+	Sort   string
+	Desc   bool
+}
+
+type PageInfo struct {
+	Size  int
+	Total int
+	// NextCursor is empty on the last page
+	NextCursor string
+}
+
+// Cursor is the position after the last item of a page: its sort key and ID,
+// the ID orders items with equal keys, so pages are stable under inserts
+type Cursor struct {
+	Sort string    `json:"s,omitempty"`
+	Desc bool      `json:"d,omitempty"`
+	Key  string    `json:"k,omitempty"`
+	ID   uuid.UUID `json:"i"`
+}
+
+func EncodeCursor(cursor *Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(encoded string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	cursor := new(Cursor)
+	if err = json.Unmarshal(data, cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// Normalize returns a copy of the request with the default size filled in and checks
+// it against the sizes and the sort fields supported by the list
+func (p *PageRequest) Normalize(sizes PageSizes, sorts ...string) (*PageRequest, error) {
+	normalized := PageRequest{}
+	if p != nil {
+		normalized = *p
+	}
+
+	if normalized.Size < 0 || normalized.Size > sizes.Max {
+		return nil, fmt.Errorf("page size must be from 1 to %d", sizes.Max)
+	}
+	if normalized.Size == 0 {
+		normalized.Size = sizes.Default
+	}
+
+	if normalized.Sort != "" {
+		supported := false
+		for _, sort := range sorts {
+			supported = supported || sort == normalized.Sort
+		}
+		if !supported {
+			return nil, fmt.Errorf("unsupported sort %s", normalized.Sort)
+		}
+	}
+
+	if _, err := normalized.DecodeCursor(); err != nil {
+		return nil, err
+	}
+	return &normalized, nil
+}
+
+// DecodeCursor returns the position to continue from, nil for the first page
+func (p *PageRequest) DecodeCursor() (*Cursor, error) {
+	if p == nil || p.Cursor == "" {
+		return nil, nil
+	}
+	cursor, err := DecodeCursor(p.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.Sort != p.Sort || cursor.Desc != p.Desc {
+		return nil, fmt.Errorf("%w: cursor of another sort", ErrInvalidCursor)
+	}
+	return cursor, nil
+}
+
+// SortKeyFloat encodes value so that string order of keys is the numeric order
+func SortKeyFloat(value float64) string {
+	bits := math.Float64bits(value)
+	if bits&(1<<63) != 0 {
+		bits = ^bits
+	} else {
+		bits |= 1 << 63
+	}
+	var b [8]byte
+	for i := range b {
+		b[i] = byte(bits >> (56 - 8*i))
+	}
+	return hex.EncodeToString(b[:])
+}
+
+func SortKeyInt(value int) string {
+	return SortKeyFloat(float64(value))
+}
+
+func SortKeyTime(value time.Time) string {
+	return fmt.Sprintf("%016x", uint64(value.UnixNano())^(1<<63))
+}
+
+// ParseSortKeyFloat decodes keys of SortKeyFloat and SortKeyInt, so that storages
+// can continue from a cursor on their own
+func ParseSortKeyFloat(key string) (float64, error) {
+	b, err := hex.DecodeString(key)
+	if err != nil || len(b) != 8 {
+		return 0, ErrInvalidCursor
+	}
+	var bits uint64
+	for _, c := range b {
+		bits = bits<<8 | uint64(c)
+	}
+	if bits&(1<<63) != 0 {
+		bits &^= 1 << 63
+	} else {
+		bits = ^bits
+	}
+	return math.Float64frombits(bits), nil
+}
+
+// ParseSortKeyTime decodes keys of SortKeyTime to Unix nanoseconds
+func ParseSortKeyTime(key string) (int64, error) {
+	bits, err := strconv.ParseUint(key, 16, 64)
+	if err != nil || len(key) != 16 {
+		return 0, ErrInvalidCursor
+	}
+	return int64(bits ^ (1 << 63)), nil
+}
+
+// Paginate applies a page request to items held in memory. sortKey returns the key
+// of an item for the requested sort and its ID. The size isn't bounded, services
+// normalize requests with their configured sizes first.
+func Paginate[T any](items []T, page *PageRequest, sortKey func(item T, sort string) (string, uuid.UUID)) ([]T, *PageInfo, error) {
+	page, err := page.Normalize(RepositoryPageSizes, page.sorts()...)
+	if err != nil {
+		return nil, nil, err
+	}
+	cursor, _ := page.DecodeCursor()
+
+	type keyed struct {
+		item T
+		key  string
+		id   uuid.UUID
+	}
+	less := func(aKey string, aId uuid.UUID, bKey string, bId uuid.UUID) bool {
+		if aKey != bKey {
+			return (aKey < bKey) != page.Desc
+		}
+		if aId == bId {
+			return false
+		}
+		return (aId.String() < bId.String()) != page.Desc
+	}
+
+	sorted := make([]keyed, 0, len(items))
+	for _, item := range items {
+		key, id := sortKey(item, page.Sort)
+		sorted = append(sorted, keyed{item: item, key: key, id: id})
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return less(sorted[i].key, sorted[i].id, sorted[j].key, sorted[j].id)
+	})
+
+	start := 0
+	if cursor != nil {
+		start = sort.Search(len(sorted), func(i int) bool {
+			return less(cursor.Key, cursor.ID, sorted[i].key, sorted[i].id)
+		})
+	}
+	end := start + page.Size
+	if end > len(sorted) {
+		end = len(sorted)
+	}
+
+	result := make([]T, 0, end-start)
+	for _, k := range sorted[start:end] {
+		result = append(result, k.item)
+	}
+	info := &PageInfo{Size: page.Size, Total: len(items)}
+	if end < len(sorted) {
+		last := sorted[end-1]
+		info.NextCursor = EncodeCursor(&Cursor{Sort: page.Sort, Desc: page.Desc, Key: last.key, ID: last.id})
+	}
+	return result, info, nil
+}
+
+// sorts lets Paginate accept the sort of the request, callers validate it beforehand
+func (p *PageRequest) sorts() []string {
+	if p == nil {
+		return nil
+	}
+	return []string{p.Sort}
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+type IRatingService interface {
+	// Recompute sets the rating of the salad recipe to the mean rate of its comments,
+	// zero without comments
+	Recompute(ctx context.Context, saladId uuid.UUID) (float32, error)
+	// RecomputeAll recomputes ratings of all recipes and returns the number of changed ones
+	RecomputeAll(ctx context.Context) (int, error)
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"context"
 	"github.com/google/uuid"
+	"time"
 )
 
 type Recipe struct {
@@ -12,6 +13,9 @@ type Recipe struct {
 	NumberOfServings int
 	TimeToCook       int
 	Rating           float32
+	// CreatedAt is set by the repository on creation
+	CreatedAt time.Time
+	Version   int
 }
 
 const (
@@ -22,18 +26,30 @@ const (
 	StoredSaladStatus     = 5
 )
 
+// RecipeFilter selects recipes, zero fields put no constraint. Recipes match when all
+// of their required ingredients are available, none of their ingredients is excluded, the salad has every
+// listed type or one of its subtypes and suits every listed diet. Calories are counted as in RecipeCandidate.Calories.
 type RecipeFilter struct {
 	AvailableIngredients []uuid.UUID
+	ExcludedIngredients  []uuid.UUID
 	MinRate              float64
 	SaladTypes           []uuid.UUID
+	Diets                []string
 	Status               int
+	MaxTimeToCook        int
+	MinServings          int
+	MaxServings          int
+	MinCalories          int
+	MaxCalories          int
+	AuthorID             uuid.UUID
+	CreatedAfter         time.Time
 }
 
 type IRecipeRepository interface {
 	Create(ctx context.Context, recipe *Recipe) (uuid.UUID, error)
 	GetById(ctx context.Context, id uuid.UUID) (*Recipe, error)
 	GetBySaladId(ctx context.Context, saladId uuid.UUID) (*Recipe, error)
-	GetAll(ctx context.Context, filter *RecipeFilter, page int) ([]*Recipe, error)
+	GetAll(ctx context.Context, filter *RecipeFilter, page *PageRequest) ([]*Recipe, *PageInfo, error)
 	Update(ctx context.Context, recipe *Recipe) error
 	DeleteById(ctx context.Context, id uuid.UUID) error
 }
@@ -42,7 +58,7 @@ type IRecipeService interface {
 	Create(ctx context.Context, user *Recipe) (uuid.UUID, error)
 	GetById(ctx context.Context, id uuid.UUID) (*Recipe, error)
 	GetBySaladId(ctx context.Context, saladId uuid.UUID) (*Recipe, error)
-	GetAll(ctx context.Context, filter *RecipeFilter, page int) ([]*Recipe, error)
+	GetAll(ctx context.Context, filter *RecipeFilter, page *PageRequest) ([]*Recipe, *PageInfo, error)
 	Update(ctx context.Context, recipe *Recipe) error
 	DeleteById(ctx context.Context, id uuid.UUID) error
 }
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// RecipeAggregateIngredient is a recipe ingredient, ingredients are shown in the order given
+type RecipeAggregateIngredient struct {
+	IngredientID  uuid.UUID
+	MeasurementID uuid.UUID
+	Amount        float64
+	Optional      bool
+	Note          string
+}
+
+type RecipeAggregate struct {
+	Salad       *Salad
+	Recipe      *Recipe
+	Steps       []*RecipeStep
+	Ingredients []*RecipeAggregateIngredient
+	TypeIDs     []uuid.UUID
+}
+
+type IRecipeAggregateService interface {
+	Create(ctx context.Context, aggregate *RecipeAggregate) (uuid.UUID, error)
+	// Replace rewrites the aggregate of the salad, Salad.Version and Recipe.Version are
+	// the versions the caller read, a stale one fails with VersionConflictError
+	Replace(ctx context.Context, aggregate *RecipeAggregate) error
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+const (
+	ExportFormatJSONLD   = "jsonld"
+	ExportFormatMarkdown = "markdown"
+	ExportFormatHTML     = "html"
+)
+
+type RecipeExport struct {
+	*SaladDetails
+	Calories int
+}
+
+type IRecipeExportService interface {
+	Assemble(ctx context.Context, saladId uuid.UUID) (*RecipeExport, error)
+	Export(ctx context.Context, saladId uuid.UUID, format string) ([]byte, error)
+	// Render writes an assembled recipe in the format, like a recipe with substitutions
+	Render(ctx context.Context, export *RecipeExport, format string) ([]byte, error)
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RecipeIngredient is an ingredient linked to a recipe together with its quantity.
+// It is the link IIngredientRepository.Link creates, Link and UpdateLink of measurements
+// remain for compatibility and see amounts rounded to integers.
+type RecipeIngredient struct {
+	ID            uuid.UUID
+	RecipeID      uuid.UUID
+	IngredientID  uuid.UUID
+	MeasurementID uuid.UUID
+	Amount        float64
+	Optional      bool
+	// Note is a preparation note like "finely chopped"
+	Note string
+	// Order is the display position in the recipe starting from 1
+	Order   int
+	Version int
+}
+
+var ErrIngredientsChanged = errors.New("recipe ingredients were changed concurrently")
+
+type IRecipeIngredientRepository interface {
+	Create(ctx context.Context, recipeIngredient *RecipeIngredient) (uuid.UUID, error)
+	GetById(ctx context.Context, id uuid.UUID) (*RecipeIngredient, error)
+	GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*RecipeIngredient, error)
+	Update(ctx context.Context, recipeIngredient *RecipeIngredient) error
+	DeleteById(ctx context.Context, id uuid.UUID) error
+	DeleteAllByRecipeId(ctx context.Context, recipeId uuid.UUID) error
+}
+
+type IRecipeIngredientService interface {
+	// Add links the ingredient to the recipe with its quantity, zero Order appends it
+	Add(ctx context.Context, recipeIngredient *RecipeIngredient) (uuid.UUID, error)
+	GetById(ctx context.Context, id uuid.UUID) (*RecipeIngredient, error)
+	// GetAllByRecipeId returns ingredients of the recipe in display order
+	GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*RecipeIngredient, error)
+	Update(ctx context.Context, recipeIngredient *RecipeIngredient) error
+	Remove(ctx context.Context, id uuid.UUID) error
+	Reorder(ctx context.Context, recipeId uuid.UUID, recipeIngredientIds []uuid.UUID) error
+}
+
+var fractions = []struct {
+	value float64
+	text  string
+}{
+	{1.0 / 8, "1/8"}, {1.0 / 4, "1/4"}, {1.0 / 3, "1/3"}, {1.0 / 2, "1/2"},
+	{2.0 / 3, "2/3"}, {3.0 / 4, "3/4"},
+}
+
+// ParseAmount parses amounts like "2", "0.5", "1/2" and "1 1/2"
+func ParseAmount(text string) (float64, error) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || len(fields) > 2 {
+		return 0, fmt.Errorf("invalid amount %q", text)
+	}
+
+	amount := 0.0
+	for i, field := range fields {
+		value, err := parseAmountPart(field)
+		if err != nil || (i == 0 && len(fields) == 2 && value != math.Trunc(value)) {
+			return 0, fmt.Errorf("invalid amount %q", text)
+		}
+		amount += value
+	}
+	return amount, nil
+}
+
+func parseAmountPart(part string) (float64, error) {
+	numerator, denominator, isFraction := strings.Cut(part, "/")
+	if !isFraction {
+		return strconv.ParseFloat(part, 64)
+	}
+
+	n, err := strconv.Atoi(numerator)
+	if err != nil {
+		return 0, err
+	}
+	d, err := strconv.Atoi(denominator)
+	if err != nil || d == 0 {
+		return 0, fmt.Errorf("invalid denominator")
+	}
+	return float64(n) / float64(d), nil
+}
+
+// FormatAmount writes common fractions as in recipes, "1 1/2" instead of 1.5
+func FormatAmount(amount float64) string {
+	whole, rest := math.Modf(amount)
+	for _, fraction := range fractions {
+		if math.Abs(rest-fraction.value) < 0.01 {
+			if whole == 0 {
+				return fraction.text
+			}
+			return fmt.Sprintf("%.0f %s", whole, fraction.text)
+		}
+	}
+	return formatAmount(amount)
+}
@@ -0,0 +1,223 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"math"
+)
+
+// RecipeSorts are the sorts of recipe and salad lists filtered by RecipeFilter.
+// Newest first is SortByCreatedAt with Desc, fastest first is SortByTimeToCook.
+var RecipeSorts = []string{SortByName, SortByRating, SortByTimeToCook, SortByCalories, SortByCreatedAt}
+
+// RecipeCandidate is a recipe with everything RecipeFilter looks at
+type RecipeCandidate struct {
+	Salad       *Salad
+	Recipe      *Recipe
+	Ingredients []*SaladIngredient
+	// TypeIDs are the types of the salad with their ancestors (see SaladTypeAncestors),
+	// so filtering by a type matches salads of its subtypes
+	TypeIDs []uuid.UUID
+	// IngredientTypes are the types of the ingredients, their contents count for diets
+	IngredientTypes []*IngredientType
+}
+
+// Grams is the weight of the ingredient in the recipe, zero without measurement. Volumes
+// are weighed with the density of the ingredient, counted units and volumes of ingredients
+// of unknown density with Measurement.Grams.
+func (i *SaladIngredient) Grams() float64 {
+	if i.Measurement == nil {
+		return 0
+	}
+	var density float64
+	if i.Ingredient != nil {
+		density = i.Ingredient.Density
+	}
+	grams, err := Convert(i.Measurement.Quantity(i.Amount), UnitGram, density)
+	if err != nil {
+		return i.Measurement.Grams * i.Amount
+	}
+	return grams.Amount
+}
+
+// CountCalories treats Ingredient.Calories as kcal per 100 grams
+func CountCalories(ingredients []*SaladIngredient) int {
+	calories := 0.0
+	for _, item := range ingredients {
+		calories += float64(item.Ingredient.Calories) * item.Grams() / 100
+	}
+	return int(math.Round(calories))
+}
+
+func (c *RecipeCandidate) Calories() int {
+	return CountCalories(c.Ingredients)
+}
+
+func (c *RecipeCandidate) Diets() []string {
+	types := make(map[uuid.UUID]*IngredientType, len(c.IngredientTypes))
+	for _, ingredientType := range c.IngredientTypes {
+		types[ingredientType.ID] = ingredientType
+	}
+
+	var contents []string
+	for _, item := range c.Ingredients {
+		ancestors, _ := IngredientTypeAncestors(item.Ingredient.TypeID, func(id uuid.UUID) (*IngredientType, error) {
+			return types[id], nil
+		})
+		contents = append(contents, IngredientContents(item.Ingredient, ancestors)...)
+	}
+	return DietsOf(contents)
+}
+
+// Validate checks the filter against the range of rates, maxRate is the best rate
+func (f *RecipeFilter) Validate(maxRate int) error {
+	if f == nil {
+		return nil
+	}
+
+	if f.MinRate < 0 || f.MinRate > float64(maxRate) {
+		return fmt.Errorf("min rate must be from 0 to %d", maxRate)
+	}
+	if f.MaxTimeToCook < 0 {
+		return fmt.Errorf("negative max time to cook")
+	}
+	if f.MinServings < 0 || f.MaxServings < 0 {
+		return fmt.Errorf("negative number of servings")
+	}
+	if f.MaxServings != 0 && f.MinServings > f.MaxServings {
+		return fmt.Errorf("min servings greater than max servings")
+	}
+	if f.MinCalories < 0 || f.MaxCalories < 0 {
+		return fmt.Errorf("negative calories")
+	}
+	if f.MaxCalories != 0 && f.MinCalories > f.MaxCalories {
+		return fmt.Errorf("min calories greater than max calories")
+	}
+	if err := VerifyDiets(f.Diets); err != nil {
+		return err
+	}
+
+	for _, id := range f.ExcludedIngredients {
+		for _, available := range f.AvailableIngredients {
+			if id == available {
+				return fmt.Errorf("ingredient %s is both available and excluded", id.String())
+			}
+		}
+	}
+	return nil
+}
+
+// Match reports whether the candidate passes the filter, a nil filter matches everything
+func (f *RecipeFilter) Match(c *RecipeCandidate) bool {
+	if f == nil {
+		return true
+	}
+	recipe := c.Recipe
+
+	if f.Status != 0 && recipe.Status != f.Status {
+		return false
+	}
+	if float64(recipe.Rating) < f.MinRate {
+		return false
+	}
+	if f.MaxTimeToCook != 0 && recipe.TimeToCook > f.MaxTimeToCook {
+		return false
+	}
+	if recipe.NumberOfServings < f.MinServings ||
+		(f.MaxServings != 0 && recipe.NumberOfServings > f.MaxServings) {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && !recipe.CreatedAt.After(f.CreatedAfter) {
+		return false
+	}
+	if f.AuthorID != uuid.Nil && (c.Salad == nil || c.Salad.AuthorID != f.AuthorID) {
+		return false
+	}
+
+	if f.MinCalories != 0 || f.MaxCalories != 0 {
+		calories := c.Calories()
+		if calories < f.MinCalories || (f.MaxCalories != 0 && calories > f.MaxCalories) {
+			return false
+		}
+	}
+
+	available := idSet(f.AvailableIngredients)
+	excluded := idSet(f.ExcludedIngredients)
+	for _, item := range c.Ingredients {
+		if excluded[item.Ingredient.ID] {
+			return false
+		}
+		if len(available) != 0 && !item.Optional && !available[item.Ingredient.ID] {
+			return false
+		}
+	}
+
+	types := idSet(c.TypeIDs)
+	for _, id := range f.SaladTypes {
+		if !types[id] {
+			return false
+		}
+	}
+
+	if len(f.Diets) != 0 {
+		diets := make(map[string]bool, len(AllDiets))
+		for _, diet := range c.Diets() {
+			diets[diet] = true
+		}
+		for _, diet := range f.Diets {
+			if !diets[diet] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func idSet(ids []uuid.UUID) map[uuid.UUID]bool {
+	set := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+// RecipeSortKey is the sort key of a candidate for Paginate, ordered by the recipe ID
+func RecipeSortKey(c *RecipeCandidate, sort string) (string, uuid.UUID) {
+	id := c.Recipe.ID
+	switch sort {
+	case SortByName:
+		if c.Salad != nil {
+			return c.Salad.Name, id
+		}
+	case SortByRating:
+		return SortKeyFloat(float64(c.Recipe.Rating)), id
+	case SortByTimeToCook:
+		return SortKeyInt(c.Recipe.TimeToCook), id
+	case SortByCalories:
+		return SortKeyInt(c.Calories()), id
+	case SortByCreatedAt:
+		return SortKeyTime(c.Recipe.CreatedAt), id
+	}
+	return "", id
+}
+
+// QueryRecipes evaluates a recipe query in memory, so that repositories keeping data
+// in process share the semantics of RecipeFilter and its sorts. Like Paginate it
+// leaves bounds of the configuration to services.
+func QueryRecipes(candidates []*RecipeCandidate, filter *RecipeFilter, page *PageRequest) ([]*RecipeCandidate, *PageInfo, error) {
+	if err := filter.Validate(math.MaxInt); err != nil {
+		return nil, nil, err
+	}
+	page, err := page.Normalize(RepositoryPageSizes, RecipeSorts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matched := make([]*RecipeCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if filter.Match(candidate) {
+			matched = append(matched, candidate)
+		}
+	}
+	return Paginate(matched, page, RecipeSortKey)
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
 )
 
@@ -11,8 +12,13 @@ type RecipeStep struct {
 	Name        string
 	Description string
 	StepNum     int
+	Version     int
 }
 
+var ErrStepsChanged = errors.New("recipe steps were changed concurrently")
+
+// IRecipeStepRepository keeps step nums unique in a recipe, Create and Update
+// fail on a num taken by another step
 type IRecipeStepRepository interface {
 	Create(ctx context.Context, recipeStep *RecipeStep) error
 	GetById(ctx context.Context, id uuid.UUID) (*RecipeStep, error)
@@ -29,4 +35,5 @@ type IRecipeStepService interface {
 	Update(ctx context.Context, recipeStep *RecipeStep) error
 	DeleteById(ctx context.Context, id uuid.UUID) error
 	DeleteAllByRecipeID(ctx context.Context, recipeId uuid.UUID) error
+	Reorder(ctx context.Context, recipeId uuid.UUID, stepIds []uuid.UUID) error
 }
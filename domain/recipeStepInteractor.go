@@ -12,4 +12,5 @@ type IRecipeStepInteractor interface {
 	Update(ctx context.Context, recipeStep *RecipeStep) error
 	DeleteById(ctx context.Context, id uuid.UUID) error
 	DeleteAllByRecipeID(ctx context.Context, recipeId uuid.UUID) error
+	Reorder(ctx context.Context, recipeId uuid.UUID, stepIds []uuid.UUID) error
 }
@@ -10,15 +10,16 @@ type Salad struct {
 	AuthorID    uuid.UUID
 	Name        string
 	Description string
+	Version     int
 	//rating float64 // todo А надо?
 }
 
 type ISaladRepository interface {
 	Create(ctx context.Context, salad *Salad) (uuid.UUID, error)
 	GetById(ctx context.Context, id uuid.UUID) (*Salad, error)
-	GetAll(ctx context.Context, filter *RecipeFilter, page int) ([]*Salad, int, error)
-	GetAllByUserId(ctx context.Context, id uuid.UUID) ([]*Salad, error)
-	GetAllRatedByUser(ctx context.Context, userId uuid.UUID, page int) ([]*Salad, int, error)
+	GetAll(ctx context.Context, filter *RecipeFilter, page *PageRequest) ([]*Salad, *PageInfo, error)
+	GetAllByUserId(ctx context.Context, id uuid.UUID, page *PageRequest) ([]*Salad, *PageInfo, error)
+	GetAllRatedByUser(ctx context.Context, userId uuid.UUID, page *PageRequest) ([]*Salad, *PageInfo, error)
 	Update(ctx context.Context, salad *Salad) error
 	DeleteById(ctx context.Context, id uuid.UUID) error
 }
@@ -26,9 +27,9 @@ type ISaladRepository interface {
 type ISaladService interface {
 	Create(ctx context.Context, salad *Salad) (uuid.UUID, error)
 	GetById(ctx context.Context, id uuid.UUID) (*Salad, error)
-	GetAll(ctx context.Context, filter *RecipeFilter, page int) ([]*Salad, int, error)
-	GetAllByUserId(ctx context.Context, id uuid.UUID) ([]*Salad, error)
-	GetAllRatedByUser(ctx context.Context, userId uuid.UUID, page int) ([]*Salad, int, error)
+	GetAll(ctx context.Context, filter *RecipeFilter, page *PageRequest) ([]*Salad, *PageInfo, error)
+	GetAllByUserId(ctx context.Context, id uuid.UUID, page *PageRequest) ([]*Salad, *PageInfo, error)
+	GetAllRatedByUser(ctx context.Context, userId uuid.UUID, page *PageRequest) ([]*Salad, *PageInfo, error)
 	Update(ctx context.Context, salad *Salad) error
 	DeleteById(ctx context.Context, id uuid.UUID) error
 }
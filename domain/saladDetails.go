@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+type SaladIngredient struct {
+	Ingredient  *Ingredient
+	Measurement *Measurement
+	Amount      float64
+	Optional    bool
+	Note        string
+}
+
+type SaladDetails struct {
+	Salad        *Salad
+	Author       *User
+	Recipe       *Recipe
+	Steps        []*RecipeStep
+	Ingredients  []*SaladIngredient
+	Types        []*SaladType
+	Comments     []*Comment
+	CommentsPage *PageInfo
+}
+
+type ISaladDetailsService interface {
+	GetById(ctx context.Context, saladId uuid.UUID, commentsPage *PageRequest) (*SaladDetails, error)
+}
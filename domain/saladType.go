@@ -5,17 +5,21 @@ import (
 	"github.com/google/uuid"
 )
 
+// SaladType is a node of the salad taxonomy, types with a nil ParentID are roots
 type SaladType struct {
 	ID          uuid.UUID
+	ParentID    uuid.UUID
 	Name        string
 	Description string
+	Version     int
 }
 
 type ISaladTypeRepository interface {
 	Create(ctx context.Context, saladType *SaladType) error
 	GetById(ctx context.Context, id uuid.UUID) (*SaladType, error)
-	GetAll(ctx context.Context, page int) ([]*SaladType, int, error)
+	GetAll(ctx context.Context, page *PageRequest) ([]*SaladType, *PageInfo, error)
 	GetAllBySaladId(ctx context.Context, saladId uuid.UUID) ([]*SaladType, error)
+	GetAllByParentId(ctx context.Context, parentId uuid.UUID) ([]*SaladType, error)
 	Update(ctx context.Context, saladType *SaladType) error
 	Link(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) error
 	Unlink(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) error
@@ -25,10 +29,17 @@ type ISaladTypeRepository interface {
 type ISaladTypeService interface {
 	Create(ctx context.Context, saladType *SaladType) error
 	GetById(ctx context.Context, id uuid.UUID) (*SaladType, error)
-	GetAll(ctx context.Context, page int) ([]*SaladType, int, error)
+	GetAll(ctx context.Context, page *PageRequest) ([]*SaladType, *PageInfo, error)
 	GetAllBySaladId(ctx context.Context, saladId uuid.UUID) ([]*SaladType, error)
+	// GetChildren returns direct subtypes, a nil parent gives the roots
+	GetChildren(ctx context.Context, parentId uuid.UUID) ([]*SaladType, error)
+	// GetSubtree returns the type followed by all of its descendants
+	GetSubtree(ctx context.Context, id uuid.UUID) ([]*SaladType, error)
+	// Move puts the type under the parent, a nil parent makes it a root
+	Move(ctx context.Context, id uuid.UUID, parentId uuid.UUID) error
 	Update(ctx context.Context, measurement *SaladType) error
 	Link(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) error
 	Unlink(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) error
+	// DeleteById fails with ErrTypeHasChildren until subtypes are moved or deleted
 	DeleteById(ctx context.Context, id uuid.UUID) error
 }
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// Substitution records that SubstituteID may replace IngredientID, Ratio is the amount
+// of the substitute per unit of the ingredient. Substitutions are one-way.
+type Substitution struct {
+	ID           uuid.UUID
+	IngredientID uuid.UUID
+	SubstituteID uuid.UUID
+	Ratio        float64
+	// Context tells where the substitution works, like "dressings" or "baking"
+	Context string
+	Version int
+}
+
+// SubstituteOption is a substitute for an ingredient of a recipe with its amount
+type SubstituteOption struct {
+	Substitution *Substitution
+	Ingredient   *Ingredient
+	Amount       float64
+}
+
+// SubstitutionChoice picks a substitution for an ingredient of a recipe
+type SubstitutionChoice struct {
+	IngredientID   uuid.UUID
+	SubstitutionID uuid.UUID
+}
+
+type ISubstitutionRepository interface {
+	Create(ctx context.Context, substitution *Substitution) (uuid.UUID, error)
+	GetById(ctx context.Context, id uuid.UUID) (*Substitution, error)
+	GetAllByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]*Substitution, error)
+	Update(ctx context.Context, substitution *Substitution) error
+	DeleteById(ctx context.Context, id uuid.UUID) error
+}
+
+type ISubstitutionService interface {
+	Create(ctx context.Context, substitution *Substitution) (uuid.UUID, error)
+	GetById(ctx context.Context, id uuid.UUID) (*Substitution, error)
+	GetAllByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]*Substitution, error)
+	Update(ctx context.Context, substitution *Substitution) error
+	DeleteById(ctx context.Context, id uuid.UUID) error
+	GetForRecipeIngredient(ctx context.Context, recipeIngredientId uuid.UUID) ([]*SubstituteOption, error)
+	// Apply assembles the recipe of the salad with the chosen substitutions, calories are
+	// counted for the substitutes. The result renders with IRecipeExportService.Render.
+	Apply(ctx context.Context, saladId uuid.UUID, choices []*SubstitutionChoice) (*RecipeExport, error)
+}
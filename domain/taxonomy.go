@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTypeCycle       = errors.New("type can't be moved under itself or its descendant")
+	ErrTypeHasChildren = errors.New("type has subtypes")
+)
+
+// SaladTypeAncestors returns the ids followed by all their ancestors among types,
+// every id appears once. Types missing from the list end the chain.
+func SaladTypeAncestors(ids []uuid.UUID, types []*SaladType) []uuid.UUID {
+	parents := make(map[uuid.UUID]uuid.UUID, len(types))
+	for _, saladType := range types {
+		parents[saladType.ID] = saladType.ParentID
+	}
+
+	seen := make(map[uuid.UUID]bool, len(ids))
+	ancestors := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		for id != uuid.Nil && !seen[id] {
+			seen[id] = true
+			ancestors = append(ancestors, id)
+			id = parents[id]
+		}
+	}
+	return ancestors
+}
+
+// IngredientTypeAncestors returns the type of the id followed by its ancestors, getType
+// looks them up. A nil type from getType ends the chain, so does a loop already stored.
+func IngredientTypeAncestors(id uuid.UUID, getType func(id uuid.UUID) (*IngredientType, error)) ([]*IngredientType, error) {
+	seen := make(map[uuid.UUID]bool)
+	ancestors := make([]*IngredientType, 0)
+	for id != uuid.Nil && !seen[id] {
+		seen[id] = true
+		ingredientType, err := getType(id)
+		if err != nil {
+			return nil, err
+		}
+		if ingredientType == nil {
+			break
+		}
+		ancestors = append(ancestors, ingredientType)
+		id = ingredientType.ParentID
+	}
+	return ancestors, nil
+}
+
+// CheckTypeMove walks up from the new parent with getParent and fails with ErrTypeCycle
+// if it meets the moved type. A loop already stored also ends the walk with ErrTypeCycle.
+func CheckTypeMove(id uuid.UUID, parentId uuid.UUID, getParent func(id uuid.UUID) (uuid.UUID, error)) error {
+	seen := make(map[uuid.UUID]bool)
+	for parentId != uuid.Nil {
+		if parentId == id || seen[parentId] {
+			return ErrTypeCycle
+		}
+		seen[parentId] = true
+
+		var err error
+		parentId, err = getParent(parentId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+type ITransaction interface {
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+}
+
+type ITransactionManager interface {
+	Begin(ctx context.Context) (ITransaction, error)
+}
+
+type transactionKey struct{}
+
+type transactionHooksKey struct{}
+
+type transactionHook struct {
+	fn         func()
+	onCommit   bool
+	onRollback bool
+}
+
+type transactionHooks struct {
+	mu    sync.Mutex
+	hooks []transactionHook
+}
+
+func (h *transactionHooks) add(hook transactionHook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = append(h.hooks, hook)
+}
+
+func (h *transactionHooks) run(committed bool) {
+	h.mu.Lock()
+	hooks := h.hooks
+	h.hooks = nil
+	h.mu.Unlock()
+	for _, hook := range hooks {
+		if committed && hook.onCommit || !committed && hook.onRollback {
+			hook.fn()
+		}
+	}
+}
+
+func hooksFromContext(ctx context.Context) (*transactionHooks, bool) {
+	hooks, ok := ctx.Value(transactionHooksKey{}).(*transactionHooks)
+	return hooks, ok
+}
+
+// AfterTransaction runs fn once the transaction of ctx is committed or rolled back,
+// right away outside a transaction. Caches use it to drop entries that reads outside
+// the transaction could load while it was running.
+func AfterTransaction(ctx context.Context, fn func()) {
+	if hooks, ok := hooksFromContext(ctx); ok {
+		hooks.add(transactionHook{fn: fn, onCommit: true, onRollback: true})
+		return
+	}
+	fn()
+}
+
+// AfterCommit runs fn once the transaction of ctx is committed, right away outside
+// a transaction. Changes outside the database, like deleted blobs, go through it
+// so that a rolled back caller doesn't lose them.
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := hooksFromContext(ctx); ok {
+		hooks.add(transactionHook{fn: fn, onCommit: true})
+		return
+	}
+	fn()
+}
+
+// AfterRollback runs fn if the transaction of ctx is rolled back, outside a
+// transaction it never runs. It undoes changes outside the database.
+func AfterRollback(ctx context.Context, fn func()) {
+	if hooks, ok := hooksFromContext(ctx); ok {
+		hooks.add(transactionHook{fn: fn, onRollback: true})
+	}
+}
+
+// ContextWithTransaction stores tx in ctx, repositories look it up with TransactionFromContext
+// and run their queries inside it instead of opening a new connection.
+func ContextWithTransaction(ctx context.Context, tx ITransaction) context.Context {
+	return context.WithValue(ctx, transactionKey{}, tx)
+}
+
+func TransactionFromContext(ctx context.Context) (ITransaction, bool) {
+	tx, ok := ctx.Value(transactionKey{}).(ITransaction)
+	return tx, ok
+}
+
+// RunInTransaction calls fn inside a transaction and commits it if fn succeeds.
+// When ctx already carries a transaction fn joins it and the outer caller commits.
+// Hooks added with AfterTransaction, AfterCommit and AfterRollback run once the transaction ends.
+func RunInTransaction(ctx context.Context, manager ITransactionManager, fn func(ctx context.Context) error) (err error) {
+	if _, ok := TransactionFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := manager.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+
+	hooks := new(transactionHooks)
+	committed := false
+	defer func() {
+		hooks.run(committed)
+	}()
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+	}()
+
+	txCtx := context.WithValue(ContextWithTransaction(ctx, tx), transactionHooksKey{}, hooks)
+	err = fn(txCtx)
+	if err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			return fmt.Errorf("%w (rollback: %s)", err, rbErr.Error())
+		}
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	committed = true
+	return nil
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	DimensionMass   = "mass"
+	DimensionVolume = "volume"
+	DimensionCount  = "count"
+)
+
+// Unit systems, units of neither system like spoons and pieces are used by both
+const (
+	MetricSystem   = "metric"
+	ImperialSystem = "imperial"
+)
+
+// Unit converts to the base unit of its dimension: grams, milliliters or pieces
+type Unit struct {
+	Code      string
+	Dimension string
+	System    string
+	Factor    float64
+}
+
+const (
+	UnitGram       = "g"
+	UnitKilogram   = "kg"
+	UnitOunce      = "oz"
+	UnitPound      = "lb"
+	UnitMilliliter = "ml"
+	UnitLiter      = "l"
+	UnitTeaspoon   = "tsp"
+	UnitTablespoon = "tbsp"
+	UnitCup        = "cup"
+	UnitFluidOunce = "fl oz"
+	UnitPint       = "pt"
+	UnitPinch      = "pinch"
+	UnitPiece      = "piece"
+)
+
+// Units are the known units, imperial volumes are US customary ones. Units of
+// a dimension and system go in ascending order of factor.
+var Units = []*Unit{
+	{Code: UnitGram, Dimension: DimensionMass, System: MetricSystem, Factor: 1},
+	{Code: UnitKilogram, Dimension: DimensionMass, System: MetricSystem, Factor: 1000},
+	{Code: UnitOunce, Dimension: DimensionMass, System: ImperialSystem, Factor: 28.349523125},
+	{Code: UnitPound, Dimension: DimensionMass, System: ImperialSystem, Factor: 453.59237},
+	{Code: UnitMilliliter, Dimension: DimensionVolume, System: MetricSystem, Factor: 1},
+	{Code: UnitLiter, Dimension: DimensionVolume, System: MetricSystem, Factor: 1000},
+	{Code: UnitPinch, Dimension: DimensionVolume, Factor: 0.308057599609375},
+	{Code: UnitTeaspoon, Dimension: DimensionVolume, Factor: 4.92892159375},
+	{Code: UnitTablespoon, Dimension: DimensionVolume, Factor: 14.78676478125},
+	{Code: UnitFluidOunce, Dimension: DimensionVolume, System: ImperialSystem, Factor: 29.5735295625},
+	{Code: UnitCup, Dimension: DimensionVolume, System: ImperialSystem, Factor: 236.5882365},
+	{Code: UnitPint, Dimension: DimensionVolume, System: ImperialSystem, Factor: 473.176473},
+	{Code: UnitPiece, Dimension: DimensionCount, Factor: 1},
+}
+
+var (
+	ErrUnknownUnit      = errors.New("unknown unit")
+	ErrDensityRequired  = errors.New("density is required to convert between mass and volume")
+	ErrIncompatibleUnit = errors.New("incompatible units")
+)
+
+func GetUnit(code string) (*Unit, error) {
+	for _, unit := range Units {
+		if unit.Code == code {
+			return unit, nil
+		}
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownUnit, code)
+}
+
+func VerifySystem(system string) error {
+	if system != MetricSystem && system != ImperialSystem {
+		return fmt.Errorf("unknown unit system %s", system)
+	}
+	return nil
+}
+
+type Quantity struct {
+	Amount float64
+	Unit   string
+}
+
+func (q Quantity) String() string {
+	return fmt.Sprintf("%s %s", formatAmount(q.Amount), q.Unit)
+}
+
+func formatAmount(amount float64) string {
+	rounded := math.Round(amount*100) / 100
+	if rounded == math.Trunc(rounded) {
+		return fmt.Sprintf("%.0f", rounded)
+	}
+	return fmt.Sprintf("%g", rounded)
+}
+
+// Convert converts the quantity to another unit. Density in grams per milliliter is
+// needed between mass and volume only, counted units don't convert to other dimensions.
+func Convert(quantity Quantity, to string, density float64) (Quantity, error) {
+	from, err := GetUnit(quantity.Unit)
+	if err != nil {
+		return Quantity{}, err
+	}
+	target, err := GetUnit(to)
+	if err != nil {
+		return Quantity{}, err
+	}
+
+	base := quantity.Amount * from.Factor
+	switch {
+	case from.Dimension == target.Dimension:
+	case from.Dimension == DimensionVolume && target.Dimension == DimensionMass:
+		if density <= 0 {
+			return Quantity{}, ErrDensityRequired
+		}
+		base *= density
+	case from.Dimension == DimensionMass && target.Dimension == DimensionVolume:
+		if density <= 0 {
+			return Quantity{}, ErrDensityRequired
+		}
+		base /= density
+	default:
+		return Quantity{}, fmt.Errorf("%w: %s to %s", ErrIncompatibleUnit, from.Code, to)
+	}
+	return Quantity{Amount: base / target.Factor, Unit: to}, nil
+}
+
+// ToSystem expresses the quantity in the largest unit of the system it's at least one of.
+// Units of neither system are kept, the dimension never changes.
+func ToSystem(quantity Quantity, system string) (Quantity, error) {
+	if err := VerifySystem(system); err != nil {
+		return Quantity{}, err
+	}
+	from, err := GetUnit(quantity.Unit)
+	if err != nil {
+		return Quantity{}, err
+	}
+	if from.System == "" || from.System == system {
+		return quantity, nil
+	}
+
+	base := quantity.Amount * from.Factor
+	var best *Unit
+	for _, unit := range Units {
+		if unit.Dimension != from.Dimension || unit.System != system {
+			continue
+		}
+		if best == nil || (base >= unit.Factor && unit.Factor > best.Factor) {
+			best = unit
+		}
+	}
+	if best == nil {
+		return quantity, nil
+	}
+	return Quantity{Amount: base / best.Factor, Unit: best.Code}, nil
+}
+
+// Quantity returns amount of the measurement, measurements without unit are counted in grams
+func (m *Measurement) Quantity(amount float64) Quantity {
+	if m.Unit == "" {
+		return Quantity{Amount: amount * m.Grams, Unit: UnitGram}
+	}
+	return Quantity{Amount: amount, Unit: m.Unit}
+}
@@ -12,14 +12,15 @@ type User struct {
 	Username string
 	Password string
 	Email    mail.Address
-	Role     string // todo: validate
+	Role     string // one of Roles, empty gives DefaultRole
+	Version  int
 }
 
 type IUserRepository interface {
 	Create(ctx context.Context, user *User) error
 	GetById(ctx context.Context, id uuid.UUID) (*User, error)
 	GetByUsername(ctx context.Context, username string) (*User, error)
-	GetAll(ctx context.Context, page int) ([]*User, error)
+	GetAll(ctx context.Context, page *PageRequest) ([]*User, *PageInfo, error)
 	Update(ctx context.Context, user *User) error
 	DeleteById(ctx context.Context, id uuid.UUID) error
 }
@@ -28,7 +29,7 @@ type IUserService interface {
 	Create(ctx context.Context, user *User) error
 	GetById(ctx context.Context, id uuid.UUID) (*User, error)
 	GetByUsername(ctx context.Context, username string) (*User, error)
-	GetAll(ctx context.Context, page int) ([]*User, error)
+	GetAll(ctx context.Context, page *PageRequest) ([]*User, *PageInfo, error)
 	Update(ctx context.Context, user *User) error
 	DeleteById(ctx context.Context, id uuid.UUID) error
 }
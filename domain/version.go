@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+)
+
+// Entities with a Version field are updated with compare-and-swap: repository Update
+// succeeds only when the stored version equals entity.Version, increments the stored
+// version and writes the new value back to entity.Version. Otherwise it returns
+// *VersionConflictError. Create sets Version to 1.
+
+var ErrVersionConflict = errors.New("version conflict")
+
+type VersionConflictError struct {
+	Entity  string
+	ID      uuid.UUID
+	Version int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s %s: version %d is stale", e.Entity, e.ID.String(), e.Version)
+}
+
+func (e *VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
+
+func ETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// ParseETag reads the version from an If-Match header value, weak tags are accepted
+func ParseETag(tag string) (int, error) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil {
+		return 0, fmt.Errorf("parsing etag %s: %w", tag, err)
+	}
+
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("parsing etag %s: invalid version", tag)
+	}
+	return version, nil
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Webhook event types partners can subscribe to
+const (
+	WebhookRecipePublished = "recipe.published"
+	WebhookCommentAdded    = "comment.added"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryDead      = "dead"
+)
+
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+var ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+
+type WebhookSubscription struct {
+	ID         uuid.UUID
+	URL        string
+	EventTypes []string
+	Secret     string
+	Active     bool
+	CreatedAt  time.Time
+	Version    int
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventType      string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+}
+
+// WebhookAttempt is one HTTP request of a delivery, StatusCode is 0 when no response was received
+type WebhookAttempt struct {
+	ID         uuid.UUID
+	DeliveryID uuid.UUID
+	Number     int
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+	Timestamp  time.Time
+}
+
+type IWebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *WebhookSubscription) error
+	GetById(ctx context.Context, id uuid.UUID) (*WebhookSubscription, error)
+	GetAll(ctx context.Context) ([]*WebhookSubscription, error)
+	GetAllByEventType(ctx context.Context, eventType string) ([]*WebhookSubscription, error)
+	Update(ctx context.Context, subscription *WebhookSubscription) error
+	DeleteById(ctx context.Context, id uuid.UUID) error
+}
+
+type IWebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *WebhookDelivery) error
+	GetById(ctx context.Context, id uuid.UUID) (*WebhookDelivery, error)
+	GetAllBySubscriptionId(ctx context.Context, subscriptionId uuid.UUID) ([]*WebhookDelivery, error)
+	// GetDue returns pending deliveries with NextAttemptAt not after now
+	GetDue(ctx context.Context, now time.Time, limit int) ([]*WebhookDelivery, error)
+	Update(ctx context.Context, delivery *WebhookDelivery) error
+	CreateAttempt(ctx context.Context, attempt *WebhookAttempt) error
+	GetAttempts(ctx context.Context, deliveryId uuid.UUID) ([]*WebhookAttempt, error)
+}
+
+// IWebhookDeadLetterRepository keeps deliveries which ran out of attempts
+type IWebhookDeadLetterRepository interface {
+	Create(ctx context.Context, delivery *WebhookDelivery) error
+	GetAll(ctx context.Context) ([]*WebhookDelivery, error)
+	DeleteById(ctx context.Context, id uuid.UUID) error
+}
+
+type IWebhookService interface {
+	CreateSubscription(ctx context.Context, subscription *WebhookSubscription) error
+	GetSubscriptionById(ctx context.Context, id uuid.UUID) (*WebhookSubscription, error)
+	GetAllSubscriptions(ctx context.Context) ([]*WebhookSubscription, error)
+	// UpdateSubscription keeps the stored secret if Secret is empty
+	UpdateSubscription(ctx context.Context, subscription *WebhookSubscription) error
+	DeleteSubscriptionById(ctx context.Context, id uuid.UUID) error
+
+	// HandleEvent is an EventHandler queueing deliveries for subscribers of the event
+	HandleEvent(ctx context.Context, event IEvent) error
+	// DeliverDue sends due deliveries and returns how many of them succeeded
+	DeliverDue(ctx context.Context, limit int) (int, error)
+	// Redeliver moves a dead delivery back to the queue
+	Redeliver(ctx context.Context, deliveryId uuid.UUID) error
+
+	GetDeliveries(ctx context.Context, subscriptionId uuid.UUID) ([]*WebhookDelivery, error)
+	GetAttempts(ctx context.Context, deliveryId uuid.UUID) ([]*WebhookAttempt, error)
+	GetDeadLetters(ctx context.Context) ([]*WebhookDelivery, error)
+}
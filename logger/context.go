@@ -0,0 +1,28 @@
+package logger
+
+import (
+	"context"
+	"github.com/Mx1q/ppo_services/domain"
+)
+
+type requestIDKey struct{}
+
+func ContextWithRequestID(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestId)
+}
+
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestId, ok := ctx.Value(requestIDKey{}).(string)
+	return requestId, ok && requestId != ""
+}
+
+func contextFields(ctx context.Context) []Field {
+	fields := make([]Field, 0, 2)
+	if requestId, ok := RequestIDFromContext(ctx); ok {
+		fields = append(fields, String(RequestIDKey, requestId))
+	}
+	if actor, ok := domain.ActorFromContext(ctx); ok && actor != nil {
+		fields = append(fields, UUID(UserIDKey, actor.ID))
+	}
+	return fields
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+const (
+	ComponentKey = "component"
+	RequestIDKey = "request_id"
+	UserIDKey    = "user_id"
+	ErrorKey     = "error"
+)
+
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key string, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Int(key string, value int) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Float(key string, value float64) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Bool(key string, value bool) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Value: value}
+}
+
+func UUID(key string, value uuid.UUID) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Err(err error) Field {
+	return Field{Key: ErrorKey, Value: err}
+}
+
+// Any logs value as JSON, sensitive fields of structs and maps are redacted
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+func addFields(event *zerolog.Event, fields []Field) *zerolog.Event {
+	for _, field := range fields {
+		if isSensitive(field.Key) {
+			event = event.Str(field.Key, redacted)
+			continue
+		}
+
+		switch value := field.Value.(type) {
+		case string:
+			event = event.Str(field.Key, value)
+		case int:
+			event = event.Int(field.Key, value)
+		case float64:
+			event = event.Float64(field.Key, value)
+		case bool:
+			event = event.Bool(field.Key, value)
+		case time.Duration:
+			event = event.Dur(field.Key, value)
+		case time.Time:
+			event = event.Time(field.Key, value)
+		case uuid.UUID:
+			event = event.Str(field.Key, value.String())
+		case error:
+			event = event.AnErr(field.Key, value)
+		case fmt.Stringer:
+			event = event.Stringer(field.Key, value)
+		default:
+			event = event.Interface(field.Key, redactValue(value))
+		}
+	}
+	return event
+}
+
+func addContextFields(ctx zerolog.Context, fields []Field) zerolog.Context {
+	for _, field := range fields {
+		if isSensitive(field.Key) {
+			ctx = ctx.Str(field.Key, redacted)
+			continue
+		}
+
+		switch value := field.Value.(type) {
+		case string:
+			ctx = ctx.Str(field.Key, value)
+		case int:
+			ctx = ctx.Int(field.Key, value)
+		case float64:
+			ctx = ctx.Float64(field.Key, value)
+		case bool:
+			ctx = ctx.Bool(field.Key, value)
+		case time.Duration:
+			ctx = ctx.Dur(field.Key, value)
+		case time.Time:
+			ctx = ctx.Time(field.Key, value)
+		case uuid.UUID:
+			ctx = ctx.Str(field.Key, value.String())
+		case error:
+			ctx = ctx.AnErr(field.Key, value)
+		case fmt.Stringer:
+			ctx = ctx.Stringer(field.Key, value)
+		default:
+			ctx = ctx.Interface(field.Key, redactValue(value))
+		}
+	}
+	return ctx
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"github.com/rs/zerolog"
 	"io"
 	"os"
@@ -10,53 +11,113 @@ const (
 	LoggerErrorLevel = "error"
 	LoggerWarnLevel  = "warn"
 	LoggerInfoLevel  = "info"
+	LoggerDebugLevel = "debug"
 )
 
+var Levels = []string{LoggerErrorLevel, LoggerWarnLevel, LoggerInfoLevel, LoggerDebugLevel}
+
 type ILogger interface {
+	Debugf(message string, args ...interface{})
 	Infof(message string, args ...interface{})
 	Warnf(message string, args ...interface{})
 	Errorf(message string, args ...interface{})
 	Fatalf(message string, args ...interface{})
+
+	Debug(message string, fields ...Field)
+	Info(message string, fields ...Field)
+	Warn(message string, fields ...Field)
+	Error(message string, fields ...Field)
+
+	// With returns a sub-logger adding fields to every record
+	With(fields ...Field) ILogger
+	// WithContext returns a sub-logger with request and user IDs found in ctx
+	WithContext(ctx context.Context) ILogger
+	// Component returns a sub-logger of a named part of the application
+	Component(name string) ILogger
 }
 
 type Logger struct {
 	logger *zerolog.Logger
 }
 
-func NewLogger(logLevel string, w io.Writer) ILogger {
-	var l zerolog.Level
+func parseLevel(logLevel string) zerolog.Level {
 	switch logLevel {
 	case LoggerErrorLevel:
-		l = zerolog.ErrorLevel
+		return zerolog.ErrorLevel
 	case LoggerWarnLevel:
-		l = zerolog.WarnLevel
+		return zerolog.WarnLevel
 	case LoggerInfoLevel:
-		l = zerolog.InfoLevel
+		return zerolog.InfoLevel
+	case LoggerDebugLevel:
+		return zerolog.DebugLevel
 	default:
-		l = zerolog.InfoLevel
+		return zerolog.InfoLevel
 	}
-	zerolog.SetGlobalLevel(l)
+}
 
+// NewLogger writes JSON records to w, the level applies only to this logger
+// and its sub-loggers
+func NewLogger(logLevel string, w io.Writer) ILogger {
 	skipFrameCount := 3
-	logger := zerolog.New(w).With().Timestamp().CallerWithSkipFrameCount(zerolog.CallerSkipFrameCount + skipFrameCount).Logger()
+	logger := zerolog.New(w).
+		Level(parseLevel(logLevel)).
+		With().Timestamp().CallerWithSkipFrameCount(zerolog.CallerSkipFrameCount + skipFrameCount).Logger()
 	return &Logger{
 		logger: &logger,
 	}
 }
 
+func (l *Logger) Debugf(message string, args ...interface{}) {
+	l.logger.Debug().Msgf(message, redactArgs(args)...)
+}
+
 func (l *Logger) Infof(message string, args ...interface{}) {
-	l.logger.Info().Msgf(message, args...)
+	l.logger.Info().Msgf(message, redactArgs(args)...)
 }
 
 func (l *Logger) Warnf(message string, args ...interface{}) {
-	l.logger.Warn().Msgf(message, args...)
+	l.logger.Warn().Msgf(message, redactArgs(args)...)
 }
 
 func (l *Logger) Errorf(message string, args ...interface{}) {
-	l.logger.Error().Msgf(message, args...)
+	l.logger.Error().Msgf(message, redactArgs(args)...)
 }
 
 func (l *Logger) Fatalf(message string, args ...interface{}) {
-	l.logger.Fatal().Msgf(message, args...)
+	l.logger.Fatal().Msgf(message, redactArgs(args)...)
 	os.Exit(1)
 }
+
+func (l *Logger) Debug(message string, fields ...Field) {
+	addFields(l.logger.Debug(), fields).Msg(message)
+}
+
+func (l *Logger) Info(message string, fields ...Field) {
+	addFields(l.logger.Info(), fields).Msg(message)
+}
+
+func (l *Logger) Warn(message string, fields ...Field) {
+	addFields(l.logger.Warn(), fields).Msg(message)
+}
+
+func (l *Logger) Error(message string, fields ...Field) {
+	addFields(l.logger.Error(), fields).Msg(message)
+}
+
+func (l *Logger) With(fields ...Field) ILogger {
+	if len(fields) == 0 {
+		return l
+	}
+	logger := addContextFields(l.logger.With(), fields).Logger()
+	return &Logger{
+		logger: &logger,
+	}
+}
+
+func (l *Logger) WithContext(ctx context.Context) ILogger {
+	return l.With(contextFields(ctx)...)
+}
+
+func (l *Logger) Component(name string) ILogger {
+	return l.With(String(ComponentKey, name))
+}
@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+const redacted = "***"
+
+var sensitiveKeys = map[string]bool{
+	"password":   true,
+	"hashedpass": true,
+	"secret":     true,
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	return sensitiveKeys[key]
+}
+
+// redactValue returns a JSON-like copy of value with sensitive keys masked at any depth
+func redactValue(value interface{}) interface{} {
+	decoded, _ := redactCopy(value)
+	return decoded
+}
+
+// redactCopy is redactValue that also reports whether anything was masked
+func redactCopy(value interface{}) (interface{}, bool) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value, false
+	}
+
+	var decoded interface{}
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		return value, false
+	}
+	return decoded, redactDecoded(decoded)
+}
+
+// RedactJSON masks sensitive keys of a JSON document at any depth, the same way
+// as log fields are masked. A document without them is returned as is.
+func RedactJSON(data []byte) ([]byte, error) {
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	if !redactDecoded(decoded) {
+		return data, nil
+	}
+	return json.Marshal(decoded)
+}
+
+// redactDecoded masks decoded JSON in place and reports whether anything was masked
+func redactDecoded(value interface{}) bool {
+	masked := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if isSensitive(key) {
+				v[key] = redacted
+				masked = true
+			} else if redactDecoded(nested) {
+				masked = true
+			}
+		}
+	case []interface{}:
+		for _, nested := range v {
+			if redactDecoded(nested) {
+				masked = true
+			}
+		}
+	}
+	return masked
+}
+
+// redactArgs replaces structs, slices and maps passed to printf methods that hold
+// sensitive fields at any depth with their masked JSON-like copies, the originals are
+// left untouched. Arguments without sensitive fields keep their own formatting.
+func redactArgs(args []interface{}) []interface{} {
+	var result []interface{}
+	for i, arg := range args {
+		if !isComposite(arg) {
+			continue
+		}
+		masked, ok := redactCopy(arg)
+		if !ok {
+			continue
+		}
+		if result == nil {
+			result = append([]interface{}(nil), args...)
+		}
+		result[i] = masked
+	}
+	if result == nil {
+		return args
+	}
+	return result
+}
+
+func isComposite(arg interface{}) bool {
+	value := reflect.ValueOf(arg)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return false
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
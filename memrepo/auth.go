@@ -0,0 +1,39 @@
+package memrepo
+
+import (
+	"context"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+// AuthRepository keeps credentials in the users of the store, Password of a
+// registered user holds the hash
+type AuthRepository struct {
+	users domain.IUserRepository
+}
+
+func NewAuthRepository(store *Store) domain.IAuthRepository {
+	return &AuthRepository{users: NewUserRepository(store)}
+}
+
+// Register ignores the role of user, self-registered users get the default one
+func (r *AuthRepository) Register(ctx context.Context, user *domain.User) (uuid.UUID, error) {
+	user.Role = ""
+	if err := r.users.Create(ctx, user); err != nil {
+		return uuid.Nil, err
+	}
+	return user.ID, nil
+}
+
+func (r *AuthRepository) GetByUsername(ctx context.Context, username string) (*domain.UserAuth, error) {
+	user, err := r.users.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.UserAuth{
+		ID:         user.ID,
+		Username:   user.Username,
+		HashedPass: user.Password,
+		Role:       user.Role,
+	}, nil
+}
@@ -0,0 +1,103 @@
+package memrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+type CommentRepository struct {
+	store *Store
+}
+
+func NewCommentRepository(store *Store) domain.ICommentRepository {
+	return &CommentRepository{store: store}
+}
+
+// Create allows one comment of a user per salad
+func (r *CommentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	comment.ID = newId(comment.ID)
+	for _, stored := range r.store.state.Comments {
+		if stored.ID == comment.ID || (stored.SaladID == comment.SaladID && stored.AuthorID == comment.AuthorID) {
+			return fmt.Errorf("comment of user %s to salad %s already exists", comment.AuthorID.String(), comment.SaladID.String())
+		}
+	}
+	comment.Version = 1
+	put(undo, r.store.state.Comments, comment.ID, copyOf(comment))
+	return nil
+}
+
+func (r *CommentRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	comment, ok := r.store.state.Comments[id]
+	if !ok {
+		return nil, fmt.Errorf("comment %s not found", id.String())
+	}
+	return copyOf(comment), nil
+}
+
+func (r *CommentRepository) GetBySaladAndUser(ctx context.Context, saladId uuid.UUID, userId uuid.UUID) (*domain.Comment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, comment := range r.store.state.Comments {
+		if comment.SaladID == saladId && comment.AuthorID == userId {
+			return copyOf(comment), nil
+		}
+	}
+	return nil, fmt.Errorf("comment of user %s to salad %s not found", userId.String(), saladId.String())
+}
+
+func (r *CommentRepository) GetAllBySaladID(ctx context.Context, saladId uuid.UUID, page *domain.PageRequest) ([]*domain.Comment, *domain.PageInfo, error) {
+	r.store.mu.RLock()
+	comments := make([]*domain.Comment, 0)
+	for _, comment := range r.store.state.Comments {
+		if comment.SaladID == saladId {
+			comments = append(comments, copyOf(comment))
+		}
+	}
+	r.store.mu.RUnlock()
+
+	return domain.Paginate(comments, page, func(comment *domain.Comment, sort string) (string, uuid.UUID) {
+		if sort == domain.SortByRating {
+			return domain.SortKeyInt(comment.Rating), comment.ID
+		}
+		return "", comment.ID
+	})
+}
+
+func (r *CommentRepository) Update(ctx context.Context, comment *domain.Comment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	stored, ok := r.store.state.Comments[comment.ID]
+	if !ok {
+		return fmt.Errorf("comment %s not found", comment.ID.String())
+	}
+	if stored.Version != comment.Version {
+		return &domain.VersionConflictError{Entity: "comment", ID: comment.ID, Version: comment.Version}
+	}
+	comment.Version++
+	put(undo, r.store.state.Comments, comment.ID, copyOf(comment))
+	return nil
+}
+
+func (r *CommentRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	if _, ok := r.store.state.Comments[id]; !ok {
+		return fmt.Errorf("comment %s not found", id.String())
+	}
+	remove(undo, r.store.state.Comments, id)
+	return nil
+}
@@ -0,0 +1,169 @@
+package memrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"strings"
+)
+
+type IngredientRepository struct {
+	store *Store
+}
+
+func NewIngredientRepository(store *Store) domain.IIngredientRepository {
+	return &IngredientRepository{store: store}
+}
+
+func copyIngredient(ingredient *domain.Ingredient) *domain.Ingredient {
+	copied := *ingredient
+	copied.Contents = append([]string(nil), ingredient.Contents...)
+	return &copied
+}
+
+func (r *IngredientRepository) Create(ctx context.Context, ingredient *domain.Ingredient) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	ingredient.ID = newId(ingredient.ID)
+	if _, ok := r.store.state.Ingredients[ingredient.ID]; ok {
+		return fmt.Errorf("ingredient %s already exists", ingredient.ID.String())
+	}
+	if err := r.checkType(ingredient); err != nil {
+		return err
+	}
+	ingredient.Version = 1
+	put(undo, r.store.state.Ingredients, ingredient.ID, copyIngredient(ingredient))
+	return nil
+}
+
+func (r *IngredientRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Ingredient, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ingredient, ok := r.store.state.Ingredients[id]
+	if !ok {
+		return nil, fmt.Errorf("ingredient %s not found", id.String())
+	}
+	return copyIngredient(ingredient), nil
+}
+
+func (r *IngredientRepository) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.Ingredient, *domain.PageInfo, error) {
+	r.store.mu.RLock()
+	ingredients := make([]*domain.Ingredient, 0, len(r.store.state.Ingredients))
+	for _, ingredient := range r.store.state.Ingredients {
+		ingredients = append(ingredients, copyIngredient(ingredient))
+	}
+	r.store.mu.RUnlock()
+
+	return domain.Paginate(ingredients, page, func(ingredient *domain.Ingredient, sort string) (string, uuid.UUID) {
+		if sort == domain.SortByCalories {
+			return domain.SortKeyInt(ingredient.Calories), ingredient.ID
+		}
+		return strings.ToLower(ingredient.Name), ingredient.ID
+	})
+}
+
+func (r *IngredientRepository) GetAllByRecipeId(ctx context.Context, id uuid.UUID) ([]*domain.Ingredient, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ingredients := make([]*domain.Ingredient, 0)
+	for _, link := range r.store.state.recipeIngredients(id) {
+		if ingredient, ok := r.store.state.Ingredients[link.IngredientID]; ok {
+			ingredients = append(ingredients, copyIngredient(ingredient))
+		}
+	}
+	return ingredients, nil
+}
+
+func (r *IngredientRepository) Link(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) (uuid.UUID, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	if _, ok := r.store.state.Ingredients[ingredientId]; !ok {
+		return uuid.Nil, fmt.Errorf("ingredient %s not found", ingredientId.String())
+	}
+	links := r.store.state.recipeIngredients(recipeId)
+	for _, link := range links {
+		if link.IngredientID == ingredientId {
+			return uuid.Nil, fmt.Errorf("ingredient %s already linked to recipe %s", ingredientId.String(), recipeId.String())
+		}
+	}
+
+	link := &domain.RecipeIngredient{
+		ID:           uuid.New(),
+		RecipeID:     recipeId,
+		IngredientID: ingredientId,
+		Order:        len(links) + 1,
+		Version:      1,
+	}
+	put(undo, r.store.state.RecipeIngredients, link.ID, link)
+	return link.ID, nil
+}
+
+func (r *IngredientRepository) Unlink(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	for _, link := range r.store.state.recipeIngredients(recipeId) {
+		if link.IngredientID == ingredientId {
+			remove(undo, r.store.state.RecipeIngredients, link.ID)
+			return nil
+		}
+	}
+	return fmt.Errorf("ingredient %s isn't linked to recipe %s", ingredientId.String(), recipeId.String())
+}
+
+func (r *IngredientRepository) Update(ctx context.Context, ingredient *domain.Ingredient) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	stored, ok := r.store.state.Ingredients[ingredient.ID]
+	if !ok {
+		return fmt.Errorf("ingredient %s not found", ingredient.ID.String())
+	}
+	if stored.Version != ingredient.Version {
+		return &domain.VersionConflictError{Entity: "ingredient", ID: ingredient.ID, Version: ingredient.Version}
+	}
+	if err := r.checkType(ingredient); err != nil {
+		return err
+	}
+	ingredient.Version++
+	put(undo, r.store.state.Ingredients, ingredient.ID, copyIngredient(ingredient))
+	return nil
+}
+
+// checkType allows ingredients without a type
+func (r *IngredientRepository) checkType(ingredient *domain.Ingredient) error {
+	if ingredient.TypeID == uuid.Nil {
+		return nil
+	}
+	if _, ok := r.store.state.IngredientTypes[ingredient.TypeID]; !ok {
+		return fmt.Errorf("ingredient type %s not found", ingredient.TypeID.String())
+	}
+	return nil
+}
+
+// DeleteById removes links of the ingredient as well
+func (r *IngredientRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	if _, ok := r.store.state.Ingredients[id]; !ok {
+		return fmt.Errorf("ingredient %s not found", id.String())
+	}
+	remove(undo, r.store.state.Ingredients, id)
+	for linkId, link := range r.store.state.RecipeIngredients {
+		if link.IngredientID == id {
+			remove(undo, r.store.state.RecipeIngredients, linkId)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package memrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"sort"
+	"strings"
+)
+
+type IngredientTypeRepository struct {
+	store *Store
+}
+
+func NewIngredientTypeRepository(store *Store) domain.IIngredientTypeRepository {
+	return &IngredientTypeRepository{store: store}
+}
+
+func copyIngredientType(ingredientType *domain.IngredientType) *domain.IngredientType {
+	copied := *ingredientType
+	copied.Contents = append([]string(nil), ingredientType.Contents...)
+	return &copied
+}
+
+func (r *IngredientTypeRepository) Create(ctx context.Context, ingredientType *domain.IngredientType) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	ingredientType.ID = newId(ingredientType.ID)
+	if _, ok := r.store.state.IngredientTypes[ingredientType.ID]; ok {
+		return fmt.Errorf("ingredient type %s already exists", ingredientType.ID.String())
+	}
+	ingredientType.Version = 1
+	put(undo, r.store.state.IngredientTypes, ingredientType.ID, copyIngredientType(ingredientType))
+	return nil
+}
+
+func (r *IngredientTypeRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.IngredientType, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ingredientType, ok := r.store.state.IngredientTypes[id]
+	if !ok {
+		return nil, fmt.Errorf("ingredient type %s not found", id.String())
+	}
+	return copyIngredientType(ingredientType), nil
+}
+
+// GetAll returns types ordered by name
+func (r *IngredientTypeRepository) GetAll(ctx context.Context) ([]*domain.IngredientType, error) {
+	return r.filter(func(*domain.IngredientType) bool { return true }), nil
+}
+
+func (r *IngredientTypeRepository) GetAllByParentId(ctx context.Context, parentId uuid.UUID) ([]*domain.IngredientType, error) {
+	return r.filter(func(ingredientType *domain.IngredientType) bool {
+		return ingredientType.ParentID == parentId
+	}), nil
+}
+
+func (r *IngredientTypeRepository) filter(match func(*domain.IngredientType) bool) []*domain.IngredientType {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ingredientTypes := make([]*domain.IngredientType, 0)
+	for _, ingredientType := range r.store.state.IngredientTypes {
+		if match(ingredientType) {
+			ingredientTypes = append(ingredientTypes, copyIngredientType(ingredientType))
+		}
+	}
+	sort.Slice(ingredientTypes, func(i, j int) bool {
+		return strings.ToLower(ingredientTypes[i].Name) < strings.ToLower(ingredientTypes[j].Name)
+	})
+	return ingredientTypes
+}
+
+func (r *IngredientTypeRepository) Update(ctx context.Context, ingredientType *domain.IngredientType) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	stored, ok := r.store.state.IngredientTypes[ingredientType.ID]
+	if !ok {
+		return fmt.Errorf("ingredient type %s not found", ingredientType.ID.String())
+	}
+	if stored.Version != ingredientType.Version {
+		return &domain.VersionConflictError{Entity: "ingredient type", ID: ingredientType.ID, Version: ingredientType.Version}
+	}
+	ingredientType.Version++
+	put(undo, r.store.state.IngredientTypes, ingredientType.ID, copyIngredientType(ingredientType))
+	return nil
+}
+
+// DeleteById leaves ingredients of the type without one
+func (r *IngredientTypeRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	if _, ok := r.store.state.IngredientTypes[id]; !ok {
+		return fmt.Errorf("ingredient type %s not found", id.String())
+	}
+	remove(undo, r.store.state.IngredientTypes, id)
+
+	for ingredientId, ingredient := range r.store.state.Ingredients {
+		if ingredient.TypeID == id {
+			untyped := copyIngredient(ingredient)
+			untyped.TypeID = uuid.Nil
+			put(undo, r.store.state.Ingredients, ingredientId, untyped)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package memrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+type KeywordRepository struct {
+	store *Store
+}
+
+func NewKeywordRepository(store *Store) domain.IKeywordValidatorRepository {
+	return &KeywordRepository{store: store}
+}
+
+func (r *KeywordRepository) Create(ctx context.Context, word *domain.KeyWord) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	word.ID = newId(word.ID)
+	for _, stored := range r.store.state.KeyWords {
+		if stored.Word == word.Word || stored.ID == word.ID {
+			return fmt.Errorf("keyword %s already exists", word.Word)
+		}
+	}
+	word.Version = 1
+	put(undo, r.store.state.KeyWords, word.ID, copyOf(word))
+	return nil
+}
+
+func (r *KeywordRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.KeyWord, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	word, ok := r.store.state.KeyWords[id]
+	if !ok {
+		return nil, fmt.Errorf("keyword %s not found", id.String())
+	}
+	return copyOf(word), nil
+}
+
+func (r *KeywordRepository) GetAll(ctx context.Context) (map[string]uuid.UUID, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	words := make(map[string]uuid.UUID, len(r.store.state.KeyWords))
+	for _, word := range r.store.state.KeyWords {
+		words[word.Word] = word.ID
+	}
+	return words, nil
+}
+
+func (r *KeywordRepository) Update(ctx context.Context, word *domain.KeyWord) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	stored, ok := r.store.state.KeyWords[word.ID]
+	if !ok {
+		return fmt.Errorf("keyword %s not found", word.ID.String())
+	}
+	if stored.Version != word.Version {
+		return &domain.VersionConflictError{Entity: "keyword", ID: word.ID, Version: word.Version}
+	}
+	word.Version++
+	put(undo, r.store.state.KeyWords, word.ID, copyOf(word))
+	return nil
+}
+
+func (r *KeywordRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	if _, ok := r.store.state.KeyWords[id]; !ok {
+		return fmt.Errorf("keyword %s not found", id.String())
+	}
+	remove(undo, r.store.state.KeyWords, id)
+	return nil
+}
@@ -0,0 +1,146 @@
+package memrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"math"
+	"sort"
+	"strings"
+)
+
+type MeasurementRepository struct {
+	store *Store
+}
+
+func NewMeasurementRepository(store *Store) domain.IMeasurementRepository {
+	return &MeasurementRepository{store: store}
+}
+
+func (r *MeasurementRepository) Create(ctx context.Context, measurement *domain.Measurement) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	measurement.ID = newId(measurement.ID)
+	if _, ok := r.store.state.Measurements[measurement.ID]; ok {
+		return fmt.Errorf("measurement %s already exists", measurement.ID.String())
+	}
+	measurement.Version = 1
+	put(undo, r.store.state.Measurements, measurement.ID, copyOf(measurement))
+	return nil
+}
+
+func (r *MeasurementRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Measurement, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	measurement, ok := r.store.state.Measurements[id]
+	if !ok {
+		return nil, fmt.Errorf("measurement %s not found", id.String())
+	}
+	return copyOf(measurement), nil
+}
+
+// GetByRecipeId returns the measurement of the ingredient in the recipe with the amount
+// rounded to an integer, as links of measurements are kept for compatibility
+func (r *MeasurementRepository) GetByRecipeId(ctx context.Context, ingredientId uuid.UUID, recipeId uuid.UUID) (*domain.Measurement, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, link := range r.store.state.recipeIngredients(recipeId) {
+		if link.IngredientID != ingredientId {
+			continue
+		}
+		measurement, ok := r.store.state.Measurements[link.MeasurementID]
+		if !ok {
+			return nil, 0, fmt.Errorf("measurement of ingredient %s not found", ingredientId.String())
+		}
+		return copyOf(measurement), int(math.Round(link.Amount)), nil
+	}
+	return nil, 0, fmt.Errorf("ingredient %s isn't linked to recipe %s", ingredientId.String(), recipeId.String())
+}
+
+func (r *MeasurementRepository) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeMeasurement, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	recipeMeasurements := make([]*domain.RecipeMeasurement, 0)
+	for _, link := range r.store.state.recipeIngredients(recipeId) {
+		measurement, ok := r.store.state.Measurements[link.MeasurementID]
+		if !ok {
+			continue
+		}
+		recipeMeasurements = append(recipeMeasurements, &domain.RecipeMeasurement{
+			IngredientID: link.IngredientID,
+			Measurement:  copyOf(measurement),
+			Amount:       int(math.Round(link.Amount)),
+		})
+	}
+	return recipeMeasurements, nil
+}
+
+// GetAll returns measurements ordered by name
+func (r *MeasurementRepository) GetAll(ctx context.Context) ([]*domain.Measurement, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	measurements := make([]*domain.Measurement, 0, len(r.store.state.Measurements))
+	for _, measurement := range r.store.state.Measurements {
+		measurements = append(measurements, copyOf(measurement))
+	}
+	sort.Slice(measurements, func(i, j int) bool {
+		return strings.ToLower(measurements[i].Name) < strings.ToLower(measurements[j].Name)
+	})
+	return measurements, nil
+}
+
+func (r *MeasurementRepository) UpdateLink(ctx context.Context, linkId uuid.UUID, measurementId uuid.UUID, amount int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	stored, ok := r.store.state.RecipeIngredients[linkId]
+	if !ok {
+		return fmt.Errorf("recipe ingredient %s not found", linkId.String())
+	}
+	if _, ok = r.store.state.Measurements[measurementId]; !ok {
+		return fmt.Errorf("measurement %s not found", measurementId.String())
+	}
+	link := copyOf(stored)
+	link.MeasurementID = measurementId
+	link.Amount = float64(amount)
+	link.Version++
+	put(undo, r.store.state.RecipeIngredients, link.ID, link)
+	return nil
+}
+
+func (r *MeasurementRepository) Update(ctx context.Context, measurement *domain.Measurement) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	stored, ok := r.store.state.Measurements[measurement.ID]
+	if !ok {
+		return fmt.Errorf("measurement %s not found", measurement.ID.String())
+	}
+	if stored.Version != measurement.Version {
+		return &domain.VersionConflictError{Entity: "measurement", ID: measurement.ID, Version: measurement.Version}
+	}
+	measurement.Version++
+	put(undo, r.store.state.Measurements, measurement.ID, copyOf(measurement))
+	return nil
+}
+
+func (r *MeasurementRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	if _, ok := r.store.state.Measurements[id]; !ok {
+		return fmt.Errorf("measurement %s not found", id.String())
+	}
+	remove(undo, r.store.state.Measurements, id)
+	return nil
+}
@@ -0,0 +1,121 @@
+package memrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"time"
+)
+
+type RecipeRepository struct {
+	store *Store
+}
+
+func NewRecipeRepository(store *Store) domain.IRecipeRepository {
+	return &RecipeRepository{store: store}
+}
+
+// Create allows one recipe per salad
+func (r *RecipeRepository) Create(ctx context.Context, recipe *domain.Recipe) (uuid.UUID, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	recipe.ID = newId(recipe.ID)
+	if _, ok := r.store.state.Recipes[recipe.ID]; ok {
+		return uuid.Nil, fmt.Errorf("recipe %s already exists", recipe.ID.String())
+	}
+	if _, ok := r.store.state.recipeOfSalad(recipe.SaladID); ok {
+		return uuid.Nil, fmt.Errorf("salad %s already has a recipe", recipe.SaladID.String())
+	}
+	recipe.CreatedAt = time.Now().UTC()
+	recipe.Version = 1
+	put(undo, r.store.state.Recipes, recipe.ID, copyOf(recipe))
+	return recipe.ID, nil
+}
+
+func (r *RecipeRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Recipe, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	recipe, ok := r.store.state.Recipes[id]
+	if !ok {
+		return nil, fmt.Errorf("recipe %s not found", id.String())
+	}
+	return copyOf(recipe), nil
+}
+
+func (r *RecipeRepository) GetBySaladId(ctx context.Context, saladId uuid.UUID) (*domain.Recipe, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	recipe, ok := r.store.state.recipeOfSalad(saladId)
+	if !ok {
+		return nil, fmt.Errorf("recipe of salad %s not found", saladId.String())
+	}
+	return copyOf(recipe), nil
+}
+
+func (r *RecipeRepository) GetAll(ctx context.Context, filter *domain.RecipeFilter, page *domain.PageRequest) ([]*domain.Recipe, *domain.PageInfo, error) {
+	r.store.mu.RLock()
+	candidates := r.store.state.candidates(false)
+	r.store.mu.RUnlock()
+
+	matched, info, err := domain.QueryRecipes(candidates, filter, page)
+	if err != nil {
+		return nil, nil, err
+	}
+	recipes := make([]*domain.Recipe, 0, len(matched))
+	for _, candidate := range matched {
+		recipes = append(recipes, candidate.Recipe)
+	}
+	return recipes, info, nil
+}
+
+// Update keeps the creation time and the salad of the recipe
+func (r *RecipeRepository) Update(ctx context.Context, recipe *domain.Recipe) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	stored, ok := r.store.state.Recipes[recipe.ID]
+	if !ok {
+		return fmt.Errorf("recipe %s not found", recipe.ID.String())
+	}
+	if stored.Version != recipe.Version {
+		return &domain.VersionConflictError{Entity: "recipe", ID: recipe.ID, Version: recipe.Version}
+	}
+	recipe.SaladID = stored.SaladID
+	recipe.CreatedAt = stored.CreatedAt
+	recipe.Version++
+	put(undo, r.store.state.Recipes, recipe.ID, copyOf(recipe))
+	return nil
+}
+
+// DeleteById removes steps and ingredients of the recipe as well
+func (r *RecipeRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	if _, ok := r.store.state.Recipes[id]; !ok {
+		return fmt.Errorf("recipe %s not found", id.String())
+	}
+	r.store.state.deleteRecipe(undo, id)
+	return nil
+}
+
+func (s *state) deleteRecipe(undo *undoLog, id uuid.UUID) {
+	remove(undo, s.Recipes, id)
+	for stepId, step := range s.RecipeSteps {
+		if step.RecipeID == id {
+			remove(undo, s.RecipeSteps, stepId)
+		}
+	}
+	for linkId, link := range s.RecipeIngredients {
+		if link.RecipeID == id {
+			remove(undo, s.RecipeIngredients, linkId)
+		}
+	}
+}
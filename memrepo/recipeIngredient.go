@@ -0,0 +1,98 @@
+package memrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+type RecipeIngredientRepository struct {
+	store *Store
+}
+
+func NewRecipeIngredientRepository(store *Store) domain.IRecipeIngredientRepository {
+	return &RecipeIngredientRepository{store: store}
+}
+
+func (r *RecipeIngredientRepository) Create(ctx context.Context, recipeIngredient *domain.RecipeIngredient) (uuid.UUID, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	recipeIngredient.ID = newId(recipeIngredient.ID)
+	if _, ok := r.store.state.RecipeIngredients[recipeIngredient.ID]; ok {
+		return uuid.Nil, fmt.Errorf("recipe ingredient %s already exists", recipeIngredient.ID.String())
+	}
+	if _, ok := r.store.state.Ingredients[recipeIngredient.IngredientID]; !ok {
+		return uuid.Nil, fmt.Errorf("ingredient %s not found", recipeIngredient.IngredientID.String())
+	}
+	recipeIngredient.Version = 1
+	put(undo, r.store.state.RecipeIngredients, recipeIngredient.ID, copyOf(recipeIngredient))
+	return recipeIngredient.ID, nil
+}
+
+func (r *RecipeIngredientRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.RecipeIngredient, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	recipeIngredient, ok := r.store.state.RecipeIngredients[id]
+	if !ok {
+		return nil, fmt.Errorf("recipe ingredient %s not found", id.String())
+	}
+	return copyOf(recipeIngredient), nil
+}
+
+func (r *RecipeIngredientRepository) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeIngredient, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	links := r.store.state.recipeIngredients(recipeId)
+	recipeIngredients := make([]*domain.RecipeIngredient, 0, len(links))
+	for _, link := range links {
+		recipeIngredients = append(recipeIngredients, copyOf(link))
+	}
+	return recipeIngredients, nil
+}
+
+func (r *RecipeIngredientRepository) Update(ctx context.Context, recipeIngredient *domain.RecipeIngredient) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	stored, ok := r.store.state.RecipeIngredients[recipeIngredient.ID]
+	if !ok {
+		return fmt.Errorf("recipe ingredient %s not found", recipeIngredient.ID.String())
+	}
+	if stored.Version != recipeIngredient.Version {
+		return &domain.VersionConflictError{Entity: "recipe ingredient", ID: recipeIngredient.ID, Version: recipeIngredient.Version}
+	}
+	recipeIngredient.Version++
+	put(undo, r.store.state.RecipeIngredients, recipeIngredient.ID, copyOf(recipeIngredient))
+	return nil
+}
+
+func (r *RecipeIngredientRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	if _, ok := r.store.state.RecipeIngredients[id]; !ok {
+		return fmt.Errorf("recipe ingredient %s not found", id.String())
+	}
+	remove(undo, r.store.state.RecipeIngredients, id)
+	return nil
+}
+
+func (r *RecipeIngredientRepository) DeleteAllByRecipeId(ctx context.Context, recipeId uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	for id, link := range r.store.state.RecipeIngredients {
+		if link.RecipeID == recipeId {
+			remove(undo, r.store.state.RecipeIngredients, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package memrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"sort"
+)
+
+type RecipeStepRepository struct {
+	store *Store
+}
+
+func NewRecipeStepRepository(store *Store) domain.IRecipeStepRepository {
+	return &RecipeStepRepository{store: store}
+}
+
+func (r *RecipeStepRepository) Create(ctx context.Context, recipeStep *domain.RecipeStep) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	recipeStep.ID = newId(recipeStep.ID)
+	if _, ok := r.store.state.RecipeSteps[recipeStep.ID]; ok {
+		return fmt.Errorf("recipe step %s already exists", recipeStep.ID.String())
+	}
+	if err := r.checkStepNum(recipeStep); err != nil {
+		return err
+	}
+	recipeStep.Version = 1
+	put(undo, r.store.state.RecipeSteps, recipeStep.ID, copyOf(recipeStep))
+	return nil
+}
+
+func (r *RecipeStepRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.RecipeStep, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	recipeStep, ok := r.store.state.RecipeSteps[id]
+	if !ok {
+		return nil, fmt.Errorf("recipe step %s not found", id.String())
+	}
+	return copyOf(recipeStep), nil
+}
+
+// GetAllByRecipeID returns steps ordered by StepNum
+func (r *RecipeStepRepository) GetAllByRecipeID(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeStep, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	steps := make([]*domain.RecipeStep, 0)
+	for _, step := range r.store.state.RecipeSteps {
+		if step.RecipeID == recipeId {
+			steps = append(steps, copyOf(step))
+		}
+	}
+	sort.Slice(steps, func(i, j int) bool {
+		return steps[i].StepNum < steps[j].StepNum
+	})
+	return steps, nil
+}
+
+func (r *RecipeStepRepository) Update(ctx context.Context, recipeStep *domain.RecipeStep) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	stored, ok := r.store.state.RecipeSteps[recipeStep.ID]
+	if !ok {
+		return fmt.Errorf("recipe step %s not found", recipeStep.ID.String())
+	}
+	if stored.Version != recipeStep.Version {
+		return &domain.VersionConflictError{Entity: "recipe step", ID: recipeStep.ID, Version: recipeStep.Version}
+	}
+	if err := r.checkStepNum(recipeStep); err != nil {
+		return err
+	}
+	recipeStep.Version++
+	put(undo, r.store.state.RecipeSteps, recipeStep.ID, copyOf(recipeStep))
+	return nil
+}
+
+// checkStepNum keeps step nums unique in a recipe
+func (r *RecipeStepRepository) checkStepNum(recipeStep *domain.RecipeStep) error {
+	for id, step := range r.store.state.RecipeSteps {
+		if id != recipeStep.ID && step.RecipeID == recipeStep.RecipeID && step.StepNum == recipeStep.StepNum {
+			return fmt.Errorf("step %d of recipe %s already exists", recipeStep.StepNum, recipeStep.RecipeID.String())
+		}
+	}
+	return nil
+}
+
+func (r *RecipeStepRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	if _, ok := r.store.state.RecipeSteps[id]; !ok {
+		return fmt.Errorf("recipe step %s not found", id.String())
+	}
+	remove(undo, r.store.state.RecipeSteps, id)
+	return nil
+}
+
+func (r *RecipeStepRepository) DeleteAllByRecipeID(ctx context.Context, recipeId uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	for id, step := range r.store.state.RecipeSteps {
+		if step.RecipeID == recipeId {
+			remove(undo, r.store.state.RecipeSteps, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package memrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"strings"
+)
+
+type SaladRepository struct {
+	store *Store
+}
+
+func NewSaladRepository(store *Store) domain.ISaladRepository {
+	return &SaladRepository{store: store}
+}
+
+func (r *SaladRepository) Create(ctx context.Context, salad *domain.Salad) (uuid.UUID, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	salad.ID = newId(salad.ID)
+	if _, ok := r.store.state.Salads[salad.ID]; ok {
+		return uuid.Nil, fmt.Errorf("salad %s already exists", salad.ID.String())
+	}
+	salad.Version = 1
+	put(undo, r.store.state.Salads, salad.ID, copyOf(salad))
+	return salad.ID, nil
+}
+
+func (r *SaladRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Salad, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	salad, ok := r.store.state.Salads[id]
+	if !ok {
+		return nil, fmt.Errorf("salad %s not found", id.String())
+	}
+	return copyOf(salad), nil
+}
+
+// GetAll matches salads without a recipe as salads with an empty one
+func (r *SaladRepository) GetAll(ctx context.Context, filter *domain.RecipeFilter, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	r.store.mu.RLock()
+	candidates := r.store.state.candidates(true)
+	r.store.mu.RUnlock()
+
+	matched, info, err := domain.QueryRecipes(candidates, filter, page)
+	if err != nil {
+		return nil, nil, err
+	}
+	salads := make([]*domain.Salad, 0, len(matched))
+	for _, candidate := range matched {
+		salads = append(salads, candidate.Salad)
+	}
+	return salads, info, nil
+}
+
+// saladNameKey orders salads by name ignoring case
+func saladNameKey(salad *domain.Salad, sort string) (string, uuid.UUID) {
+	return strings.ToLower(salad.Name), salad.ID
+}
+
+// GetAllByUserId orders salads of the author by name
+func (r *SaladRepository) GetAllByUserId(ctx context.Context, id uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	r.store.mu.RLock()
+	salads := make([]*domain.Salad, 0)
+	for _, salad := range r.store.state.Salads {
+		if salad.AuthorID == id {
+			salads = append(salads, copyOf(salad))
+		}
+	}
+	r.store.mu.RUnlock()
+
+	return domain.Paginate(salads, page, saladNameKey)
+}
+
+// GetAllRatedByUser returns salads the user commented on
+func (r *SaladRepository) GetAllRatedByUser(ctx context.Context, userId uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	r.store.mu.RLock()
+	salads := make([]*domain.Salad, 0)
+	for _, comment := range r.store.state.Comments {
+		if salad, ok := r.store.state.Salads[comment.SaladID]; ok && comment.AuthorID == userId {
+			salads = append(salads, copyOf(salad))
+		}
+	}
+	r.store.mu.RUnlock()
+
+	return domain.Paginate(salads, page, saladNameKey)
+}
+
+func (r *SaladRepository) Update(ctx context.Context, salad *domain.Salad) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	stored, ok := r.store.state.Salads[salad.ID]
+	if !ok {
+		return fmt.Errorf("salad %s not found", salad.ID.String())
+	}
+	if stored.Version != salad.Version {
+		return &domain.VersionConflictError{Entity: "salad", ID: salad.ID, Version: salad.Version}
+	}
+	salad.Version++
+	put(undo, r.store.state.Salads, salad.ID, copyOf(salad))
+	return nil
+}
+
+// DeleteById removes the recipe, comments and type links of the salad as well
+func (r *SaladRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	if _, ok := r.store.state.Salads[id]; !ok {
+		return fmt.Errorf("salad %s not found", id.String())
+	}
+	remove(undo, r.store.state.Salads, id)
+
+	if recipe, ok := r.store.state.recipeOfSalad(id); ok {
+		r.store.state.deleteRecipe(undo, recipe.ID)
+	}
+	for commentId, comment := range r.store.state.Comments {
+		if comment.SaladID == id {
+			remove(undo, r.store.state.Comments, commentId)
+		}
+	}
+	r.store.state.removeLinks(undo, func(link saladTypeLink) bool { return link.SaladID == id })
+	return nil
+}
@@ -0,0 +1,148 @@
+package memrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"sort"
+	"strings"
+)
+
+type SaladTypeRepository struct {
+	store *Store
+}
+
+func NewSaladTypeRepository(store *Store) domain.ISaladTypeRepository {
+	return &SaladTypeRepository{store: store}
+}
+
+func (r *SaladTypeRepository) Create(ctx context.Context, saladType *domain.SaladType) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	saladType.ID = newId(saladType.ID)
+	if _, ok := r.store.state.SaladTypes[saladType.ID]; ok {
+		return fmt.Errorf("salad type %s already exists", saladType.ID.String())
+	}
+	saladType.Version = 1
+	put(undo, r.store.state.SaladTypes, saladType.ID, copyOf(saladType))
+	return nil
+}
+
+func (r *SaladTypeRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.SaladType, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	saladType, ok := r.store.state.SaladTypes[id]
+	if !ok {
+		return nil, fmt.Errorf("salad type %s not found", id.String())
+	}
+	return copyOf(saladType), nil
+}
+
+func (r *SaladTypeRepository) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.SaladType, *domain.PageInfo, error) {
+	saladTypes := r.filter(func(*domain.SaladType) bool { return true })
+	return domain.Paginate(saladTypes, page, func(saladType *domain.SaladType, sort string) (string, uuid.UUID) {
+		return strings.ToLower(saladType.Name), saladType.ID
+	})
+}
+
+func (r *SaladTypeRepository) GetAllBySaladId(ctx context.Context, saladId uuid.UUID) ([]*domain.SaladType, error) {
+	r.store.mu.RLock()
+	linked := make(map[uuid.UUID]bool)
+	for _, link := range r.store.state.SaladTypeLinks {
+		if link.SaladID == saladId {
+			linked[link.SaladTypeID] = true
+		}
+	}
+	r.store.mu.RUnlock()
+
+	return r.filter(func(saladType *domain.SaladType) bool { return linked[saladType.ID] }), nil
+}
+
+func (r *SaladTypeRepository) GetAllByParentId(ctx context.Context, parentId uuid.UUID) ([]*domain.SaladType, error) {
+	return r.filter(func(saladType *domain.SaladType) bool {
+		return saladType.ParentID == parentId
+	}), nil
+}
+
+// filter returns matching types ordered by name
+func (r *SaladTypeRepository) filter(match func(*domain.SaladType) bool) []*domain.SaladType {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	saladTypes := make([]*domain.SaladType, 0)
+	for _, saladType := range r.store.state.SaladTypes {
+		if match(saladType) {
+			saladTypes = append(saladTypes, copyOf(saladType))
+		}
+	}
+	sort.Slice(saladTypes, func(i, j int) bool {
+		return strings.ToLower(saladTypes[i].Name) < strings.ToLower(saladTypes[j].Name)
+	})
+	return saladTypes
+}
+
+func (r *SaladTypeRepository) Update(ctx context.Context, saladType *domain.SaladType) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	stored, ok := r.store.state.SaladTypes[saladType.ID]
+	if !ok {
+		return fmt.Errorf("salad type %s not found", saladType.ID.String())
+	}
+	if stored.Version != saladType.Version {
+		return &domain.VersionConflictError{Entity: "salad type", ID: saladType.ID, Version: saladType.Version}
+	}
+	saladType.Version++
+	put(undo, r.store.state.SaladTypes, saladType.ID, copyOf(saladType))
+	return nil
+}
+
+func (r *SaladTypeRepository) Link(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	if _, ok := r.store.state.SaladTypes[saladTypeId]; !ok {
+		return fmt.Errorf("salad type %s not found", saladTypeId.String())
+	}
+	link := saladTypeLink{SaladID: saladId, SaladTypeID: saladTypeId}
+	for _, linked := range r.store.state.SaladTypeLinks {
+		if linked == link {
+			return fmt.Errorf("salad type %s already linked to salad %s", saladTypeId.String(), saladId.String())
+		}
+	}
+	r.store.state.addLink(undo, link)
+	return nil
+}
+
+func (r *SaladTypeRepository) Unlink(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	link := saladTypeLink{SaladID: saladId, SaladTypeID: saladTypeId}
+	if r.store.state.removeLinks(undo, func(linked saladTypeLink) bool { return linked == link }) {
+		return nil
+	}
+	return fmt.Errorf("salad type %s isn't linked to salad %s", saladTypeId.String(), saladId.String())
+}
+
+// DeleteById removes links of the type as well
+func (r *SaladTypeRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	if _, ok := r.store.state.SaladTypes[id]; !ok {
+		return fmt.Errorf("salad type %s not found", id.String())
+	}
+	remove(undo, r.store.state.SaladTypes, id)
+
+	r.store.state.removeLinks(undo, func(link saladTypeLink) bool { return link.SaladTypeID == id })
+	return nil
+}
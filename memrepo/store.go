@@ -0,0 +1,226 @@
+package memrepo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Store holds the state of memory repositories. Repositories of one store share it,
+// so links made by one of them are seen by the others. Save and OpenStore keep the
+// state in a JSON file between runs of command line tools.
+type Store struct {
+	mu    sync.RWMutex
+	state state
+}
+
+type saladTypeLink struct {
+	SaladID     uuid.UUID `json:"saladId"`
+	SaladTypeID uuid.UUID `json:"saladTypeId"`
+}
+
+type state struct {
+	Ingredients       map[uuid.UUID]*domain.Ingredient       `json:"ingredients"`
+	IngredientTypes   map[uuid.UUID]*domain.IngredientType   `json:"ingredientTypes"`
+	Measurements      map[uuid.UUID]*domain.Measurement      `json:"measurements"`
+	SaladTypes        map[uuid.UUID]*domain.SaladType        `json:"saladTypes"`
+	KeyWords          map[uuid.UUID]*domain.KeyWord          `json:"keywords"`
+	RecipeIngredients map[uuid.UUID]*domain.RecipeIngredient `json:"recipeIngredients"`
+	SaladTypeLinks    []saladTypeLink                        `json:"saladTypeLinks"`
+	Users             map[uuid.UUID]*domain.User             `json:"users"`
+	Salads            map[uuid.UUID]*domain.Salad            `json:"salads"`
+	Recipes           map[uuid.UUID]*domain.Recipe           `json:"recipes"`
+	RecipeSteps       map[uuid.UUID]*domain.RecipeStep       `json:"recipeSteps"`
+	Comments          map[uuid.UUID]*domain.Comment          `json:"comments"`
+}
+
+func NewStore() *Store {
+	store := &Store{}
+	store.state.init()
+	return store
+}
+
+func (s *state) init() {
+	if s.Ingredients == nil {
+		s.Ingredients = make(map[uuid.UUID]*domain.Ingredient)
+	}
+	if s.IngredientTypes == nil {
+		s.IngredientTypes = make(map[uuid.UUID]*domain.IngredientType)
+	}
+	if s.Measurements == nil {
+		s.Measurements = make(map[uuid.UUID]*domain.Measurement)
+	}
+	if s.SaladTypes == nil {
+		s.SaladTypes = make(map[uuid.UUID]*domain.SaladType)
+	}
+	if s.KeyWords == nil {
+		s.KeyWords = make(map[uuid.UUID]*domain.KeyWord)
+	}
+	if s.RecipeIngredients == nil {
+		s.RecipeIngredients = make(map[uuid.UUID]*domain.RecipeIngredient)
+	}
+	if s.Users == nil {
+		s.Users = make(map[uuid.UUID]*domain.User)
+	}
+	if s.Salads == nil {
+		s.Salads = make(map[uuid.UUID]*domain.Salad)
+	}
+	if s.Recipes == nil {
+		s.Recipes = make(map[uuid.UUID]*domain.Recipe)
+	}
+	if s.RecipeSteps == nil {
+		s.RecipeSteps = make(map[uuid.UUID]*domain.RecipeStep)
+	}
+	if s.Comments == nil {
+		s.Comments = make(map[uuid.UUID]*domain.Comment)
+	}
+}
+
+// OpenStore loads the state saved to the file, a missing file gives an empty store
+func OpenStore(path string) (*Store, error) {
+	store := NewStore()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading store: %w", err)
+	}
+
+	if err = json.Unmarshal(data, &store.state); err != nil {
+		return nil, fmt.Errorf("decoding store: %w", err)
+	}
+	store.state.init()
+	return store, nil
+}
+
+// Save writes the state to a temporary file renamed over the path
+func (s *Store) Save(path string) error {
+	s.mu.RLock()
+	data, err := json.MarshalIndent(&s.state, "", "  ")
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("encoding store: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".store-*")
+	if err != nil {
+		return fmt.Errorf("saving store: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		return fmt.Errorf("saving store: %w", err)
+	}
+	return nil
+}
+
+// copyOf copies entities without slices, stored entities are never handed out
+func copyOf[T any](item *T) *T {
+	copied := *item
+	return &copied
+}
+
+func newId(id uuid.UUID) uuid.UUID {
+	if id == uuid.Nil {
+		return uuid.New()
+	}
+	return id
+}
+
+// recipeIngredients returns links of the recipe in display order
+func (s *state) recipeIngredients(recipeId uuid.UUID) []*domain.RecipeIngredient {
+	links := make([]*domain.RecipeIngredient, 0)
+	for _, link := range s.RecipeIngredients {
+		if link.RecipeID == recipeId {
+			links = append(links, link)
+		}
+	}
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].Order != links[j].Order {
+			return links[i].Order < links[j].Order
+		}
+		return links[i].ID.String() < links[j].ID.String()
+	})
+	return links
+}
+
+func (s *state) recipeOfSalad(saladId uuid.UUID) (*domain.Recipe, bool) {
+	for _, recipe := range s.Recipes {
+		if recipe.SaladID == saladId {
+			return recipe, true
+		}
+	}
+	return nil, false
+}
+
+// candidates builds what RecipeFilter looks at for every salad. With withoutRecipe
+// a salad without a recipe gets an empty one with the ID of the salad, otherwise it is skipped.
+func (s *state) candidates(withoutRecipe bool) []*domain.RecipeCandidate {
+	saladTypes := make([]*domain.SaladType, 0, len(s.SaladTypes))
+	for _, saladType := range s.SaladTypes {
+		saladTypes = append(saladTypes, saladType)
+	}
+	ingredientTypes := make([]*domain.IngredientType, 0, len(s.IngredientTypes))
+	for _, ingredientType := range s.IngredientTypes {
+		ingredientTypes = append(ingredientTypes, copyIngredientType(ingredientType))
+	}
+
+	candidates := make([]*domain.RecipeCandidate, 0, len(s.Salads))
+	for _, salad := range s.Salads {
+		recipe, ok := s.recipeOfSalad(salad.ID)
+		if !ok && !withoutRecipe {
+			continue
+		}
+		if !ok {
+			recipe = &domain.Recipe{ID: salad.ID, SaladID: salad.ID}
+		}
+
+		ingredients := make([]*domain.SaladIngredient, 0)
+		for _, link := range s.recipeIngredients(recipe.ID) {
+			ingredient, ok := s.Ingredients[link.IngredientID]
+			if !ok {
+				continue
+			}
+			item := &domain.SaladIngredient{
+				Ingredient: copyIngredient(ingredient),
+				Amount:     link.Amount,
+				Optional:   link.Optional,
+				Note:       link.Note,
+			}
+			if measurement, ok := s.Measurements[link.MeasurementID]; ok {
+				item.Measurement = copyOf(measurement)
+			}
+			ingredients = append(ingredients, item)
+		}
+
+		typeIds := make([]uuid.UUID, 0)
+		for _, link := range s.SaladTypeLinks {
+			if link.SaladID == salad.ID {
+				typeIds = append(typeIds, link.SaladTypeID)
+			}
+		}
+
+		candidates = append(candidates, &domain.RecipeCandidate{
+			Salad:           copyOf(salad),
+			Recipe:          copyOf(recipe),
+			Ingredients:     ingredients,
+			TypeIDs:         domain.SaladTypeAncestors(typeIds, saladTypes),
+			IngredientTypes: ingredientTypes,
+		})
+	}
+	return candidates
+}
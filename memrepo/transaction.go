@@ -0,0 +1,144 @@
+package memrepo
+
+import (
+	"context"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+// TransactionManager gives transactions that log an undo entry for each of their writes.
+// They aren't isolated, other callers see their writes before the commit, but a rollback
+// undoes only the writes of the transaction, writes of other callers made meanwhile stay.
+type TransactionManager struct {
+	store *Store
+}
+
+func NewTransactionManager(store *Store) domain.ITransactionManager {
+	return &TransactionManager{store: store}
+}
+
+type transaction struct {
+	store *Store
+	undo  undoLog
+	done  bool
+}
+
+func (m *TransactionManager) Begin(ctx context.Context) (domain.ITransaction, error) {
+	return &transaction{store: m.store}, nil
+}
+
+func (t *transaction) Commit(ctx context.Context) error {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	t.undo.changes = nil
+	t.done = true
+	return nil
+}
+
+func (t *transaction) Rollback(ctx context.Context) error {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	if t.done {
+		return nil
+	}
+	for i := len(t.undo.changes) - 1; i >= 0; i-- {
+		t.undo.changes[i]()
+	}
+	t.undo.changes = nil
+	t.done = true
+	return nil
+}
+
+// undoLog holds the undo entries of a transaction, rollback runs them newest first.
+// An entry restores an item only while it is as the transaction left it, so a later
+// write of another caller wins over the rollback.
+type undoLog struct {
+	changes []func()
+}
+
+// undoLog returns the log of the unfinished transaction of the store in ctx, nil
+// outside of one. The caller holds the write lock of the store.
+func (s *Store) undoLog(ctx context.Context) *undoLog {
+	tx, ok := domain.TransactionFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	t, ok := tx.(*transaction)
+	if !ok || t.store != s || t.done {
+		return nil
+	}
+	return &t.undo
+}
+
+func (l *undoLog) record(undo func()) {
+	if l != nil {
+		l.changes = append(l.changes, undo)
+	}
+}
+
+// put stores the item, stored items are replaced and never changed in place
+func put[T any](undo *undoLog, items map[uuid.UUID]*T, id uuid.UUID, item *T) {
+	before, existed := items[id]
+	items[id] = item
+	undo.record(func() {
+		if items[id] != item {
+			return
+		}
+		if existed {
+			items[id] = before
+		} else {
+			delete(items, id)
+		}
+	})
+}
+
+func remove[T any](undo *undoLog, items map[uuid.UUID]*T, id uuid.UUID) {
+	before, existed := items[id]
+	if !existed {
+		return
+	}
+	delete(items, id)
+	undo.record(func() {
+		if _, ok := items[id]; !ok {
+			items[id] = before
+		}
+	})
+}
+
+func (s *state) addLink(undo *undoLog, link saladTypeLink) {
+	s.SaladTypeLinks = append(s.SaladTypeLinks, link)
+	undo.record(func() {
+		s.removeLinks(nil, func(linked saladTypeLink) bool { return linked == link })
+	})
+}
+
+// removeLinks removes matching links and tells whether there were any
+func (s *state) removeLinks(undo *undoLog, match func(link saladTypeLink) bool) bool {
+	removed := false
+	links := s.SaladTypeLinks[:0]
+	for _, link := range s.SaladTypeLinks {
+		if !match(link) {
+			links = append(links, link)
+			continue
+		}
+		removed = true
+		undo.record(func() {
+			if !s.hasLink(link) {
+				s.SaladTypeLinks = append(s.SaladTypeLinks, link)
+			}
+		})
+	}
+	s.SaladTypeLinks = links
+	return removed
+}
+
+func (s *state) hasLink(link saladTypeLink) bool {
+	for _, linked := range s.SaladTypeLinks {
+		if linked == link {
+			return true
+		}
+	}
+	return false
+}
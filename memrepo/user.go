@@ -0,0 +1,110 @@
+package memrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"strings"
+)
+
+type UserRepository struct {
+	store *Store
+}
+
+func NewUserRepository(store *Store) domain.IUserRepository {
+	return &UserRepository{store: store}
+}
+
+// Create gives users without a role the default one, usernames are unique
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	user.ID = newId(user.ID)
+	for _, stored := range r.store.state.Users {
+		if stored.ID == user.ID || stored.Username == user.Username {
+			return fmt.Errorf("user %s already exists", user.Username)
+		}
+	}
+	if user.Role == "" {
+		user.Role = domain.DefaultRole
+	}
+	user.Version = 1
+	put(undo, r.store.state.Users, user.ID, copyOf(user))
+	return nil
+}
+
+func (r *UserRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	user, ok := r.store.state.Users[id]
+	if !ok {
+		return nil, fmt.Errorf("user %s not found", id.String())
+	}
+	return copyOf(user), nil
+}
+
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, user := range r.store.state.Users {
+		if user.Username == username {
+			return copyOf(user), nil
+		}
+	}
+	return nil, fmt.Errorf("user %s not found", username)
+}
+
+func (r *UserRepository) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.User, *domain.PageInfo, error) {
+	r.store.mu.RLock()
+	users := make([]*domain.User, 0, len(r.store.state.Users))
+	for _, user := range r.store.state.Users {
+		users = append(users, copyOf(user))
+	}
+	r.store.mu.RUnlock()
+
+	return domain.Paginate(users, page, func(user *domain.User, sort string) (string, uuid.UUID) {
+		if sort == domain.SortByName {
+			return strings.ToLower(user.Name), user.ID
+		}
+		return user.Username, user.ID
+	})
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	stored, ok := r.store.state.Users[user.ID]
+	if !ok {
+		return fmt.Errorf("user %s not found", user.ID.String())
+	}
+	if stored.Version != user.Version {
+		return &domain.VersionConflictError{Entity: "user", ID: user.ID, Version: user.Version}
+	}
+	for _, other := range r.store.state.Users {
+		if other.ID != user.ID && other.Username == user.Username {
+			return fmt.Errorf("user %s already exists", user.Username)
+		}
+	}
+	user.Version++
+	put(undo, r.store.state.Users, user.ID, copyOf(user))
+	return nil
+}
+
+func (r *UserRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	undo := r.store.undoLog(ctx)
+
+	if _, ok := r.store.state.Users[id]; !ok {
+		return fmt.Errorf("user %s not found", id.String())
+	}
+	remove(undo, r.store.state.Users, id)
+	return nil
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+	"time"
+)
+
+type AuditService struct {
+	auditRepo domain.IAuditRepository
+	logger    logger.ILogger
+	now       func() time.Time
+}
+
+func NewAuditService(auditRepo domain.IAuditRepository, logger logger.ILogger) domain.IAuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// snapshot marshals entity to JSON with sensitive fields masked at any depth
+func snapshot(entity interface{}) (json.RawMessage, error) {
+	if entity == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) == "null" {
+		return nil, nil
+	}
+	return logger.RedactJSON(data)
+}
+
+func (s *AuditService) Record(ctx context.Context,
+	action string,
+	entityType string,
+	entityId uuid.UUID,
+	before, after interface{}) error {
+	s.logger.Infof("audit: %s %s %s", action, entityType, entityId.String())
+
+	record := &domain.AuditRecord{
+		ID:         uuid.New(),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityId,
+		Timestamp:  s.now().UTC(),
+	}
+	if actor, ok := domain.ActorFromContext(ctx); ok {
+		record.ActorID = actor.ID
+		record.ActorRole = actor.Role
+	}
+
+	var err error
+	record.Before, err = snapshot(before)
+	if err != nil {
+		s.logger.Errorf("audit: snapshot error: %s", err.Error())
+		return fmt.Errorf("recording audit: %w", err)
+	}
+	record.After, err = snapshot(after)
+	if err != nil {
+		s.logger.Errorf("audit: snapshot error: %s", err.Error())
+		return fmt.Errorf("recording audit: %w", err)
+	}
+
+	err = s.auditRepo.Create(ctx, record)
+	if err != nil {
+		s.logger.Errorf("audit: saving record error: %s", err.Error())
+		return fmt.Errorf("recording audit: %w", err)
+	}
+	return nil
+}
+
+func (s *AuditService) GetByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*domain.AuditRecord, error) {
+	s.logger.Infof("getting audit records of %s %s", entityType, entityId.String())
+
+	records, err := s.auditRepo.GetByEntity(ctx, entityType, entityId)
+	if err != nil {
+		s.logger.Errorf("getting audit records by entity error: %s", err.Error())
+		return nil, fmt.Errorf("getting audit records by entity: %w", err)
+	}
+	return records, nil
+}
+
+func (s *AuditService) GetByActor(ctx context.Context, actorId uuid.UUID) ([]*domain.AuditRecord, error) {
+	s.logger.Infof("getting audit records of actor %s", actorId.String())
+
+	records, err := s.auditRepo.GetByActor(ctx, actorId)
+	if err != nil {
+		s.logger.Errorf("getting audit records by actor error: %s", err.Error())
+		return nil, fmt.Errorf("getting audit records by actor: %w", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,930 @@
+package services
+
+import (
+	"context"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"io"
+)
+
+// Audited* decorators record every successful mutating call of the wrapped service.
+// The snapshot read, the call and its record run in one transaction, so a failed
+// record fails the call and rolls it back. Bulk calls, rating recomputes of every
+// salad and catalog imports, commit on their own and return a failed record after.
+
+type linkSnapshot struct {
+	From uuid.UUID
+	To   uuid.UUID
+}
+
+type AuditedSaladService struct {
+	domain.ISaladService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedSaladService(saladService domain.ISaladService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.ISaladService {
+	return &AuditedSaladService{ISaladService: saladService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedSaladService) Create(ctx context.Context, salad *domain.Salad) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		id, err = s.ISaladService.Create(ctx, salad)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntitySalad, id, nil, salad)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (s *AuditedSaladService) Update(ctx context.Context, salad *domain.Salad) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.ISaladService.GetById(ctx, salad.ID)
+		if err := s.ISaladService.Update(ctx, salad); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntitySalad, salad.ID, before, salad)
+	})
+}
+
+func (s *AuditedSaladService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.ISaladService.GetById(ctx, id)
+		if err := s.ISaladService.DeleteById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntitySalad, id, before, nil)
+	})
+}
+
+type AuditedRecipeService struct {
+	domain.IRecipeService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedRecipeService(recipeService domain.IRecipeService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.IRecipeService {
+	return &AuditedRecipeService{IRecipeService: recipeService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedRecipeService) Create(ctx context.Context, recipe *domain.Recipe) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		id, err = s.IRecipeService.Create(ctx, recipe)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntityRecipe, id, nil, recipe)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (s *AuditedRecipeService) Update(ctx context.Context, recipe *domain.Recipe) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IRecipeService.GetById(ctx, recipe.ID)
+		if err := s.IRecipeService.Update(ctx, recipe); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntityRecipe, recipe.ID, before, recipe)
+	})
+}
+
+func (s *AuditedRecipeService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IRecipeService.GetById(ctx, id)
+		if err := s.IRecipeService.DeleteById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntityRecipe, id, before, nil)
+	})
+}
+
+type AuditedRecipeStepService struct {
+	domain.IRecipeStepService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedRecipeStepService(recipeStepService domain.IRecipeStepService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.IRecipeStepService {
+	return &AuditedRecipeStepService{IRecipeStepService: recipeStepService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedRecipeStepService) Create(ctx context.Context, recipeStep *domain.RecipeStep) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.IRecipeStepService.Create(ctx, recipeStep); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntityRecipeStep, recipeStep.ID, nil, recipeStep)
+	})
+}
+
+func (s *AuditedRecipeStepService) Update(ctx context.Context, recipeStep *domain.RecipeStep) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IRecipeStepService.GetById(ctx, recipeStep.ID)
+		if err := s.IRecipeStepService.Update(ctx, recipeStep); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntityRecipeStep, recipeStep.ID, before, recipeStep)
+	})
+}
+
+func (s *AuditedRecipeStepService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IRecipeStepService.GetById(ctx, id)
+		if err := s.IRecipeStepService.DeleteById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntityRecipeStep, id, before, nil)
+	})
+}
+
+func (s *AuditedRecipeStepService) DeleteAllByRecipeID(ctx context.Context, recipeId uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IRecipeStepService.GetAllByRecipeID(ctx, recipeId)
+		if err := s.IRecipeStepService.DeleteAllByRecipeID(ctx, recipeId); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntityRecipe, recipeId, before, nil)
+	})
+}
+
+func (s *AuditedRecipeStepService) Reorder(ctx context.Context, recipeId uuid.UUID, stepIds []uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IRecipeStepService.GetAllByRecipeID(ctx, recipeId)
+		if err := s.IRecipeStepService.Reorder(ctx, recipeId, stepIds); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionReorder, domain.AuditEntityRecipe, recipeId, before, stepIds)
+	})
+}
+
+type AuditedRecipeIngredientService struct {
+	domain.IRecipeIngredientService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedRecipeIngredientService(recipeIngredientService domain.IRecipeIngredientService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.IRecipeIngredientService {
+	return &AuditedRecipeIngredientService{IRecipeIngredientService: recipeIngredientService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedRecipeIngredientService) Add(ctx context.Context, recipeIngredient *domain.RecipeIngredient) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		id, err = s.IRecipeIngredientService.Add(ctx, recipeIngredient)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionLink, domain.AuditEntityRecipeIngredient, id, nil, recipeIngredient)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (s *AuditedRecipeIngredientService) Update(ctx context.Context, recipeIngredient *domain.RecipeIngredient) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IRecipeIngredientService.GetById(ctx, recipeIngredient.ID)
+		if err := s.IRecipeIngredientService.Update(ctx, recipeIngredient); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntityRecipeIngredient, recipeIngredient.ID, before, recipeIngredient)
+	})
+}
+
+func (s *AuditedRecipeIngredientService) Remove(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IRecipeIngredientService.GetById(ctx, id)
+		if err := s.IRecipeIngredientService.Remove(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUnlink, domain.AuditEntityRecipeIngredient, id, before, nil)
+	})
+}
+
+func (s *AuditedRecipeIngredientService) Reorder(ctx context.Context, recipeId uuid.UUID, recipeIngredientIds []uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IRecipeIngredientService.GetAllByRecipeId(ctx, recipeId)
+		if err := s.IRecipeIngredientService.Reorder(ctx, recipeId, recipeIngredientIds); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionReorder, domain.AuditEntityRecipe, recipeId, before, recipeIngredientIds)
+	})
+}
+
+type AuditedSubstitutionService struct {
+	domain.ISubstitutionService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedSubstitutionService(substitutionService domain.ISubstitutionService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.ISubstitutionService {
+	return &AuditedSubstitutionService{ISubstitutionService: substitutionService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedSubstitutionService) Create(ctx context.Context, substitution *domain.Substitution) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		id, err = s.ISubstitutionService.Create(ctx, substitution)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntitySubstitution, id, nil, substitution)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (s *AuditedSubstitutionService) Update(ctx context.Context, substitution *domain.Substitution) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.ISubstitutionService.GetById(ctx, substitution.ID)
+		if err := s.ISubstitutionService.Update(ctx, substitution); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntitySubstitution, substitution.ID, before, substitution)
+	})
+}
+
+func (s *AuditedSubstitutionService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.ISubstitutionService.GetById(ctx, id)
+		if err := s.ISubstitutionService.DeleteById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntitySubstitution, id, before, nil)
+	})
+}
+
+type AuditedCommentService struct {
+	domain.ICommentService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedCommentService(commentService domain.ICommentService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.ICommentService {
+	return &AuditedCommentService{ICommentService: commentService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedCommentService) Create(ctx context.Context, comment *domain.Comment) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.ICommentService.Create(ctx, comment); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntityComment, comment.ID, nil, comment)
+	})
+}
+
+func (s *AuditedCommentService) Update(ctx context.Context, comment *domain.Comment) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.ICommentService.GetById(ctx, comment.ID)
+		if err := s.ICommentService.Update(ctx, comment); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntityComment, comment.ID, before, comment)
+	})
+}
+
+func (s *AuditedCommentService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.ICommentService.GetById(ctx, id)
+		if err := s.ICommentService.DeleteById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntityComment, id, before, nil)
+	})
+}
+
+type AuditedIngredientService struct {
+	domain.IIngredientService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedIngredientService(ingredientService domain.IIngredientService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.IIngredientService {
+	return &AuditedIngredientService{IIngredientService: ingredientService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedIngredientService) Create(ctx context.Context, ingredient *domain.Ingredient) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.IIngredientService.Create(ctx, ingredient); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntityIngredient, ingredient.ID, nil, ingredient)
+	})
+}
+
+func (s *AuditedIngredientService) Update(ctx context.Context, ingredient *domain.Ingredient) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IIngredientService.GetById(ctx, ingredient.ID)
+		if err := s.IIngredientService.Update(ctx, ingredient); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntityIngredient, ingredient.ID, before, ingredient)
+	})
+}
+
+func (s *AuditedIngredientService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IIngredientService.GetById(ctx, id)
+		if err := s.IIngredientService.DeleteById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntityIngredient, id, before, nil)
+	})
+}
+
+func (s *AuditedIngredientService) Link(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) (uuid.UUID, error) {
+	var linkId uuid.UUID
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		linkId, err = s.IIngredientService.Link(ctx, recipeId, ingredientId)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionLink, domain.AuditEntityRecipeIngredient, linkId,
+			nil, linkSnapshot{From: recipeId, To: ingredientId})
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return linkId, nil
+}
+
+func (s *AuditedIngredientService) Unlink(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.IIngredientService.Unlink(ctx, recipeId, ingredientId); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUnlink, domain.AuditEntityRecipe, recipeId,
+			linkSnapshot{From: recipeId, To: ingredientId}, nil)
+	})
+}
+
+type AuditedIngredientTypeService struct {
+	domain.IIngredientTypeService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedIngredientTypeService(ingredientTypeService domain.IIngredientTypeService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.IIngredientTypeService {
+	return &AuditedIngredientTypeService{IIngredientTypeService: ingredientTypeService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedIngredientTypeService) Create(ctx context.Context, ingredientType *domain.IngredientType) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.IIngredientTypeService.Create(ctx, ingredientType); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntityIngredientType, ingredientType.ID, nil, ingredientType)
+	})
+}
+
+func (s *AuditedIngredientTypeService) Update(ctx context.Context, ingredientType *domain.IngredientType) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IIngredientTypeService.GetById(ctx, ingredientType.ID)
+		if err := s.IIngredientTypeService.Update(ctx, ingredientType); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntityIngredientType, ingredientType.ID, before, ingredientType)
+	})
+}
+
+func (s *AuditedIngredientTypeService) Move(ctx context.Context, id uuid.UUID, parentId uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IIngredientTypeService.GetById(ctx, id)
+		if err := s.IIngredientTypeService.Move(ctx, id, parentId); err != nil {
+			return err
+		}
+		after, _ := s.IIngredientTypeService.GetById(ctx, id)
+		return s.audit.Record(ctx, domain.AuditActionMove, domain.AuditEntityIngredientType, id, before, after)
+	})
+}
+
+func (s *AuditedIngredientTypeService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IIngredientTypeService.GetById(ctx, id)
+		if err := s.IIngredientTypeService.DeleteById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntityIngredientType, id, before, nil)
+	})
+}
+
+type AuditedSaladTypeService struct {
+	domain.ISaladTypeService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedSaladTypeService(saladTypeService domain.ISaladTypeService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.ISaladTypeService {
+	return &AuditedSaladTypeService{ISaladTypeService: saladTypeService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedSaladTypeService) Create(ctx context.Context, saladType *domain.SaladType) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.ISaladTypeService.Create(ctx, saladType); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntitySaladType, saladType.ID, nil, saladType)
+	})
+}
+
+func (s *AuditedSaladTypeService) Update(ctx context.Context, saladType *domain.SaladType) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.ISaladTypeService.GetById(ctx, saladType.ID)
+		if err := s.ISaladTypeService.Update(ctx, saladType); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntitySaladType, saladType.ID, before, saladType)
+	})
+}
+
+func (s *AuditedSaladTypeService) Move(ctx context.Context, id uuid.UUID, parentId uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.ISaladTypeService.GetById(ctx, id)
+		if err := s.ISaladTypeService.Move(ctx, id, parentId); err != nil {
+			return err
+		}
+		after, _ := s.ISaladTypeService.GetById(ctx, id)
+		return s.audit.Record(ctx, domain.AuditActionMove, domain.AuditEntitySaladType, id, before, after)
+	})
+}
+
+func (s *AuditedSaladTypeService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.ISaladTypeService.GetById(ctx, id)
+		if err := s.ISaladTypeService.DeleteById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntitySaladType, id, before, nil)
+	})
+}
+
+func (s *AuditedSaladTypeService) Link(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.ISaladTypeService.Link(ctx, saladId, saladTypeId); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionLink, domain.AuditEntitySalad, saladId,
+			nil, linkSnapshot{From: saladId, To: saladTypeId})
+	})
+}
+
+func (s *AuditedSaladTypeService) Unlink(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.ISaladTypeService.Unlink(ctx, saladId, saladTypeId); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUnlink, domain.AuditEntitySalad, saladId,
+			linkSnapshot{From: saladId, To: saladTypeId}, nil)
+	})
+}
+
+type AuditedMeasurementService struct {
+	domain.IMeasurementService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedMeasurementService(measurementService domain.IMeasurementService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.IMeasurementService {
+	return &AuditedMeasurementService{IMeasurementService: measurementService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedMeasurementService) Create(ctx context.Context, measurement *domain.Measurement) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.IMeasurementService.Create(ctx, measurement); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntityMeasurement, measurement.ID, nil, measurement)
+	})
+}
+
+func (s *AuditedMeasurementService) Update(ctx context.Context, measurement *domain.Measurement) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IMeasurementService.GetById(ctx, measurement.ID)
+		if err := s.IMeasurementService.Update(ctx, measurement); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntityMeasurement, measurement.ID, before, measurement)
+	})
+}
+
+func (s *AuditedMeasurementService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IMeasurementService.GetById(ctx, id)
+		if err := s.IMeasurementService.DeleteById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntityMeasurement, id, before, nil)
+	})
+}
+
+func (s *AuditedMeasurementService) UpdateLink(ctx context.Context, linkId uuid.UUID, measurementId uuid.UUID, amount int) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.IMeasurementService.UpdateLink(ctx, linkId, measurementId, amount); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntityRecipeIngredient, linkId, nil,
+			struct {
+				MeasurementID uuid.UUID
+				Amount        int
+			}{measurementId, amount})
+	})
+}
+
+type AuditedKeywordValidatorService struct {
+	domain.IKeywordValidatorService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedKeywordValidatorService(keywordService domain.IKeywordValidatorService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.IKeywordValidatorService {
+	return &AuditedKeywordValidatorService{IKeywordValidatorService: keywordService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedKeywordValidatorService) Create(ctx context.Context, word *domain.KeyWord) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.IKeywordValidatorService.Create(ctx, word); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntityKeyword, word.ID, nil, word)
+	})
+}
+
+func (s *AuditedKeywordValidatorService) Update(ctx context.Context, word *domain.KeyWord) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IKeywordValidatorService.GetById(ctx, word.ID)
+		if err := s.IKeywordValidatorService.Update(ctx, word); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntityKeyword, word.ID, before, word)
+	})
+}
+
+func (s *AuditedKeywordValidatorService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IKeywordValidatorService.GetById(ctx, id)
+		if err := s.IKeywordValidatorService.DeleteById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntityKeyword, id, before, nil)
+	})
+}
+
+type AuditedUserService struct {
+	domain.IUserService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedUserService(userService domain.IUserService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.IUserService {
+	return &AuditedUserService{IUserService: userService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedUserService) Create(ctx context.Context, user *domain.User) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.IUserService.Create(ctx, user); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntityUser, user.ID, nil, user)
+	})
+}
+
+func (s *AuditedUserService) Update(ctx context.Context, user *domain.User) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IUserService.GetById(ctx, user.ID)
+		if err := s.IUserService.Update(ctx, user); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntityUser, user.ID, before, user)
+	})
+}
+
+func (s *AuditedUserService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IUserService.GetById(ctx, id)
+		if err := s.IUserService.DeleteById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntityUser, id, before, nil)
+	})
+}
+
+type AuditedAuthService struct {
+	domain.IAuthService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedAuthService(authService domain.IAuthService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.IAuthService {
+	return &AuditedAuthService{IAuthService: authService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedAuthService) Register(ctx context.Context, user *domain.User) (string, error) {
+	var token string
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		token, err = s.IAuthService.Register(ctx, user)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntityUser, user.ID, nil, user)
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+type AuditedRecipeAggregateService struct {
+	domain.IRecipeAggregateService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedRecipeAggregateService(aggregateService domain.IRecipeAggregateService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.IRecipeAggregateService {
+	return &AuditedRecipeAggregateService{IRecipeAggregateService: aggregateService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedRecipeAggregateService) Create(ctx context.Context, aggregate *domain.RecipeAggregate) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		id, err = s.IRecipeAggregateService.Create(ctx, aggregate)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntitySalad, id, nil, aggregate)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (s *AuditedRecipeAggregateService) Replace(ctx context.Context, aggregate *domain.RecipeAggregate) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.IRecipeAggregateService.Replace(ctx, aggregate); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntitySalad, aggregate.Salad.ID, nil, aggregate)
+	})
+}
+
+// AuditedModerationService records decisions of moderators, the recipe update
+// itself is recorded by the recipe service
+type AuditedModerationService struct {
+	domain.IModerationService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedModerationService(moderationService domain.IModerationService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.IModerationService {
+	return &AuditedModerationService{IModerationService: moderationService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedModerationService) Approve(ctx context.Context, recipeId uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.IModerationService.Approve(ctx, recipeId); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionApprove, domain.AuditEntityRecipe, recipeId, nil, nil)
+	})
+}
+
+func (s *AuditedModerationService) Reject(ctx context.Context, recipeId uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.IModerationService.Reject(ctx, recipeId); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionReject, domain.AuditEntityRecipe, recipeId, nil, nil)
+	})
+}
+
+type ratingSnapshot struct {
+	Rating float32
+}
+
+type recomputeSnapshot struct {
+	Changed int
+}
+
+type AuditedRatingService struct {
+	domain.IRatingService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedRatingService(ratingService domain.IRatingService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.IRatingService {
+	return &AuditedRatingService{IRatingService: ratingService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedRatingService) Recompute(ctx context.Context, saladId uuid.UUID) (float32, error) {
+	var rating float32
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		rating, err = s.IRatingService.Recompute(ctx, saladId)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionRecompute, domain.AuditEntitySalad, saladId, nil, ratingSnapshot{rating})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return rating, nil
+}
+
+func (s *AuditedRatingService) RecomputeAll(ctx context.Context) (int, error) {
+	changed, err := s.IRatingService.RecomputeAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+	err = s.audit.Record(ctx, domain.AuditActionRecompute, domain.AuditEntityRecipe, uuid.Nil, nil, recomputeSnapshot{changed})
+	return changed, err
+}
+
+type AuditedMediaService struct {
+	domain.IMediaService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedMediaService(mediaService domain.IMediaService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.IMediaService {
+	return &AuditedMediaService{IMediaService: mediaService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedMediaService) Upload(ctx context.Context, upload *domain.MediaUpload) (*domain.Media, error) {
+	var media *domain.Media
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		media, err = s.IMediaService.Upload(ctx, upload)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntityMedia, media.ID, nil, media)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+func (s *AuditedMediaService) Reorder(ctx context.Context, ownerType string, ownerId uuid.UUID, mediaIds []uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IMediaService.GetAllByOwner(ctx, ownerType, ownerId)
+		if err := s.IMediaService.Reorder(ctx, ownerType, ownerId, mediaIds); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionReorder, ownerType, ownerId, before, mediaIds)
+	})
+}
+
+func (s *AuditedMediaService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IMediaService.GetById(ctx, id)
+		if err := s.IMediaService.DeleteById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntityMedia, id, before, nil)
+	})
+}
+
+func (s *AuditedMediaService) DeleteAllByOwner(ctx context.Context, ownerType string, ownerId uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IMediaService.GetAllByOwner(ctx, ownerType, ownerId)
+		if err := s.IMediaService.DeleteAllByOwner(ctx, ownerType, ownerId); err != nil {
+			return err
+		}
+		if len(before) == 0 {
+			return nil
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntityMedia, ownerId, before, nil)
+	})
+}
+
+type AuditedTranslationService struct {
+	domain.ITranslationService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedTranslationService(translationService domain.ITranslationService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.ITranslationService {
+	return &AuditedTranslationService{ITranslationService: translationService, txManager: txManager, audit: audit}
+}
+
+// Set records a create or an update depending on whether the field had a translation
+func (s *AuditedTranslationService) Set(ctx context.Context, translation *domain.Translation) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var before *domain.Translation
+		existing, _ := s.ITranslationService.GetAllByEntity(ctx, translation.EntityType, translation.EntityID)
+		for _, candidate := range existing {
+			if candidate.Locale == translation.Locale && candidate.Field == translation.Field {
+				before = candidate
+			}
+		}
+
+		if err := s.ITranslationService.Set(ctx, translation); err != nil {
+			return err
+		}
+		if before == nil {
+			return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntityTranslation, translation.ID, nil, translation)
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntityTranslation, translation.ID, before, translation)
+	})
+}
+
+func (s *AuditedTranslationService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.ITranslationService.DeleteById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntityTranslation, id, nil, nil)
+	})
+}
+
+type AuditedWebhookService struct {
+	domain.IWebhookService
+	txManager domain.ITransactionManager
+	audit     domain.IAuditService
+}
+
+func NewAuditedWebhookService(webhookService domain.IWebhookService, txManager domain.ITransactionManager, audit domain.IAuditService) domain.IWebhookService {
+	return &AuditedWebhookService{IWebhookService: webhookService, txManager: txManager, audit: audit}
+}
+
+func (s *AuditedWebhookService) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.IWebhookService.CreateSubscription(ctx, subscription); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditEntityWebhook, subscription.ID, nil, subscription)
+	})
+}
+
+func (s *AuditedWebhookService) UpdateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IWebhookService.GetSubscriptionById(ctx, subscription.ID)
+		if err := s.IWebhookService.UpdateSubscription(ctx, subscription); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditEntityWebhook, subscription.ID, before, subscription)
+	})
+}
+
+func (s *AuditedWebhookService) DeleteSubscriptionById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, _ := s.IWebhookService.GetSubscriptionById(ctx, id)
+		if err := s.IWebhookService.DeleteSubscriptionById(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditEntityWebhook, id, before, nil)
+	})
+}
+
+func (s *AuditedWebhookService) Redeliver(ctx context.Context, deliveryId uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		if err := s.IWebhookService.Redeliver(ctx, deliveryId); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditActionRedeliver, domain.AuditEntityWebhookDelivery, deliveryId, nil, nil)
+	})
+}
+
+// AuditedCatalogService records the report of an import, rows it writes are
+// recorded by the services of their entities. Dry runs write nothing and aren't recorded.
+type AuditedCatalogService struct {
+	domain.ICatalogService
+	audit domain.IAuditService
+}
+
+func NewAuditedCatalogService(catalogService domain.ICatalogService, audit domain.IAuditService) domain.ICatalogService {
+	return &AuditedCatalogService{ICatalogService: catalogService, audit: audit}
+}
+
+func (s *AuditedCatalogService) Import(ctx context.Context,
+	catalog string,
+	r io.Reader,
+	options *domain.CatalogImportOptions) (*domain.CatalogImportReport, error) {
+	report, err := s.ICatalogService.Import(ctx, catalog, r, options)
+	if err != nil || options != nil && options.DryRun {
+		return report, err
+	}
+	err = s.audit.Record(ctx, domain.AuditActionImport, domain.AuditEntityCatalog, uuid.Nil, nil, report)
+	return report, err
+}
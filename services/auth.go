@@ -3,9 +3,11 @@ package services
 import (
 	"context"
 	"fmt"
+	"github.com/Mx1q/ppo_services/config"
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/logger"
 	"net/mail"
+	"time"
 )
 
 type AuthService struct {
@@ -13,14 +15,18 @@ type AuthService struct {
 	authRepo domain.IAuthRepository
 	crypto   IHashCrypto
 	jwtKey   string
+	tokenTTL time.Duration
 }
 
-func NewAuthService(repo domain.IAuthRepository, logger logger.ILogger, crypto IHashCrypto, jwtKey string) domain.IAuthService {
+// NewAuthService signs tokens with the key of cfg valid for its TokenTTL,
+// crypto is expected to hash with cfg.BcryptCost
+func NewAuthService(repo domain.IAuthRepository, logger logger.ILogger, crypto IHashCrypto, cfg config.AuthConfig) domain.IAuthService {
 	return &AuthService{
 		logger:   logger,
 		authRepo: repo,
 		crypto:   crypto,
-		jwtKey:   jwtKey,
+		jwtKey:   string(cfg.JWTKey),
+		tokenTTL: time.Duration(cfg.TokenTTL),
 	}
 }
 
@@ -61,7 +67,7 @@ func (s *AuthService) Register(ctx context.Context, user *domain.User) (string,
 		return "", fmt.Errorf("registration user: %w", err)
 	}
 
-	token, err := GenerateAuthToken(uid.String(), s.jwtKey, domain.DefaultRole)
+	token, err := GenerateAuthToken(uid.String(), s.jwtKey, domain.DefaultRole, s.tokenTTL)
 	if err != nil {
 		s.logger.Warnf("login user: geerating auth token error (%s)", err.Error())
 		return "", fmt.Errorf("generating token: %w", err)
@@ -94,7 +100,7 @@ func (s *AuthService) Login(ctx context.Context, authInfo *domain.UserAuth) (tok
 		return "", fmt.Errorf("invalid password")
 	}
 
-	token, err = GenerateAuthToken(userAuth.ID.String(), s.jwtKey, userAuth.Role)
+	token, err = GenerateAuthToken(userAuth.ID.String(), s.jwtKey, userAuth.Role, s.tokenTTL)
 	if err != nil {
 		s.logger.Warnf("login user: geerating auth token error (%s)", err.Error())
 		return "", fmt.Errorf("generating token: %w", err)
@@ -0,0 +1,788 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// contentsSeparator joins contents in a CSV cell
+const contentsSeparator = ";"
+
+var (
+	errCatalogDuplicate = errors.New("already exists, use upsert to update it")
+	errUnknownCatalog   = errors.New("unknown catalog")
+)
+
+// catalogColumns are the CSV columns of the catalogues, also the order of export
+var catalogColumns = map[string][]string{
+	domain.CatalogIngredientTypes: {"name", "parent", "description", "contents"},
+	domain.CatalogIngredients:     {"name", "type", "calories", "density", "contents"},
+	domain.CatalogMeasurements:    {"name", "unit", "grams"},
+	domain.CatalogSaladTypes:      {"name", "parent", "description"},
+	domain.CatalogKeywords:        {"word"},
+}
+
+const (
+	catalogCreated = iota
+	catalogUpdated
+	catalogUnchanged
+)
+
+// catalogRowFunc imports a row and returns its outcome
+type catalogRowFunc func(ctx context.Context, row *domain.CatalogRow) (int, error)
+
+type CatalogService struct {
+	ingredientTypeService domain.IIngredientTypeService
+	ingredientService     domain.IIngredientService
+	measurementService    domain.IMeasurementService
+	saladTypeService      domain.ISaladTypeService
+	keywordService        domain.IKeywordValidatorService
+	logger                logger.ILogger
+}
+
+func NewCatalogService(
+	ingredientTypeService domain.IIngredientTypeService,
+	ingredientService domain.IIngredientService,
+	measurementService domain.IMeasurementService,
+	saladTypeService domain.ISaladTypeService,
+	keywordService domain.IKeywordValidatorService,
+	logger logger.ILogger,
+) domain.ICatalogService {
+	return &CatalogService{
+		ingredientTypeService: ingredientTypeService,
+		ingredientService:     ingredientService,
+		measurementService:    measurementService,
+		saladTypeService:      saladTypeService,
+		keywordService:        keywordService,
+		logger:                logger,
+	}
+}
+
+func (s *CatalogService) Import(ctx context.Context, catalog string, r io.Reader, options *domain.CatalogImportOptions) (*domain.CatalogImportReport, error) {
+	if options == nil {
+		options = &domain.CatalogImportOptions{}
+	}
+	s.logger.Infof("importing catalog %s: %+v", catalog, *options)
+
+	columns, ok := catalogColumns[catalog]
+	if !ok {
+		s.logger.Warnf("importing unknown catalog %s", catalog)
+		return nil, fmt.Errorf("importing catalog: %w %s", errUnknownCatalog, catalog)
+	}
+
+	report := &domain.CatalogImportReport{Catalog: catalog}
+	rows, err := readCatalogRows(r, options.Format, columns, report)
+	if err != nil {
+		s.logger.Warnf("reading catalog %s error: %s", catalog, err.Error())
+		return nil, fmt.Errorf("importing catalog: %w", err)
+	}
+
+	var importRow catalogRowFunc
+	switch catalog {
+	case domain.CatalogIngredientTypes:
+		importRow, err = s.ingredientTypeImporter(ctx, options)
+	case domain.CatalogIngredients:
+		importRow, err = s.ingredientImporter(ctx, options)
+	case domain.CatalogMeasurements:
+		importRow, err = s.measurementImporter(ctx, options)
+	case domain.CatalogSaladTypes:
+		importRow, err = s.saladTypeImporter(ctx, options)
+	case domain.CatalogKeywords:
+		importRow, err = s.keywordImporter(ctx, options)
+	}
+	if err != nil {
+		s.logger.Errorf("loading catalog %s error: %s", catalog, err.Error())
+		return nil, fmt.Errorf("importing catalog: %w", err)
+	}
+
+	if catalog == domain.CatalogIngredientTypes || catalog == domain.CatalogSaladTypes {
+		rows = orderByParent(rows)
+	}
+	for _, row := range rows {
+		outcome, err := importRow(ctx, row.CatalogRow)
+		if err != nil {
+			report.Errors = append(report.Errors, &domain.CatalogRowError{Row: row.number, Name: row.name(), Err: err})
+			continue
+		}
+		switch outcome {
+		case catalogCreated:
+			report.Created++
+		case catalogUpdated:
+			report.Updated++
+		case catalogUnchanged:
+			report.Unchanged++
+		}
+	}
+
+	slices.SortStableFunc(report.Errors, func(a, b *domain.CatalogRowError) int {
+		return a.Row - b.Row
+	})
+	if len(report.Errors) > 0 {
+		s.logger.Warnf("catalog %s import rejected %d rows", catalog, len(report.Errors))
+	}
+	return report, nil
+}
+
+func (s *CatalogService) Export(ctx context.Context, catalog string, w io.Writer, format string) error {
+	s.logger.Infof("exporting catalog %s to %s", catalog, format)
+
+	columns, ok := catalogColumns[catalog]
+	if !ok {
+		s.logger.Warnf("exporting unknown catalog %s", catalog)
+		return fmt.Errorf("exporting catalog: %w %s", errUnknownCatalog, catalog)
+	}
+
+	rows, err := s.exportRows(ctx, catalog)
+	if err != nil {
+		s.logger.Errorf("exporting catalog %s error: %s", catalog, err.Error())
+		return fmt.Errorf("exporting catalog: %w", err)
+	}
+
+	if err = writeCatalogRows(w, format, columns, rows); err != nil {
+		s.logger.Errorf("writing catalog %s error: %s", catalog, err.Error())
+		return fmt.Errorf("exporting catalog: %w", err)
+	}
+	return nil
+}
+
+func (s *CatalogService) exportRows(ctx context.Context, catalog string) ([]*domain.CatalogRow, error) {
+	rows := make([]*domain.CatalogRow, 0)
+	switch catalog {
+	case domain.CatalogIngredientTypes:
+		ingredientTypes, err := s.ingredientTypeService.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := make(map[uuid.UUID]string, len(ingredientTypes))
+		for _, ingredientType := range ingredientTypes {
+			names[ingredientType.ID] = ingredientType.Name
+		}
+		for _, ingredientType := range ingredientTypes {
+			rows = append(rows, &domain.CatalogRow{
+				Name:        ingredientType.Name,
+				Parent:      names[ingredientType.ParentID],
+				Description: ingredientType.Description,
+				Contents:    ingredientType.Contents,
+			})
+		}
+
+	case domain.CatalogIngredients:
+		ingredientTypes, err := s.ingredientTypeService.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := make(map[uuid.UUID]string, len(ingredientTypes))
+		for _, ingredientType := range ingredientTypes {
+			names[ingredientType.ID] = ingredientType.Name
+		}
+		ingredients, err := allPages(ctx, s.ingredientService.GetAll)
+		if err != nil {
+			return nil, err
+		}
+		for _, ingredient := range ingredients {
+			rows = append(rows, &domain.CatalogRow{
+				Name:     ingredient.Name,
+				Type:     names[ingredient.TypeID],
+				Calories: ingredient.Calories,
+				Density:  ingredient.Density,
+				Contents: ingredient.Contents,
+			})
+		}
+
+	case domain.CatalogMeasurements:
+		measurements, err := s.measurementService.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, measurement := range measurements {
+			rows = append(rows, &domain.CatalogRow{
+				Name:  measurement.Name,
+				Unit:  measurement.Unit,
+				Grams: measurement.Grams,
+			})
+		}
+
+	case domain.CatalogSaladTypes:
+		saladTypes, err := allPages(ctx, s.saladTypeService.GetAll)
+		if err != nil {
+			return nil, err
+		}
+		names := make(map[uuid.UUID]string, len(saladTypes))
+		for _, saladType := range saladTypes {
+			names[saladType.ID] = saladType.Name
+		}
+		for _, saladType := range saladTypes {
+			rows = append(rows, &domain.CatalogRow{
+				Name:        saladType.Name,
+				Parent:      names[saladType.ParentID],
+				Description: saladType.Description,
+			})
+		}
+
+	case domain.CatalogKeywords:
+		words, err := s.keywordService.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for word := range words {
+			rows = append(rows, &domain.CatalogRow{Word: word})
+		}
+		slices.SortFunc(rows, func(a, b *domain.CatalogRow) int {
+			return strings.Compare(a.Word, b.Word)
+		})
+	}
+	return rows, nil
+}
+
+func (s *CatalogService) ingredientTypeImporter(ctx context.Context, options *domain.CatalogImportOptions) (catalogRowFunc, error) {
+	ingredientTypes, err := s.ingredientTypeService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := newCatalogNames[*domain.IngredientType]()
+	for _, ingredientType := range ingredientTypes {
+		names.add(ingredientType.Name, ingredientType)
+	}
+
+	return func(ctx context.Context, row *domain.CatalogRow) (int, error) {
+		parentId, err := names.resolve(row.Parent, "parent", func(parent *domain.IngredientType) uuid.UUID { return parent.ID })
+		if err != nil {
+			return 0, err
+		}
+		existing, found, err := names.get(row.Name)
+		if err != nil {
+			return 0, err
+		}
+		if found && !options.Upsert {
+			return 0, errCatalogDuplicate
+		}
+
+		ingredientType := &domain.IngredientType{
+			ParentID:    parentId,
+			Name:        row.Name,
+			Description: row.Description,
+			Contents:    row.Contents,
+		}
+		changed := true
+		if found {
+			ingredientType.ID, ingredientType.Version = existing.ID, existing.Version
+			changed = ingredientType.Name != existing.Name || ingredientType.ParentID != existing.ParentID ||
+				ingredientType.Description != existing.Description || !slices.Equal(ingredientType.Contents, existing.Contents)
+		}
+		if err = verifyIngredientType(ingredientType); err != nil {
+			return 0, err
+		}
+
+		outcome, err := saveCatalogRow(ctx, options.DryRun, found, changed,
+			func(ctx context.Context) error { return s.ingredientTypeService.Create(ctx, ingredientType) },
+			func(ctx context.Context) error { return s.ingredientTypeService.Update(ctx, ingredientType) },
+		)
+		if err != nil {
+			return 0, err
+		}
+		if ingredientType.ID == uuid.Nil {
+			ingredientType.ID = uuid.New()
+		}
+		names.set(row.Name, ingredientType)
+		return outcome, nil
+	}, nil
+}
+
+func (s *CatalogService) ingredientImporter(ctx context.Context, options *domain.CatalogImportOptions) (catalogRowFunc, error) {
+	ingredientTypes, err := s.ingredientTypeService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	typeNames := newCatalogNames[*domain.IngredientType]()
+	for _, ingredientType := range ingredientTypes {
+		typeNames.add(ingredientType.Name, ingredientType)
+	}
+
+	ingredients, err := allPages(ctx, s.ingredientService.GetAll)
+	if err != nil {
+		return nil, err
+	}
+	names := newCatalogNames[*domain.Ingredient]()
+	for _, ingredient := range ingredients {
+		names.add(ingredient.Name, ingredient)
+	}
+
+	return func(ctx context.Context, row *domain.CatalogRow) (int, error) {
+		typeId, err := typeNames.resolve(row.Type, "type", func(ingredientType *domain.IngredientType) uuid.UUID { return ingredientType.ID })
+		if err != nil {
+			return 0, err
+		}
+		existing, found, err := names.get(row.Name)
+		if err != nil {
+			return 0, err
+		}
+		if found && !options.Upsert {
+			return 0, errCatalogDuplicate
+		}
+
+		ingredient := &domain.Ingredient{
+			TypeID:   typeId,
+			Name:     row.Name,
+			Calories: row.Calories,
+			Density:  row.Density,
+			Contents: row.Contents,
+		}
+		changed := true
+		if found {
+			ingredient.ID, ingredient.Version = existing.ID, existing.Version
+			changed = ingredient.Name != existing.Name || ingredient.TypeID != existing.TypeID || ingredient.Calories != existing.Calories ||
+				ingredient.Density != existing.Density || !slices.Equal(ingredient.Contents, existing.Contents)
+		}
+		if err = verifyIngredient(ingredient); err != nil {
+			return 0, err
+		}
+
+		outcome, err := saveCatalogRow(ctx, options.DryRun, found, changed,
+			func(ctx context.Context) error { return s.ingredientService.Create(ctx, ingredient) },
+			func(ctx context.Context) error { return s.ingredientService.Update(ctx, ingredient) },
+		)
+		if err != nil {
+			return 0, err
+		}
+		names.set(row.Name, ingredient)
+		return outcome, nil
+	}, nil
+}
+
+func (s *CatalogService) measurementImporter(ctx context.Context, options *domain.CatalogImportOptions) (catalogRowFunc, error) {
+	measurements, err := s.measurementService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := newCatalogNames[*domain.Measurement]()
+	for _, measurement := range measurements {
+		names.add(measurement.Name, measurement)
+	}
+
+	return func(ctx context.Context, row *domain.CatalogRow) (int, error) {
+		existing, found, err := names.get(row.Name)
+		if err != nil {
+			return 0, err
+		}
+		if found && !options.Upsert {
+			return 0, errCatalogDuplicate
+		}
+
+		measurement := &domain.Measurement{
+			Name:  row.Name,
+			Unit:  row.Unit,
+			Grams: row.Grams,
+		}
+		if err = verifyMeasurement(measurement); err != nil {
+			return 0, err
+		}
+		changed := true
+		if found {
+			measurement.ID, measurement.Version = existing.ID, existing.Version
+			changed = measurement.Name != existing.Name || measurement.Unit != existing.Unit || measurement.Grams != existing.Grams
+		}
+
+		outcome, err := saveCatalogRow(ctx, options.DryRun, found, changed,
+			func(ctx context.Context) error { return s.measurementService.Create(ctx, measurement) },
+			func(ctx context.Context) error { return s.measurementService.Update(ctx, measurement) },
+		)
+		if err != nil {
+			return 0, err
+		}
+		names.set(row.Name, measurement)
+		return outcome, nil
+	}, nil
+}
+
+func (s *CatalogService) saladTypeImporter(ctx context.Context, options *domain.CatalogImportOptions) (catalogRowFunc, error) {
+	saladTypes, err := allPages(ctx, s.saladTypeService.GetAll)
+	if err != nil {
+		return nil, err
+	}
+	names := newCatalogNames[*domain.SaladType]()
+	for _, saladType := range saladTypes {
+		names.add(saladType.Name, saladType)
+	}
+
+	return func(ctx context.Context, row *domain.CatalogRow) (int, error) {
+		parentId, err := names.resolve(row.Parent, "parent", func(parent *domain.SaladType) uuid.UUID { return parent.ID })
+		if err != nil {
+			return 0, err
+		}
+		existing, found, err := names.get(row.Name)
+		if err != nil {
+			return 0, err
+		}
+		if found && !options.Upsert {
+			return 0, errCatalogDuplicate
+		}
+
+		saladType := &domain.SaladType{
+			ParentID:    parentId,
+			Name:        row.Name,
+			Description: row.Description,
+		}
+		changed := true
+		if found {
+			saladType.ID, saladType.Version = existing.ID, existing.Version
+			changed = saladType.Name != existing.Name || saladType.ParentID != existing.ParentID ||
+				saladType.Description != existing.Description
+		}
+		if err = verifySaladType(saladType); err != nil {
+			return 0, err
+		}
+
+		outcome, err := saveCatalogRow(ctx, options.DryRun, found, changed,
+			func(ctx context.Context) error { return s.saladTypeService.Create(ctx, saladType) },
+			func(ctx context.Context) error { return s.saladTypeService.Update(ctx, saladType) },
+		)
+		if err != nil {
+			return 0, err
+		}
+		if saladType.ID == uuid.Nil {
+			saladType.ID = uuid.New()
+		}
+		names.set(row.Name, saladType)
+		return outcome, nil
+	}, nil
+}
+
+// keywordImporter stores words in lower case, the validator looks them up that way
+func (s *CatalogService) keywordImporter(ctx context.Context, options *domain.CatalogImportOptions) (catalogRowFunc, error) {
+	words, err := s.keywordService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(words))
+	for word := range words {
+		known[strings.ToLower(word)] = true
+	}
+
+	return func(ctx context.Context, row *domain.CatalogRow) (int, error) {
+		word := &domain.KeyWord{Word: strings.ToLower(row.Word)}
+		if known[word.Word] {
+			if !options.Upsert {
+				return 0, errCatalogDuplicate
+			}
+			return catalogUnchanged, nil
+		}
+		if err := verifyKeyword(word); err != nil {
+			return 0, err
+		}
+
+		outcome, err := saveCatalogRow(ctx, options.DryRun, false, true,
+			func(ctx context.Context) error { return s.keywordService.Create(ctx, word) },
+			nil,
+		)
+		if err != nil {
+			return 0, err
+		}
+		known[word.Word] = true
+		return outcome, nil
+	}, nil
+}
+
+// saveCatalogRow writes the entity of a row unless it is a dry run or the row changes nothing
+func saveCatalogRow(ctx context.Context, dryRun bool, found bool, changed bool, create func(ctx context.Context) error, update func(ctx context.Context) error) (int, error) {
+	switch {
+	case found && !changed:
+		return catalogUnchanged, nil
+	case found:
+		if dryRun {
+			return catalogUpdated, nil
+		}
+		return catalogUpdated, update(ctx)
+	default:
+		if dryRun {
+			return catalogCreated, nil
+		}
+		return catalogCreated, create(ctx)
+	}
+}
+
+// catalogNames finds entities by case-insensitive name, a name of several entities is ambiguous
+type catalogNames[T any] struct {
+	items     map[string]T
+	ambiguous map[string]bool
+}
+
+func newCatalogNames[T any]() *catalogNames[T] {
+	return &catalogNames[T]{
+		items:     make(map[string]T),
+		ambiguous: make(map[string]bool),
+	}
+}
+
+func (n *catalogNames[T]) add(name string, item T) {
+	key := strings.ToLower(name)
+	if _, ok := n.items[key]; ok {
+		n.ambiguous[key] = true
+	}
+	n.items[key] = item
+}
+
+// set replaces the entity of the name after a row is imported
+func (n *catalogNames[T]) set(name string, item T) {
+	n.items[strings.ToLower(name)] = item
+}
+
+func (n *catalogNames[T]) get(name string) (T, bool, error) {
+	key := strings.ToLower(name)
+	if n.ambiguous[key] {
+		var zero T
+		return zero, false, fmt.Errorf("ambiguous name %s", name)
+	}
+	item, ok := n.items[key]
+	return item, ok, nil
+}
+
+// resolve returns the id of the referenced entity, an empty reference gives a nil id
+func (n *catalogNames[T]) resolve(name string, reference string, id func(item T) uuid.UUID) (uuid.UUID, error) {
+	if name == "" {
+		return uuid.Nil, nil
+	}
+	item, ok, err := n.get(name)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", reference, err)
+	}
+	if !ok {
+		return uuid.Nil, fmt.Errorf("unknown %s %s", reference, name)
+	}
+	return id(item), nil
+}
+
+// allPages collects every page of a list, pages have the default size as every
+// configuration accepts it
+func allPages[T any](ctx context.Context, list func(ctx context.Context, page *domain.PageRequest) ([]T, *domain.PageInfo, error)) ([]T, error) {
+	items := make([]T, 0)
+	page := &domain.PageRequest{}
+	for {
+		pageItems, info, err := list(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+		if info == nil || info.NextCursor == "" {
+			return items, nil
+		}
+		page = &domain.PageRequest{Cursor: info.NextCursor}
+	}
+}
+
+type catalogRow struct {
+	*domain.CatalogRow
+	number int
+}
+
+func (r *catalogRow) name() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Word
+}
+
+// orderByParent moves rows after the rows of their parents, rows of a loop keep their order
+func orderByParent(rows []*catalogRow) []*catalogRow {
+	ordered := make([]*catalogRow, 0, len(rows))
+	pending := rows
+	for len(pending) > 0 {
+		names := make(map[string]bool, len(pending))
+		for _, row := range pending {
+			names[strings.ToLower(row.Name)] = true
+		}
+
+		waiting := make([]*catalogRow, 0)
+		for _, row := range pending {
+			parent := strings.ToLower(row.Parent)
+			if parent != "" && parent != strings.ToLower(row.Name) && names[parent] {
+				waiting = append(waiting, row)
+			} else {
+				ordered = append(ordered, row)
+			}
+		}
+		if len(waiting) == len(pending) {
+			return append(ordered, waiting...)
+		}
+		pending = waiting
+	}
+	return ordered
+}
+
+// readCatalogRows decodes rows of the format, rows with malformed values are added
+// to the report and skipped
+func readCatalogRows(r io.Reader, format string, columns []string, report *domain.CatalogImportReport) ([]*catalogRow, error) {
+	rows := make([]*catalogRow, 0)
+	switch format {
+	case domain.CatalogFormatJSON:
+		decoded := make([]*domain.CatalogRow, 0)
+		decoder := json.NewDecoder(r)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&decoded); err != nil {
+			return nil, fmt.Errorf("decoding json: %w", err)
+		}
+		for i, row := range decoded {
+			if row == nil {
+				row = &domain.CatalogRow{}
+			}
+			rows = append(rows, &catalogRow{CatalogRow: trimCatalogRow(row), number: i + 1})
+		}
+
+	case domain.CatalogFormatCSV:
+		reader := csv.NewReader(r)
+		reader.TrimLeadingSpace = true
+		header, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("reading csv header: %w", err)
+		}
+		for i, column := range header {
+			header[i] = strings.ToLower(strings.TrimSpace(column))
+			if !slices.Contains(columns, header[i]) {
+				return nil, fmt.Errorf("unknown column %s, expected %s", column, strings.Join(columns, ", "))
+			}
+		}
+
+		for number := 1; ; number++ {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("reading csv: %w", err)
+			}
+
+			row := &catalogRow{CatalogRow: &domain.CatalogRow{}, number: number}
+			for i, value := range record {
+				if err = setCatalogField(row.CatalogRow, header[i], strings.TrimSpace(value)); err != nil {
+					break
+				}
+			}
+			if err != nil {
+				report.Errors = append(report.Errors, &domain.CatalogRowError{Row: number, Name: row.name(), Err: err})
+				continue
+			}
+			rows = append(rows, row)
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown catalog format %s", format)
+	}
+	return rows, nil
+}
+
+func trimCatalogRow(row *domain.CatalogRow) *domain.CatalogRow {
+	row.Name = strings.TrimSpace(row.Name)
+	row.Word = strings.TrimSpace(row.Word)
+	row.Type = strings.TrimSpace(row.Type)
+	row.Parent = strings.TrimSpace(row.Parent)
+	row.Unit = strings.TrimSpace(row.Unit)
+	return row
+}
+
+func setCatalogField(row *domain.CatalogRow, column string, value string) error {
+	var err error
+	switch column {
+	case "name":
+		row.Name = value
+	case "word":
+		row.Word = value
+	case "type":
+		row.Type = value
+	case "parent":
+		row.Parent = value
+	case "description":
+		row.Description = value
+	case "unit":
+		row.Unit = value
+	case "contents":
+		row.Contents = nil
+		for _, content := range strings.Split(value, contentsSeparator) {
+			if content = strings.TrimSpace(content); content != "" {
+				row.Contents = append(row.Contents, content)
+			}
+		}
+	case "calories":
+		row.Calories, err = parseCatalogInt(value)
+	case "grams":
+		if value != "" {
+			row.Grams, err = strconv.ParseFloat(value, 64)
+		}
+	case "density":
+		if value != "" {
+			row.Density, err = strconv.ParseFloat(value, 64)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s %q", column, value)
+	}
+	return nil
+}
+
+func parseCatalogInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func getCatalogField(row *domain.CatalogRow, column string) string {
+	switch column {
+	case "name":
+		return row.Name
+	case "word":
+		return row.Word
+	case "type":
+		return row.Type
+	case "parent":
+		return row.Parent
+	case "description":
+		return row.Description
+	case "unit":
+		return row.Unit
+	case "contents":
+		return strings.Join(row.Contents, contentsSeparator)
+	case "calories":
+		return strconv.Itoa(row.Calories)
+	case "grams":
+		return strconv.FormatFloat(row.Grams, 'f', -1, 64)
+	case "density":
+		return strconv.FormatFloat(row.Density, 'f', -1, 64)
+	}
+	return ""
+}
+
+func writeCatalogRows(w io.Writer, format string, columns []string, rows []*domain.CatalogRow) error {
+	switch format {
+	case domain.CatalogFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rows)
+
+	case domain.CatalogFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			record := make([]string, 0, len(columns))
+			for _, column := range columns {
+				record = append(record, getCatalogField(row, column))
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+	return fmt.Errorf("unknown catalog format %s", format)
+}
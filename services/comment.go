@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"github.com/Mx1q/ppo_services/config"
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/logger"
 	"github.com/google/uuid"
@@ -10,18 +11,22 @@ import (
 
 type CommentService struct {
 	commentRepo domain.ICommentRepository
+	rating      config.RatingConfig
+	pages       config.PageConfig
 	logger      logger.ILogger
 }
 
-func NewCommentService(commentRepo domain.ICommentRepository, logger logger.ILogger) domain.ICommentService {
+func NewCommentService(commentRepo domain.ICommentRepository, logger logger.ILogger, rating config.RatingConfig, pages config.PageConfig) domain.ICommentService {
 	return &CommentService{
 		commentRepo: commentRepo,
+		rating:      rating,
+		pages:       pages,
 		logger:      logger,
 	}
 }
 
 func (s *CommentService) verify(comment *domain.Comment) error {
-	if comment.Rating < domain.MinRate || comment.Rating > domain.MaxRate {
+	if comment.Rating < s.rating.MinRate || comment.Rating > s.rating.MaxRate {
 		return fmt.Errorf("rate out of range")
 	}
 	return nil
@@ -83,15 +88,21 @@ func (s *CommentService) GetBySaladAndUser(ctx context.Context, saladId uuid.UUI
 	return comment, nil
 }
 
-func (s *CommentService) GetAllBySaladID(ctx context.Context, saladId uuid.UUID, page int) ([]*domain.Comment, int, error) {
+func (s *CommentService) GetAllBySaladID(ctx context.Context, saladId uuid.UUID, page *domain.PageRequest) ([]*domain.Comment, *domain.PageInfo, error) {
 	s.logger.Infof("getting all comments by salad id %s", saladId.String())
 
-	comments, numPages, err := s.commentRepo.GetAllBySaladID(ctx, saladId, page)
+	page, err := page.Normalize(s.pages.Sizes(), domain.SortByRating)
+	if err != nil {
+		s.logger.Warnf("invalid page request: %s", err.Error())
+		return nil, nil, fmt.Errorf("getting all comments by salad id: %w", err)
+	}
+
+	comments, pageInfo, err := s.commentRepo.GetAllBySaladID(ctx, saladId, page)
 	if err != nil {
 		s.logger.Errorf("getting all comments by salad id error %s", err.Error())
-		return nil, 0, fmt.Errorf("getting all comments by salad id: %w", err)
+		return nil, nil, fmt.Errorf("getting all comments by salad id: %w", err)
 	}
-	return comments, numPages, nil
+	return comments, pageInfo, nil
 }
 
 func (s *CommentService) DeleteById(ctx context.Context, id uuid.UUID) error {
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+)
+
+type DietService struct {
+	recipeRepo         domain.IRecipeRepository
+	ingredientRepo     domain.IIngredientRepository
+	ingredientTypeRepo domain.IIngredientTypeRepository
+	saladTypeRepo      domain.ISaladTypeRepository
+	logger             logger.ILogger
+}
+
+func NewDietService(
+	recipeRepo domain.IRecipeRepository,
+	ingredientRepo domain.IIngredientRepository,
+	ingredientTypeRepo domain.IIngredientTypeRepository,
+	saladTypeRepo domain.ISaladTypeRepository,
+	logger logger.ILogger) domain.IDietService {
+	return &DietService{
+		recipeRepo:         recipeRepo,
+		ingredientRepo:     ingredientRepo,
+		ingredientTypeRepo: ingredientTypeRepo,
+		saladTypeRepo:      saladTypeRepo,
+		logger:             logger,
+	}
+}
+
+type ingredientContents struct {
+	ingredientId uuid.UUID
+	contents     []string
+}
+
+// contents returns the contents of every ingredient of the recipe, types pass their
+// contents down to subtypes
+func (s *DietService) contents(ctx context.Context, recipeId uuid.UUID) ([]ingredientContents, error) {
+	ingredients, err := s.ingredientRepo.GetAllByRecipeId(ctx, recipeId)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[uuid.UUID]*domain.IngredientType)
+	getType := func(id uuid.UUID) (*domain.IngredientType, error) {
+		ingredientType, ok := types[id]
+		if !ok {
+			ingredientType, err = s.ingredientTypeRepo.GetById(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			types[id] = ingredientType
+		}
+		return ingredientType, nil
+	}
+
+	contents := make([]ingredientContents, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		ancestors, err := domain.IngredientTypeAncestors(ingredient.TypeID, getType)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, ingredientContents{
+			ingredientId: ingredient.ID,
+			contents:     domain.IngredientContents(ingredient, ancestors),
+		})
+	}
+	return contents, nil
+}
+
+func (s *DietService) GetRecipeDiets(ctx context.Context, recipeId uuid.UUID) ([]string, error) {
+	s.logger.Infof("getting diets of recipe: %s", recipeId.String())
+
+	contents, err := s.contents(ctx, recipeId)
+	if err != nil {
+		s.logger.Errorf("getting contents of recipe error: %s", err.Error())
+		return nil, fmt.Errorf("getting diets of recipe: %w", err)
+	}
+
+	var all []string
+	for _, item := range contents {
+		all = append(all, item.contents...)
+	}
+	return domain.DietsOf(all), nil
+}
+
+// conflict checks the salad type against the contents, nil if the type stands for no diet
+func conflict(saladId uuid.UUID, saladType *domain.SaladType, contents []ingredientContents) *domain.DietConflict {
+	diet, ok := domain.DietOfName(saladType.Name)
+	if !ok {
+		return nil
+	}
+
+	var ingredients []uuid.UUID
+	for _, item := range contents {
+		if domain.Excludes(item.contents, diet) {
+			ingredients = append(ingredients, item.ingredientId)
+		}
+	}
+	if len(ingredients) == 0 {
+		return nil
+	}
+
+	return &domain.DietConflict{
+		SaladID:     saladId,
+		SaladTypeID: saladType.ID,
+		Diet:        diet,
+		Ingredients: ingredients,
+	}
+}
+
+func (s *DietService) saladContents(ctx context.Context, saladId uuid.UUID) ([]ingredientContents, error) {
+	recipe, err := s.recipeRepo.GetBySaladId(ctx, saladId)
+	if err != nil {
+		return nil, err
+	}
+	return s.contents(ctx, recipe.ID)
+}
+
+func (s *DietService) CheckSaladType(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) (*domain.DietConflict, error) {
+	s.logger.Infof("checking salad type %s of salad %s", saladTypeId.String(), saladId.String())
+
+	saladType, err := s.saladTypeRepo.GetById(ctx, saladTypeId)
+	if err != nil {
+		s.logger.Errorf("getting salad type error: %s", err.Error())
+		return nil, fmt.Errorf("checking salad type: %w", err)
+	}
+	if _, ok := domain.DietOfName(saladType.Name); !ok {
+		return nil, nil
+	}
+
+	contents, err := s.saladContents(ctx, saladId)
+	if err != nil {
+		s.logger.Errorf("getting contents of salad error: %s", err.Error())
+		return nil, fmt.Errorf("checking salad type: %w", err)
+	}
+
+	return conflict(saladId, saladType, contents), nil
+}
+
+func (s *DietService) GetSaladConflicts(ctx context.Context, saladId uuid.UUID) ([]*domain.DietConflict, error) {
+	s.logger.Infof("getting diet conflicts of salad: %s", saladId.String())
+
+	saladTypes, err := s.saladTypeRepo.GetAllBySaladId(ctx, saladId)
+	if err != nil {
+		s.logger.Errorf("getting salad types error: %s", err.Error())
+		return nil, fmt.Errorf("getting diet conflicts of salad: %w", err)
+	}
+
+	contents, err := s.saladContents(ctx, saladId)
+	if err != nil {
+		s.logger.Errorf("getting contents of salad error: %s", err.Error())
+		return nil, fmt.Errorf("getting diet conflicts of salad: %w", err)
+	}
+
+	conflicts := make([]*domain.DietConflict, 0)
+	for _, saladType := range saladTypes {
+		if found := conflict(saladId, saladType, contents); found != nil {
+			conflicts = append(conflicts, found)
+		}
+	}
+	return conflicts, nil
+}
+
+// DietCheckedSaladTypeService warns when a salad gets tagged with a type standing for
+// a diet its ingredients rule out. The tag is kept, the author decides on the warning.
+type DietCheckedSaladTypeService struct {
+	domain.ISaladTypeService
+	diets  domain.IDietService
+	logger logger.ILogger
+}
+
+func NewDietCheckedSaladTypeService(
+	inner domain.ISaladTypeService,
+	diets domain.IDietService,
+	logger logger.ILogger) domain.ISaladTypeService {
+	return &DietCheckedSaladTypeService{
+		ISaladTypeService: inner,
+		diets:             diets,
+		logger:            logger,
+	}
+}
+
+func (s *DietCheckedSaladTypeService) Link(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) error {
+	err := s.ISaladTypeService.Link(ctx, saladId, saladTypeId)
+	if err != nil {
+		return err
+	}
+
+	found, err := s.diets.CheckSaladType(ctx, saladId, saladTypeId)
+	if err != nil {
+		s.logger.Warnf("checking diet of salad type error: %s", err.Error())
+		return nil
+	}
+	if found != nil {
+		s.logger.Warn("salad tagged with contradicting diet",
+			logger.UUID("salad_id", saladId),
+			logger.UUID("salad_type_id", saladTypeId),
+			logger.String("diet", found.Diet),
+			logger.Int("ingredients", len(found.Ingredients)))
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+// Evented* decorators publish domain events of the wrapped service. The write and
+// the publishing share one transaction, so with an outbox publisher the events are
+// stored atomically with the change and a failed publish rolls the write back.
+// Events carry copies of entities, async subscribers don't share them with the caller.
+
+func eventCopy[T any](entity *T) *T {
+	copied := *entity
+	return &copied
+}
+
+type EventedSaladService struct {
+	domain.ISaladService
+	txManager domain.ITransactionManager
+	publisher domain.IEventPublisher
+}
+
+func NewEventedSaladService(
+	saladService domain.ISaladService,
+	txManager domain.ITransactionManager,
+	publisher domain.IEventPublisher) domain.ISaladService {
+	return &EventedSaladService{ISaladService: saladService, txManager: txManager, publisher: publisher}
+}
+
+func (s *EventedSaladService) Create(ctx context.Context, salad *domain.Salad) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		id, err = s.ISaladService.Create(ctx, salad)
+		if err != nil {
+			return err
+		}
+		salad.ID = id
+		return s.publisher.Publish(ctx, &domain.SaladCreated{Salad: eventCopy(salad)})
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (s *EventedSaladService) Update(ctx context.Context, salad *domain.Salad) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		err := s.ISaladService.Update(ctx, salad)
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, &domain.SaladUpdated{Salad: eventCopy(salad)})
+	})
+}
+
+func (s *EventedSaladService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		err := s.ISaladService.DeleteById(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, &domain.SaladDeleted{SaladID: id})
+	})
+}
+
+type EventedRecipeService struct {
+	domain.IRecipeService
+	txManager domain.ITransactionManager
+	publisher domain.IEventPublisher
+}
+
+func NewEventedRecipeService(
+	recipeService domain.IRecipeService,
+	txManager domain.ITransactionManager,
+	publisher domain.IEventPublisher) domain.IRecipeService {
+	return &EventedRecipeService{IRecipeService: recipeService, txManager: txManager, publisher: publisher}
+}
+
+func (s *EventedRecipeService) Create(ctx context.Context, recipe *domain.Recipe) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		id, err = s.IRecipeService.Create(ctx, recipe)
+		if err != nil {
+			return err
+		}
+		recipe.ID = id
+		return s.publisher.Publish(ctx, &domain.RecipeCreated{Recipe: eventCopy(recipe)})
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (s *EventedRecipeService) Update(ctx context.Context, recipe *domain.Recipe) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		before, err := s.IRecipeService.GetById(ctx, recipe.ID)
+		if err != nil {
+			return err
+		}
+
+		err = s.IRecipeService.Update(ctx, recipe)
+		if err != nil {
+			return err
+		}
+
+		return s.publisher.Publish(ctx, recipeUpdatedEvents(before, recipe)...)
+	})
+}
+
+func (s *EventedRecipeService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		recipe, err := s.IRecipeService.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.IRecipeService.DeleteById(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, &domain.RecipeDeleted{RecipeID: id, SaladID: recipe.SaladID})
+	})
+}
+
+func recipeUpdatedEvents(before *domain.Recipe, recipe *domain.Recipe) []domain.IEvent {
+	events := []domain.IEvent{&domain.RecipeUpdated{Recipe: eventCopy(recipe)}}
+	if before.Status != recipe.Status {
+		events = append(events, &domain.RecipeStatusChanged{
+			RecipeID:  recipe.ID,
+			SaladID:   recipe.SaladID,
+			OldStatus: before.Status,
+			NewStatus: recipe.Status,
+		})
+	}
+	return events
+}
+
+// EventedRecipeAggregateService publishes events of the salad and the recipe,
+// the aggregate service writes them through repositories
+type EventedRecipeAggregateService struct {
+	domain.IRecipeAggregateService
+	recipeRepo domain.IRecipeRepository
+	txManager  domain.ITransactionManager
+	publisher  domain.IEventPublisher
+}
+
+func NewEventedRecipeAggregateService(
+	aggregateService domain.IRecipeAggregateService,
+	recipeRepo domain.IRecipeRepository,
+	txManager domain.ITransactionManager,
+	publisher domain.IEventPublisher) domain.IRecipeAggregateService {
+	return &EventedRecipeAggregateService{
+		IRecipeAggregateService: aggregateService,
+		recipeRepo:              recipeRepo,
+		txManager:               txManager,
+		publisher:               publisher,
+	}
+}
+
+func (s *EventedRecipeAggregateService) Create(ctx context.Context, aggregate *domain.RecipeAggregate) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		id, err = s.IRecipeAggregateService.Create(ctx, aggregate)
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx,
+			&domain.SaladCreated{Salad: eventCopy(aggregate.Salad)},
+			&domain.RecipeCreated{Recipe: eventCopy(aggregate.Recipe)},
+		)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (s *EventedRecipeAggregateService) Replace(ctx context.Context, aggregate *domain.RecipeAggregate) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var before *domain.Recipe
+		if aggregate.Salad != nil {
+			before, _ = s.recipeRepo.GetBySaladId(ctx, aggregate.Salad.ID)
+		}
+
+		err := s.IRecipeAggregateService.Replace(ctx, aggregate)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			before = aggregate.Recipe
+		}
+		events := []domain.IEvent{&domain.SaladUpdated{Salad: eventCopy(aggregate.Salad)}}
+		return s.publisher.Publish(ctx, append(events, recipeUpdatedEvents(before, aggregate.Recipe)...)...)
+	})
+}
+
+type EventedCommentService struct {
+	domain.ICommentService
+	txManager domain.ITransactionManager
+	publisher domain.IEventPublisher
+}
+
+func NewEventedCommentService(
+	commentService domain.ICommentService,
+	txManager domain.ITransactionManager,
+	publisher domain.IEventPublisher) domain.ICommentService {
+	return &EventedCommentService{ICommentService: commentService, txManager: txManager, publisher: publisher}
+}
+
+func (s *EventedCommentService) Create(ctx context.Context, comment *domain.Comment) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		err := s.ICommentService.Create(ctx, comment)
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, &domain.CommentAdded{Comment: eventCopy(comment)})
+	})
+}
+
+func (s *EventedCommentService) Update(ctx context.Context, comment *domain.Comment) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		err := s.ICommentService.Update(ctx, comment)
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, &domain.CommentUpdated{Comment: eventCopy(comment)})
+	})
+}
+
+func (s *EventedCommentService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		comment, err := s.ICommentService.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.ICommentService.DeleteById(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, &domain.CommentDeleted{CommentID: id, SaladID: comment.SaladID})
+	})
+}
+
+type EventedKeywordValidatorService struct {
+	domain.IKeywordValidatorService
+	txManager domain.ITransactionManager
+	publisher domain.IEventPublisher
+}
+
+func NewEventedKeywordValidatorService(
+	keywordService domain.IKeywordValidatorService,
+	txManager domain.ITransactionManager,
+	publisher domain.IEventPublisher) domain.IKeywordValidatorService {
+	return &EventedKeywordValidatorService{IKeywordValidatorService: keywordService, txManager: txManager, publisher: publisher}
+}
+
+func (s *EventedKeywordValidatorService) Create(ctx context.Context, word *domain.KeyWord) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		err := s.IKeywordValidatorService.Create(ctx, word)
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, &domain.KeywordCreated{KeyWord: eventCopy(word)})
+	})
+}
+
+func (s *EventedKeywordValidatorService) Update(ctx context.Context, word *domain.KeyWord) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		err := s.IKeywordValidatorService.Update(ctx, word)
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, &domain.KeywordUpdated{KeyWord: eventCopy(word)})
+	})
+}
+
+func (s *EventedKeywordValidatorService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		err := s.IKeywordValidatorService.DeleteById(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, &domain.KeywordDeleted{KeywordID: id})
+	})
+}
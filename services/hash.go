@@ -11,14 +11,20 @@ type IHashCrypto interface {
 }
 
 type HashCrypto struct {
+	cost int
 }
 
 func NewHashCrypto() IHashCrypto {
-	return HashCrypto{}
+	return HashCrypto{cost: bcrypt.DefaultCost}
+}
+
+// NewHashCryptoWithCost hashes with the bcrypt cost, from bcrypt.MinCost to bcrypt.MaxCost
+func NewHashCryptoWithCost(cost int) IHashCrypto {
+	return HashCrypto{cost: cost}
 }
 
 func (c HashCrypto) GenerateHashPass(password string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), c.cost)
 	if err != nil {
 		return "", fmt.Errorf("generating hash: %w", err)
 	}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"github.com/Mx1q/ppo_services/config"
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/logger"
 	"github.com/google/uuid"
@@ -10,17 +11,19 @@ import (
 
 type IngredientService struct {
 	ingredientRepo domain.IIngredientRepository
+	pages          config.PageConfig
 	logger         logger.ILogger
 }
 
-func NewIngredientService(ingredientRepo domain.IIngredientRepository, logger logger.ILogger) domain.IIngredientService {
+func NewIngredientService(ingredientRepo domain.IIngredientRepository, logger logger.ILogger, pages config.PageConfig) domain.IIngredientService {
 	return &IngredientService{
 		ingredientRepo: ingredientRepo,
+		pages:          pages,
 		logger:         logger,
 	}
 }
 
-func (s *IngredientService) verify(ingredient *domain.Ingredient) error {
+func verifyIngredient(ingredient *domain.Ingredient) error {
 	if ingredient.Name == "" {
 		return fmt.Errorf("empty name")
 	}
@@ -29,13 +32,17 @@ func (s *IngredientService) verify(ingredient *domain.Ingredient) error {
 		return fmt.Errorf("negative calories")
 	}
 
+	if err := domain.VerifyContents(ingredient.Contents); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func (s *IngredientService) Create(ctx context.Context, ingredient *domain.Ingredient) error {
 	s.logger.Infof("creating ingredient: %s", ingredient.Name)
 
-	err := s.verify(ingredient)
+	err := verifyIngredient(ingredient)
 	if err != nil {
 		s.logger.Warnf("failed to verify ingredient: %s", err.Error())
 		return fmt.Errorf("creating ingredient: %w", err)
@@ -52,7 +59,7 @@ func (s *IngredientService) Create(ctx context.Context, ingredient *domain.Ingre
 func (s *IngredientService) Update(ctx context.Context, ingredient *domain.Ingredient) error {
 	s.logger.Infof("updating ingredient: %s", ingredient.Name)
 
-	err := s.verify(ingredient)
+	err := verifyIngredient(ingredient)
 	if err != nil {
 		s.logger.Warnf("failed to verify ingredient: %s", err.Error())
 		return fmt.Errorf("updating ingredient: %w", err)
@@ -77,15 +84,21 @@ func (s *IngredientService) GetById(ctx context.Context, id uuid.UUID) (*domain.
 	return ingredient, nil
 }
 
-func (s *IngredientService) GetAll(ctx context.Context, page int) ([]*domain.Ingredient, int, error) {
-	s.logger.Infof("getting all ingredients on page: %d", page)
+func (s *IngredientService) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.Ingredient, *domain.PageInfo, error) {
+	s.logger.Infof("getting all ingredients on page: %+v", page)
+
+	page, err := page.Normalize(s.pages.Sizes(), domain.SortByName, domain.SortByCalories)
+	if err != nil {
+		s.logger.Warnf("invalid page request: %s", err.Error())
+		return nil, nil, fmt.Errorf("getting all ingredients: %w", err)
+	}
 
-	salads, numPages, err := s.ingredientRepo.GetAll(ctx, page)
+	ingredients, pageInfo, err := s.ingredientRepo.GetAll(ctx, page)
 	if err != nil {
 		s.logger.Errorf("getting all ingredients error: %s", err.Error())
-		return nil, 0, fmt.Errorf("getting all ingredients: %w", err)
+		return nil, nil, fmt.Errorf("getting all ingredients: %w", err)
 	}
-	return salads, numPages, nil
+	return ingredients, pageInfo, nil
 }
 
 func (s *IngredientService) DeleteById(ctx context.Context, id uuid.UUID) error {
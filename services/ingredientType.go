@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/logger"
@@ -10,35 +11,70 @@ import (
 
 type IngredientTypeService struct {
 	ingredientTypeRepo domain.IIngredientTypeRepository
+	txManager          domain.ITransactionManager
 	logger             logger.ILogger
 }
 
-func NewIngredientTypeService(ingredientTypeRepo domain.IIngredientTypeRepository, logger logger.ILogger) domain.IIngredientTypeService {
+func NewIngredientTypeService(
+	ingredientTypeRepo domain.IIngredientTypeRepository,
+	txManager domain.ITransactionManager,
+	logger logger.ILogger) domain.IIngredientTypeService {
 	return &IngredientTypeService{
 		ingredientTypeRepo: ingredientTypeRepo,
+		txManager:          txManager,
 		logger:             logger,
 	}
 }
 
-func (s *IngredientTypeService) verify(ingredientType *domain.IngredientType) error {
+func verifyIngredientType(ingredientType *domain.IngredientType) error {
 	if ingredientType.Name == "" {
 		return fmt.Errorf("empty name")
 	}
-	return nil
+	return domain.VerifyContents(ingredientType.Contents)
+}
+
+// checkParent verifies the parent exists and isn't the type or its descendant. Callers
+// run it in one transaction with the write, so concurrent moves can't make a cycle.
+func (s *IngredientTypeService) checkParent(ctx context.Context, id uuid.UUID, parentId uuid.UUID) error {
+	return domain.CheckTypeMove(id, parentId, func(id uuid.UUID) (uuid.UUID, error) {
+		parent, err := s.ingredientTypeRepo.GetById(ctx, id)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return parent.ParentID, nil
+	})
+}
+
+// logTreeError logs rejected changes of the tree as warnings and failures with the format
+func (s *IngredientTypeService) logTreeError(format string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTypeCycle):
+		s.logger.Warnf("failed to verify ingredient type parent: %s", err.Error())
+	case errors.Is(err, domain.ErrTypeHasChildren):
+		s.logger.Warnf("failed to delete ingredient type: %s", err.Error())
+	default:
+		s.logger.Errorf(format, err.Error())
+	}
 }
 
 func (s *IngredientTypeService) Create(ctx context.Context, ingredientType *domain.IngredientType) error {
 	s.logger.Infof("create ingredient type: %s", ingredientType.Name)
 
-	err := s.verify(ingredientType)
+	err := verifyIngredientType(ingredientType)
 	if err != nil {
 		s.logger.Warnf("failed to verify ingredient type: %s", err.Error())
 		return fmt.Errorf("creating ingredient type: %w", err)
 	}
 
-	err = s.ingredientTypeRepo.Create(ctx, ingredientType)
+	err = domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		err := s.checkParent(ctx, ingredientType.ID, ingredientType.ParentID)
+		if err != nil {
+			return err
+		}
+		return s.ingredientTypeRepo.Create(ctx, ingredientType)
+	})
 	if err != nil {
-		s.logger.Errorf("creating ingredient type error: %s", err.Error())
+		s.logTreeError("creating ingredient type error: %s", err)
 		return fmt.Errorf("creating ingredient type: %w", err)
 	}
 
@@ -48,15 +84,21 @@ func (s *IngredientTypeService) Create(ctx context.Context, ingredientType *doma
 func (s *IngredientTypeService) Update(ctx context.Context, ingredientType *domain.IngredientType) error {
 	s.logger.Infof("update ingredient type: %s", ingredientType.Name)
 
-	err := s.verify(ingredientType)
+	err := verifyIngredientType(ingredientType)
 	if err != nil {
 		s.logger.Warnf("failed to verify ingredient type: %s", err.Error())
 		return fmt.Errorf("updating ingredient type: %w", err)
 	}
 
-	err = s.ingredientTypeRepo.Update(ctx, ingredientType)
+	err = domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		err := s.checkParent(ctx, ingredientType.ID, ingredientType.ParentID)
+		if err != nil {
+			return err
+		}
+		return s.ingredientTypeRepo.Update(ctx, ingredientType)
+	})
 	if err != nil {
-		s.logger.Errorf("updating ingredient type error: %s", err.Error())
+		s.logTreeError("updating ingredient type error: %s", err)
 		return fmt.Errorf("updating ingredient type: %w", err)
 	}
 	return nil
@@ -84,12 +126,82 @@ func (s *IngredientTypeService) GetAll(ctx context.Context) ([]*domain.Ingredien
 	return measurements, nil
 }
 
+func (s *IngredientTypeService) GetChildren(ctx context.Context, parentId uuid.UUID) ([]*domain.IngredientType, error) {
+	s.logger.Infof("getting subtypes of ingredient type: %s", parentId.String())
+
+	ingredientTypes, err := s.ingredientTypeRepo.GetAllByParentId(ctx, parentId)
+	if err != nil {
+		s.logger.Errorf("getting subtypes of ingredient type error: %s", err.Error())
+		return nil, fmt.Errorf("getting subtypes of ingredient type: %w", err)
+	}
+	return ingredientTypes, nil
+}
+
+func (s *IngredientTypeService) GetSubtree(ctx context.Context, id uuid.UUID) ([]*domain.IngredientType, error) {
+	s.logger.Infof("getting subtree of ingredient type: %s", id.String())
+
+	root, err := s.ingredientTypeRepo.GetById(ctx, id)
+	if err != nil {
+		s.logger.Errorf("getting subtree of ingredient type error: %s", err.Error())
+		return nil, fmt.Errorf("getting subtree of ingredient type: %w", err)
+	}
+
+	subtree := []*domain.IngredientType{root}
+	seen := map[uuid.UUID]bool{root.ID: true}
+	for i := 0; i < len(subtree); i++ {
+		children, err := s.ingredientTypeRepo.GetAllByParentId(ctx, subtree[i].ID)
+		if err != nil {
+			s.logger.Errorf("getting subtree of ingredient type error: %s", err.Error())
+			return nil, fmt.Errorf("getting subtree of ingredient type: %w", err)
+		}
+		for _, child := range children {
+			if !seen[child.ID] {
+				seen[child.ID] = true
+				subtree = append(subtree, child)
+			}
+		}
+	}
+	return subtree, nil
+}
+
+func (s *IngredientTypeService) Move(ctx context.Context, id uuid.UUID, parentId uuid.UUID) error {
+	s.logger.Infof("moving ingredient type %s under %s", id.String(), parentId.String())
+
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		ingredientType, err := s.ingredientTypeRepo.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+		err = s.checkParent(ctx, id, parentId)
+		if err != nil {
+			return err
+		}
+
+		ingredientType.ParentID = parentId
+		return s.ingredientTypeRepo.Update(ctx, ingredientType)
+	})
+	if err != nil {
+		s.logTreeError("moving ingredient type error: %s", err)
+		return fmt.Errorf("moving ingredient type: %w", err)
+	}
+	return nil
+}
+
 func (s *IngredientTypeService) DeleteById(ctx context.Context, id uuid.UUID) error {
 	s.logger.Infof("deleting ingredient type by id: %s", id.String())
 
-	err := s.ingredientTypeRepo.DeleteById(ctx, id)
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		children, err := s.ingredientTypeRepo.GetAllByParentId(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(children) != 0 {
+			return domain.ErrTypeHasChildren
+		}
+		return s.ingredientTypeRepo.DeleteById(ctx, id)
+	})
 	if err != nil {
-		s.logger.Errorf("deleting ingredient type error: %s", err.Error())
+		s.logTreeError("deleting ingredient type error: %s", err)
 		return fmt.Errorf("deleting ingredient type by id: %w", err)
 	}
 	return nil
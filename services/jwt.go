@@ -2,7 +2,9 @@ package services
 
 import (
 	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"time"
 )
 
@@ -11,13 +13,13 @@ type JwtPayload struct {
 	Role string
 }
 
-func GenerateAuthToken(id string, jwtKey string, role string) (tokenString string, err error) {
+func GenerateAuthToken(id string, jwtKey string, role string, ttl time.Duration) (tokenString string, err error) {
 	token := jwt.NewWithClaims(
 		jwt.SigningMethodHS256,
 		jwt.MapClaims{
 			"user_id": id,
 			"role":    role,
-			"exp":     time.Now().Add(time.Hour * 24).Unix(),
+			"exp":     time.Now().Add(ttl).Unix(),
 		})
 
 	tokenString, err = token.SignedString([]byte(jwtKey))
@@ -48,3 +50,20 @@ func VerifyAuthToken(tokenString, jwtKey string) (*JwtPayload, error) {
 
 	return payload, nil
 }
+
+func ActorFromToken(tokenString, jwtKey string) (*domain.Actor, error) {
+	payload, err := VerifyAuthToken(tokenString, jwtKey)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(payload.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parse user id: %w", err)
+	}
+
+	return &domain.Actor{
+		ID:   id,
+		Role: payload.Role,
+	}, nil
+}
@@ -28,7 +28,7 @@ func NewKeywordValidatorService(ctx context.Context, validatorRepo domain.IKeywo
 	}, nil
 }
 
-func (s *KeywordValidatorService) verifyWord(word *domain.KeyWord) error {
+func verifyKeyword(word *domain.KeyWord) error {
 	if word.Word == "" {
 		return fmt.Errorf("empty word")
 	}
@@ -41,7 +41,7 @@ func (s *KeywordValidatorService) verifyWord(word *domain.KeyWord) error {
 func (s *KeywordValidatorService) Create(ctx context.Context, word *domain.KeyWord) error {
 	s.logger.Infof("creating keyword: %s", word.Word)
 
-	err := s.verifyWord(word)
+	err := verifyKeyword(word)
 	if err != nil {
 		s.logger.Warnf("failed to verify word: %s", err.Error())
 		return fmt.Errorf("creating keyword: %w", err)
@@ -59,7 +59,7 @@ func (s *KeywordValidatorService) Create(ctx context.Context, word *domain.KeyWo
 func (s *KeywordValidatorService) Update(ctx context.Context, word *domain.KeyWord) error {
 	s.logger.Infof("updating keyword: %s", word.Word)
 
-	err := s.verifyWord(word)
+	err := verifyKeyword(word)
 	if err != nil {
 		s.logger.Warnf("failed to verify word: %s", err.Error())
 		return fmt.Errorf("updating keyword: %w", err)
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+// Localized* decorators translate texts of entities the wrapped service reads to the
+// locale of the context. Errors of localization are logged by ITranslationService and
+// leave the source texts. Entities are read for editing without a locale in the context,
+// otherwise Update would store translations as the source.
+
+func ingredientTexts(ingredients ...*domain.Ingredient) []domain.TranslatableText {
+	texts := make([]domain.TranslatableText, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		texts = append(texts, domain.TranslatableText{
+			EntityID: ingredient.ID, Field: domain.TranslationFieldName, Text: &ingredient.Name,
+		})
+	}
+	return texts
+}
+
+func ingredientTypeTexts(ingredientTypes ...*domain.IngredientType) []domain.TranslatableText {
+	texts := make([]domain.TranslatableText, 0, 2*len(ingredientTypes))
+	for _, ingredientType := range ingredientTypes {
+		texts = append(texts,
+			domain.TranslatableText{EntityID: ingredientType.ID, Field: domain.TranslationFieldName, Text: &ingredientType.Name},
+			domain.TranslatableText{EntityID: ingredientType.ID, Field: domain.TranslationFieldDescription, Text: &ingredientType.Description},
+		)
+	}
+	return texts
+}
+
+func saladTypeTexts(saladTypes ...*domain.SaladType) []domain.TranslatableText {
+	texts := make([]domain.TranslatableText, 0, 2*len(saladTypes))
+	for _, saladType := range saladTypes {
+		texts = append(texts,
+			domain.TranslatableText{EntityID: saladType.ID, Field: domain.TranslationFieldName, Text: &saladType.Name},
+			domain.TranslatableText{EntityID: saladType.ID, Field: domain.TranslationFieldDescription, Text: &saladType.Description},
+		)
+	}
+	return texts
+}
+
+func measurementTexts(measurements ...*domain.Measurement) []domain.TranslatableText {
+	texts := make([]domain.TranslatableText, 0, len(measurements))
+	for _, measurement := range measurements {
+		texts = append(texts, domain.TranslatableText{
+			EntityID: measurement.ID, Field: domain.TranslationFieldName, Text: &measurement.Name,
+		})
+	}
+	return texts
+}
+
+func saladTexts(salads ...*domain.Salad) []domain.TranslatableText {
+	texts := make([]domain.TranslatableText, 0, 2*len(salads))
+	for _, salad := range salads {
+		texts = append(texts,
+			domain.TranslatableText{EntityID: salad.ID, Field: domain.TranslationFieldName, Text: &salad.Name},
+			domain.TranslatableText{EntityID: salad.ID, Field: domain.TranslationFieldDescription, Text: &salad.Description},
+		)
+	}
+	return texts
+}
+
+type LocalizedIngredientService struct {
+	domain.IIngredientService
+	translations domain.ITranslationService
+}
+
+func NewLocalizedIngredientService(ingredientService domain.IIngredientService, translations domain.ITranslationService) domain.IIngredientService {
+	return &LocalizedIngredientService{IIngredientService: ingredientService, translations: translations}
+}
+
+func (s *LocalizedIngredientService) GetById(ctx context.Context, id uuid.UUID) (*domain.Ingredient, error) {
+	ingredient, err := s.IIngredientService.GetById(ctx, id)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntityIngredient, ingredientTexts(ingredient))
+	}
+	return ingredient, err
+}
+
+func (s *LocalizedIngredientService) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.Ingredient, *domain.PageInfo, error) {
+	ingredients, pageInfo, err := s.IIngredientService.GetAll(ctx, page)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntityIngredient, ingredientTexts(ingredients...))
+	}
+	return ingredients, pageInfo, err
+}
+
+func (s *LocalizedIngredientService) GetAllByRecipeId(ctx context.Context, id uuid.UUID) ([]*domain.Ingredient, error) {
+	ingredients, err := s.IIngredientService.GetAllByRecipeId(ctx, id)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntityIngredient, ingredientTexts(ingredients...))
+	}
+	return ingredients, err
+}
+
+type LocalizedIngredientTypeService struct {
+	domain.IIngredientTypeService
+	translations domain.ITranslationService
+}
+
+func NewLocalizedIngredientTypeService(ingredientTypeService domain.IIngredientTypeService, translations domain.ITranslationService) domain.IIngredientTypeService {
+	return &LocalizedIngredientTypeService{IIngredientTypeService: ingredientTypeService, translations: translations}
+}
+
+func (s *LocalizedIngredientTypeService) GetById(ctx context.Context, id uuid.UUID) (*domain.IngredientType, error) {
+	ingredientType, err := s.IIngredientTypeService.GetById(ctx, id)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntityIngredientType, ingredientTypeTexts(ingredientType))
+	}
+	return ingredientType, err
+}
+
+func (s *LocalizedIngredientTypeService) GetAll(ctx context.Context) ([]*domain.IngredientType, error) {
+	ingredientTypes, err := s.IIngredientTypeService.GetAll(ctx)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntityIngredientType, ingredientTypeTexts(ingredientTypes...))
+	}
+	return ingredientTypes, err
+}
+
+func (s *LocalizedIngredientTypeService) GetChildren(ctx context.Context, parentId uuid.UUID) ([]*domain.IngredientType, error) {
+	ingredientTypes, err := s.IIngredientTypeService.GetChildren(ctx, parentId)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntityIngredientType, ingredientTypeTexts(ingredientTypes...))
+	}
+	return ingredientTypes, err
+}
+
+func (s *LocalizedIngredientTypeService) GetSubtree(ctx context.Context, id uuid.UUID) ([]*domain.IngredientType, error) {
+	ingredientTypes, err := s.IIngredientTypeService.GetSubtree(ctx, id)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntityIngredientType, ingredientTypeTexts(ingredientTypes...))
+	}
+	return ingredientTypes, err
+}
+
+type LocalizedSaladTypeService struct {
+	domain.ISaladTypeService
+	translations domain.ITranslationService
+}
+
+func NewLocalizedSaladTypeService(saladTypeService domain.ISaladTypeService, translations domain.ITranslationService) domain.ISaladTypeService {
+	return &LocalizedSaladTypeService{ISaladTypeService: saladTypeService, translations: translations}
+}
+
+func (s *LocalizedSaladTypeService) GetById(ctx context.Context, id uuid.UUID) (*domain.SaladType, error) {
+	saladType, err := s.ISaladTypeService.GetById(ctx, id)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntitySaladType, saladTypeTexts(saladType))
+	}
+	return saladType, err
+}
+
+func (s *LocalizedSaladTypeService) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.SaladType, *domain.PageInfo, error) {
+	saladTypes, pageInfo, err := s.ISaladTypeService.GetAll(ctx, page)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntitySaladType, saladTypeTexts(saladTypes...))
+	}
+	return saladTypes, pageInfo, err
+}
+
+func (s *LocalizedSaladTypeService) GetAllBySaladId(ctx context.Context, saladId uuid.UUID) ([]*domain.SaladType, error) {
+	saladTypes, err := s.ISaladTypeService.GetAllBySaladId(ctx, saladId)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntitySaladType, saladTypeTexts(saladTypes...))
+	}
+	return saladTypes, err
+}
+
+func (s *LocalizedSaladTypeService) GetChildren(ctx context.Context, parentId uuid.UUID) ([]*domain.SaladType, error) {
+	saladTypes, err := s.ISaladTypeService.GetChildren(ctx, parentId)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntitySaladType, saladTypeTexts(saladTypes...))
+	}
+	return saladTypes, err
+}
+
+func (s *LocalizedSaladTypeService) GetSubtree(ctx context.Context, id uuid.UUID) ([]*domain.SaladType, error) {
+	saladTypes, err := s.ISaladTypeService.GetSubtree(ctx, id)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntitySaladType, saladTypeTexts(saladTypes...))
+	}
+	return saladTypes, err
+}
+
+type LocalizedMeasurementService struct {
+	domain.IMeasurementService
+	translations domain.ITranslationService
+}
+
+func NewLocalizedMeasurementService(measurementService domain.IMeasurementService, translations domain.ITranslationService) domain.IMeasurementService {
+	return &LocalizedMeasurementService{IMeasurementService: measurementService, translations: translations}
+}
+
+func (s *LocalizedMeasurementService) GetById(ctx context.Context, id uuid.UUID) (*domain.Measurement, error) {
+	measurement, err := s.IMeasurementService.GetById(ctx, id)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntityMeasurement, measurementTexts(measurement))
+	}
+	return measurement, err
+}
+
+func (s *LocalizedMeasurementService) GetByRecipeId(ctx context.Context, ingredientId uuid.UUID, recipeId uuid.UUID) (*domain.Measurement, int, error) {
+	measurement, amount, err := s.IMeasurementService.GetByRecipeId(ctx, ingredientId, recipeId)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntityMeasurement, measurementTexts(measurement))
+	}
+	return measurement, amount, err
+}
+
+func (s *LocalizedMeasurementService) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeMeasurement, error) {
+	recipeMeasurements, err := s.IMeasurementService.GetAllByRecipeId(ctx, recipeId)
+	if err == nil {
+		measurements := make([]*domain.Measurement, 0, len(recipeMeasurements))
+		for _, recipeMeasurement := range recipeMeasurements {
+			measurements = append(measurements, recipeMeasurement.Measurement)
+		}
+		_ = s.translations.Localize(ctx, domain.TranslationEntityMeasurement, measurementTexts(measurements...))
+	}
+	return recipeMeasurements, err
+}
+
+func (s *LocalizedMeasurementService) GetAll(ctx context.Context) ([]*domain.Measurement, error) {
+	measurements, err := s.IMeasurementService.GetAll(ctx)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntityMeasurement, measurementTexts(measurements...))
+	}
+	return measurements, err
+}
+
+type LocalizedSaladService struct {
+	domain.ISaladService
+	translations domain.ITranslationService
+}
+
+func NewLocalizedSaladService(saladService domain.ISaladService, translations domain.ITranslationService) domain.ISaladService {
+	return &LocalizedSaladService{ISaladService: saladService, translations: translations}
+}
+
+func (s *LocalizedSaladService) GetById(ctx context.Context, id uuid.UUID) (*domain.Salad, error) {
+	salad, err := s.ISaladService.GetById(ctx, id)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntitySalad, saladTexts(salad))
+	}
+	return salad, err
+}
+
+func (s *LocalizedSaladService) GetAll(ctx context.Context, filter *domain.RecipeFilter, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	salads, pageInfo, err := s.ISaladService.GetAll(ctx, filter, page)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntitySalad, saladTexts(salads...))
+	}
+	return salads, pageInfo, err
+}
+
+func (s *LocalizedSaladService) GetAllByUserId(ctx context.Context, id uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	salads, pageInfo, err := s.ISaladService.GetAllByUserId(ctx, id, page)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntitySalad, saladTexts(salads...))
+	}
+	return salads, pageInfo, err
+}
+
+func (s *LocalizedSaladService) GetAllRatedByUser(ctx context.Context, userId uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	salads, pageInfo, err := s.ISaladService.GetAllRatedByUser(ctx, userId, page)
+	if err == nil {
+		_ = s.translations.Localize(ctx, domain.TranslationEntitySalad, saladTexts(salads...))
+	}
+	return salads, pageInfo, err
+}
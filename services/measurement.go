@@ -20,11 +20,22 @@ func NewMeasurementService(measurementRepo domain.IMeasurementRepository, logger
 	}
 }
 
-func (s *MeasurementService) verify(measurement *domain.Measurement) error {
+// verifyMeasurement fills Grams of measurements in mass units from the unit
+func verifyMeasurement(measurement *domain.Measurement) error {
 	if measurement.Name == "" {
 		return fmt.Errorf("empty name")
 	}
 
+	if measurement.Unit != "" {
+		unit, err := domain.GetUnit(measurement.Unit)
+		if err != nil {
+			return err
+		}
+		if unit.Dimension == domain.DimensionMass {
+			measurement.Grams = unit.Factor
+		}
+	}
+
 	if measurement.Grams <= 0 {
 		return fmt.Errorf("negative or zero grams count")
 	}
@@ -35,7 +46,7 @@ func (s *MeasurementService) verify(measurement *domain.Measurement) error {
 func (s *MeasurementService) Create(ctx context.Context, measurement *domain.Measurement) error {
 	s.logger.Infof("create measurement: %s", measurement.Name)
 
-	err := s.verify(measurement)
+	err := verifyMeasurement(measurement)
 	if err != nil {
 		s.logger.Warnf("fail to verify measurement: %s", err.Error())
 		return fmt.Errorf("creating measurement unit: %w", err)
@@ -53,7 +64,7 @@ func (s *MeasurementService) Create(ctx context.Context, measurement *domain.Mea
 func (s *MeasurementService) Update(ctx context.Context, measurement *domain.Measurement) error {
 	s.logger.Infof("updating measurement: %s", measurement.ID.String())
 
-	err := s.verify(measurement)
+	err := verifyMeasurement(measurement)
 	if err != nil {
 		s.logger.Warnf("fail to verify measurement: %s", err.Error())
 		return fmt.Errorf("updating measurement unit: %w", err)
@@ -92,6 +103,17 @@ func (s *MeasurementService) GetByRecipeId(ctx context.Context,
 	return measurement, count, nil
 }
 
+func (s *MeasurementService) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeMeasurement, error) {
+	s.logger.Infof("getting all measurements of recipe: %s", recipeId.String())
+
+	measurements, err := s.measurementRepo.GetAllByRecipeId(ctx, recipeId)
+	if err != nil {
+		s.logger.Errorf("getting all measurements of recipe error: %s", err.Error())
+		return nil, fmt.Errorf("getting all measurement units by recipe id: %w", err)
+	}
+	return measurements, nil
+}
+
 func (s *MeasurementService) GetAll(ctx context.Context) ([]*domain.Measurement, error) {
 	s.logger.Infof("getting all measurements")
 
@@ -128,3 +150,38 @@ func (s *MeasurementService) UpdateLink(ctx context.Context, linkId uuid.UUID, m
 	}
 	return nil
 }
+
+func (s *MeasurementService) Convert(ctx context.Context,
+	quantity domain.Quantity,
+	to string,
+	ingredient *domain.Ingredient) (domain.Quantity, error) {
+	s.logger.Infof("converting %s to %s", quantity.String(), to)
+
+	density := 0.0
+	if ingredient != nil {
+		density = ingredient.Density
+	}
+
+	converted, err := domain.Convert(quantity, to, density)
+	if err != nil {
+		s.logger.Warnf("converting quantity error: %s", err.Error())
+		return domain.Quantity{}, fmt.Errorf("converting quantity: %w", err)
+	}
+	return converted, nil
+}
+
+func (s *MeasurementService) ToSystem(ctx context.Context, item *domain.SaladIngredient, system string) (domain.Quantity, error) {
+	s.logger.Infof("converting ingredient to %s system", system)
+
+	if item.Measurement == nil {
+		s.logger.Warnf("converting ingredient without measurement")
+		return domain.Quantity{}, fmt.Errorf("converting ingredient: empty measurement")
+	}
+
+	converted, err := domain.ToSystem(item.Measurement.Quantity(item.Amount), system)
+	if err != nil {
+		s.logger.Warnf("converting ingredient error: %s", err.Error())
+		return domain.Quantity{}, fmt.Errorf("converting ingredient: %w", err)
+	}
+	return converted, nil
+}
@@ -0,0 +1,454 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"slices"
+	"sort"
+)
+
+const (
+	defaultMediaMaxSize       = 10 << 20
+	defaultMediaMaxDimension  = 8192
+	defaultMediaThumbnailSize = 256
+)
+
+type MediaService struct {
+	mediaRepo      domain.IMediaRepository
+	saladRepo      domain.ISaladRepository
+	recipeStepRepo domain.IRecipeStepRepository
+	storage        domain.IBlobStorage
+	txManager      domain.ITransactionManager
+	limits         domain.MediaLimits
+	logger         logger.ILogger
+}
+
+func NewMediaService(
+	mediaRepo domain.IMediaRepository,
+	saladRepo domain.ISaladRepository,
+	recipeStepRepo domain.IRecipeStepRepository,
+	storage domain.IBlobStorage,
+	txManager domain.ITransactionManager,
+	limits domain.MediaLimits,
+	logger logger.ILogger) domain.IMediaService {
+	if limits.MaxSize <= 0 {
+		limits.MaxSize = defaultMediaMaxSize
+	}
+	if limits.MaxWidth <= 0 {
+		limits.MaxWidth = defaultMediaMaxDimension
+	}
+	if limits.MaxHeight <= 0 {
+		limits.MaxHeight = defaultMediaMaxDimension
+	}
+	if limits.ThumbnailSize <= 0 {
+		limits.ThumbnailSize = defaultMediaThumbnailSize
+	}
+
+	return &MediaService{
+		mediaRepo:      mediaRepo,
+		saladRepo:      saladRepo,
+		recipeStepRepo: recipeStepRepo,
+		storage:        storage,
+		txManager:      txManager,
+		limits:         limits,
+		logger:         logger,
+	}
+}
+
+func verifyMediaOwner(ownerType string, ownerId uuid.UUID) error {
+	if ownerType != domain.MediaOwnerSalad && ownerType != domain.MediaOwnerRecipeStep {
+		return fmt.Errorf("unknown owner type %s", ownerType)
+	}
+
+	if ownerId == uuid.Nil {
+		return fmt.Errorf("empty owner")
+	}
+
+	return nil
+}
+
+// verifyMediaContent sniffs the type of the content and checks its size and dimensions
+func (s *MediaService) verifyMediaContent(data []byte) (*domain.Media, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty file")
+	}
+
+	if int64(len(data)) > s.limits.MaxSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", s.limits.MaxSize)
+	}
+
+	mimeType := http.DetectContentType(data)
+	if !slices.Contains(domain.MediaTypes, mimeType) {
+		return nil, fmt.Errorf("unsupported media type %s", mimeType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image: %w", err)
+	}
+	if config.Width > s.limits.MaxWidth || config.Height > s.limits.MaxHeight {
+		return nil, fmt.Errorf("image is larger than %dx%d", s.limits.MaxWidth, s.limits.MaxHeight)
+	}
+
+	return &domain.Media{
+		MimeType: mimeType,
+		Size:     int64(len(data)),
+		Width:    config.Width,
+		Height:   config.Height,
+	}, nil
+}
+
+// makeThumbnail scales the image down to fit a square of the thumbnail size, jpeg images
+// keep their format and the rest become png to keep transparency
+func (s *MediaService) makeThumbnail(data []byte, mimeType string) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decoding image: %w", err)
+	}
+
+	thumbnail := scaleDown(img, s.limits.ThumbnailSize)
+	buf := new(bytes.Buffer)
+	if mimeType == "image/jpeg" {
+		err = jpeg.Encode(buf, thumbnail, &jpeg.Options{Quality: 85})
+	} else {
+		err = png.Encode(buf, thumbnail)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encoding thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// scaleDown averages boxes of source pixels, images fitting the size are copied as is
+func scaleDown(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > size || height > size {
+		if width >= height {
+			width, height = size, max(1, height*size/width)
+		} else {
+			width, height = max(1, width*size/height), size
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n >> 8)
+			dst.Pix[i+1] = uint8(g / n >> 8)
+			dst.Pix[i+2] = uint8(b / n >> 8)
+			dst.Pix[i+3] = uint8(a / n >> 8)
+		}
+	}
+	return dst
+}
+
+func (s *MediaService) checkOwner(ctx context.Context, ownerType string, ownerId uuid.UUID) error {
+	var err error
+	if ownerType == domain.MediaOwnerSalad {
+		_, err = s.saladRepo.GetById(ctx, ownerId)
+	} else {
+		_, err = s.recipeStepRepo.GetById(ctx, ownerId)
+	}
+	return err
+}
+
+// getSorted returns media of the owner in display order
+func (s *MediaService) getSorted(ctx context.Context, ownerType string, ownerId uuid.UUID) ([]*domain.Media, error) {
+	media, err := s.mediaRepo.GetAllByOwner(ctx, ownerType, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(media, func(i, j int) bool {
+		return media[i].Order < media[j].Order
+	})
+	return media, nil
+}
+
+// reorder makes orders follow the slice (1..N), only changed media are updated
+func (s *MediaService) reorder(ctx context.Context, media []*domain.Media) error {
+	for i, item := range media {
+		if item.Order == i+1 {
+			continue
+		}
+		item.Order = i + 1
+		err := s.mediaRepo.Update(ctx, item)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertMedia places the media at its order, reorder skips it as it's already there
+func insertMedia(media []*domain.Media, item *domain.Media) []*domain.Media {
+	pos := item.Order - 1
+	media = append(media, nil)
+	copy(media[pos+1:], media[pos:])
+	media[pos] = item
+	return media
+}
+
+// deleteBlobs removes content of deleted media, failures leave orphan blobs and are only logged
+func (s *MediaService) deleteBlobs(ctx context.Context, media ...*domain.Media) {
+	for _, item := range media {
+		for _, key := range []string{item.Key, item.ThumbnailKey} {
+			if err := s.storage.Delete(ctx, key); err != nil {
+				s.logger.Errorf("deleting media blob %s error: %s", key, err.Error())
+			}
+		}
+	}
+}
+
+func (s *MediaService) Upload(ctx context.Context, upload *domain.MediaUpload) (*domain.Media, error) {
+	s.logger.Infof("uploading media to %s %s", upload.OwnerType, upload.OwnerID.String())
+
+	err := verifyMediaOwner(upload.OwnerType, upload.OwnerID)
+	if err != nil {
+		s.logger.Warnf("failed to verify media: %s", err.Error())
+		return nil, fmt.Errorf("uploading media: %w", err)
+	}
+	if upload.Order < 0 {
+		s.logger.Warnf("failed to verify media: negative order")
+		return nil, fmt.Errorf("uploading media: negative order")
+	}
+
+	media, err := s.verifyMediaContent(upload.Data)
+	if err != nil {
+		s.logger.Warnf("failed to verify media: %s", err.Error())
+		return nil, fmt.Errorf("uploading media: %w", err)
+	}
+	media.OwnerType = upload.OwnerType
+	media.OwnerID = upload.OwnerID
+	media.Order = upload.Order
+
+	thumbnail, err := s.makeThumbnail(upload.Data, media.MimeType)
+	if err != nil {
+		s.logger.Warnf("failed to verify media: %s", err.Error())
+		return nil, fmt.Errorf("uploading media: %w", err)
+	}
+
+	key := uuid.New().String()
+	media.Key = key
+	media.ThumbnailKey = key + ".thumbnail"
+
+	err = s.storage.Put(ctx, media.Key, upload.Data)
+	if err == nil {
+		err = s.storage.Put(ctx, media.ThumbnailKey, thumbnail)
+	}
+	if err != nil {
+		s.deleteBlobs(ctx, media)
+		s.logger.Errorf("uploading media error: %s", err.Error())
+		return nil, fmt.Errorf("uploading media: %w", err)
+	}
+
+	err = domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		err := s.checkOwner(ctx, media.OwnerType, media.OwnerID)
+		if err != nil {
+			return err
+		}
+
+		attached, err := s.getSorted(ctx, media.OwnerType, media.OwnerID)
+		if err != nil {
+			return err
+		}
+		if media.Order == 0 {
+			media.Order = len(attached) + 1
+		}
+		if media.Order > len(attached)+1 {
+			return fmt.Errorf("invalid order")
+		}
+
+		err = s.reorder(ctx, insertMedia(attached, media))
+		if err != nil {
+			return err
+		}
+
+		media.ID, err = s.mediaRepo.Create(ctx, media)
+		return err
+	})
+	if err != nil {
+		s.deleteBlobs(ctx, media)
+		s.logger.Errorf("uploading media error: %s", err.Error())
+		return nil, fmt.Errorf("uploading media: %w", err)
+	}
+	domain.AfterRollback(ctx, func() {
+		s.deleteBlobs(ctx, media)
+	})
+	return media, nil
+}
+
+func (s *MediaService) GetById(ctx context.Context, id uuid.UUID) (*domain.Media, error) {
+	s.logger.Infof("getting media by id: %s", id.String())
+
+	media, err := s.mediaRepo.GetById(ctx, id)
+	if err != nil {
+		s.logger.Errorf("getting media by id error: %s", err.Error())
+		return nil, fmt.Errorf("getting media by id: %w", err)
+	}
+	return media, nil
+}
+
+func (s *MediaService) GetAllByOwner(ctx context.Context, ownerType string, ownerId uuid.UUID) ([]*domain.Media, error) {
+	s.logger.Infof("getting all media of %s %s", ownerType, ownerId.String())
+
+	media, err := s.getSorted(ctx, ownerType, ownerId)
+	if err != nil {
+		s.logger.Errorf("getting all media of owner error: %s", err.Error())
+		return nil, fmt.Errorf("getting all media of owner: %w", err)
+	}
+	return media, nil
+}
+
+func (s *MediaService) GetContent(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	s.logger.Infof("getting content of media: %s", id.String())
+
+	data, err := s.getBlob(ctx, id, func(media *domain.Media) string { return media.Key })
+	if err != nil {
+		s.logger.Errorf("getting content of media error: %s", err.Error())
+		return nil, fmt.Errorf("getting content of media: %w", err)
+	}
+	return data, nil
+}
+
+func (s *MediaService) GetThumbnail(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	s.logger.Infof("getting thumbnail of media: %s", id.String())
+
+	data, err := s.getBlob(ctx, id, func(media *domain.Media) string { return media.ThumbnailKey })
+	if err != nil {
+		s.logger.Errorf("getting thumbnail of media error: %s", err.Error())
+		return nil, fmt.Errorf("getting thumbnail of media: %w", err)
+	}
+	return data, nil
+}
+
+func (s *MediaService) getBlob(ctx context.Context, id uuid.UUID, key func(media *domain.Media) string) ([]byte, error) {
+	media, err := s.mediaRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.Get(ctx, key(media))
+}
+
+func (s *MediaService) Reorder(ctx context.Context, ownerType string, ownerId uuid.UUID, mediaIds []uuid.UUID) error {
+	s.logger.Infof("reordering media of %s %s", ownerType, ownerId.String())
+
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		media, err := s.mediaRepo.GetAllByOwner(ctx, ownerType, ownerId)
+		if err != nil {
+			return err
+		}
+		if len(media) != len(mediaIds) {
+			return domain.ErrMediaChanged
+		}
+
+		byId := make(map[uuid.UUID]*domain.Media, len(media))
+		for _, item := range media {
+			byId[item.ID] = item
+		}
+
+		ordered := make([]*domain.Media, 0, len(mediaIds))
+		for _, id := range mediaIds {
+			item, ok := byId[id]
+			if !ok {
+				return domain.ErrMediaChanged
+			}
+			delete(byId, id)
+			ordered = append(ordered, item)
+		}
+
+		return s.reorder(ctx, ordered)
+	})
+	if err != nil {
+		s.logger.Errorf("reordering media error: %s", err.Error())
+		return fmt.Errorf("reordering media: %w", err)
+	}
+	return nil
+}
+
+// DeleteById detaches the media and closes the gap in orders, blobs are removed after the commit
+func (s *MediaService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	s.logger.Infof("deleting media: %s", id.String())
+
+	var deleted *domain.Media
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		deleted, err = s.mediaRepo.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.mediaRepo.DeleteById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		media, err := s.getSorted(ctx, deleted.OwnerType, deleted.OwnerID)
+		if err != nil {
+			return err
+		}
+		return s.reorder(ctx, media)
+	})
+	if err != nil {
+		s.logger.Errorf("deleting media error: %s", err.Error())
+		return fmt.Errorf("deleting media: %w", err)
+	}
+
+	domain.AfterCommit(ctx, func() {
+		s.deleteBlobs(ctx, deleted)
+	})
+	return nil
+}
+
+func (s *MediaService) DeleteAllByOwner(ctx context.Context, ownerType string, ownerId uuid.UUID) error {
+	s.logger.Infof("deleting all media of %s %s", ownerType, ownerId.String())
+
+	var deleted []*domain.Media
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		deleted, err = s.mediaRepo.GetAllByOwner(ctx, ownerType, ownerId)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range deleted {
+			err = s.mediaRepo.DeleteById(ctx, item.ID)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Errorf("deleting all media of owner error: %s", err.Error())
+		return fmt.Errorf("deleting all media of owner: %w", err)
+	}
+
+	domain.AfterCommit(ctx, func() {
+		s.deleteBlobs(ctx, deleted...)
+	})
+	return nil
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+)
+
+// MediaCleanup* decorators delete media of the entities the wrapped service deletes.
+// The deletion succeeds even if the cleanup fails, orphan media are only logged.
+
+type MediaCleanupSaladService struct {
+	domain.ISaladService
+	recipeRepo     domain.IRecipeRepository
+	recipeStepRepo domain.IRecipeStepRepository
+	media          domain.IMediaService
+	logger         logger.ILogger
+}
+
+func NewMediaCleanupSaladService(
+	saladService domain.ISaladService,
+	recipeRepo domain.IRecipeRepository,
+	recipeStepRepo domain.IRecipeStepRepository,
+	media domain.IMediaService,
+	logger logger.ILogger) domain.ISaladService {
+	return &MediaCleanupSaladService{
+		ISaladService:  saladService,
+		recipeRepo:     recipeRepo,
+		recipeStepRepo: recipeStepRepo,
+		media:          media,
+		logger:         logger,
+	}
+}
+
+// DeleteById also cleans media of the recipe steps, they are deleted with the salad
+func (s *MediaCleanupSaladService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	var steps []*domain.RecipeStep
+	recipe, err := s.recipeRepo.GetBySaladId(ctx, id)
+	if err == nil {
+		steps, err = s.recipeStepRepo.GetAllByRecipeID(ctx, recipe.ID)
+	}
+	if err != nil {
+		s.logger.Warnf("getting steps of deleted salad %s: %s", id.String(), err.Error())
+	}
+
+	err = s.ISaladService.DeleteById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_ = s.media.DeleteAllByOwner(ctx, domain.MediaOwnerSalad, id)
+	for _, step := range steps {
+		_ = s.media.DeleteAllByOwner(ctx, domain.MediaOwnerRecipeStep, step.ID)
+	}
+	return nil
+}
+
+type MediaCleanupRecipeStepService struct {
+	domain.IRecipeStepService
+	media domain.IMediaService
+}
+
+func NewMediaCleanupRecipeStepService(recipeStepService domain.IRecipeStepService, media domain.IMediaService) domain.IRecipeStepService {
+	return &MediaCleanupRecipeStepService{IRecipeStepService: recipeStepService, media: media}
+}
+
+func (s *MediaCleanupRecipeStepService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	err := s.IRecipeStepService.DeleteById(ctx, id)
+	if err == nil {
+		_ = s.media.DeleteAllByOwner(ctx, domain.MediaOwnerRecipeStep, id)
+	}
+	return err
+}
+
+func (s *MediaCleanupRecipeStepService) DeleteAllByRecipeID(ctx context.Context, recipeId uuid.UUID) error {
+	steps, _ := s.IRecipeStepService.GetAllByRecipeID(ctx, recipeId)
+	err := s.IRecipeStepService.DeleteAllByRecipeID(ctx, recipeId)
+	if err == nil {
+		for _, step := range steps {
+			_ = s.media.DeleteAllByOwner(ctx, domain.MediaOwnerRecipeStep, step.ID)
+		}
+	}
+	return err
+}
+
+type MediaCleanupRecipeService struct {
+	domain.IRecipeService
+	recipeStepRepo domain.IRecipeStepRepository
+	media          domain.IMediaService
+	logger         logger.ILogger
+}
+
+func NewMediaCleanupRecipeService(
+	recipeService domain.IRecipeService,
+	recipeStepRepo domain.IRecipeStepRepository,
+	media domain.IMediaService,
+	logger logger.ILogger) domain.IRecipeService {
+	return &MediaCleanupRecipeService{
+		IRecipeService: recipeService,
+		recipeStepRepo: recipeStepRepo,
+		media:          media,
+		logger:         logger,
+	}
+}
+
+// DeleteById cleans media of the recipe steps, they are deleted with the recipe
+func (s *MediaCleanupRecipeService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	steps, err := s.recipeStepRepo.GetAllByRecipeID(ctx, id)
+	if err != nil {
+		s.logger.Warnf("getting steps of deleted recipe %s: %s", id.String(), err.Error())
+	}
+
+	err = s.IRecipeService.DeleteById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	for _, step := range steps {
+		_ = s.media.DeleteAllByOwner(ctx, domain.MediaOwnerRecipeStep, step.ID)
+	}
+	return nil
+}
+
+type MediaCleanupRecipeAggregateService struct {
+	domain.IRecipeAggregateService
+	recipeRepo     domain.IRecipeRepository
+	recipeStepRepo domain.IRecipeStepRepository
+	media          domain.IMediaService
+	logger         logger.ILogger
+}
+
+func NewMediaCleanupRecipeAggregateService(
+	aggregateService domain.IRecipeAggregateService,
+	recipeRepo domain.IRecipeRepository,
+	recipeStepRepo domain.IRecipeStepRepository,
+	media domain.IMediaService,
+	logger logger.ILogger) domain.IRecipeAggregateService {
+	return &MediaCleanupRecipeAggregateService{
+		IRecipeAggregateService: aggregateService,
+		recipeRepo:              recipeRepo,
+		recipeStepRepo:          recipeStepRepo,
+		media:                   media,
+		logger:                  logger,
+	}
+}
+
+// Replace cleans media of the steps the new aggregate doesn't keep by their IDs
+func (s *MediaCleanupRecipeAggregateService) Replace(ctx context.Context, aggregate *domain.RecipeAggregate) error {
+	var steps []*domain.RecipeStep
+	if aggregate.Salad != nil {
+		recipe, err := s.recipeRepo.GetBySaladId(ctx, aggregate.Salad.ID)
+		if err == nil {
+			steps, err = s.recipeStepRepo.GetAllByRecipeID(ctx, recipe.ID)
+		}
+		if err != nil {
+			s.logger.Warnf("getting steps of replaced recipe of salad %s: %s", aggregate.Salad.ID.String(), err.Error())
+		}
+	}
+
+	err := s.IRecipeAggregateService.Replace(ctx, aggregate)
+	if err != nil {
+		return err
+	}
+
+	kept := make(map[uuid.UUID]bool, len(aggregate.Steps))
+	for _, step := range aggregate.Steps {
+		kept[step.ID] = true
+	}
+	for _, step := range steps {
+		if !kept[step.ID] {
+			_ = s.media.DeleteAllByOwner(ctx, domain.MediaOwnerRecipeStep, step.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+)
+
+type ModerationService struct {
+	recipeService domain.IRecipeService
+	logger        logger.ILogger
+}
+
+func NewModerationService(recipeService domain.IRecipeService, logger logger.ILogger) domain.IModerationService {
+	return &ModerationService{
+		recipeService: recipeService,
+		logger:        logger,
+	}
+}
+
+func (s *ModerationService) GetQueue(ctx context.Context, page *domain.PageRequest) ([]*domain.Recipe, *domain.PageInfo, error) {
+	s.logger.Infof("getting moderation queue on page %+v", page)
+
+	queuePage := &domain.PageRequest{Sort: domain.SortByCreatedAt}
+	if page != nil {
+		queuePage.Size, queuePage.Cursor = page.Size, page.Cursor
+	}
+
+	filter := &domain.RecipeFilter{Status: domain.ModerationSaladStatus}
+	recipes, pageInfo, err := s.recipeService.GetAll(ctx, filter, queuePage)
+	if err != nil {
+		s.logger.Errorf("getting moderation queue error: %s", err.Error())
+		return nil, nil, fmt.Errorf("getting moderation queue: %w", err)
+	}
+	return recipes, pageInfo, nil
+}
+
+func (s *ModerationService) Approve(ctx context.Context, recipeId uuid.UUID) error {
+	s.logger.Infof("approving recipe %s", recipeId.String())
+
+	err := s.moderate(ctx, recipeId, domain.PublishedSaladStatus)
+	if err != nil {
+		return fmt.Errorf("approving recipe: %w", err)
+	}
+	return nil
+}
+
+func (s *ModerationService) Reject(ctx context.Context, recipeId uuid.UUID) error {
+	s.logger.Infof("rejecting recipe %s", recipeId.String())
+
+	err := s.moderate(ctx, recipeId, domain.RejectedSaladStatus)
+	if err != nil {
+		return fmt.Errorf("rejecting recipe: %w", err)
+	}
+	return nil
+}
+
+func (s *ModerationService) moderate(ctx context.Context, recipeId uuid.UUID, status int) error {
+	recipe, err := s.recipeService.GetById(ctx, recipeId)
+	if err != nil {
+		s.logger.Errorf("getting moderated recipe error: %s", err.Error())
+		return err
+	}
+
+	if recipe.Status != domain.ModerationSaladStatus {
+		s.logger.Warnf("recipe %s has status %d", recipeId.String(), recipe.Status)
+		return domain.ErrNotInModeration
+	}
+
+	recipe.Status = status
+	err = s.recipeService.Update(ctx, recipe)
+	if err != nil {
+		s.logger.Errorf("updating moderated recipe error: %s", err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+	"time"
+)
+
+// OutboxPublisher is an IEventPublisher storing events to the outbox instead of
+// delivering them, OutboxRelay forwards them to the bus later
+type OutboxPublisher struct {
+	outbox domain.IEventOutbox
+	now    func() time.Time
+}
+
+func NewOutboxPublisher(outbox domain.IEventOutbox) domain.IEventPublisher {
+	return &OutboxPublisher{
+		outbox: outbox,
+		now:    time.Now,
+	}
+}
+
+func (p *OutboxPublisher) Publish(ctx context.Context, events ...domain.IEvent) error {
+	entries := make([]*domain.OutboxEntry, 0, len(events))
+	for _, event := range events {
+		entry, err := domain.NewOutboxEntry(event, p.now().UTC())
+		if err != nil {
+			return fmt.Errorf("saving events to outbox: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	err := p.outbox.Save(ctx, entries...)
+	if err != nil {
+		return fmt.Errorf("saving events to outbox: %w", err)
+	}
+	return nil
+}
+
+type OutboxRelay struct {
+	outbox domain.IEventOutbox
+	bus    domain.IEventPublisher
+	logger logger.ILogger
+	now    func() time.Time
+}
+
+func NewOutboxRelay(outbox domain.IEventOutbox, bus domain.IEventPublisher, logger logger.ILogger) domain.IOutboxRelay {
+	return &OutboxRelay{
+		outbox: outbox,
+		bus:    bus,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Relay holds back the later entries of an aggregate once one of its entries fails to
+// publish, so the entries of one aggregate are never published out of order, while other
+// aggregates go on. Entries that can't be decoded, like events unknown after a deploy,
+// never will be, they are marked failed and don't hold back their aggregate.
+func (r *OutboxRelay) Relay(ctx context.Context, limit int) (int, error) {
+	entries, err := r.outbox.GetPending(ctx, limit)
+	if err != nil {
+		r.logger.Errorf("getting pending outbox entries error: %s", err.Error())
+		return 0, fmt.Errorf("relaying outbox: %w", err)
+	}
+
+	published := make([]uuid.UUID, 0, len(entries))
+	blocked := make(map[uuid.UUID]bool)
+	var publishErr error
+	for _, entry := range entries {
+		if blocked[entry.AggregateID] {
+			continue
+		}
+
+		event, err := entry.Event()
+		if err != nil {
+			r.logger.Errorf("decoding outbox entry error: %s", err.Error())
+			err = r.outbox.MarkFailed(ctx, entry.ID, err.Error(), r.now().UTC())
+		} else {
+			err = r.bus.Publish(ctx, event)
+			if err == nil {
+				published = append(published, entry.ID)
+			}
+		}
+		if err != nil {
+			blocked[entry.AggregateID] = true
+			if publishErr == nil {
+				publishErr = err
+			}
+		}
+	}
+
+	if len(published) > 0 {
+		err = r.outbox.MarkPublished(ctx, published, r.now().UTC())
+		if err != nil {
+			r.logger.Errorf("marking outbox entries published error: %s", err.Error())
+			return 0, fmt.Errorf("relaying outbox: %w", err)
+		}
+	}
+	if publishErr != nil {
+		r.logger.Errorf("publishing outbox entry error: %s", publishErr.Error())
+		return len(published), fmt.Errorf("relaying outbox: %w", publishErr)
+	}
+
+	r.logger.Infof("relayed %d outbox entries", len(published))
+	return len(published), nil
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+)
+
+type RatingService struct {
+	recipeService  domain.IRecipeService
+	commentService domain.ICommentService
+	logger         logger.ILogger
+}
+
+func NewRatingService(recipeService domain.IRecipeService, commentService domain.ICommentService, logger logger.ILogger) domain.IRatingService {
+	return &RatingService{
+		recipeService:  recipeService,
+		commentService: commentService,
+		logger:         logger,
+	}
+}
+
+func (s *RatingService) Recompute(ctx context.Context, saladId uuid.UUID) (float32, error) {
+	s.logger.Infof("recomputing rating of salad %s", saladId.String())
+
+	recipe, err := s.recipeService.GetBySaladId(ctx, saladId)
+	if err != nil {
+		s.logger.Errorf("getting recipe of rated salad error: %s", err.Error())
+		return 0, fmt.Errorf("recomputing rating: %w", err)
+	}
+
+	_, err = s.recompute(ctx, recipe)
+	if err != nil {
+		return 0, fmt.Errorf("recomputing rating: %w", err)
+	}
+	return recipe.Rating, nil
+}
+
+func (s *RatingService) RecomputeAll(ctx context.Context) (int, error) {
+	s.logger.Infof("recomputing ratings of all recipes")
+
+	recipes, err := allPages(ctx, func(ctx context.Context, page *domain.PageRequest) ([]*domain.Recipe, *domain.PageInfo, error) {
+		return s.recipeService.GetAll(ctx, nil, page)
+	})
+	if err != nil {
+		s.logger.Errorf("getting rated recipes error: %s", err.Error())
+		return 0, fmt.Errorf("recomputing ratings: %w", err)
+	}
+
+	changed := 0
+	for _, recipe := range recipes {
+		ok, err := s.recompute(ctx, recipe)
+		if err != nil {
+			return changed, fmt.Errorf("recomputing ratings: %w", err)
+		}
+		if ok {
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// recompute updates the recipe when its rating differs from the mean rate of comments
+func (s *RatingService) recompute(ctx context.Context, recipe *domain.Recipe) (bool, error) {
+	comments, err := allPages(ctx, func(ctx context.Context, page *domain.PageRequest) ([]*domain.Comment, *domain.PageInfo, error) {
+		return s.commentService.GetAllBySaladID(ctx, recipe.SaladID, page)
+	})
+	if err != nil {
+		s.logger.Errorf("getting comments of salad %s error: %s", recipe.SaladID.String(), err.Error())
+		return false, err
+	}
+
+	rating := float32(0)
+	if len(comments) > 0 {
+		sum := 0
+		for _, comment := range comments {
+			sum += comment.Rating
+		}
+		rating = float32(sum) / float32(len(comments))
+	}
+	if rating == recipe.Rating {
+		return false, nil
+	}
+
+	recipe.Rating = rating
+	err = s.recipeService.Update(ctx, recipe)
+	if err != nil {
+		s.logger.Errorf("updating rating of recipe %s error: %s", recipe.ID.String(), err.Error())
+		return false, err
+	}
+	return true, nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"github.com/Mx1q/ppo_services/config"
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/logger"
 	"github.com/google/uuid"
@@ -10,17 +11,21 @@ import (
 
 type RecipeService struct {
 	recipeRepo domain.IRecipeRepository
+	rating     config.RatingConfig
+	pages      config.PageConfig
 	logger     logger.ILogger
 }
 
-func NewRecipeService(recipeRepo domain.IRecipeRepository, logger logger.ILogger) domain.IRecipeService {
+func NewRecipeService(recipeRepo domain.IRecipeRepository, logger logger.ILogger, rating config.RatingConfig, pages config.PageConfig) domain.IRecipeService {
 	return &RecipeService{
 		recipeRepo: recipeRepo,
+		rating:     rating,
+		pages:      pages,
 		logger:     logger,
 	}
 }
 
-func (s *RecipeService) verify(recipe *domain.Recipe) error {
+func verifyRecipe(recipe *domain.Recipe) error {
 	if recipe.NumberOfServings <= 0 {
 		return fmt.Errorf("negative or zero number of servings")
 	}
@@ -36,7 +41,7 @@ func (s *RecipeService) Create(ctx context.Context, recipe *domain.Recipe) (uuid
 	s.logger.Infof("create recipe ща salad: %s", recipe.SaladID.String())
 
 	id := uuid.Nil
-	err := s.verify(recipe)
+	err := verifyRecipe(recipe)
 	if err != nil {
 		s.logger.Warnf("failed to verify recipe: %s", err.Error())
 		return id, fmt.Errorf("creating recipe: %w", err)
@@ -54,7 +59,7 @@ func (s *RecipeService) Create(ctx context.Context, recipe *domain.Recipe) (uuid
 func (s *RecipeService) Update(ctx context.Context, recipe *domain.Recipe) error {
 	s.logger.Infof("updating recipe with id: %s", recipe.ID.String())
 
-	err := s.verify(recipe)
+	err := verifyRecipe(recipe)
 	if err != nil {
 		s.logger.Warnf("failed to verify recipe: %s", err.Error())
 		return fmt.Errorf("updating recipe: %w", err)
@@ -90,15 +95,27 @@ func (s *RecipeService) GetBySaladId(ctx context.Context, saladId uuid.UUID) (*d
 	return recipe, nil
 }
 
-func (s *RecipeService) GetAll(ctx context.Context, filter *domain.RecipeFilter, page int) ([]*domain.Recipe, error) {
+func (s *RecipeService) GetAll(ctx context.Context, filter *domain.RecipeFilter, page *domain.PageRequest) ([]*domain.Recipe, *domain.PageInfo, error) {
 	s.logger.Infof("getting all recipes with filter: %+v", filter)
 
-	recipes, err := s.recipeRepo.GetAll(ctx, filter, page)
+	err := filter.Validate(s.rating.MaxRate)
+	if err != nil {
+		s.logger.Warnf("invalid recipe filter: %s", err.Error())
+		return nil, nil, fmt.Errorf("getting all recipes: %w", err)
+	}
+
+	page, err = page.Normalize(s.pages.Sizes(), domain.RecipeSorts...)
+	if err != nil {
+		s.logger.Warnf("invalid page request: %s", err.Error())
+		return nil, nil, fmt.Errorf("getting all recipes: %w", err)
+	}
+
+	recipes, pageInfo, err := s.recipeRepo.GetAll(ctx, filter, page)
 	if err != nil {
 		s.logger.Errorf("getting all recipes error: %s", err.Error())
-		return nil, fmt.Errorf("getting all recipes: %w", err)
+		return nil, nil, fmt.Errorf("getting all recipes: %w", err)
 	}
-	return recipes, nil
+	return recipes, pageInfo, nil
 }
 
 func (s *RecipeService) DeleteById(ctx context.Context, id uuid.UUID) error {
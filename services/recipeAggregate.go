@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+	"strings"
+)
+
+type RecipeAggregateService struct {
+	txManager            domain.ITransactionManager
+	saladRepo            domain.ISaladRepository
+	recipeRepo           domain.IRecipeRepository
+	recipeStepRepo       domain.IRecipeStepRepository
+	recipeIngredientRepo domain.IRecipeIngredientRepository
+	saladTypeRepo        domain.ISaladTypeRepository
+	validators           []domain.IValidatorService
+	logger               logger.ILogger
+}
+
+func NewRecipeAggregateService(
+	txManager domain.ITransactionManager,
+	saladRepo domain.ISaladRepository,
+	recipeRepo domain.IRecipeRepository,
+	recipeStepRepo domain.IRecipeStepRepository,
+	recipeIngredientRepo domain.IRecipeIngredientRepository,
+	saladTypeRepo domain.ISaladTypeRepository,
+	validators []domain.IValidatorService,
+	logger logger.ILogger) domain.IRecipeAggregateService {
+	return &RecipeAggregateService{
+		txManager:            txManager,
+		saladRepo:            saladRepo,
+		recipeRepo:           recipeRepo,
+		recipeStepRepo:       recipeStepRepo,
+		recipeIngredientRepo: recipeIngredientRepo,
+		saladTypeRepo:        saladTypeRepo,
+		validators:           validators,
+		logger:               logger,
+	}
+}
+
+func verifyWords(ctx context.Context, validators []domain.IValidatorService, str string) error {
+	for _, word := range strings.Fields(str) {
+		for _, validator := range validators {
+			if err := validator.Verify(ctx, word); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *RecipeAggregateService) verify(ctx context.Context, aggregate *domain.RecipeAggregate) error {
+	if aggregate.Salad == nil {
+		return fmt.Errorf("empty salad")
+	}
+	if aggregate.Recipe == nil {
+		return fmt.Errorf("empty recipe")
+	}
+
+	if aggregate.Salad.Name == "" {
+		return fmt.Errorf("empty salad name")
+	}
+	if err := verifyWords(ctx, s.validators, aggregate.Salad.Name); err != nil {
+		return fmt.Errorf("salad name: %w", err)
+	}
+	if err := verifyWords(ctx, s.validators, aggregate.Salad.Description); err != nil {
+		return fmt.Errorf("salad description: %w", err)
+	}
+
+	if err := verifyRecipe(aggregate.Recipe); err != nil {
+		return err
+	}
+
+	stepNums := make(map[int]bool, len(aggregate.Steps))
+	for _, step := range aggregate.Steps {
+		if err := verifyRecipeStep(step); err != nil {
+			return fmt.Errorf("step %d: %w", step.StepNum, err)
+		}
+		if step.StepNum > len(aggregate.Steps) || stepNums[step.StepNum] {
+			return fmt.Errorf("step %d: step nums must be unique and go from 1 to %d",
+				step.StepNum, len(aggregate.Steps))
+		}
+		stepNums[step.StepNum] = true
+
+		if err := verifyWords(ctx, s.validators, step.Name); err != nil {
+			return fmt.Errorf("step %d name: %w", step.StepNum, err)
+		}
+		if err := verifyWords(ctx, s.validators, step.Description); err != nil {
+			return fmt.Errorf("step %d description: %w", step.StepNum, err)
+		}
+	}
+
+	ingredients := make(map[uuid.UUID]bool, len(aggregate.Ingredients))
+	for _, ingredient := range aggregate.Ingredients {
+		if ingredients[ingredient.IngredientID] {
+			return fmt.Errorf("duplicate ingredient %s", ingredient.IngredientID.String())
+		}
+		ingredients[ingredient.IngredientID] = true
+
+		err := verifyRecipeIngredient(&domain.RecipeIngredient{
+			IngredientID:  ingredient.IngredientID,
+			MeasurementID: ingredient.MeasurementID,
+			Amount:        ingredient.Amount,
+		})
+		if err != nil {
+			return fmt.Errorf("ingredient %s: %w", ingredient.IngredientID.String(), err)
+		}
+	}
+
+	types := make(map[uuid.UUID]bool, len(aggregate.TypeIDs))
+	for _, typeId := range aggregate.TypeIDs {
+		if types[typeId] {
+			return fmt.Errorf("duplicate salad type %s", typeId.String())
+		}
+		types[typeId] = true
+	}
+
+	return nil
+}
+
+func (s *RecipeAggregateService) Create(ctx context.Context, aggregate *domain.RecipeAggregate) (uuid.UUID, error) {
+	s.logger.Infof("creating recipe aggregate")
+
+	err := s.verify(ctx, aggregate)
+	if err != nil {
+		s.logger.Warnf("failed to verify recipe aggregate: %s", err.Error())
+		return uuid.Nil, fmt.Errorf("creating recipe aggregate: %w", err)
+	}
+
+	var saladId uuid.UUID
+	err = domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		var err error
+		saladId, err = s.saladRepo.Create(ctx, aggregate.Salad)
+		if err != nil {
+			return err
+		}
+		aggregate.Salad.ID = saladId
+		aggregate.Recipe.SaladID = saladId
+
+		recipeId, err := s.recipeRepo.Create(ctx, aggregate.Recipe)
+		if err != nil {
+			return err
+		}
+		aggregate.Recipe.ID = recipeId
+
+		return s.createParts(ctx, aggregate)
+	})
+	if err != nil {
+		s.logger.Errorf("creating recipe aggregate error: %s", err.Error())
+		return uuid.Nil, fmt.Errorf("creating recipe aggregate: %w", err)
+	}
+	return saladId, nil
+}
+
+func (s *RecipeAggregateService) Replace(ctx context.Context, aggregate *domain.RecipeAggregate) error {
+	s.logger.Infof("replacing recipe aggregate")
+
+	err := s.verify(ctx, aggregate)
+	if err == nil && aggregate.Salad.ID == uuid.Nil {
+		err = fmt.Errorf("empty salad id")
+	}
+	if err != nil {
+		s.logger.Warnf("failed to verify recipe aggregate: %s", err.Error())
+		return fmt.Errorf("replacing recipe aggregate: %w", err)
+	}
+
+	err = domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		err := s.saladRepo.Update(ctx, aggregate.Salad)
+		if err != nil {
+			return err
+		}
+
+		recipe, err := s.recipeRepo.GetBySaladId(ctx, aggregate.Salad.ID)
+		if err != nil {
+			return err
+		}
+		aggregate.Recipe.ID = recipe.ID
+		aggregate.Recipe.SaladID = aggregate.Salad.ID
+
+		err = s.recipeRepo.Update(ctx, aggregate.Recipe)
+		if err != nil {
+			return err
+		}
+
+		err = s.recipeStepRepo.DeleteAllByRecipeID(ctx, recipe.ID)
+		if err != nil {
+			return err
+		}
+
+		err = s.recipeIngredientRepo.DeleteAllByRecipeId(ctx, recipe.ID)
+		if err != nil {
+			return err
+		}
+
+		saladTypes, err := s.saladTypeRepo.GetAllBySaladId(ctx, aggregate.Salad.ID)
+		if err != nil {
+			return err
+		}
+		for _, saladType := range saladTypes {
+			err = s.saladTypeRepo.Unlink(ctx, aggregate.Salad.ID, saladType.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.createParts(ctx, aggregate)
+	})
+	if err != nil {
+		s.logger.Errorf("replacing recipe aggregate error: %s", err.Error())
+		return fmt.Errorf("replacing recipe aggregate: %w", err)
+	}
+	return nil
+}
+
+func (s *RecipeAggregateService) createParts(ctx context.Context, aggregate *domain.RecipeAggregate) error {
+	recipeId := aggregate.Recipe.ID
+
+	for _, step := range aggregate.Steps {
+		step.RecipeID = recipeId
+		err := s.recipeStepRepo.Create(ctx, step)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, ingredient := range aggregate.Ingredients {
+		_, err := s.recipeIngredientRepo.Create(ctx, &domain.RecipeIngredient{
+			RecipeID:      recipeId,
+			IngredientID:  ingredient.IngredientID,
+			MeasurementID: ingredient.MeasurementID,
+			Amount:        ingredient.Amount,
+			Optional:      ingredient.Optional,
+			Note:          ingredient.Note,
+			Order:         i + 1,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, typeId := range aggregate.TypeIDs {
+		err := s.saladTypeRepo.Link(ctx, aggregate.Salad.ID, typeId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Mx1q/ppo_services/config"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+	"html/template"
+	"math"
+	"strings"
+)
+
+type RecipeExportService struct {
+	saladDetailsService domain.ISaladDetailsService
+	rating              config.RatingConfig
+	logger              logger.ILogger
+}
+
+func NewRecipeExportService(saladDetailsService domain.ISaladDetailsService, logger logger.ILogger, rating config.RatingConfig) domain.IRecipeExportService {
+	return &RecipeExportService{
+		saladDetailsService: saladDetailsService,
+		rating:              rating,
+		logger:              logger,
+	}
+}
+
+func (s *RecipeExportService) Assemble(ctx context.Context, saladId uuid.UUID) (*domain.RecipeExport, error) {
+	s.logger.Infof("assembling recipe export for salad %s", saladId.String())
+
+	details, err := s.saladDetailsService.GetById(ctx, saladId, nil)
+	if err != nil {
+		s.logger.Errorf("assembling recipe export error: %s", err.Error())
+		return nil, fmt.Errorf("assembling recipe export: %w", err)
+	}
+
+	return &domain.RecipeExport{
+		SaladDetails: details,
+		Calories:     domain.CountCalories(details.Ingredients),
+	}, nil
+}
+
+func (s *RecipeExportService) Export(ctx context.Context, saladId uuid.UUID, format string) ([]byte, error) {
+	s.logger.Infof("exporting salad %s to %s", saladId.String(), format)
+
+	render, err := s.renderer(format)
+	if err != nil {
+		return nil, fmt.Errorf("exporting recipe: %w", err)
+	}
+
+	export, err := s.Assemble(ctx, saladId)
+	if err != nil {
+		return nil, fmt.Errorf("exporting recipe: %w", err)
+	}
+
+	data, err := render(export)
+	if err != nil {
+		s.logger.Errorf("rendering recipe error: %s", err.Error())
+		return nil, fmt.Errorf("exporting recipe: %w", err)
+	}
+	return data, nil
+}
+
+func (s *RecipeExportService) Render(ctx context.Context, export *domain.RecipeExport, format string) ([]byte, error) {
+	s.logger.Infof("rendering recipe to %s", format)
+
+	render, err := s.renderer(format)
+	if err != nil {
+		return nil, fmt.Errorf("rendering recipe: %w", err)
+	}
+
+	data, err := render(export)
+	if err != nil {
+		s.logger.Errorf("rendering recipe error: %s", err.Error())
+		return nil, fmt.Errorf("rendering recipe: %w", err)
+	}
+	return data, nil
+}
+
+func (s *RecipeExportService) renderer(format string) (func(export *domain.RecipeExport) ([]byte, error), error) {
+	switch format {
+	case domain.ExportFormatJSONLD:
+		return s.renderJSONLD, nil
+	case domain.ExportFormatMarkdown:
+		return s.renderMarkdown, nil
+	case domain.ExportFormatHTML:
+		return renderHTML, nil
+	default:
+		s.logger.Warnf("exporting recipe: unknown format %s", format)
+		return nil, fmt.Errorf("unknown format %s", format)
+	}
+}
+
+func ingredientLine(item *domain.SaladIngredient) string {
+	line := item.Ingredient.Name
+	if item.Measurement != nil {
+		line = fmt.Sprintf("%s — %s %s (%.0f g)",
+			item.Ingredient.Name, domain.FormatAmount(item.Amount), item.Measurement.Name, math.Round(item.Grams()))
+	}
+	if item.Note != "" {
+		line += ", " + item.Note
+	}
+	if item.Optional {
+		line += " (optional)"
+	}
+	return line
+}
+
+func typeNames(types []*domain.SaladType) []string {
+	names := make([]string, 0, len(types))
+	for _, saladType := range types {
+		names = append(names, saladType.Name)
+	}
+	return names
+}
+
+func authorName(export *domain.RecipeExport) string {
+	if export.Author == nil {
+		return ""
+	}
+	if export.Author.Name != "" {
+		return export.Author.Name
+	}
+	return export.Author.Username
+}
+
+type jsonLDPerson struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+type jsonLDStep struct {
+	Type     string `json:"@type"`
+	Position int    `json:"position"`
+	Name     string `json:"name"`
+	Text     string `json:"text"`
+}
+
+type jsonLDNutrition struct {
+	Type     string `json:"@type"`
+	Calories string `json:"calories"`
+}
+
+type jsonLDRating struct {
+	Type        string  `json:"@type"`
+	RatingValue float32 `json:"ratingValue"`
+	BestRating  int     `json:"bestRating"`
+	WorstRating int     `json:"worstRating"`
+}
+
+type jsonLDRecipe struct {
+	Context      string          `json:"@context"`
+	Type         string          `json:"@type"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description,omitempty"`
+	Author       *jsonLDPerson   `json:"author,omitempty"`
+	Yield        string          `json:"recipeYield,omitempty"`
+	TotalTime    string          `json:"totalTime,omitempty"`
+	Category     []string        `json:"recipeCategory,omitempty"`
+	Ingredients  []string        `json:"recipeIngredient"`
+	Instructions []jsonLDStep    `json:"recipeInstructions"`
+	Nutrition    jsonLDNutrition `json:"nutrition"`
+	Rating       *jsonLDRating   `json:"aggregateRating,omitempty"`
+}
+
+func (s *RecipeExportService) renderJSONLD(export *domain.RecipeExport) ([]byte, error) {
+	recipe := jsonLDRecipe{
+		Context:      "https://schema.org",
+		Type:         "Recipe",
+		Name:         export.Salad.Name,
+		Description:  export.Salad.Description,
+		Yield:        fmt.Sprintf("%d servings", export.Recipe.NumberOfServings),
+		TotalTime:    fmt.Sprintf("PT%dM", export.Recipe.TimeToCook),
+		Category:     typeNames(export.Types),
+		Ingredients:  make([]string, 0, len(export.Ingredients)),
+		Instructions: make([]jsonLDStep, 0, len(export.Steps)),
+		Nutrition: jsonLDNutrition{
+			Type:     "NutritionInformation",
+			Calories: fmt.Sprintf("%d kcal", export.Calories),
+		},
+	}
+	if name := authorName(export); name != "" {
+		recipe.Author = &jsonLDPerson{Type: "Person", Name: name}
+	}
+	if export.Recipe.Rating > 0 {
+		recipe.Rating = &jsonLDRating{
+			Type:        "AggregateRating",
+			RatingValue: export.Recipe.Rating,
+			BestRating:  s.rating.MaxRate,
+			WorstRating: s.rating.MinRate,
+		}
+	}
+	for _, item := range export.Ingredients {
+		recipe.Ingredients = append(recipe.Ingredients, ingredientLine(item))
+	}
+	for _, step := range export.Steps {
+		recipe.Instructions = append(recipe.Instructions, jsonLDStep{
+			Type:     "HowToStep",
+			Position: step.StepNum,
+			Name:     step.Name,
+			Text:     step.Description,
+		})
+	}
+
+	data, err := json.MarshalIndent(recipe, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("rendering json-ld: %w", err)
+	}
+	return data, nil
+}
+
+func (s *RecipeExportService) renderMarkdown(export *domain.RecipeExport) ([]byte, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", export.Salad.Name)
+	if export.Salad.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", export.Salad.Description)
+	}
+	if name := authorName(export); name != "" {
+		fmt.Fprintf(&b, "- **Author:** %s\n", name)
+	}
+	if len(export.Types) > 0 {
+		fmt.Fprintf(&b, "- **Types:** %s\n", strings.Join(typeNames(export.Types), ", "))
+	}
+	fmt.Fprintf(&b, "- **Servings:** %d\n", export.Recipe.NumberOfServings)
+	fmt.Fprintf(&b, "- **Time to cook:** %d min\n", export.Recipe.TimeToCook)
+	fmt.Fprintf(&b, "- **Calories:** %d kcal\n", export.Calories)
+	if export.Recipe.Rating > 0 {
+		fmt.Fprintf(&b, "- **Rating:** %.1f/%d\n", export.Recipe.Rating, s.rating.MaxRate)
+	}
+
+	b.WriteString("\n## Ingredients\n\n")
+	for _, item := range export.Ingredients {
+		fmt.Fprintf(&b, "- %s\n", ingredientLine(item))
+	}
+
+	b.WriteString("\n## Steps\n\n")
+	for _, step := range export.Steps {
+		fmt.Fprintf(&b, "%d. **%s** — %s\n", step.StepNum, step.Name, step.Description)
+	}
+
+	return []byte(b.String()), nil
+}
+
+var recipeCardTemplate = template.Must(template.New("recipe").
+	Funcs(template.FuncMap{"ingredient": ingredientLine}).
+	Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Salad.Name}}</title>
+<style>
+body { font-family: Georgia, serif; max-width: 720px; margin: 2em auto; color: #222; }
+h1 { margin-bottom: 0.2em; }
+.meta { color: #555; font-size: 0.95em; }
+.types span { display: inline-block; border: 1px solid #999; border-radius: 4px; padding: 0 0.4em; margin-right: 0.3em; }
+table.nutrition { border-collapse: collapse; margin: 1em 0; }
+table.nutrition td { border: 1px solid #999; padding: 0.2em 0.6em; }
+@media print { body { margin: 0; max-width: none; } }
+</style>
+</head>
+<body>
+<h1>{{.Salad.Name}}</h1>
+{{with .Author}}<p class="meta">{{if .Name}}{{.Name}}{{else}}{{.Username}}{{end}}</p>{{end}}
+{{with .Salad.Description}}<p>{{.}}</p>{{end}}
+{{with .Types}}<p class="types">{{range .}}<span>{{.Name}}</span>{{end}}</p>{{end}}
+<p class="meta">Servings: {{.Recipe.NumberOfServings}} · Time to cook: {{.Recipe.TimeToCook}} min{{if gt .Recipe.Rating 0.0}} · Rating: {{printf "%.1f" .Recipe.Rating}}{{end}}</p>
+<table class="nutrition">
+<tr><td>Calories</td><td>{{.Calories}} kcal</td></tr>
+</table>
+<h2>Ingredients</h2>
+<ul>
+{{range .Ingredients}}<li>{{ingredient .}}</li>
+{{end}}</ul>
+<h2>Steps</h2>
+<ol>
+{{range .Steps}}<li value="{{.StepNum}}"><strong>{{.Name}}</strong> — {{.Description}}</li>
+{{end}}</ol>
+</body>
+</html>
+`))
+
+func renderHTML(export *domain.RecipeExport) ([]byte, error) {
+	var buf bytes.Buffer
+	err := recipeCardTemplate.Execute(&buf, export)
+	if err != nil {
+		return nil, fmt.Errorf("rendering html: %w", err)
+	}
+	return buf.Bytes(), nil
+}
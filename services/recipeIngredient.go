@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+	"sort"
+)
+
+type RecipeIngredientService struct {
+	recipeIngredientRepo domain.IRecipeIngredientRepository
+	txManager            domain.ITransactionManager
+	logger               logger.ILogger
+}
+
+func NewRecipeIngredientService(
+	recipeIngredientRepo domain.IRecipeIngredientRepository,
+	txManager domain.ITransactionManager,
+	logger logger.ILogger) domain.IRecipeIngredientService {
+	return &RecipeIngredientService{
+		recipeIngredientRepo: recipeIngredientRepo,
+		txManager:            txManager,
+		logger:               logger,
+	}
+}
+
+func verifyRecipeIngredient(recipeIngredient *domain.RecipeIngredient) error {
+	if recipeIngredient.IngredientID == uuid.Nil {
+		return fmt.Errorf("empty ingredient")
+	}
+
+	if recipeIngredient.MeasurementID == uuid.Nil {
+		return fmt.Errorf("empty measurement")
+	}
+
+	if recipeIngredient.Amount <= 0 {
+		return fmt.Errorf("negative or zero amount")
+	}
+
+	if recipeIngredient.Order < 0 {
+		return fmt.Errorf("negative order")
+	}
+
+	return nil
+}
+
+// getSorted returns ingredients of the recipe in display order
+func (s *RecipeIngredientService) getSorted(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeIngredient, error) {
+	recipeIngredients, err := s.recipeIngredientRepo.GetAllByRecipeId(ctx, recipeId)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(recipeIngredients, func(i, j int) bool {
+		return recipeIngredients[i].Order < recipeIngredients[j].Order
+	})
+	return recipeIngredients, nil
+}
+
+// reorder makes orders follow the slice (1..N), only changed ingredients are updated
+func (s *RecipeIngredientService) reorder(ctx context.Context, recipeIngredients []*domain.RecipeIngredient) error {
+	for i, recipeIngredient := range recipeIngredients {
+		if recipeIngredient.Order == i+1 {
+			continue
+		}
+		recipeIngredient.Order = i + 1
+		err := s.recipeIngredientRepo.Update(ctx, recipeIngredient)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertRecipeIngredient places the ingredient at its order, reorder skips it as it's already there
+func insertRecipeIngredient(recipeIngredients []*domain.RecipeIngredient,
+	recipeIngredient *domain.RecipeIngredient) []*domain.RecipeIngredient {
+	pos := recipeIngredient.Order - 1
+	recipeIngredients = append(recipeIngredients, nil)
+	copy(recipeIngredients[pos+1:], recipeIngredients[pos:])
+	recipeIngredients[pos] = recipeIngredient
+	return recipeIngredients
+}
+
+func (s *RecipeIngredientService) Add(ctx context.Context, recipeIngredient *domain.RecipeIngredient) (uuid.UUID, error) {
+	s.logger.Infof("adding ingredient %s to recipe %s",
+		recipeIngredient.IngredientID.String(), recipeIngredient.RecipeID.String())
+
+	err := verifyRecipeIngredient(recipeIngredient)
+	if err != nil {
+		s.logger.Warnf("failed to verify recipe ingredient: %s", err.Error())
+		return uuid.Nil, fmt.Errorf("adding recipe ingredient: %w", err)
+	}
+
+	var id uuid.UUID
+	err = domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		recipeIngredients, err := s.getSorted(ctx, recipeIngredient.RecipeID)
+		if err != nil {
+			return err
+		}
+		for _, linked := range recipeIngredients {
+			if linked.IngredientID == recipeIngredient.IngredientID {
+				return fmt.Errorf("ingredient already added")
+			}
+		}
+
+		if recipeIngredient.Order == 0 {
+			recipeIngredient.Order = len(recipeIngredients) + 1
+		}
+		if recipeIngredient.Order > len(recipeIngredients)+1 {
+			return fmt.Errorf("invalid order")
+		}
+
+		err = s.reorder(ctx, insertRecipeIngredient(recipeIngredients, recipeIngredient))
+		if err != nil {
+			return err
+		}
+
+		id, err = s.recipeIngredientRepo.Create(ctx, recipeIngredient)
+		return err
+	})
+	if err != nil {
+		s.logger.Errorf("adding recipe ingredient error: %s", err.Error())
+		return uuid.Nil, fmt.Errorf("adding recipe ingredient: %w", err)
+	}
+	recipeIngredient.ID = id
+	return id, nil
+}
+
+func (s *RecipeIngredientService) GetById(ctx context.Context, id uuid.UUID) (*domain.RecipeIngredient, error) {
+	s.logger.Infof("getting recipe ingredient by id: %s", id.String())
+
+	recipeIngredient, err := s.recipeIngredientRepo.GetById(ctx, id)
+	if err != nil {
+		s.logger.Errorf("getting recipe ingredient by id error: %s", err.Error())
+		return nil, fmt.Errorf("getting recipe ingredient by id: %w", err)
+	}
+	return recipeIngredient, nil
+}
+
+func (s *RecipeIngredientService) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeIngredient, error) {
+	s.logger.Infof("getting all ingredients of recipe: %s", recipeId.String())
+
+	recipeIngredients, err := s.getSorted(ctx, recipeId)
+	if err != nil {
+		s.logger.Errorf("getting all ingredients of recipe error: %s", err.Error())
+		return nil, fmt.Errorf("getting all ingredients of recipe: %w", err)
+	}
+	return recipeIngredients, nil
+}
+
+// Update changes the quantity, note and optional flag, orders are changed by Reorder
+func (s *RecipeIngredientService) Update(ctx context.Context, recipeIngredient *domain.RecipeIngredient) error {
+	s.logger.Infof("updating recipe ingredient: %s", recipeIngredient.ID.String())
+
+	err := verifyRecipeIngredient(recipeIngredient)
+	if err != nil {
+		s.logger.Warnf("failed to verify recipe ingredient: %s", err.Error())
+		return fmt.Errorf("updating recipe ingredient: %w", err)
+	}
+
+	err = domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		beforeUpdate, err := s.recipeIngredientRepo.GetById(ctx, recipeIngredient.ID)
+		if err != nil {
+			return err
+		}
+		if beforeUpdate.Version != recipeIngredient.Version {
+			return &domain.VersionConflictError{
+				Entity:  "recipe ingredient",
+				ID:      recipeIngredient.ID,
+				Version: recipeIngredient.Version,
+			}
+		}
+		if recipeIngredient.RecipeID == uuid.Nil {
+			recipeIngredient.RecipeID = beforeUpdate.RecipeID
+		}
+		if recipeIngredient.RecipeID != beforeUpdate.RecipeID ||
+			recipeIngredient.IngredientID != beforeUpdate.IngredientID {
+			return fmt.Errorf("changing recipe or ingredient of link")
+		}
+		recipeIngredient.Order = beforeUpdate.Order
+
+		return s.recipeIngredientRepo.Update(ctx, recipeIngredient)
+	})
+	if err != nil {
+		s.logger.Errorf("updating recipe ingredient error: %s", err.Error())
+		return fmt.Errorf("updating recipe ingredient: %w", err)
+	}
+	return nil
+}
+
+func (s *RecipeIngredientService) Remove(ctx context.Context, id uuid.UUID) error {
+	s.logger.Infof("removing recipe ingredient: %s", id.String())
+
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		removed, err := s.recipeIngredientRepo.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.recipeIngredientRepo.DeleteById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		recipeIngredients, err := s.getSorted(ctx, removed.RecipeID)
+		if err != nil {
+			return err
+		}
+		return s.reorder(ctx, recipeIngredients)
+	})
+	if err != nil {
+		s.logger.Errorf("removing recipe ingredient error: %s", err.Error())
+		return fmt.Errorf("removing recipe ingredient: %w", err)
+	}
+	return nil
+}
+
+func (s *RecipeIngredientService) Reorder(ctx context.Context, recipeId uuid.UUID, recipeIngredientIds []uuid.UUID) error {
+	s.logger.Infof("reordering ingredients of recipe: %s", recipeId.String())
+
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		recipeIngredients, err := s.recipeIngredientRepo.GetAllByRecipeId(ctx, recipeId)
+		if err != nil {
+			return err
+		}
+		if len(recipeIngredients) != len(recipeIngredientIds) {
+			return domain.ErrIngredientsChanged
+		}
+
+		byId := make(map[uuid.UUID]*domain.RecipeIngredient, len(recipeIngredients))
+		for _, recipeIngredient := range recipeIngredients {
+			byId[recipeIngredient.ID] = recipeIngredient
+		}
+
+		ordered := make([]*domain.RecipeIngredient, 0, len(recipeIngredientIds))
+		for _, id := range recipeIngredientIds {
+			recipeIngredient, ok := byId[id]
+			if !ok {
+				return domain.ErrIngredientsChanged
+			}
+			delete(byId, id)
+			ordered = append(ordered, recipeIngredient)
+		}
+
+		return s.reorder(ctx, ordered)
+	})
+	if err != nil {
+		s.logger.Errorf("reordering ingredients of recipe error: %s", err.Error())
+		return fmt.Errorf("reordering ingredients of recipe: %w", err)
+	}
+	return nil
+}
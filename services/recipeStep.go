@@ -6,21 +6,27 @@ import (
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/logger"
 	"github.com/google/uuid"
+	"sort"
 )
 
 type RecipeStepService struct {
 	recipeStepRepo domain.IRecipeStepRepository
+	txManager      domain.ITransactionManager
 	logger         logger.ILogger
 }
 
-func NewRecipeStepService(recipeStepRepo domain.IRecipeStepRepository, logger logger.ILogger) domain.IRecipeStepService {
+func NewRecipeStepService(
+	recipeStepRepo domain.IRecipeStepRepository,
+	txManager domain.ITransactionManager,
+	logger logger.ILogger) domain.IRecipeStepService {
 	return &RecipeStepService{
 		recipeStepRepo: recipeStepRepo,
+		txManager:      txManager,
 		logger:         logger,
 	}
 }
 
-func (s *RecipeStepService) verify(step *domain.RecipeStep) error {
+func verifyRecipeStep(step *domain.RecipeStep) error {
 	if step.Name == "" {
 		return fmt.Errorf("empty name")
 	}
@@ -36,16 +42,95 @@ func (s *RecipeStepService) verify(step *domain.RecipeStep) error {
 	return nil
 }
 
+// getSorted returns steps of the recipe ordered by StepNum
+func (s *RecipeStepService) getSorted(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeStep, error) {
+	steps, err := s.recipeStepRepo.GetAllByRecipeID(ctx, recipeId)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].StepNum < steps[j].StepNum
+	})
+	return steps, nil
+}
+
+// renumber makes step nums follow the order of steps (1..N), only changed steps are updated,
+// so a new step placed at its position is left for the caller to create. Step nums are
+// unique in a recipe, a step takes its num once the step holding it has moved on, and
+// steps moving in a cycle, like swapped ones, are parked at the negated num first.
+func (s *RecipeStepService) renumber(ctx context.Context, steps []*domain.RecipeStep) error {
+	held := make(map[int]bool, len(steps))
+	var changed []int
+	for i, step := range steps {
+		if step.StepNum != i+1 {
+			held[step.StepNum] = true
+			changed = append(changed, i)
+		}
+	}
+
+	for len(changed) != 0 {
+		next := -1
+		for j, i := range changed {
+			if !held[i+1] {
+				next = j
+				break
+			}
+		}
+
+		if next == -1 {
+			step := steps[changed[0]]
+			delete(held, step.StepNum)
+			step.StepNum = -step.StepNum
+			if err := s.recipeStepRepo.Update(ctx, step); err != nil {
+				return err
+			}
+			continue
+		}
+
+		i := changed[next]
+		step := steps[i]
+		delete(held, step.StepNum)
+		step.StepNum = i + 1
+		if err := s.recipeStepRepo.Update(ctx, step); err != nil {
+			return err
+		}
+		changed = append(changed[:next], changed[next+1:]...)
+	}
+	return nil
+}
+
+func insertStep(steps []*domain.RecipeStep, step *domain.RecipeStep) []*domain.RecipeStep {
+	pos := step.StepNum - 1
+	steps = append(steps, nil)
+	copy(steps[pos+1:], steps[pos:])
+	steps[pos] = step
+	return steps
+}
+
 func (s *RecipeStepService) Create(ctx context.Context, recipeStep *domain.RecipeStep) error {
 	s.logger.Infof("create recipeStep %+v", recipeStep)
 
-	err := s.verify(recipeStep)
+	err := verifyRecipeStep(recipeStep)
 	if err != nil {
 		s.logger.Warnf("failed to verify recipeStep %+v", recipeStep)
 		return fmt.Errorf("creating recipe step: %w", err)
 	}
 
-	err = s.recipeStepRepo.Create(ctx, recipeStep)
+	err = domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		steps, err := s.getSorted(ctx, recipeStep.RecipeID)
+		if err != nil {
+			return err
+		}
+		if recipeStep.StepNum > len(steps)+1 {
+			return fmt.Errorf("invalid step num")
+		}
+
+		err = s.renumber(ctx, insertStep(steps, recipeStep))
+		if err != nil {
+			return err
+		}
+		return s.recipeStepRepo.Create(ctx, recipeStep)
+	})
 	if err != nil {
 		s.logger.Errorf("creating recipe step error: %s", err.Error())
 		return fmt.Errorf("creating recipe step: %w", err)
@@ -53,60 +138,68 @@ func (s *RecipeStepService) Create(ctx context.Context, recipeStep *domain.Recip
 	return nil
 }
 
-//func (s *RecipeStepService) Update(ctx context.Context, recipeStep *domain.RecipeStep) error {
-//	err := s.verify(recipeStep)
-//	if err != nil {
-//		return fmt.Errorf("updating recipe step: %w", err)
-//	}
-//
-//	beforeUpdateStep, err := s.recipeStepRepo.GetById(ctx, recipeStep.ID)
-//	if err != nil {
-//		return fmt.Errorf("updating recipe step: %w", err)
-//	}
-//
-//	steps, err := s.recipeStepRepo.GetAllByRecipeID(ctx, beforeUpdateStep.RecipeID)
-//	if err != nil {
-//		return fmt.Errorf("updating recipe step: %w", err)
-//	}
-//
-//	if recipeStep.StepNum > len(steps) {
-//		return fmt.Errorf("updating recipe step: invalid step num")
-//	} else {
-//		if beforeUpdateStep.StepNum != recipeStep.StepNum {
-//			for _, step := range steps[beforeUpdateStep.StepNum:] {
-//				step.StepNum--
-//				err := s.recipeStepRepo.Update(ctx, step)
-//				if err != nil {
-//					return fmt.Errorf("updating recipe step: %w", err)
-//				}
-//			}
-//		}
-//	}
-//
-//	err = s.recipeStepRepo.Update(ctx, recipeStep)
-//	if err != nil {
-//		return fmt.Errorf("updating recipe step: %w", err)
-//	}
-//
-//	return nil
-//}
-
-// FIXME: should use update below this comment
 func (s *RecipeStepService) Update(ctx context.Context, recipeStep *domain.RecipeStep) error {
 	s.logger.Infof("updating recipeStep %+v", recipeStep)
 
-	err := s.verify(recipeStep)
+	err := verifyRecipeStep(recipeStep)
 	if err != nil {
 		s.logger.Warnf("failed to verify recipeStep %+v", recipeStep)
 		return fmt.Errorf("updating recipe step: %w", err)
 	}
 
-	err = s.recipeStepRepo.Update(ctx, recipeStep)
+	err = domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		beforeUpdate, err := s.recipeStepRepo.GetById(ctx, recipeStep.ID)
+		if err != nil {
+			return err
+		}
+		if beforeUpdate.Version != recipeStep.Version {
+			return &domain.VersionConflictError{Entity: "recipe step", ID: recipeStep.ID, Version: recipeStep.Version}
+		}
+		if recipeStep.RecipeID == uuid.Nil {
+			recipeStep.RecipeID = beforeUpdate.RecipeID
+		} else if recipeStep.RecipeID != beforeUpdate.RecipeID {
+			return fmt.Errorf("moving step to another recipe")
+		}
+
+		if beforeUpdate.StepNum != recipeStep.StepNum {
+			steps, err := s.getSorted(ctx, recipeStep.RecipeID)
+			if err != nil {
+				return err
+			}
+			if recipeStep.StepNum > len(steps) {
+				return fmt.Errorf("invalid step num")
+			}
+
+			others := make([]*domain.RecipeStep, 0, len(steps))
+			for _, step := range steps {
+				if step.ID != recipeStep.ID {
+					others = append(others, step)
+				}
+			}
+			if len(others) == len(steps) {
+				return domain.ErrStepsChanged
+			}
+
+			// the step leaves its num to the steps shifting in its place
+			parked := *beforeUpdate
+			parked.StepNum = -parked.StepNum
+			if err = s.recipeStepRepo.Update(ctx, &parked); err != nil {
+				return err
+			}
+			recipeStep.Version = parked.Version
+
+			err = s.renumber(ctx, insertStep(others, recipeStep))
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.recipeStepRepo.Update(ctx, recipeStep)
+	})
 	if err != nil {
 		s.logger.Errorf("updating recipe step error: %s", err.Error())
 		return fmt.Errorf("updating recipe step: %w", err)
 	}
-
 	return nil
 }
 
@@ -132,46 +225,30 @@ func (s *RecipeStepService) GetAllByRecipeID(ctx context.Context, recipeId uuid.
 	return recipeSteps, nil
 }
 
-//func (s *RecipeStepService) DeleteById(ctx context.Context, id uuid.UUID) error {
-//	deletedStep, err := s.recipeStepRepo.GetById(ctx, id)
-//	if err != nil {
-//		return fmt.Errorf("deleting step by id: %w", err)
-//	}
-//
-//	err = s.recipeStepRepo.DeleteById(ctx, id)
-//	if err != nil {
-//		return fmt.Errorf("deleting step by id: %w", err)
-//	}
-//
-//	steps, err := s.recipeStepRepo.GetAllByRecipeID(ctx, deletedStep.RecipeID)
-//	if err != nil {
-//		return fmt.Errorf("deleting step by id: %w", err)
-//	}
-//
-//	deletedStepNum := deletedStep.StepNum
-//	if deletedStepNum != len(steps)+1 {
-//		for _, step := range steps[deletedStepNum-1:] {
-//			step.StepNum--
-//			err := s.recipeStepRepo.Update(ctx, step)
-//			if err != nil {
-//				return fmt.Errorf("deleting step by id: %w", err)
-//			}
-//		}
-//	}
-//
-//	return nil
-//}
-
-// FIXME: should user delete by id below this comment
 func (s *RecipeStepService) DeleteById(ctx context.Context, id uuid.UUID) error {
 	s.logger.Infof("deleting recipeStep by id: %s", id.String())
 
-	err := s.recipeStepRepo.DeleteById(ctx, id)
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		deletedStep, err := s.recipeStepRepo.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.recipeStepRepo.DeleteById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		steps, err := s.getSorted(ctx, deletedStep.RecipeID)
+		if err != nil {
+			return err
+		}
+		return s.renumber(ctx, steps)
+	})
 	if err != nil {
 		s.logger.Errorf("deleting recipeStep by id error: %s", err.Error())
 		return fmt.Errorf("deleting step by id: %w", err)
 	}
-
 	return nil
 }
 
@@ -185,3 +262,39 @@ func (s *RecipeStepService) DeleteAllByRecipeID(ctx context.Context, recipeId uu
 	}
 	return nil
 }
+
+func (s *RecipeStepService) Reorder(ctx context.Context, recipeId uuid.UUID, stepIds []uuid.UUID) error {
+	s.logger.Infof("reordering steps of recipe: %s", recipeId.String())
+
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		steps, err := s.recipeStepRepo.GetAllByRecipeID(ctx, recipeId)
+		if err != nil {
+			return err
+		}
+		if len(steps) != len(stepIds) {
+			return domain.ErrStepsChanged
+		}
+
+		byId := make(map[uuid.UUID]*domain.RecipeStep, len(steps))
+		for _, step := range steps {
+			byId[step.ID] = step
+		}
+
+		ordered := make([]*domain.RecipeStep, 0, len(stepIds))
+		for _, id := range stepIds {
+			step, ok := byId[id]
+			if !ok {
+				return domain.ErrStepsChanged
+			}
+			delete(byId, id)
+			ordered = append(ordered, step)
+		}
+
+		return s.renumber(ctx, ordered)
+	})
+	if err != nil {
+		s.logger.Errorf("reordering steps of recipe error: %s", err.Error())
+		return fmt.Errorf("reordering steps of recipe: %w", err)
+	}
+	return nil
+}
@@ -85,3 +85,7 @@ func (i *RecipeStepInteractor) DeleteById(ctx context.Context, id uuid.UUID) err
 func (i *RecipeStepInteractor) DeleteAllByRecipeID(ctx context.Context, recipeId uuid.UUID) error {
 	return i.recipeStepService.DeleteAllByRecipeID(ctx, recipeId)
 }
+
+func (i *RecipeStepInteractor) Reorder(ctx context.Context, recipeId uuid.UUID, stepIds []uuid.UUID) error {
+	return i.recipeStepService.Reorder(ctx, recipeId, stepIds)
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"github.com/Mx1q/ppo_services/config"
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/logger"
 	"github.com/google/uuid"
@@ -10,12 +11,16 @@ import (
 
 type SaladService struct {
 	saladRepo domain.ISaladRepository
+	rating    config.RatingConfig
+	pages     config.PageConfig
 	logger    logger.ILogger
 }
 
-func NewSaladService(saladRepo domain.ISaladRepository, logger logger.ILogger) domain.ISaladService {
+func NewSaladService(saladRepo domain.ISaladRepository, logger logger.ILogger, rating config.RatingConfig, pages config.PageConfig) domain.ISaladService {
 	return &SaladService{
 		saladRepo: saladRepo,
+		rating:    rating,
+		pages:     pages,
 		logger:    logger,
 	}
 }
@@ -64,15 +69,27 @@ func (s SaladService) GetById(ctx context.Context, id uuid.UUID) (*domain.Salad,
 	return salad, nil
 }
 
-func (s SaladService) GetAll(ctx context.Context, filter *domain.RecipeFilter, page int) ([]*domain.Salad, int, error) {
+func (s SaladService) GetAll(ctx context.Context, filter *domain.RecipeFilter, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
 	s.logger.Infof("getting all salads by filter: %+v", filter)
 
-	salads, numPages, err := s.saladRepo.GetAll(ctx, filter, page)
+	err := filter.Validate(s.rating.MaxRate)
+	if err != nil {
+		s.logger.Warnf("invalid recipe filter: %s", err.Error())
+		return nil, nil, fmt.Errorf("getting all salads: %w", err)
+	}
+
+	page, err = page.Normalize(s.pages.Sizes(), domain.RecipeSorts...)
+	if err != nil {
+		s.logger.Warnf("invalid page request: %s", err.Error())
+		return nil, nil, fmt.Errorf("getting all salads: %w", err)
+	}
+
+	salads, pageInfo, err := s.saladRepo.GetAll(ctx, filter, page)
 	if err != nil {
 		s.logger.Errorf("getting salads by filter error: %s", err.Error())
-		return nil, 0, fmt.Errorf("getting all salads: %w", err)
+		return nil, nil, fmt.Errorf("getting all salads: %w", err)
 	}
-	return salads, numPages, nil
+	return salads, pageInfo, nil
 }
 
 func (s SaladService) DeleteById(ctx context.Context, id uuid.UUID) error {
@@ -86,24 +103,36 @@ func (s SaladService) DeleteById(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (s SaladService) GetAllByUserId(ctx context.Context, id uuid.UUID) ([]*domain.Salad, error) {
+func (s SaladService) GetAllByUserId(ctx context.Context, id uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
 	s.logger.Infof("getting all salads by user id: %s", id.String())
 
-	authorSalads, err := s.saladRepo.GetAllByUserId(ctx, id)
+	page, err := page.Normalize(s.pages.Sizes(), domain.SortByName)
+	if err != nil {
+		s.logger.Warnf("invalid page request: %s", err.Error())
+		return nil, nil, fmt.Errorf("getting all salads by author id: %w", err)
+	}
+
+	authorSalads, pageInfo, err := s.saladRepo.GetAllByUserId(ctx, id, page)
 	if err != nil {
 		s.logger.Errorf("getting salads by user id error: %s", err.Error())
-		return nil, fmt.Errorf("getting all salads by author id: %w", err)
+		return nil, nil, fmt.Errorf("getting all salads by author id: %w", err)
 	}
-	return authorSalads, nil
+	return authorSalads, pageInfo, nil
 }
 
-func (s SaladService) GetAllRatedByUser(ctx context.Context, userId uuid.UUID, page int) ([]*domain.Salad, int, error) {
+func (s SaladService) GetAllRatedByUser(ctx context.Context, userId uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
 	s.logger.Infof("getting all salads rated by user with id: %s", userId.String())
 
-	salads, numPages, err := s.saladRepo.GetAllRatedByUser(ctx, userId, page)
+	page, err := page.Normalize(s.pages.Sizes(), domain.SortByName, domain.SortByRating)
+	if err != nil {
+		s.logger.Warnf("invalid page request: %s", err.Error())
+		return nil, nil, fmt.Errorf("getting all salads rated by user: %w", err)
+	}
+
+	salads, pageInfo, err := s.saladRepo.GetAllRatedByUser(ctx, userId, page)
 	if err != nil {
 		s.logger.Errorf("getting salads rated by user error: %s", err.Error())
-		return nil, 0, fmt.Errorf("getting all salads rated by user: %w", err)
+		return nil, nil, fmt.Errorf("getting all salads rated by user: %w", err)
 	}
-	return salads, numPages, nil
+	return salads, pageInfo, nil
 }
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+	"sort"
+)
+
+type SaladDetailsService struct {
+	saladService            domain.ISaladService
+	userService             domain.IUserService
+	recipeService           domain.IRecipeService
+	recipeStepService       domain.IRecipeStepService
+	ingredientService       domain.IIngredientService
+	recipeIngredientService domain.IRecipeIngredientService
+	measurementService      domain.IMeasurementService
+	saladTypeService        domain.ISaladTypeService
+	commentService          domain.ICommentService
+	logger                  logger.ILogger
+}
+
+func NewSaladDetailsService(
+	saladService domain.ISaladService,
+	userService domain.IUserService,
+	recipeService domain.IRecipeService,
+	recipeStepService domain.IRecipeStepService,
+	ingredientService domain.IIngredientService,
+	recipeIngredientService domain.IRecipeIngredientService,
+	measurementService domain.IMeasurementService,
+	saladTypeService domain.ISaladTypeService,
+	commentService domain.ICommentService,
+	logger logger.ILogger) domain.ISaladDetailsService {
+	return &SaladDetailsService{
+		saladService:            saladService,
+		userService:             userService,
+		recipeService:           recipeService,
+		recipeStepService:       recipeStepService,
+		ingredientService:       ingredientService,
+		recipeIngredientService: recipeIngredientService,
+		measurementService:      measurementService,
+		saladTypeService:        saladTypeService,
+		commentService:          commentService,
+		logger:                  logger,
+	}
+}
+
+// GetById fetches independent parts of the salad concurrently, the first failed
+// query cancels the rest. Comments are skipped when commentsPage is nil.
+func (s *SaladDetailsService) GetById(ctx context.Context, saladId uuid.UUID, commentsPage *domain.PageRequest) (*domain.SaladDetails, error) {
+	s.logger.Infof("getting salad details by id: %s", saladId.String())
+
+	details := new(domain.SaladDetails)
+	g, gctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		salad, err := s.saladService.GetById(gctx, saladId)
+		if err != nil {
+			return err
+		}
+		details.Salad = salad
+
+		details.Author, err = s.userService.GetById(gctx, salad.AuthorID)
+		return err
+	})
+
+	g.Go(func() error {
+		recipe, err := s.recipeService.GetBySaladId(gctx, saladId)
+		if err != nil {
+			return err
+		}
+		details.Recipe = recipe
+
+		return s.getRecipeParts(gctx, recipe.ID, details)
+	})
+
+	g.Go(func() error {
+		var err error
+		details.Types, err = s.saladTypeService.GetAllBySaladId(gctx, saladId)
+		return err
+	})
+
+	if commentsPage != nil {
+		g.Go(func() error {
+			var err error
+			details.Comments, details.CommentsPage, err = s.commentService.GetAllBySaladID(gctx, saladId, commentsPage)
+			return err
+		})
+	}
+
+	err := g.Wait()
+	if err != nil {
+		s.logger.Errorf("getting salad details error: %s", err.Error())
+		return nil, fmt.Errorf("getting salad details: %w", err)
+	}
+	return details, nil
+}
+
+func (s *SaladDetailsService) getRecipeParts(ctx context.Context, recipeId uuid.UUID, details *domain.SaladDetails) error {
+	var (
+		ingredients       []*domain.Ingredient
+		recipeIngredients []*domain.RecipeIngredient
+		measurements      []*domain.RecipeMeasurement
+	)
+	g, gctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		steps, err := s.recipeStepService.GetAllByRecipeID(gctx, recipeId)
+		if err != nil {
+			return err
+		}
+		sort.SliceStable(steps, func(i, j int) bool {
+			return steps[i].StepNum < steps[j].StepNum
+		})
+		details.Steps = steps
+		return nil
+	})
+
+	g.Go(func() error {
+		var err error
+		ingredients, err = s.ingredientService.GetAllByRecipeId(gctx, recipeId)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		recipeIngredients, err = s.recipeIngredientService.GetAllByRecipeId(gctx, recipeId)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		measurements, err = s.measurementService.GetAllByRecipeId(gctx, recipeId)
+		return err
+	})
+
+	err := g.Wait()
+	if err != nil {
+		return err
+	}
+
+	byId := make(map[uuid.UUID]*domain.Ingredient, len(ingredients))
+	for _, ingredient := range ingredients {
+		byId[ingredient.ID] = ingredient
+	}
+	measurementsById := make(map[uuid.UUID]*domain.Measurement, len(measurements))
+	for _, recipeMeasurement := range measurements {
+		measurementsById[recipeMeasurement.Measurement.ID] = recipeMeasurement.Measurement
+	}
+
+	// recipe ingredients go in display order, ingredients linked without quantity follow them
+	details.Ingredients = make([]*domain.SaladIngredient, 0, len(ingredients))
+	for _, recipeIngredient := range recipeIngredients {
+		ingredient, ok := byId[recipeIngredient.IngredientID]
+		if !ok {
+			continue
+		}
+		delete(byId, ingredient.ID)
+		details.Ingredients = append(details.Ingredients, &domain.SaladIngredient{
+			Ingredient:  ingredient,
+			Measurement: measurementsById[recipeIngredient.MeasurementID],
+			Amount:      recipeIngredient.Amount,
+			Optional:    recipeIngredient.Optional,
+			Note:        recipeIngredient.Note,
+		})
+	}
+	for _, ingredient := range ingredients {
+		if _, ok := byId[ingredient.ID]; ok {
+			details.Ingredients = append(details.Ingredients, &domain.SaladIngredient{Ingredient: ingredient})
+		}
+	}
+	return nil
+}
@@ -75,18 +75,18 @@ func (i *SaladInteractor) GetById(ctx context.Context, id uuid.UUID) (*domain.Sa
 	return i.saladService.GetById(ctx, id)
 }
 
-func (i *SaladInteractor) GetAll(ctx context.Context, filter *domain.RecipeFilter, page int) ([]*domain.Salad, int, error) {
+func (i *SaladInteractor) GetAll(ctx context.Context, filter *domain.RecipeFilter, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
 	return i.saladService.GetAll(ctx, filter, page)
 }
 
-func (i *SaladInteractor) GetAllByUserId(ctx context.Context, id uuid.UUID) ([]*domain.Salad, error) {
-	return i.saladService.GetAllByUserId(ctx, id)
+func (i *SaladInteractor) GetAllByUserId(ctx context.Context, id uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	return i.saladService.GetAllByUserId(ctx, id, page)
 }
 
 func (i *SaladInteractor) DeleteById(ctx context.Context, id uuid.UUID) error {
 	return i.saladService.DeleteById(ctx, id)
 }
 
-func (i *SaladInteractor) GetAllRatedByUser(ctx context.Context, userId uuid.UUID, page int) ([]*domain.Salad, int, error) {
+func (i *SaladInteractor) GetAllRatedByUser(ctx context.Context, userId uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
 	return i.saladService.GetAllRatedByUser(ctx, userId, page)
 }
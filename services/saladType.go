@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/Mx1q/ppo_services/config"
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/logger"
 	"github.com/google/uuid"
@@ -10,35 +12,73 @@ import (
 
 type SaladTypeService struct {
 	saladTypeRepo domain.ISaladTypeRepository
+	txManager     domain.ITransactionManager
+	pages         config.PageConfig
 	logger        logger.ILogger
 }
 
-func NewSaladTypeService(saladTypeRepo domain.ISaladTypeRepository, logger logger.ILogger) domain.ISaladTypeService {
+func NewSaladTypeService(
+	saladTypeRepo domain.ISaladTypeRepository,
+	txManager domain.ITransactionManager,
+	logger logger.ILogger,
+	pages config.PageConfig) domain.ISaladTypeService {
 	return &SaladTypeService{
 		saladTypeRepo: saladTypeRepo,
+		txManager:     txManager,
+		pages:         pages,
 		logger:        logger,
 	}
 }
 
-func (s *SaladTypeService) verify(saladType *domain.SaladType) error {
+func verifySaladType(saladType *domain.SaladType) error {
 	if saladType.Name == "" {
 		return fmt.Errorf("empty name")
 	}
 	return nil
 }
 
+// checkParent verifies the parent exists and isn't the type or its descendant. Callers
+// run it in one transaction with the write, so concurrent moves can't make a cycle.
+func (s *SaladTypeService) checkParent(ctx context.Context, id uuid.UUID, parentId uuid.UUID) error {
+	return domain.CheckTypeMove(id, parentId, func(id uuid.UUID) (uuid.UUID, error) {
+		parent, err := s.saladTypeRepo.GetById(ctx, id)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return parent.ParentID, nil
+	})
+}
+
+// logTreeError logs rejected changes of the tree as warnings and failures with the format
+func (s *SaladTypeService) logTreeError(format string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTypeCycle):
+		s.logger.Warnf("failed to verify salad type parent: %s", err.Error())
+	case errors.Is(err, domain.ErrTypeHasChildren):
+		s.logger.Warnf("failed to delete salad type: %s", err.Error())
+	default:
+		s.logger.Errorf(format, err.Error())
+	}
+}
+
 func (s *SaladTypeService) Create(ctx context.Context, saladType *domain.SaladType) error {
 	s.logger.Infof("creating salad type: %+v", saladType)
 
-	err := s.verify(saladType)
+	err := verifySaladType(saladType)
 	if err != nil {
 		s.logger.Warnf("failed to verify salad type: %s", err.Error())
 		return fmt.Errorf("creating salad type: %w", err)
 	}
 
-	err = s.saladTypeRepo.Create(ctx, saladType)
+	err = domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		err := s.checkParent(ctx, saladType.ID, saladType.ParentID)
+		if err != nil {
+			return err
+		}
+		return s.saladTypeRepo.Create(ctx, saladType)
+	})
 	if err != nil {
-		s.logger.Errorf("creating salad type error: %s", err.Error())
+		s.logTreeError("creating salad type error: %s", err)
 		return fmt.Errorf("creating salad type: %w", err)
 	}
 	return nil
@@ -47,15 +87,21 @@ func (s *SaladTypeService) Create(ctx context.Context, saladType *domain.SaladTy
 func (s *SaladTypeService) Update(ctx context.Context, saladType *domain.SaladType) error {
 	s.logger.Infof("updating salad type: %+v", saladType)
 
-	err := s.verify(saladType)
+	err := verifySaladType(saladType)
 	if err != nil {
 		s.logger.Warnf("failed to verify salad type: %s", err.Error())
 		return fmt.Errorf("updating salad type: %w", err)
 	}
 
-	err = s.saladTypeRepo.Update(ctx, saladType)
+	err = domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		err := s.checkParent(ctx, saladType.ID, saladType.ParentID)
+		if err != nil {
+			return err
+		}
+		return s.saladTypeRepo.Update(ctx, saladType)
+	})
 	if err != nil {
-		s.logger.Errorf("updating salad type error: %s", err.Error())
+		s.logTreeError("updating salad type error: %s", err)
 		return fmt.Errorf("updating salad type: %w", err)
 	}
 	return nil
@@ -72,15 +118,21 @@ func (s *SaladTypeService) GetById(ctx context.Context, id uuid.UUID) (*domain.S
 	return saladType, nil
 }
 
-func (s *SaladTypeService) GetAll(ctx context.Context, page int) ([]*domain.SaladType, int, error) {
-	s.logger.Infof("gettign all salad types on page %d", page)
+func (s *SaladTypeService) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.SaladType, *domain.PageInfo, error) {
+	s.logger.Infof("gettign all salad types on page %+v", page)
 
-	saladTypes, numPages, err := s.saladTypeRepo.GetAll(ctx, page)
+	page, err := page.Normalize(s.pages.Sizes(), domain.SortByName)
+	if err != nil {
+		s.logger.Warnf("invalid page request: %s", err.Error())
+		return nil, nil, fmt.Errorf("getting all salad types: %w", err)
+	}
+
+	saladTypes, pageInfo, err := s.saladTypeRepo.GetAll(ctx, page)
 	if err != nil {
 		s.logger.Errorf("getting salad types error: %s", err.Error())
-		return nil, 0, fmt.Errorf("getting all salad types: %w", err)
+		return nil, nil, fmt.Errorf("getting all salad types: %w", err)
 	}
-	return saladTypes, numPages, nil
+	return saladTypes, pageInfo, nil
 }
 
 func (s *SaladTypeService) GetAllBySaladId(ctx context.Context, saladId uuid.UUID) ([]*domain.SaladType, error) {
@@ -94,12 +146,82 @@ func (s *SaladTypeService) GetAllBySaladId(ctx context.Context, saladId uuid.UUI
 	return saladTypes, err
 }
 
+func (s *SaladTypeService) GetChildren(ctx context.Context, parentId uuid.UUID) ([]*domain.SaladType, error) {
+	s.logger.Infof("getting subtypes of salad type %s", parentId.String())
+
+	saladTypes, err := s.saladTypeRepo.GetAllByParentId(ctx, parentId)
+	if err != nil {
+		s.logger.Errorf("getting subtypes of salad type error: %s", err.Error())
+		return nil, fmt.Errorf("getting subtypes of salad type: %w", err)
+	}
+	return saladTypes, nil
+}
+
+func (s *SaladTypeService) GetSubtree(ctx context.Context, id uuid.UUID) ([]*domain.SaladType, error) {
+	s.logger.Infof("getting subtree of salad type %s", id.String())
+
+	root, err := s.saladTypeRepo.GetById(ctx, id)
+	if err != nil {
+		s.logger.Errorf("getting subtree of salad type error: %s", err.Error())
+		return nil, fmt.Errorf("getting subtree of salad type: %w", err)
+	}
+
+	subtree := []*domain.SaladType{root}
+	seen := map[uuid.UUID]bool{root.ID: true}
+	for i := 0; i < len(subtree); i++ {
+		children, err := s.saladTypeRepo.GetAllByParentId(ctx, subtree[i].ID)
+		if err != nil {
+			s.logger.Errorf("getting subtree of salad type error: %s", err.Error())
+			return nil, fmt.Errorf("getting subtree of salad type: %w", err)
+		}
+		for _, child := range children {
+			if !seen[child.ID] {
+				seen[child.ID] = true
+				subtree = append(subtree, child)
+			}
+		}
+	}
+	return subtree, nil
+}
+
+func (s *SaladTypeService) Move(ctx context.Context, id uuid.UUID, parentId uuid.UUID) error {
+	s.logger.Infof("moving salad type %s under %s", id.String(), parentId.String())
+
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		saladType, err := s.saladTypeRepo.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+		err = s.checkParent(ctx, id, parentId)
+		if err != nil {
+			return err
+		}
+
+		saladType.ParentID = parentId
+		return s.saladTypeRepo.Update(ctx, saladType)
+	})
+	if err != nil {
+		s.logTreeError("moving salad type error: %s", err)
+		return fmt.Errorf("moving salad type: %w", err)
+	}
+	return nil
+}
+
 func (s *SaladTypeService) DeleteById(ctx context.Context, id uuid.UUID) error {
 	s.logger.Infof("deleting salad type by id %s", id.String())
 
-	err := s.saladTypeRepo.DeleteById(ctx, id)
+	err := domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		children, err := s.saladTypeRepo.GetAllByParentId(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(children) != 0 {
+			return domain.ErrTypeHasChildren
+		}
+		return s.saladTypeRepo.DeleteById(ctx, id)
+	})
 	if err != nil {
-		s.logger.Errorf("deleting salad type by id %s error: %s", id.String(), err.Error())
+		s.logTreeError("deleting salad type by id error: %s", err)
 		return fmt.Errorf("deleting salad type by id: %w", err)
 	}
 	return nil
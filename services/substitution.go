@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+)
+
+type SubstitutionService struct {
+	substitutionRepo     domain.ISubstitutionRepository
+	ingredientRepo       domain.IIngredientRepository
+	recipeIngredientRepo domain.IRecipeIngredientRepository
+	saladDetailsService  domain.ISaladDetailsService
+	txManager            domain.ITransactionManager
+	logger               logger.ILogger
+}
+
+func NewSubstitutionService(
+	substitutionRepo domain.ISubstitutionRepository,
+	ingredientRepo domain.IIngredientRepository,
+	recipeIngredientRepo domain.IRecipeIngredientRepository,
+	saladDetailsService domain.ISaladDetailsService,
+	txManager domain.ITransactionManager,
+	logger logger.ILogger) domain.ISubstitutionService {
+	return &SubstitutionService{
+		substitutionRepo:     substitutionRepo,
+		ingredientRepo:       ingredientRepo,
+		recipeIngredientRepo: recipeIngredientRepo,
+		saladDetailsService:  saladDetailsService,
+		txManager:            txManager,
+		logger:               logger,
+	}
+}
+
+func verifySubstitution(substitution *domain.Substitution) error {
+	if substitution.IngredientID == uuid.Nil {
+		return fmt.Errorf("empty ingredient")
+	}
+
+	if substitution.SubstituteID == uuid.Nil {
+		return fmt.Errorf("empty substitute")
+	}
+
+	if substitution.IngredientID == substitution.SubstituteID {
+		return fmt.Errorf("ingredient substitutes itself")
+	}
+
+	if substitution.Ratio <= 0 {
+		return fmt.Errorf("negative or zero ratio")
+	}
+
+	return nil
+}
+
+// checkDuplicate fails if the ingredient already has the substitute in the context
+func (s *SubstitutionService) checkDuplicate(ctx context.Context, substitution *domain.Substitution) error {
+	substitutions, err := s.substitutionRepo.GetAllByIngredientId(ctx, substitution.IngredientID)
+	if err != nil {
+		return err
+	}
+	for _, recorded := range substitutions {
+		if recorded.ID != substitution.ID && recorded.SubstituteID == substitution.SubstituteID &&
+			recorded.Context == substitution.Context {
+			return fmt.Errorf("substitution already exists")
+		}
+	}
+	return nil
+}
+
+func (s *SubstitutionService) Create(ctx context.Context, substitution *domain.Substitution) (uuid.UUID, error) {
+	s.logger.Infof("creating substitution of ingredient %s with %s",
+		substitution.IngredientID.String(), substitution.SubstituteID.String())
+
+	err := verifySubstitution(substitution)
+	if err != nil {
+		s.logger.Warnf("failed to verify substitution: %s", err.Error())
+		return uuid.Nil, fmt.Errorf("creating substitution: %w", err)
+	}
+
+	var id uuid.UUID
+	err = domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		_, err := s.ingredientRepo.GetById(ctx, substitution.IngredientID)
+		if err != nil {
+			return err
+		}
+		_, err = s.ingredientRepo.GetById(ctx, substitution.SubstituteID)
+		if err != nil {
+			return err
+		}
+
+		err = s.checkDuplicate(ctx, substitution)
+		if err != nil {
+			return err
+		}
+
+		id, err = s.substitutionRepo.Create(ctx, substitution)
+		return err
+	})
+	if err != nil {
+		s.logger.Errorf("creating substitution error: %s", err.Error())
+		return uuid.Nil, fmt.Errorf("creating substitution: %w", err)
+	}
+	substitution.ID = id
+	return id, nil
+}
+
+func (s *SubstitutionService) GetById(ctx context.Context, id uuid.UUID) (*domain.Substitution, error) {
+	s.logger.Infof("getting substitution by id: %s", id.String())
+
+	substitution, err := s.substitutionRepo.GetById(ctx, id)
+	if err != nil {
+		s.logger.Errorf("getting substitution by id error: %s", err.Error())
+		return nil, fmt.Errorf("getting substitution by id: %w", err)
+	}
+	return substitution, nil
+}
+
+func (s *SubstitutionService) GetAllByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]*domain.Substitution, error) {
+	s.logger.Infof("getting all substitutions of ingredient: %s", ingredientId.String())
+
+	substitutions, err := s.substitutionRepo.GetAllByIngredientId(ctx, ingredientId)
+	if err != nil {
+		s.logger.Errorf("getting all substitutions of ingredient error: %s", err.Error())
+		return nil, fmt.Errorf("getting all substitutions of ingredient: %w", err)
+	}
+	return substitutions, nil
+}
+
+// Update changes the ratio and context, the ingredients of a substitution don't change
+func (s *SubstitutionService) Update(ctx context.Context, substitution *domain.Substitution) error {
+	s.logger.Infof("updating substitution: %s", substitution.ID.String())
+
+	err := verifySubstitution(substitution)
+	if err != nil {
+		s.logger.Warnf("failed to verify substitution: %s", err.Error())
+		return fmt.Errorf("updating substitution: %w", err)
+	}
+
+	err = domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		beforeUpdate, err := s.substitutionRepo.GetById(ctx, substitution.ID)
+		if err != nil {
+			return err
+		}
+		if beforeUpdate.Version != substitution.Version {
+			return &domain.VersionConflictError{
+				Entity:  "substitution",
+				ID:      substitution.ID,
+				Version: substitution.Version,
+			}
+		}
+		if substitution.IngredientID != beforeUpdate.IngredientID ||
+			substitution.SubstituteID != beforeUpdate.SubstituteID {
+			return fmt.Errorf("changing ingredients of substitution")
+		}
+
+		err = s.checkDuplicate(ctx, substitution)
+		if err != nil {
+			return err
+		}
+
+		return s.substitutionRepo.Update(ctx, substitution)
+	})
+	if err != nil {
+		s.logger.Errorf("updating substitution error: %s", err.Error())
+		return fmt.Errorf("updating substitution: %w", err)
+	}
+	return nil
+}
+
+func (s *SubstitutionService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	s.logger.Infof("deleting substitution: %s", id.String())
+
+	err := s.substitutionRepo.DeleteById(ctx, id)
+	if err != nil {
+		s.logger.Errorf("deleting substitution error: %s", err.Error())
+		return fmt.Errorf("deleting substitution: %w", err)
+	}
+	return nil
+}
+
+// GetForRecipeIngredient lists substitutes with amounts scaled to the quantity in the recipe
+func (s *SubstitutionService) GetForRecipeIngredient(ctx context.Context, recipeIngredientId uuid.UUID) ([]*domain.SubstituteOption, error) {
+	s.logger.Infof("getting substitutes of recipe ingredient: %s", recipeIngredientId.String())
+
+	options, err := s.getForRecipeIngredient(ctx, recipeIngredientId)
+	if err != nil {
+		s.logger.Errorf("getting substitutes of recipe ingredient error: %s", err.Error())
+		return nil, fmt.Errorf("getting substitutes of recipe ingredient: %w", err)
+	}
+	return options, nil
+}
+
+func (s *SubstitutionService) getForRecipeIngredient(ctx context.Context, recipeIngredientId uuid.UUID) ([]*domain.SubstituteOption, error) {
+	recipeIngredient, err := s.recipeIngredientRepo.GetById(ctx, recipeIngredientId)
+	if err != nil {
+		return nil, err
+	}
+
+	substitutions, err := s.substitutionRepo.GetAllByIngredientId(ctx, recipeIngredient.IngredientID)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]*domain.SubstituteOption, 0, len(substitutions))
+	for _, substitution := range substitutions {
+		substitute, err := s.ingredientRepo.GetById(ctx, substitution.SubstituteID)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, &domain.SubstituteOption{
+			Substitution: substitution,
+			Ingredient:   substitute,
+			Amount:       recipeIngredient.Amount * substitution.Ratio,
+		})
+	}
+	return options, nil
+}
+
+func (s *SubstitutionService) Apply(ctx context.Context, saladId uuid.UUID,
+	choices []*domain.SubstitutionChoice) (*domain.RecipeExport, error) {
+	s.logger.Infof("applying %d substitutions to salad %s", len(choices), saladId.String())
+
+	details, err := s.saladDetailsService.GetById(ctx, saladId, nil)
+	if err != nil {
+		s.logger.Errorf("applying substitutions error: %s", err.Error())
+		return nil, fmt.Errorf("applying substitutions: %w", err)
+	}
+
+	chosen := make(map[uuid.UUID]uuid.UUID, len(choices))
+	for _, choice := range choices {
+		if _, ok := chosen[choice.IngredientID]; ok {
+			s.logger.Warnf("applying substitutions: ingredient %s substituted twice", choice.IngredientID.String())
+			return nil, fmt.Errorf("applying substitutions: ingredient %s substituted twice", choice.IngredientID.String())
+		}
+		chosen[choice.IngredientID] = choice.SubstitutionID
+	}
+
+	// ingredients are copied, the details may be shared with other callers
+	ingredients := make([]*domain.SaladIngredient, 0, len(details.Ingredients))
+	for _, item := range details.Ingredients {
+		substitutionId, ok := chosen[item.Ingredient.ID]
+		if !ok {
+			ingredients = append(ingredients, item)
+			continue
+		}
+		delete(chosen, item.Ingredient.ID)
+
+		substituted, err := s.substitute(ctx, item, substitutionId)
+		if err != nil {
+			s.logger.Errorf("applying substitutions error: %s", err.Error())
+			return nil, fmt.Errorf("applying substitutions: %w", err)
+		}
+		ingredients = append(ingredients, substituted)
+	}
+	for ingredientId := range chosen {
+		s.logger.Warnf("applying substitutions: ingredient %s is not in recipe", ingredientId.String())
+		return nil, fmt.Errorf("applying substitutions: ingredient %s is not in recipe", ingredientId.String())
+	}
+
+	substitutedDetails := *details
+	substitutedDetails.Ingredients = ingredients
+	return &domain.RecipeExport{
+		SaladDetails: &substitutedDetails,
+		Calories:     domain.CountCalories(ingredients),
+	}, nil
+}
+
+// substitute replaces the ingredient keeping its measurement, the amount is scaled by the ratio
+func (s *SubstitutionService) substitute(ctx context.Context, item *domain.SaladIngredient,
+	substitutionId uuid.UUID) (*domain.SaladIngredient, error) {
+	substitution, err := s.substitutionRepo.GetById(ctx, substitutionId)
+	if err != nil {
+		return nil, err
+	}
+	if substitution.IngredientID != item.Ingredient.ID {
+		return nil, fmt.Errorf("substitution %s is not for ingredient %s",
+			substitutionId.String(), item.Ingredient.ID.String())
+	}
+
+	substitute, err := s.ingredientRepo.GetById(ctx, substitution.SubstituteID)
+	if err != nil {
+		return nil, err
+	}
+
+	note := "instead of " + item.Ingredient.Name
+	if item.Note != "" {
+		note = item.Note + ", " + note
+	}
+	return &domain.SaladIngredient{
+		Ingredient:  substitute,
+		Measurement: item.Measurement,
+		Amount:      item.Amount * substitution.Ratio,
+		Optional:    item.Optional,
+		Note:        note,
+	}, nil
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+	"slices"
+)
+
+type TranslationService struct {
+	translationRepo domain.ITranslationRepository
+	txManager       domain.ITransactionManager
+	logger          logger.ILogger
+}
+
+func NewTranslationService(
+	translationRepo domain.ITranslationRepository,
+	txManager domain.ITransactionManager,
+	logger logger.ILogger) domain.ITranslationService {
+	return &TranslationService{
+		translationRepo: translationRepo,
+		txManager:       txManager,
+		logger:          logger,
+	}
+}
+
+func verifyTranslatable(entityType string, locale string) error {
+	if _, ok := domain.TranslatableFields[entityType]; !ok {
+		return fmt.Errorf("unknown entity type %s", entityType)
+	}
+	return domain.VerifyLocale(locale)
+}
+
+func verifyTranslation(translation *domain.Translation) error {
+	err := verifyTranslatable(translation.EntityType, translation.Locale)
+	if err != nil {
+		return err
+	}
+
+	if translation.EntityID == uuid.Nil {
+		return fmt.Errorf("empty entity")
+	}
+
+	if !slices.Contains(domain.TranslatableFields[translation.EntityType], translation.Field) {
+		return fmt.Errorf("field %s of %s isn't translatable", translation.Field, translation.EntityType)
+	}
+
+	if translation.Text == "" {
+		return fmt.Errorf("empty text")
+	}
+
+	return nil
+}
+
+func (s *TranslationService) Set(ctx context.Context, translation *domain.Translation) error {
+	s.logger.Infof("setting %s translation of %s %s %s", translation.Locale,
+		translation.EntityType, translation.EntityID.String(), translation.Field)
+
+	err := verifyTranslation(translation)
+	if err != nil {
+		s.logger.Warnf("failed to verify translation: %s", err.Error())
+		return fmt.Errorf("setting translation: %w", err)
+	}
+
+	err = domain.RunInTransaction(ctx, s.txManager, func(ctx context.Context) error {
+		translations, err := s.translationRepo.GetAllByEntity(ctx, translation.EntityType, translation.EntityID)
+		if err != nil {
+			return err
+		}
+		for _, existing := range translations {
+			if existing.Locale == translation.Locale && existing.Field == translation.Field {
+				translation.ID = existing.ID
+				translation.Version = existing.Version
+				return s.translationRepo.Update(ctx, translation)
+			}
+		}
+
+		translation.ID, err = s.translationRepo.Create(ctx, translation)
+		return err
+	})
+	if err != nil {
+		s.logger.Errorf("setting translation error: %s", err.Error())
+		return fmt.Errorf("setting translation: %w", err)
+	}
+	return nil
+}
+
+func (s *TranslationService) GetAllByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*domain.Translation, error) {
+	s.logger.Infof("getting translations of %s %s", entityType, entityId.String())
+
+	translations, err := s.translationRepo.GetAllByEntity(ctx, entityType, entityId)
+	if err != nil {
+		s.logger.Errorf("getting translations of entity error: %s", err.Error())
+		return nil, fmt.Errorf("getting translations of entity: %w", err)
+	}
+	return translations, nil
+}
+
+func (s *TranslationService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	s.logger.Infof("deleting translation: %s", id.String())
+
+	err := s.translationRepo.DeleteById(ctx, id)
+	if err != nil {
+		s.logger.Errorf("deleting translation error: %s", err.Error())
+		return fmt.Errorf("deleting translation: %w", err)
+	}
+	return nil
+}
+
+type translationKey struct {
+	entityId uuid.UUID
+	field    string
+	locale   string
+}
+
+func (s *TranslationService) Localize(ctx context.Context, entityType string, texts []domain.TranslatableText) error {
+	locales, ok := domain.LocalesFromContext(ctx)
+	if !ok || len(texts) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(texts))
+	for _, text := range texts {
+		if !slices.Contains(ids, text.EntityID) {
+			ids = append(ids, text.EntityID)
+		}
+	}
+
+	translations, err := s.translationRepo.GetAllByEntities(ctx, entityType, ids, locales)
+	if err != nil {
+		s.logger.Errorf("localizing %s error: %s", entityType, err.Error())
+		return fmt.Errorf("localizing %s: %w", entityType, err)
+	}
+
+	byKey := make(map[translationKey]string, len(translations))
+	for _, translation := range translations {
+		byKey[translationKey{translation.EntityID, translation.Field, translation.Locale}] = translation.Text
+	}
+	for _, text := range texts {
+		for _, locale := range locales {
+			if translated, ok := byKey[translationKey{text.EntityID, text.Field, locale}]; ok {
+				*text.Text = translated
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (s *TranslationService) GetMissing(ctx context.Context, entityType string, locale string) ([]*domain.MissingTranslation, error) {
+	s.logger.Infof("getting missing %s translations of %s", locale, entityType)
+
+	err := verifyTranslatable(entityType, locale)
+	if err != nil {
+		s.logger.Warnf("failed to verify missing translations request: %s", err.Error())
+		return nil, fmt.Errorf("getting missing translations: %w", err)
+	}
+
+	missing, err := s.translationRepo.GetMissing(ctx, entityType, locale)
+	if err != nil {
+		s.logger.Errorf("getting missing translations error: %s", err.Error())
+		return nil, fmt.Errorf("getting missing translations: %w", err)
+	}
+	return missing, nil
+}
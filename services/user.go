@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"github.com/Mx1q/ppo_services/config"
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/logger"
 	"github.com/google/uuid"
@@ -11,12 +12,14 @@ import (
 
 type UserService struct {
 	userRepo domain.IUserRepository
+	pages    config.PageConfig
 	logger   logger.ILogger
 }
 
-func NewUserService(userRepo domain.IUserRepository, logger logger.ILogger) domain.IUserService {
+func NewUserService(userRepo domain.IUserRepository, logger logger.ILogger, pages config.PageConfig) domain.IUserService {
 	return &UserService{
 		userRepo: userRepo,
+		pages:    pages,
 		logger:   logger,
 	}
 }
@@ -38,11 +41,17 @@ func (s *UserService) verify(user *domain.User) error {
 		return err
 	}
 
+	if user.Role != "" {
+		if err := domain.VerifyRole(user.Role); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (s *UserService) Create(ctx context.Context, user *domain.User) error {
-	s.logger.Infof("creating user: %v", user)
+	s.logger.Infof("creating user: %s", user.Username)
 
 	err := s.verify(user)
 	if err != nil {
@@ -60,7 +69,7 @@ func (s *UserService) Create(ctx context.Context, user *domain.User) error {
 }
 
 func (s *UserService) Update(ctx context.Context, user *domain.User) error {
-	s.logger.Warnf("updating user: %v", user)
+	s.logger.Infof("updating user: %s", user.ID.String())
 
 	err := s.verify(user)
 	if err != nil {
@@ -87,15 +96,21 @@ func (s *UserService) GetById(ctx context.Context, id uuid.UUID) (*domain.User,
 	return user, nil
 }
 
-func (s *UserService) GetAll(ctx context.Context, page int) ([]*domain.User, error) {
-	s.logger.Infof("getting all users on page %d", page)
+func (s *UserService) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.User, *domain.PageInfo, error) {
+	s.logger.Infof("getting all users on page %+v", page)
+
+	page, err := page.Normalize(s.pages.Sizes(), domain.SortByName, domain.SortByUsername)
+	if err != nil {
+		s.logger.Warnf("invalid page request: %s", err.Error())
+		return nil, nil, fmt.Errorf("getting all users: %w", err)
+	}
 
-	users, err := s.userRepo.GetAll(ctx, page)
+	users, pageInfo, err := s.userRepo.GetAll(ctx, page)
 	if err != nil {
 		s.logger.Errorf("getting all users error: %s", err.Error())
-		return nil, fmt.Errorf("getting all users: %w", err)
+		return nil, nil, fmt.Errorf("getting all users: %w", err)
 	}
-	return users, nil
+	return users, pageInfo, nil
 }
 
 func (s *UserService) DeleteById(ctx context.Context, id uuid.UUID) error {
@@ -0,0 +1,443 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type WebhookConfig struct {
+	MaxAttempts int
+	// BaseBackoff is the delay after the first failed attempt, it doubles with
+	// every next one up to MaxBackoff
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Timeout     time.Duration
+}
+
+var DefaultWebhookConfig = WebhookConfig{
+	MaxAttempts: 8,
+	BaseBackoff: 30 * time.Second,
+	MaxBackoff:  6 * time.Hour,
+	Timeout:     10 * time.Second,
+}
+
+type WebhookService struct {
+	subscriptionRepo domain.IWebhookSubscriptionRepository
+	deliveryRepo     domain.IWebhookDeliveryRepository
+	deadLetterRepo   domain.IWebhookDeadLetterRepository
+	client           *http.Client
+	config           WebhookConfig
+	logger           logger.ILogger
+	now              func() time.Time
+}
+
+func NewWebhookService(
+	subscriptionRepo domain.IWebhookSubscriptionRepository,
+	deliveryRepo domain.IWebhookDeliveryRepository,
+	deadLetterRepo domain.IWebhookDeadLetterRepository,
+	client *http.Client,
+	config WebhookConfig,
+	logger logger.ILogger) domain.IWebhookService {
+	if client == nil {
+		client = &http.Client{Timeout: config.Timeout}
+	}
+	return &WebhookService{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		deadLetterRepo:   deadLetterRepo,
+		client:           client,
+		config:           config,
+		logger:           logger,
+		now:              time.Now,
+	}
+}
+
+// SignWebhook returns the signature header value of body sent at timestamp (unix seconds)
+func SignWebhook(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature is meant for receivers, it compares signatures in constant time
+func VerifyWebhookSignature(secret string, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignWebhook(secret, timestamp, body)), []byte(signature))
+}
+
+var webhookEventTypes = map[string]bool{
+	domain.WebhookRecipePublished: true,
+	domain.WebhookCommentAdded:    true,
+}
+
+func verifyWebhookSubscription(subscription *domain.WebhookSubscription) error {
+	parsed, err := url.Parse(subscription.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid url")
+	}
+
+	if len(subscription.EventTypes) == 0 {
+		return fmt.Errorf("empty event types")
+	}
+	for _, eventType := range subscription.EventTypes {
+		if !webhookEventTypes[eventType] {
+			return fmt.Errorf("unknown event type %s", eventType)
+		}
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func (s *WebhookService) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	s.logger.Infof("creating webhook subscription to %s", subscription.URL)
+
+	err := verifyWebhookSubscription(subscription)
+	if err != nil {
+		s.logger.Warnf("failed to verify webhook subscription: %s", err.Error())
+		return fmt.Errorf("creating webhook subscription: %w", err)
+	}
+
+	if subscription.Secret == "" {
+		subscription.Secret, err = generateWebhookSecret()
+		if err != nil {
+			s.logger.Errorf("generating webhook secret error: %s", err.Error())
+			return fmt.Errorf("creating webhook subscription: %w", err)
+		}
+	}
+	subscription.ID = uuid.New()
+	subscription.CreatedAt = s.now().UTC()
+
+	err = s.subscriptionRepo.Create(ctx, subscription)
+	if err != nil {
+		s.logger.Errorf("creating webhook subscription error: %s", err.Error())
+		return fmt.Errorf("creating webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (s *WebhookService) GetSubscriptionById(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	s.logger.Infof("getting webhook subscription by id: %s", id.String())
+
+	subscription, err := s.subscriptionRepo.GetById(ctx, id)
+	if err != nil {
+		s.logger.Errorf("getting webhook subscription by id error: %s", err.Error())
+		return nil, fmt.Errorf("getting webhook subscription by id: %w", err)
+	}
+	return subscription, nil
+}
+
+func (s *WebhookService) GetAllSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	s.logger.Infof("getting all webhook subscriptions")
+
+	subscriptions, err := s.subscriptionRepo.GetAll(ctx)
+	if err != nil {
+		s.logger.Errorf("getting all webhook subscriptions error: %s", err.Error())
+		return nil, fmt.Errorf("getting all webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// UpdateSubscription keeps the stored secret when the update has none, the version check
+// of the update fails if the secret was changed since it was read
+func (s *WebhookService) UpdateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	s.logger.Infof("updating webhook subscription: %s", subscription.ID.String())
+
+	err := verifyWebhookSubscription(subscription)
+	if err != nil {
+		s.logger.Warnf("failed to verify webhook subscription: %s", err.Error())
+		return fmt.Errorf("updating webhook subscription: %w", err)
+	}
+
+	if subscription.Secret == "" {
+		stored, err := s.subscriptionRepo.GetById(ctx, subscription.ID)
+		if err != nil {
+			s.logger.Errorf("getting webhook subscription error: %s", err.Error())
+			return fmt.Errorf("updating webhook subscription: %w", err)
+		}
+		subscription.Secret = stored.Secret
+	}
+
+	err = s.subscriptionRepo.Update(ctx, subscription)
+	if err != nil {
+		s.logger.Errorf("updating webhook subscription error: %s", err.Error())
+		return fmt.Errorf("updating webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (s *WebhookService) DeleteSubscriptionById(ctx context.Context, id uuid.UUID) error {
+	s.logger.Infof("deleting webhook subscription by id: %s", id.String())
+
+	err := s.subscriptionRepo.DeleteById(ctx, id)
+	if err != nil {
+		s.logger.Errorf("deleting webhook subscription by id error: %s", err.Error())
+		return fmt.Errorf("deleting webhook subscription by id: %w", err)
+	}
+	return nil
+}
+
+// webhookEvent maps a domain event to the webhook event type, ok is false for
+// events partners can't subscribe to
+func webhookEvent(event domain.IEvent) (eventType string, data interface{}, ok bool) {
+	switch e := event.(type) {
+	case *domain.RecipeStatusChanged:
+		if e.NewStatus == domain.PublishedSaladStatus && e.OldStatus != domain.PublishedSaladStatus {
+			return domain.WebhookRecipePublished, e, true
+		}
+	case *domain.CommentAdded:
+		return domain.WebhookCommentAdded, e.Comment, true
+	}
+	return "", nil, false
+}
+
+func (s *WebhookService) HandleEvent(ctx context.Context, event domain.IEvent) error {
+	eventType, data, ok := webhookEvent(event)
+	if !ok {
+		return nil
+	}
+	s.logger.Infof("queueing webhook deliveries of %s", eventType)
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		s.logger.Errorf("encoding webhook payload error: %s", err.Error())
+		return fmt.Errorf("queueing webhook deliveries: %w", err)
+	}
+
+	subscriptions, err := s.subscriptionRepo.GetAllByEventType(ctx, eventType)
+	if err != nil {
+		s.logger.Errorf("getting webhook subscriptions by event type error: %s", err.Error())
+		return fmt.Errorf("queueing webhook deliveries: %w", err)
+	}
+
+	now := s.now().UTC()
+	for _, subscription := range subscriptions {
+		if !subscription.Active {
+			continue
+		}
+
+		err = s.deliveryRepo.Create(ctx, &domain.WebhookDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: subscription.ID,
+			EventType:      eventType,
+			Payload:        payload,
+			Status:         domain.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+		if err != nil {
+			s.logger.Errorf("creating webhook delivery error: %s", err.Error())
+			return fmt.Errorf("queueing webhook deliveries: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	delay := s.config.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if s.config.MaxBackoff > 0 && delay >= s.config.MaxBackoff {
+			return s.config.MaxBackoff
+		}
+	}
+	return delay
+}
+
+func (s *WebhookService) DeliverDue(ctx context.Context, limit int) (int, error) {
+	deliveries, err := s.deliveryRepo.GetDue(ctx, s.now().UTC(), limit)
+	if err != nil {
+		s.logger.Errorf("getting due webhook deliveries error: %s", err.Error())
+		return 0, fmt.Errorf("delivering webhooks: %w", err)
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		ok, err := s.deliver(ctx, delivery)
+		if err != nil {
+			s.logger.Errorf("delivering webhook %s error: %s", delivery.ID.String(), err.Error())
+			return delivered, fmt.Errorf("delivering webhooks: %w", err)
+		}
+		if ok {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// deliver makes one attempt, schedules the next one or moves the delivery to the
+// dead letters. err is returned when the subscription can't be read or the state
+// can't be saved, the delivery stays due then and is retried.
+func (s *WebhookService) deliver(ctx context.Context, delivery *domain.WebhookDelivery) (bool, error) {
+	subscription, err := s.subscriptionRepo.GetById(ctx, delivery.SubscriptionID)
+	if errors.Is(err, domain.ErrSubscriptionNotFound) || (err == nil && !subscription.Active) {
+		s.logger.Warnf("webhook subscription %s is removed or inactive", delivery.SubscriptionID.String())
+		return false, s.kill(ctx, delivery)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	delivery.Attempts++
+	attempt := s.send(ctx, subscription, delivery)
+	err = s.deliveryRepo.CreateAttempt(ctx, attempt)
+	if err != nil {
+		return false, err
+	}
+
+	if attempt.Error == "" {
+		delivery.Status = domain.WebhookDeliveryDelivered
+		return true, s.deliveryRepo.Update(ctx, delivery)
+	}
+
+	s.logger.Warnf("webhook delivery %s attempt %d failed: %s", delivery.ID.String(), delivery.Attempts, attempt.Error)
+	if delivery.Attempts >= s.config.MaxAttempts {
+		return false, s.kill(ctx, delivery)
+	}
+	delivery.NextAttemptAt = attempt.Timestamp.Add(s.backoff(delivery.Attempts))
+	return false, s.deliveryRepo.Update(ctx, delivery)
+}
+
+func (s *WebhookService) kill(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	delivery.Status = domain.WebhookDeliveryDead
+	err := s.deliveryRepo.Update(ctx, delivery)
+	if err != nil {
+		return err
+	}
+	return s.deadLetterRepo.Create(ctx, delivery)
+}
+
+func (s *WebhookService) send(ctx context.Context,
+	subscription *domain.WebhookSubscription,
+	delivery *domain.WebhookDelivery) *domain.WebhookAttempt {
+	start := s.now()
+	attempt := &domain.WebhookAttempt{
+		ID:         uuid.New(),
+		DeliveryID: delivery.ID,
+		Number:     delivery.Attempts,
+		Timestamp:  start.UTC(),
+	}
+
+	body, err := json.Marshal(struct {
+		ID        uuid.UUID       `json:"id"`
+		Event     string          `json:"event"`
+		CreatedAt time.Time       `json:"createdAt"`
+		Data      json.RawMessage `json:"data"`
+	}{delivery.ID, delivery.EventType, delivery.CreatedAt, delivery.Payload})
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	timestamp := strconv.FormatInt(start.Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(domain.WebhookEventHeader, delivery.EventType)
+	request.Header.Set(domain.WebhookDeliveryHeader, delivery.ID.String())
+	request.Header.Set(domain.WebhookTimestampHeader, timestamp)
+	request.Header.Set(domain.WebhookSignatureHeader, SignWebhook(subscription.Secret, timestamp, body))
+
+	response, err := s.client.Do(request)
+	attempt.Duration = s.now().Sub(start)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+
+	attempt.StatusCode = response.StatusCode
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		attempt.Error = fmt.Sprintf("unexpected status %s", response.Status)
+	}
+	return attempt
+}
+
+func (s *WebhookService) Redeliver(ctx context.Context, deliveryId uuid.UUID) error {
+	s.logger.Infof("redelivering webhook: %s", deliveryId.String())
+
+	delivery, err := s.deliveryRepo.GetById(ctx, deliveryId)
+	if err != nil {
+		s.logger.Errorf("getting webhook delivery by id error: %s", err.Error())
+		return fmt.Errorf("redelivering webhook: %w", err)
+	}
+	if delivery.Status != domain.WebhookDeliveryDead {
+		s.logger.Warnf("webhook delivery %s is not dead", deliveryId.String())
+		return fmt.Errorf("redelivering webhook: delivery is %s", delivery.Status)
+	}
+
+	delivery.Status = domain.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = s.now().UTC()
+	err = s.deliveryRepo.Update(ctx, delivery)
+	if err != nil {
+		s.logger.Errorf("updating webhook delivery error: %s", err.Error())
+		return fmt.Errorf("redelivering webhook: %w", err)
+	}
+
+	err = s.deadLetterRepo.DeleteById(ctx, deliveryId)
+	if err != nil {
+		s.logger.Errorf("deleting webhook dead letter error: %s", err.Error())
+		return fmt.Errorf("redelivering webhook: %w", err)
+	}
+	return nil
+}
+
+func (s *WebhookService) GetDeliveries(ctx context.Context, subscriptionId uuid.UUID) ([]*domain.WebhookDelivery, error) {
+	s.logger.Infof("getting webhook deliveries of subscription: %s", subscriptionId.String())
+
+	deliveries, err := s.deliveryRepo.GetAllBySubscriptionId(ctx, subscriptionId)
+	if err != nil {
+		s.logger.Errorf("getting webhook deliveries error: %s", err.Error())
+		return nil, fmt.Errorf("getting webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (s *WebhookService) GetAttempts(ctx context.Context, deliveryId uuid.UUID) ([]*domain.WebhookAttempt, error) {
+	s.logger.Infof("getting attempts of webhook delivery: %s", deliveryId.String())
+
+	attempts, err := s.deliveryRepo.GetAttempts(ctx, deliveryId)
+	if err != nil {
+		s.logger.Errorf("getting webhook attempts error: %s", err.Error())
+		return nil, fmt.Errorf("getting webhook attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+func (s *WebhookService) GetDeadLetters(ctx context.Context) ([]*domain.WebhookDelivery, error) {
+	s.logger.Infof("getting webhook dead letters")
+
+	deliveries, err := s.deadLetterRepo.GetAll(ctx)
+	if err != nil {
+		s.logger.Errorf("getting webhook dead letters error: %s", err.Error())
+		return nil, fmt.Errorf("getting webhook dead letters: %w", err)
+	}
+	return deliveries, nil
+}
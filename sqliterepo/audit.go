@@ -0,0 +1,60 @@
+package sqliterepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+// AuditRepository writes records in the transaction of the change they describe,
+// so rolled back changes leave no records
+type AuditRepository struct {
+	db *DB
+}
+
+func NewAuditRepository(db *DB) domain.IAuditRepository {
+	return &AuditRepository{db: db}
+}
+
+const auditColumns = "id, actor_id, actor_role, action, entity_type, entity_id, before, after, timestamp"
+
+func scanAuditRecord(row scanner) (*domain.AuditRecord, error) {
+	record := &domain.AuditRecord{}
+	err := row.Scan(&record.ID, &record.ActorID, &record.ActorRole, &record.Action, &record.EntityType, &record.EntityID,
+		textScanner{&record.Before}, textScanner{&record.After}, timeScanner{&record.Timestamp})
+	return record, err
+}
+
+func (r *AuditRepository) Create(ctx context.Context, record *domain.AuditRecord) error {
+	_, err := r.db.conn(ctx).ExecContext(ctx, "INSERT INTO audit_records ("+auditColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		record.ID, record.ActorID, record.ActorRole, record.Action, record.EntityType, record.EntityID,
+		textValue(record.Before), textValue(record.After), timeValue(record.Timestamp))
+	if isPrimaryKeyViolation(err) {
+		return fmt.Errorf("audit record %s already exists", record.ID.String())
+	}
+	if err != nil {
+		return fmt.Errorf("creating audit record: %w", err)
+	}
+	return nil
+}
+
+// GetByEntity returns records of the entity in the order they were made
+func (r *AuditRepository) GetByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*domain.AuditRecord, error) {
+	records, err := queryAll(ctx, r.db.conn(ctx), scanAuditRecord, "SELECT "+auditColumns+
+		" FROM audit_records WHERE entity_type = ? AND entity_id = ? ORDER BY timestamp, rowid", entityType, entityId)
+	if err != nil {
+		return nil, fmt.Errorf("getting audit records: %w", err)
+	}
+	return records, nil
+}
+
+// GetByActor returns records of the actor in the order they were made
+func (r *AuditRepository) GetByActor(ctx context.Context, actorId uuid.UUID) ([]*domain.AuditRecord, error) {
+	records, err := queryAll(ctx, r.db.conn(ctx), scanAuditRecord, "SELECT "+auditColumns+
+		" FROM audit_records WHERE actor_id = ? ORDER BY timestamp, rowid", actorId)
+	if err != nil {
+		return nil, fmt.Errorf("getting audit records: %w", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,105 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+type CommentRepository struct {
+	db *DB
+}
+
+func NewCommentRepository(db *DB) domain.ICommentRepository {
+	return &CommentRepository{db: db}
+}
+
+const commentColumns = "id, author_id, salad_id, text, rating, version"
+
+func scanComment(row scanner) (*domain.Comment, error) {
+	comment := &domain.Comment{}
+	err := row.Scan(&comment.ID, &comment.AuthorID, &comment.SaladID, &comment.Text, &comment.Rating, &comment.Version)
+	return comment, err
+}
+
+// Create allows one comment of a user per salad
+func (r *CommentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	id := newId(comment.ID)
+	_, err := r.db.conn(ctx).ExecContext(ctx, "INSERT INTO comments ("+commentColumns+") VALUES (?, ?, ?, ?, ?, 1)",
+		id, comment.AuthorID, comment.SaladID, comment.Text, comment.Rating)
+	switch {
+	case isPrimaryKeyViolation(err) || isUniqueViolation(err):
+		return fmt.Errorf("comment of user %s to salad %s already exists", comment.AuthorID.String(), comment.SaladID.String())
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("salad %s not found", comment.SaladID.String())
+	case err != nil:
+		return fmt.Errorf("creating comment: %w", err)
+	}
+	comment.ID = id
+	comment.Version = 1
+	return nil
+}
+
+func (r *CommentRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	comment, err := scanComment(r.db.conn(ctx).QueryRowContext(ctx, "SELECT "+commentColumns+" FROM comments WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("comment %s not found", id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting comment: %w", err)
+	}
+	return comment, nil
+}
+
+func (r *CommentRepository) GetBySaladAndUser(ctx context.Context, saladId uuid.UUID, userId uuid.UUID) (*domain.Comment, error) {
+	comment, err := scanComment(r.db.conn(ctx).QueryRowContext(ctx,
+		"SELECT "+commentColumns+" FROM comments WHERE salad_id = ? AND author_id = ?", saladId, userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("comment of user %s to salad %s not found", userId.String(), saladId.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting comment: %w", err)
+	}
+	return comment, nil
+}
+
+func (r *CommentRepository) GetAllBySaladID(ctx context.Context, saladId uuid.UUID, page *domain.PageRequest) ([]*domain.Comment, *domain.PageInfo, error) {
+	comments, err := queryAll(ctx, r.db.conn(ctx), scanComment, "SELECT "+commentColumns+" FROM comments WHERE salad_id = ?", saladId)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting comments: %w", err)
+	}
+
+	return domain.Paginate(comments, page, func(comment *domain.Comment, sort string) (string, uuid.UUID) {
+		if sort == domain.SortByRating {
+			return domain.SortKeyInt(comment.Rating), comment.ID
+		}
+		return "", comment.ID
+	})
+}
+
+func (r *CommentRepository) Update(ctx context.Context, comment *domain.Comment) error {
+	q := r.db.conn(ctx)
+	result, err := q.ExecContext(ctx, `UPDATE comments SET author_id = ?, salad_id = ?, text = ?, rating = ?,
+		version = version + 1 WHERE id = ? AND version = ?`,
+		comment.AuthorID, comment.SaladID, comment.Text, comment.Rating, comment.ID, comment.Version)
+	switch {
+	case isUniqueViolation(err):
+		return fmt.Errorf("comment of user %s to salad %s already exists", comment.AuthorID.String(), comment.SaladID.String())
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("salad %s not found", comment.SaladID.String())
+	case err != nil:
+		return fmt.Errorf("updating comment: %w", err)
+	}
+	if err = checkUpdated(ctx, q, result, "comments", "comment", comment.ID, comment.Version); err != nil {
+		return err
+	}
+	comment.Version++
+	return nil
+}
+
+func (r *CommentRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return deleteById(ctx, r.db.conn(ctx), "comments", "comment", id)
+}
@@ -0,0 +1,273 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Connections enforce foreign keys, wait for locks of other writers instead of failing
+// and begin transactions as writers, so that a transaction reading before it writes
+// doesn't fail to upgrade its lock
+const pragmas = "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_txlock=immediate"
+
+// DB is an SQLite database with the schema of the repositories. Repositories of a DB
+// run their statements in the transaction of ctx when it was begun by the
+// TransactionManager of the same DB.
+type DB struct {
+	db *sql.DB
+}
+
+// Open opens the database file, creating it if it is missing, and migrates its schema
+func Open(path string) (*DB, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty file name")
+	}
+	db, err := sql.Open("sqlite", path+pragmas)
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+
+	if err = migrate(context.Background(), db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &DB{db: db}, nil
+}
+
+func (d *DB) Ping(ctx context.Context) error {
+	return d.db.PingContext(ctx)
+}
+
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn returns the transaction of ctx if it belongs to the database
+func (d *DB) conn(ctx context.Context) querier {
+	if tx, ok := domain.TransactionFromContext(ctx); ok {
+		if tx, ok := tx.(*transaction); ok && tx.db == d {
+			return tx.tx
+		}
+	}
+	return d.db
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// queryAll scans every row of the query, no rows give an empty slice
+func queryAll[T any](ctx context.Context, q querier, scan func(row scanner) (T, error), query string, args ...any) ([]T, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]T, 0)
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// checkUpdated tells why an update by ID and version changed nothing
+func checkUpdated(ctx context.Context, q querier, result sql.Result, table string, entity string, id uuid.UUID, version int) error {
+	affected, err := result.RowsAffected()
+	if err != nil || affected != 0 {
+		return err
+	}
+	return notUpdated(ctx, q, table, entity, id, version)
+}
+
+// notUpdated tells whether the entity is missing or has another version
+func notUpdated(ctx context.Context, q querier, table string, entity string, id uuid.UUID, version int) error {
+	var found int
+	err := q.QueryRowContext(ctx, "SELECT 1 FROM "+table+" WHERE id = ?", id).Scan(&found)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%s %s not found", entity, id.String())
+	}
+	if err != nil {
+		return err
+	}
+	return &domain.VersionConflictError{Entity: entity, ID: id, Version: version}
+}
+
+// deleteById removes the row of the entity, failing when there is none
+func deleteById(ctx context.Context, q querier, table string, entity string, id uuid.UUID) error {
+	result, err := q.ExecContext(ctx, "DELETE FROM "+table+" WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %w", entity, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting %s: %w", entity, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s %s not found", entity, id.String())
+	}
+	return nil
+}
+
+// sortByName orders case-insensitively like memory repositories, lower() of SQLite
+// folds ASCII letters only
+func sortByName[T any](items []T, name func(item T) string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return strings.ToLower(name(items[i])) < strings.ToLower(name(items[j]))
+	})
+}
+
+func newId(id uuid.UUID) uuid.UUID {
+	if id == uuid.Nil {
+		return uuid.New()
+	}
+	return id
+}
+
+func errorCode(err error) int {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code()
+	}
+	return 0
+}
+
+func isPrimaryKeyViolation(err error) bool {
+	return errorCode(err) == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+}
+
+// isUniqueViolation is true for other unique constraints than the primary key
+func isUniqueViolation(err error) bool {
+	return errorCode(err) == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
+
+func isForeignKeyViolation(err error) bool {
+	return errorCode(err) == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY
+}
+
+// nullId stores the nil UUID as NULL, for references that are optional
+func nullId(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
+
+// nullIdScanner scans NULL as the nil UUID
+type nullIdScanner struct {
+	id *uuid.UUID
+}
+
+func (s nullIdScanner) Scan(src any) error {
+	var id uuid.NullUUID
+	if err := id.Scan(src); err != nil {
+		return err
+	}
+	*s.id = id.UUID
+	return nil
+}
+
+// timeValue stores times as Unix nanoseconds, the zero time as NULL
+func timeValue(t time.Time) sql.NullInt64 {
+	if t.IsZero() {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: t.UnixNano(), Valid: true}
+}
+
+// timeScanner reads times stored by timeValue in UTC
+type timeScanner struct {
+	t *time.Time
+}
+
+func (s timeScanner) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*s.t = time.Time{}
+	case int64:
+		*s.t = time.Unix(0, value).UTC()
+	default:
+		return fmt.Errorf("unsupported time %T", src)
+	}
+	return nil
+}
+
+// listValue stores lists of strings as JSON arrays
+func listValue(list []string) (string, error) {
+	if list == nil {
+		list = []string{}
+	}
+	data, err := json.Marshal(list)
+	return string(data), err
+}
+
+// listScanner reads lists stored by listValue, empty lists as nil
+type listScanner struct {
+	list *[]string
+}
+
+func (s listScanner) Scan(src any) error {
+	var data []byte
+	switch value := src.(type) {
+	case string:
+		data = []byte(value)
+	case []byte:
+		data = value
+	default:
+		return fmt.Errorf("unsupported list %T", src)
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		list = nil
+	}
+	*s.list = list
+	return nil
+}
+
+// textValue stores JSON documents as text, empty ones as NULL
+func textValue(data json.RawMessage) sql.NullString {
+	if len(data) == 0 {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(data), Valid: true}
+}
+
+// textScanner reads documents stored by textValue, NULL as nil
+type textScanner struct {
+	data *json.RawMessage
+}
+
+func (s textScanner) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*s.data = nil
+	case string:
+		*s.data = json.RawMessage(value)
+	case []byte:
+		*s.data = append(json.RawMessage(nil), value...)
+	default:
+		return fmt.Errorf("unsupported document %T", src)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"strings"
+)
+
+type IngredientRepository struct {
+	db *DB
+}
+
+func NewIngredientRepository(db *DB) domain.IIngredientRepository {
+	return &IngredientRepository{db: db}
+}
+
+const ingredientColumns = "id, type_id, name, calories, density, contents, version"
+
+func scanIngredient(row scanner) (*domain.Ingredient, error) {
+	ingredient := &domain.Ingredient{}
+	err := row.Scan(&ingredient.ID, nullIdScanner{&ingredient.TypeID}, &ingredient.Name, &ingredient.Calories,
+		&ingredient.Density, listScanner{&ingredient.Contents}, &ingredient.Version)
+	return ingredient, err
+}
+
+func (r *IngredientRepository) Create(ctx context.Context, ingredient *domain.Ingredient) error {
+	contents, err := listValue(ingredient.Contents)
+	if err != nil {
+		return fmt.Errorf("creating ingredient: %w", err)
+	}
+
+	id := newId(ingredient.ID)
+	_, err = r.db.conn(ctx).ExecContext(ctx, "INSERT INTO ingredients ("+ingredientColumns+") VALUES (?, ?, ?, ?, ?, ?, 1)",
+		id, nullId(ingredient.TypeID), ingredient.Name, ingredient.Calories, ingredient.Density, contents)
+	switch {
+	case isPrimaryKeyViolation(err):
+		return fmt.Errorf("ingredient %s already exists", id.String())
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("ingredient type %s not found", ingredient.TypeID.String())
+	case err != nil:
+		return fmt.Errorf("creating ingredient: %w", err)
+	}
+	ingredient.ID = id
+	ingredient.Version = 1
+	return nil
+}
+
+func (r *IngredientRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Ingredient, error) {
+	ingredient, err := scanIngredient(r.db.conn(ctx).QueryRowContext(ctx, "SELECT "+ingredientColumns+" FROM ingredients WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("ingredient %s not found", id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting ingredient: %w", err)
+	}
+	return ingredient, nil
+}
+
+func (r *IngredientRepository) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.Ingredient, *domain.PageInfo, error) {
+	ingredients, err := queryAll(ctx, r.db.conn(ctx), scanIngredient, "SELECT "+ingredientColumns+" FROM ingredients")
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting ingredients: %w", err)
+	}
+
+	return domain.Paginate(ingredients, page, func(ingredient *domain.Ingredient, sort string) (string, uuid.UUID) {
+		if sort == domain.SortByCalories {
+			return domain.SortKeyInt(ingredient.Calories), ingredient.ID
+		}
+		return strings.ToLower(ingredient.Name), ingredient.ID
+	})
+}
+
+// GetAllByRecipeId returns ingredients of the recipe in display order
+func (r *IngredientRepository) GetAllByRecipeId(ctx context.Context, id uuid.UUID) ([]*domain.Ingredient, error) {
+	ingredients, err := queryAll(ctx, r.db.conn(ctx), scanIngredient, `SELECT i.id, i.type_id, i.name, i.calories,
+		i.density, i.contents, i.version FROM recipe_ingredients ri JOIN ingredients i ON i.id = ri.ingredient_id
+		WHERE ri.recipe_id = ? ORDER BY ri.position, ri.id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting ingredients of recipe: %w", err)
+	}
+	return ingredients, nil
+}
+
+// Link appends the ingredient to the recipe without a quantity
+func (r *IngredientRepository) Link(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) (uuid.UUID, error) {
+	q := r.db.conn(ctx)
+	var found, linked bool
+	err := q.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM ingredients WHERE id = ?),
+		EXISTS (SELECT 1 FROM recipe_ingredients WHERE recipe_id = ? AND ingredient_id = ?)`,
+		ingredientId, recipeId, ingredientId).Scan(&found, &linked)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("linking ingredient: %w", err)
+	}
+	if !found {
+		return uuid.Nil, fmt.Errorf("ingredient %s not found", ingredientId.String())
+	}
+	if linked {
+		return uuid.Nil, fmt.Errorf("ingredient %s already linked to recipe %s", ingredientId.String(), recipeId.String())
+	}
+
+	id := uuid.New()
+	_, err = q.ExecContext(ctx, `INSERT INTO recipe_ingredients (`+recipeIngredientColumns+`)
+		SELECT ?, ?, ?, NULL, 0, 0, '', COUNT(*) + 1, 1 FROM recipe_ingredients WHERE recipe_id = ?`,
+		id, recipeId, ingredientId, recipeId)
+	if isForeignKeyViolation(err) {
+		return uuid.Nil, fmt.Errorf("recipe %s not found", recipeId.String())
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("linking ingredient: %w", err)
+	}
+	return id, nil
+}
+
+func (r *IngredientRepository) Unlink(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) error {
+	result, err := r.db.conn(ctx).ExecContext(ctx, `DELETE FROM recipe_ingredients WHERE id = (SELECT id FROM recipe_ingredients
+		WHERE recipe_id = ? AND ingredient_id = ? ORDER BY position, id LIMIT 1)`, recipeId, ingredientId)
+	if err != nil {
+		return fmt.Errorf("unlinking ingredient: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unlinking ingredient: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("ingredient %s isn't linked to recipe %s", ingredientId.String(), recipeId.String())
+	}
+	return nil
+}
+
+func (r *IngredientRepository) Update(ctx context.Context, ingredient *domain.Ingredient) error {
+	contents, err := listValue(ingredient.Contents)
+	if err != nil {
+		return fmt.Errorf("updating ingredient: %w", err)
+	}
+
+	q := r.db.conn(ctx)
+	result, err := q.ExecContext(ctx, `UPDATE ingredients SET type_id = ?, name = ?, calories = ?, density = ?, contents = ?,
+		version = version + 1 WHERE id = ? AND version = ?`,
+		nullId(ingredient.TypeID), ingredient.Name, ingredient.Calories, ingredient.Density, contents, ingredient.ID, ingredient.Version)
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("ingredient type %s not found", ingredient.TypeID.String())
+	}
+	if err != nil {
+		return fmt.Errorf("updating ingredient: %w", err)
+	}
+	if err = checkUpdated(ctx, q, result, "ingredients", "ingredient", ingredient.ID, ingredient.Version); err != nil {
+		return err
+	}
+	ingredient.Version++
+	return nil
+}
+
+// DeleteById removes links and substitutions of the ingredient as well
+func (r *IngredientRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return deleteById(ctx, r.db.conn(ctx), "ingredients", "ingredient", id)
+}
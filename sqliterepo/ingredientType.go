@@ -0,0 +1,118 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+type IngredientTypeRepository struct {
+	db *DB
+}
+
+func NewIngredientTypeRepository(db *DB) domain.IIngredientTypeRepository {
+	return &IngredientTypeRepository{db: db}
+}
+
+const ingredientTypeColumns = "id, parent_id, name, description, contents, version"
+
+func scanIngredientType(row scanner) (*domain.IngredientType, error) {
+	ingredientType := &domain.IngredientType{}
+	err := row.Scan(&ingredientType.ID, nullIdScanner{&ingredientType.ParentID}, &ingredientType.Name,
+		&ingredientType.Description, listScanner{&ingredientType.Contents}, &ingredientType.Version)
+	return ingredientType, err
+}
+
+func (r *IngredientTypeRepository) Create(ctx context.Context, ingredientType *domain.IngredientType) error {
+	contents, err := listValue(ingredientType.Contents)
+	if err != nil {
+		return fmt.Errorf("creating ingredient type: %w", err)
+	}
+
+	id := newId(ingredientType.ID)
+	_, err = r.db.conn(ctx).ExecContext(ctx, "INSERT INTO ingredient_types ("+ingredientTypeColumns+") VALUES (?, ?, ?, ?, ?, 1)",
+		id, nullId(ingredientType.ParentID), ingredientType.Name, ingredientType.Description, contents)
+	switch {
+	case isPrimaryKeyViolation(err):
+		return fmt.Errorf("ingredient type %s already exists", id.String())
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("ingredient type %s not found", ingredientType.ParentID.String())
+	case err != nil:
+		return fmt.Errorf("creating ingredient type: %w", err)
+	}
+	ingredientType.ID = id
+	ingredientType.Version = 1
+	return nil
+}
+
+func (r *IngredientTypeRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.IngredientType, error) {
+	ingredientType, err := scanIngredientType(r.db.conn(ctx).QueryRowContext(ctx,
+		"SELECT "+ingredientTypeColumns+" FROM ingredient_types WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("ingredient type %s not found", id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting ingredient type: %w", err)
+	}
+	return ingredientType, nil
+}
+
+// GetAll returns types ordered by name
+func (r *IngredientTypeRepository) GetAll(ctx context.Context) ([]*domain.IngredientType, error) {
+	ingredientTypes, err := queryAll(ctx, r.db.conn(ctx), scanIngredientType,
+		"SELECT "+ingredientTypeColumns+" FROM ingredient_types")
+	if err != nil {
+		return nil, fmt.Errorf("getting ingredient types: %w", err)
+	}
+	sortByName(ingredientTypes, func(ingredientType *domain.IngredientType) string { return ingredientType.Name })
+	return ingredientTypes, nil
+}
+
+// GetAllByParentId returns subtypes ordered by name, the nil parent gives the roots
+func (r *IngredientTypeRepository) GetAllByParentId(ctx context.Context, parentId uuid.UUID) ([]*domain.IngredientType, error) {
+	ingredientTypes, err := queryAll(ctx, r.db.conn(ctx), scanIngredientType,
+		"SELECT "+ingredientTypeColumns+" FROM ingredient_types WHERE parent_id IS ?", nullId(parentId))
+	if err != nil {
+		return nil, fmt.Errorf("getting ingredient types: %w", err)
+	}
+	sortByName(ingredientTypes, func(ingredientType *domain.IngredientType) string { return ingredientType.Name })
+	return ingredientTypes, nil
+}
+
+func (r *IngredientTypeRepository) Update(ctx context.Context, ingredientType *domain.IngredientType) error {
+	contents, err := listValue(ingredientType.Contents)
+	if err != nil {
+		return fmt.Errorf("updating ingredient type: %w", err)
+	}
+
+	q := r.db.conn(ctx)
+	result, err := q.ExecContext(ctx, `UPDATE ingredient_types SET parent_id = ?, name = ?, description = ?, contents = ?,
+		version = version + 1 WHERE id = ? AND version = ?`,
+		nullId(ingredientType.ParentID), ingredientType.Name, ingredientType.Description, contents,
+		ingredientType.ID, ingredientType.Version)
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("ingredient type %s not found", ingredientType.ParentID.String())
+	}
+	if err != nil {
+		return fmt.Errorf("updating ingredient type: %w", err)
+	}
+	err = checkUpdated(ctx, q, result, "ingredient_types", "ingredient type", ingredientType.ID, ingredientType.Version)
+	if err != nil {
+		return err
+	}
+	ingredientType.Version++
+	return nil
+}
+
+// DeleteById fails with ErrTypeHasChildren while the type has subtypes,
+// ingredients of the type are left without one
+func (r *IngredientTypeRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	err := deleteById(ctx, r.db.conn(ctx), "ingredient_types", "ingredient type", id)
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("deleting ingredient type: %w", domain.ErrTypeHasChildren)
+	}
+	return err
+}
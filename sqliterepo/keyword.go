@@ -0,0 +1,88 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+type KeywordRepository struct {
+	db *DB
+}
+
+func NewKeywordRepository(db *DB) domain.IKeywordValidatorRepository {
+	return &KeywordRepository{db: db}
+}
+
+func (r *KeywordRepository) Create(ctx context.Context, word *domain.KeyWord) error {
+	id := newId(word.ID)
+	_, err := r.db.conn(ctx).ExecContext(ctx, "INSERT INTO keywords (id, word, version) VALUES (?, ?, 1)", id, word.Word)
+	if isPrimaryKeyViolation(err) || isUniqueViolation(err) {
+		return fmt.Errorf("keyword %s already exists", word.Word)
+	}
+	if err != nil {
+		return fmt.Errorf("creating keyword: %w", err)
+	}
+	word.ID = id
+	word.Version = 1
+	return nil
+}
+
+func (r *KeywordRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.KeyWord, error) {
+	word := &domain.KeyWord{}
+	err := r.db.conn(ctx).QueryRowContext(ctx, "SELECT id, word, version FROM keywords WHERE id = ?", id).
+		Scan(&word.ID, &word.Word, &word.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("keyword %s not found", id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting keyword: %w", err)
+	}
+	return word, nil
+}
+
+func (r *KeywordRepository) GetAll(ctx context.Context) (map[string]uuid.UUID, error) {
+	rows, err := r.db.conn(ctx).QueryContext(ctx, "SELECT id, word FROM keywords")
+	if err != nil {
+		return nil, fmt.Errorf("getting keywords: %w", err)
+	}
+	defer rows.Close()
+
+	words := make(map[string]uuid.UUID)
+	for rows.Next() {
+		var id uuid.UUID
+		var word string
+		if err = rows.Scan(&id, &word); err != nil {
+			return nil, fmt.Errorf("getting keywords: %w", err)
+		}
+		words[word] = id
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("getting keywords: %w", err)
+	}
+	return words, nil
+}
+
+func (r *KeywordRepository) Update(ctx context.Context, word *domain.KeyWord) error {
+	q := r.db.conn(ctx)
+	result, err := q.ExecContext(ctx, "UPDATE keywords SET word = ?, version = version + 1 WHERE id = ? AND version = ?",
+		word.Word, word.ID, word.Version)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("keyword %s already exists", word.Word)
+	}
+	if err != nil {
+		return fmt.Errorf("updating keyword: %w", err)
+	}
+	if err = checkUpdated(ctx, q, result, "keywords", "keyword", word.ID, word.Version); err != nil {
+		return err
+	}
+	word.Version++
+	return nil
+}
+
+func (r *KeywordRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return deleteById(ctx, r.db.conn(ctx), "keywords", "keyword", id)
+}
@@ -0,0 +1,144 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"math"
+)
+
+type MeasurementRepository struct {
+	db *DB
+}
+
+func NewMeasurementRepository(db *DB) domain.IMeasurementRepository {
+	return &MeasurementRepository{db: db}
+}
+
+const measurementColumns = "id, name, unit, grams, version"
+
+func scanMeasurement(row scanner) (*domain.Measurement, error) {
+	measurement := &domain.Measurement{}
+	err := row.Scan(&measurement.ID, &measurement.Name, &measurement.Unit, &measurement.Grams, &measurement.Version)
+	return measurement, err
+}
+
+func (r *MeasurementRepository) Create(ctx context.Context, measurement *domain.Measurement) error {
+	id := newId(measurement.ID)
+	_, err := r.db.conn(ctx).ExecContext(ctx, "INSERT INTO measurements ("+measurementColumns+") VALUES (?, ?, ?, ?, 1)",
+		id, measurement.Name, measurement.Unit, measurement.Grams)
+	if isPrimaryKeyViolation(err) {
+		return fmt.Errorf("measurement %s already exists", id.String())
+	}
+	if err != nil {
+		return fmt.Errorf("creating measurement: %w", err)
+	}
+	measurement.ID = id
+	measurement.Version = 1
+	return nil
+}
+
+func (r *MeasurementRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Measurement, error) {
+	measurement, err := scanMeasurement(r.db.conn(ctx).QueryRowContext(ctx,
+		"SELECT "+measurementColumns+" FROM measurements WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("measurement %s not found", id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting measurement: %w", err)
+	}
+	return measurement, nil
+}
+
+// GetByRecipeId returns the measurement of the ingredient in the recipe with the amount
+// rounded to an integer, as links of measurements are kept for compatibility
+func (r *MeasurementRepository) GetByRecipeId(ctx context.Context, ingredientId uuid.UUID, recipeId uuid.UUID) (*domain.Measurement, int, error) {
+	var measurementId uuid.NullUUID
+	var amount float64
+	measurement := &domain.Measurement{}
+	err := r.db.conn(ctx).QueryRowContext(ctx, `SELECT m.id, COALESCE(m.name, ''), COALESCE(m.unit, ''),
+		COALESCE(m.grams, 0), COALESCE(m.version, 0), ri.amount
+		FROM recipe_ingredients ri LEFT JOIN measurements m ON m.id = ri.measurement_id
+		WHERE ri.recipe_id = ? AND ri.ingredient_id = ? ORDER BY ri.position, ri.id LIMIT 1`, recipeId, ingredientId).
+		Scan(&measurementId, &measurement.Name, &measurement.Unit, &measurement.Grams, &measurement.Version, &amount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, 0, fmt.Errorf("ingredient %s isn't linked to recipe %s", ingredientId.String(), recipeId.String())
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("getting measurement of recipe: %w", err)
+	}
+	if !measurementId.Valid {
+		return nil, 0, fmt.Errorf("measurement of ingredient %s not found", ingredientId.String())
+	}
+	measurement.ID = measurementId.UUID
+	return measurement, int(math.Round(amount)), nil
+}
+
+func (r *MeasurementRepository) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeMeasurement, error) {
+	recipeMeasurements, err := queryAll(ctx, r.db.conn(ctx), func(row scanner) (*domain.RecipeMeasurement, error) {
+		measurement := &domain.Measurement{}
+		recipeMeasurement := &domain.RecipeMeasurement{Measurement: measurement}
+		var amount float64
+		err := row.Scan(&recipeMeasurement.IngredientID, &measurement.ID, &measurement.Name, &measurement.Unit,
+			&measurement.Grams, &measurement.Version, &amount)
+		recipeMeasurement.Amount = int(math.Round(amount))
+		return recipeMeasurement, err
+	}, `SELECT ri.ingredient_id, m.id, m.name, m.unit, m.grams, m.version, ri.amount
+		FROM recipe_ingredients ri JOIN measurements m ON m.id = ri.measurement_id
+		WHERE ri.recipe_id = ? ORDER BY ri.position, ri.id`, recipeId)
+	if err != nil {
+		return nil, fmt.Errorf("getting measurements of recipe: %w", err)
+	}
+	return recipeMeasurements, nil
+}
+
+// GetAll returns measurements ordered by name
+func (r *MeasurementRepository) GetAll(ctx context.Context) ([]*domain.Measurement, error) {
+	measurements, err := queryAll(ctx, r.db.conn(ctx), scanMeasurement, "SELECT "+measurementColumns+" FROM measurements")
+	if err != nil {
+		return nil, fmt.Errorf("getting measurements: %w", err)
+	}
+	sortByName(measurements, func(measurement *domain.Measurement) string { return measurement.Name })
+	return measurements, nil
+}
+
+func (r *MeasurementRepository) UpdateLink(ctx context.Context, linkId uuid.UUID, measurementId uuid.UUID, amount int) error {
+	result, err := r.db.conn(ctx).ExecContext(ctx, `UPDATE recipe_ingredients SET measurement_id = ?, amount = ?,
+		version = version + 1 WHERE id = ?`, measurementId, float64(amount), linkId)
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("measurement %s not found", measurementId.String())
+	}
+	if err != nil {
+		return fmt.Errorf("updating measurement of recipe: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("updating measurement of recipe: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("recipe ingredient %s not found", linkId.String())
+	}
+	return nil
+}
+
+func (r *MeasurementRepository) Update(ctx context.Context, measurement *domain.Measurement) error {
+	q := r.db.conn(ctx)
+	result, err := q.ExecContext(ctx, `UPDATE measurements SET name = ?, unit = ?, grams = ?, version = version + 1
+		WHERE id = ? AND version = ?`, measurement.Name, measurement.Unit, measurement.Grams, measurement.ID, measurement.Version)
+	if err != nil {
+		return fmt.Errorf("updating measurement: %w", err)
+	}
+	if err = checkUpdated(ctx, q, result, "measurements", "measurement", measurement.ID, measurement.Version); err != nil {
+		return err
+	}
+	measurement.Version++
+	return nil
+}
+
+// DeleteById leaves recipe ingredients of the measurement without one
+func (r *MeasurementRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return deleteById(ctx, r.db.conn(ctx), "measurements", "measurement", id)
+}
@@ -0,0 +1,85 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+// MediaRepository keeps descriptions of media, their content is kept by an IBlobStorage
+type MediaRepository struct {
+	db *DB
+}
+
+func NewMediaRepository(db *DB) domain.IMediaRepository {
+	return &MediaRepository{db: db}
+}
+
+const mediaColumns = "id, owner_type, owner_id, key, thumbnail_key, mime_type, size, width, height, position, version"
+
+func scanMedia(row scanner) (*domain.Media, error) {
+	media := &domain.Media{}
+	err := row.Scan(&media.ID, &media.OwnerType, &media.OwnerID, &media.Key, &media.ThumbnailKey, &media.MimeType,
+		&media.Size, &media.Width, &media.Height, &media.Order, &media.Version)
+	return media, err
+}
+
+func (r *MediaRepository) Create(ctx context.Context, media *domain.Media) (uuid.UUID, error) {
+	id := newId(media.ID)
+	_, err := r.db.conn(ctx).ExecContext(ctx, "INSERT INTO media ("+mediaColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1)",
+		id, media.OwnerType, media.OwnerID, media.Key, media.ThumbnailKey, media.MimeType,
+		media.Size, media.Width, media.Height, media.Order)
+	if isPrimaryKeyViolation(err) {
+		return uuid.Nil, fmt.Errorf("media %s already exists", id.String())
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("creating media: %w", err)
+	}
+	media.ID = id
+	media.Version = 1
+	return media.ID, nil
+}
+
+func (r *MediaRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Media, error) {
+	media, err := scanMedia(r.db.conn(ctx).QueryRowContext(ctx, "SELECT "+mediaColumns+" FROM media WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("media %s not found", id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting media: %w", err)
+	}
+	return media, nil
+}
+
+// GetAllByOwner returns media of the owner in display order
+func (r *MediaRepository) GetAllByOwner(ctx context.Context, ownerType string, ownerId uuid.UUID) ([]*domain.Media, error) {
+	media, err := queryAll(ctx, r.db.conn(ctx), scanMedia,
+		"SELECT "+mediaColumns+" FROM media WHERE owner_type = ? AND owner_id = ? ORDER BY position, id", ownerType, ownerId)
+	if err != nil {
+		return nil, fmt.Errorf("getting media: %w", err)
+	}
+	return media, nil
+}
+
+func (r *MediaRepository) Update(ctx context.Context, media *domain.Media) error {
+	q := r.db.conn(ctx)
+	result, err := q.ExecContext(ctx, `UPDATE media SET owner_type = ?, owner_id = ?, key = ?, thumbnail_key = ?,
+		mime_type = ?, size = ?, width = ?, height = ?, position = ?, version = version + 1 WHERE id = ? AND version = ?`,
+		media.OwnerType, media.OwnerID, media.Key, media.ThumbnailKey, media.MimeType,
+		media.Size, media.Width, media.Height, media.Order, media.ID, media.Version)
+	if err != nil {
+		return fmt.Errorf("updating media: %w", err)
+	}
+	if err = checkUpdated(ctx, q, result, "media", "media", media.ID, media.Version); err != nil {
+		return err
+	}
+	media.Version++
+	return nil
+}
+
+func (r *MediaRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return deleteById(ctx, r.db.conn(ctx), "media", "media", id)
+}
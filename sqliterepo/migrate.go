@@ -0,0 +1,124 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Migrations are numbered by the prefix of their file names, like 0001_core.sql.
+// Applied migrations are never changed, changes of the schema go to a new file.
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+type migration struct {
+	version int
+	name    string
+	script  string
+}
+
+func loadMigrations() ([]migration, error) {
+	files, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := make([]migration, 0, len(files))
+	for _, file := range files {
+		name := strings.TrimSuffix(strings.TrimPrefix(file, "migrations/"), ".sql")
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s isn't numbered", name)
+		}
+		script, err := migrations.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		loaded = append(loaded, migration{version: version, name: name, script: string(script)})
+	}
+
+	sort.Slice(loaded, func(i, j int) bool {
+		return loaded[i].version < loaded[j].version
+	})
+	for i := 1; i < len(loaded); i++ {
+		if loaded[i].version == loaded[i-1].version {
+			return nil, fmt.Errorf("migrations %s and %s have the same version", loaded[i-1].name, loaded[i].name)
+		}
+	}
+	return loaded, nil
+}
+
+// migrate applies migrations newer than the schema of the database, each one in
+// its own transaction together with its record in schema_migrations. The version
+// is read in the transaction, which begins as a writer (see _txlock in Open),
+// so processes migrating one database don't apply a migration twice.
+func migrate(ctx context.Context, db *sql.DB) error {
+	loaded, err := loadMigrations()
+	if err != nil {
+		return fmt.Errorf("loading migrations: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at INTEGER NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	for _, m := range loaded {
+		if err = apply(ctx, db, m); err != nil {
+			return fmt.Errorf("applying migration %s: %w", m.name, err)
+		}
+	}
+	return nil
+}
+
+func apply(ctx context.Context, db *sql.DB, m migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := schemaVersion(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if m.version <= current {
+		return nil
+	}
+
+	if _, err = tx.ExecContext(ctx, m.script); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+		m.version, m.name, time.Now().UnixNano())
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func schemaVersion(ctx context.Context, q querier) (int, error) {
+	var version int
+	err := q.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("getting schema version: %w", err)
+	}
+	return version, nil
+}
+
+// SchemaVersion returns the version of the last applied migration
+func (d *DB) SchemaVersion(ctx context.Context) (int, error) {
+	return schemaVersion(ctx, d.db)
+}
@@ -0,0 +1,130 @@
+-- Users, salads with their recipes and the catalogues recipes are made of.
+-- IDs are UUID strings, times are Unix nanoseconds in UTC with NULL for the zero time,
+-- lists of strings are JSON arrays.
+
+CREATE TABLE users (
+    id         TEXT PRIMARY KEY,
+    name       TEXT NOT NULL,
+    username   TEXT NOT NULL UNIQUE,
+    password   TEXT NOT NULL,
+    email      TEXT NOT NULL,
+    email_name TEXT NOT NULL,
+    role       TEXT NOT NULL,
+    version    INTEGER NOT NULL
+);
+
+-- authors aren't referenced, users may be kept by another service
+CREATE TABLE salads (
+    id          TEXT PRIMARY KEY,
+    author_id   TEXT NOT NULL,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL,
+    version     INTEGER NOT NULL
+);
+CREATE INDEX salads_author ON salads (author_id);
+
+CREATE TABLE recipes (
+    id                 TEXT PRIMARY KEY,
+    salad_id           TEXT NOT NULL UNIQUE REFERENCES salads (id) ON DELETE CASCADE,
+    status             INTEGER NOT NULL,
+    number_of_servings INTEGER NOT NULL,
+    time_to_cook       INTEGER NOT NULL,
+    rating             REAL NOT NULL,
+    created_at         INTEGER,
+    version            INTEGER NOT NULL
+);
+-- RecipeFilter: Status with CreatedAfter (moderation queue, newest first), MinRate,
+-- MaxTimeToCook and servings
+CREATE INDEX recipes_status_created ON recipes (status, created_at);
+CREATE INDEX recipes_rating ON recipes (rating);
+CREATE INDEX recipes_time_to_cook ON recipes (time_to_cook);
+CREATE INDEX recipes_servings ON recipes (number_of_servings);
+
+CREATE TABLE recipe_steps (
+    id          TEXT PRIMARY KEY,
+    recipe_id   TEXT NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL,
+    step_num    INTEGER NOT NULL,
+    version     INTEGER NOT NULL,
+    UNIQUE (recipe_id, step_num)
+);
+
+CREATE TABLE ingredient_types (
+    id          TEXT PRIMARY KEY,
+    parent_id   TEXT REFERENCES ingredient_types (id),
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL,
+    contents    TEXT NOT NULL,
+    version     INTEGER NOT NULL
+);
+CREATE INDEX ingredient_types_parent ON ingredient_types (parent_id);
+
+CREATE TABLE ingredients (
+    id       TEXT PRIMARY KEY,
+    type_id  TEXT REFERENCES ingredient_types (id) ON DELETE SET NULL,
+    name     TEXT NOT NULL,
+    calories INTEGER NOT NULL,
+    density  REAL NOT NULL,
+    contents TEXT NOT NULL,
+    version  INTEGER NOT NULL
+);
+CREATE INDEX ingredients_type ON ingredients (type_id);
+
+CREATE TABLE measurements (
+    id      TEXT PRIMARY KEY,
+    name    TEXT NOT NULL,
+    unit    TEXT NOT NULL,
+    grams   INTEGER NOT NULL,
+    version INTEGER NOT NULL
+);
+
+CREATE TABLE recipe_ingredients (
+    id             TEXT PRIMARY KEY,
+    recipe_id      TEXT NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    ingredient_id  TEXT NOT NULL REFERENCES ingredients (id) ON DELETE CASCADE,
+    measurement_id TEXT REFERENCES measurements (id) ON DELETE SET NULL,
+    amount         REAL NOT NULL,
+    optional       INTEGER NOT NULL,
+    note           TEXT NOT NULL,
+    position       INTEGER NOT NULL,
+    version        INTEGER NOT NULL
+);
+-- RecipeFilter: AvailableIngredients and ExcludedIngredients
+CREATE INDEX recipe_ingredients_recipe ON recipe_ingredients (recipe_id, position);
+CREATE INDEX recipe_ingredients_ingredient ON recipe_ingredients (ingredient_id);
+CREATE INDEX recipe_ingredients_measurement ON recipe_ingredients (measurement_id);
+
+CREATE TABLE salad_types (
+    id          TEXT PRIMARY KEY,
+    parent_id   TEXT REFERENCES salad_types (id),
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL,
+    version     INTEGER NOT NULL
+);
+CREATE INDEX salad_types_parent ON salad_types (parent_id);
+
+CREATE TABLE salad_type_links (
+    salad_id      TEXT NOT NULL REFERENCES salads (id) ON DELETE CASCADE,
+    salad_type_id TEXT NOT NULL REFERENCES salad_types (id) ON DELETE CASCADE,
+    PRIMARY KEY (salad_id, salad_type_id)
+);
+-- RecipeFilter: SaladTypes
+CREATE INDEX salad_type_links_type ON salad_type_links (salad_type_id);
+
+CREATE TABLE comments (
+    id        TEXT PRIMARY KEY,
+    author_id TEXT NOT NULL,
+    salad_id  TEXT NOT NULL REFERENCES salads (id) ON DELETE CASCADE,
+    text      TEXT NOT NULL,
+    rating    INTEGER NOT NULL,
+    version   INTEGER NOT NULL,
+    UNIQUE (salad_id, author_id)
+);
+CREATE INDEX comments_author ON comments (author_id);
+
+CREATE TABLE keywords (
+    id      TEXT PRIMARY KEY,
+    word    TEXT NOT NULL UNIQUE,
+    version INTEGER NOT NULL
+);
@@ -0,0 +1,109 @@
+-- Audit, substitutions, translations, media, the event outbox and webhooks
+
+CREATE TABLE audit_records (
+    id          TEXT PRIMARY KEY,
+    actor_id    TEXT NOT NULL,
+    actor_role  TEXT NOT NULL,
+    action      TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id   TEXT NOT NULL,
+    before      TEXT,
+    after       TEXT,
+    timestamp   INTEGER
+);
+CREATE INDEX audit_records_entity ON audit_records (entity_type, entity_id, timestamp);
+CREATE INDEX audit_records_actor ON audit_records (actor_id, timestamp);
+
+CREATE TABLE substitutions (
+    id            TEXT PRIMARY KEY,
+    ingredient_id TEXT NOT NULL REFERENCES ingredients (id) ON DELETE CASCADE,
+    substitute_id TEXT NOT NULL REFERENCES ingredients (id) ON DELETE CASCADE,
+    ratio         REAL NOT NULL,
+    context       TEXT NOT NULL,
+    version       INTEGER NOT NULL
+);
+CREATE INDEX substitutions_ingredient ON substitutions (ingredient_id);
+CREATE INDEX substitutions_substitute ON substitutions (substitute_id);
+
+CREATE TABLE translations (
+    id          TEXT PRIMARY KEY,
+    entity_type TEXT NOT NULL,
+    entity_id   TEXT NOT NULL,
+    locale      TEXT NOT NULL,
+    field       TEXT NOT NULL,
+    text        TEXT NOT NULL,
+    version     INTEGER NOT NULL,
+    UNIQUE (entity_type, entity_id, locale, field)
+);
+CREATE INDEX translations_locale ON translations (entity_type, locale);
+
+CREATE TABLE media (
+    id            TEXT PRIMARY KEY,
+    owner_type    TEXT NOT NULL,
+    owner_id      TEXT NOT NULL,
+    key           TEXT NOT NULL,
+    thumbnail_key TEXT NOT NULL,
+    mime_type     TEXT NOT NULL,
+    size          INTEGER NOT NULL,
+    width         INTEGER NOT NULL,
+    height        INTEGER NOT NULL,
+    position      INTEGER NOT NULL,
+    version       INTEGER NOT NULL
+);
+CREATE INDEX media_owner ON media (owner_type, owner_id, position);
+
+CREATE TABLE outbox (
+    id           TEXT PRIMARY KEY,
+    event_name   TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    payload      TEXT NOT NULL,
+    created_at   INTEGER,
+    published_at INTEGER
+);
+CREATE INDEX outbox_pending ON outbox (created_at) WHERE published_at IS NULL;
+
+CREATE TABLE webhook_subscriptions (
+    id          TEXT PRIMARY KEY,
+    url         TEXT NOT NULL,
+    event_types TEXT NOT NULL,
+    secret      TEXT NOT NULL,
+    active      INTEGER NOT NULL,
+    created_at  INTEGER,
+    version     INTEGER NOT NULL
+);
+
+-- deliveries outlive their subscriptions as the history of sent events
+CREATE TABLE webhook_deliveries (
+    id              TEXT PRIMARY KEY,
+    subscription_id TEXT NOT NULL,
+    event_type      TEXT NOT NULL,
+    payload         TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    attempts        INTEGER NOT NULL,
+    next_attempt_at INTEGER,
+    created_at      INTEGER
+);
+CREATE INDEX webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
+CREATE INDEX webhook_deliveries_subscription ON webhook_deliveries (subscription_id, created_at);
+
+CREATE TABLE webhook_attempts (
+    id          TEXT PRIMARY KEY,
+    delivery_id TEXT NOT NULL REFERENCES webhook_deliveries (id) ON DELETE CASCADE,
+    number      INTEGER NOT NULL,
+    status_code INTEGER NOT NULL,
+    error       TEXT NOT NULL,
+    duration    INTEGER NOT NULL,
+    timestamp   INTEGER
+);
+CREATE INDEX webhook_attempts_delivery ON webhook_attempts (delivery_id, number);
+
+CREATE TABLE webhook_dead_letters (
+    id              TEXT PRIMARY KEY,
+    subscription_id TEXT NOT NULL,
+    event_type      TEXT NOT NULL,
+    payload         TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    attempts        INTEGER NOT NULL,
+    next_attempt_at INTEGER,
+    created_at      INTEGER
+);
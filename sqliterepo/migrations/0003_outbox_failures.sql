@@ -0,0 +1,6 @@
+-- Outbox entries that can't be decoded are set aside instead of blocking the relay
+ALTER TABLE outbox ADD COLUMN failed_at INTEGER;
+ALTER TABLE outbox ADD COLUMN failure TEXT;
+
+DROP INDEX outbox_pending;
+CREATE INDEX outbox_pending ON outbox (created_at) WHERE published_at IS NULL AND failed_at IS NULL;
@@ -0,0 +1,84 @@
+package sqliterepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"time"
+)
+
+// EventOutbox saves entries in the transaction of the change that raised the events,
+// so events of rolled back changes are never relayed
+type EventOutbox struct {
+	db *DB
+}
+
+func NewEventOutbox(db *DB) domain.IEventOutbox {
+	return &EventOutbox{db: db}
+}
+
+func (o *EventOutbox) Save(ctx context.Context, entries ...*domain.OutboxEntry) error {
+	q := o.db.conn(ctx)
+	for _, entry := range entries {
+		_, err := q.ExecContext(ctx, `INSERT INTO outbox (id, event_name, aggregate_id, payload, created_at, published_at)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			entry.ID, entry.EventName, entry.AggregateID, string(entry.Payload), timeValue(entry.CreatedAt), publishedValue(entry.PublishedAt))
+		if isPrimaryKeyViolation(err) {
+			return fmt.Errorf("outbox entry %s already exists", entry.ID.String())
+		}
+		if err != nil {
+			return fmt.Errorf("saving outbox entry: %w", err)
+		}
+	}
+	return nil
+}
+
+func publishedValue(publishedAt *time.Time) any {
+	if publishedAt == nil {
+		return nil
+	}
+	return timeValue(*publishedAt)
+}
+
+// GetPending returns unpublished entries in the order they were saved, limit <= 0 means all of them
+func (o *EventOutbox) GetPending(ctx context.Context, limit int) ([]*domain.OutboxEntry, error) {
+	if limit <= 0 {
+		limit = -1
+	}
+	entries, err := queryAll(ctx, o.db.conn(ctx), func(row scanner) (*domain.OutboxEntry, error) {
+		entry := &domain.OutboxEntry{}
+		var payload string
+		err := row.Scan(&entry.ID, &entry.EventName, &entry.AggregateID, &payload, timeScanner{&entry.CreatedAt})
+		entry.Payload = json.RawMessage(payload)
+		return entry, err
+	}, `SELECT id, event_name, aggregate_id, payload, created_at FROM outbox
+		WHERE published_at IS NULL AND failed_at IS NULL ORDER BY created_at, rowid LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("getting pending outbox entries: %w", err)
+	}
+	return entries, nil
+}
+
+func (o *EventOutbox) MarkPublished(ctx context.Context, ids []uuid.UUID, publishedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	args := append([]any{timeValue(publishedAt)}, idArgs(ids)...)
+	_, err := o.db.conn(ctx).ExecContext(ctx,
+		"UPDATE outbox SET published_at = ? WHERE id IN ("+placeholders(len(ids))+")", args...)
+	if err != nil {
+		return fmt.Errorf("marking outbox entries published: %w", err)
+	}
+	return nil
+}
+
+func (o *EventOutbox) MarkFailed(ctx context.Context, id uuid.UUID, reason string, failedAt time.Time) error {
+	_, err := o.db.conn(ctx).ExecContext(ctx,
+		"UPDATE outbox SET failed_at = ?, failure = ? WHERE id = ?", timeValue(failedAt), reason, id)
+	if err != nil {
+		return fmt.Errorf("marking outbox entry failed: %w", err)
+	}
+	return nil
+}
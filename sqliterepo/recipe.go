@@ -0,0 +1,112 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"time"
+)
+
+type RecipeRepository struct {
+	db *DB
+}
+
+func NewRecipeRepository(db *DB) domain.IRecipeRepository {
+	return &RecipeRepository{db: db}
+}
+
+const recipeColumns = "id, salad_id, status, number_of_servings, time_to_cook, rating, created_at, version"
+
+func scanRecipe(row scanner) (*domain.Recipe, error) {
+	recipe := &domain.Recipe{}
+	var rating float64
+	err := row.Scan(&recipe.ID, &recipe.SaladID, &recipe.Status, &recipe.NumberOfServings, &recipe.TimeToCook,
+		&rating, timeScanner{&recipe.CreatedAt}, &recipe.Version)
+	recipe.Rating = float32(rating)
+	return recipe, err
+}
+
+// Create allows one recipe per salad
+func (r *RecipeRepository) Create(ctx context.Context, recipe *domain.Recipe) (uuid.UUID, error) {
+	id := newId(recipe.ID)
+	createdAt := time.Now().UTC()
+	_, err := r.db.conn(ctx).ExecContext(ctx, "INSERT INTO recipes ("+recipeColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, 1)",
+		id, recipe.SaladID, recipe.Status, recipe.NumberOfServings, recipe.TimeToCook, float64(recipe.Rating), timeValue(createdAt))
+	switch {
+	case isPrimaryKeyViolation(err):
+		return uuid.Nil, fmt.Errorf("recipe %s already exists", id.String())
+	case isUniqueViolation(err):
+		return uuid.Nil, fmt.Errorf("salad %s already has a recipe", recipe.SaladID.String())
+	case isForeignKeyViolation(err):
+		return uuid.Nil, fmt.Errorf("salad %s not found", recipe.SaladID.String())
+	case err != nil:
+		return uuid.Nil, fmt.Errorf("creating recipe: %w", err)
+	}
+	recipe.ID = id
+	recipe.CreatedAt = createdAt
+	recipe.Version = 1
+	return recipe.ID, nil
+}
+
+func (r *RecipeRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Recipe, error) {
+	recipe, err := scanRecipe(r.db.conn(ctx).QueryRowContext(ctx, "SELECT "+recipeColumns+" FROM recipes WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("recipe %s not found", id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe: %w", err)
+	}
+	return recipe, nil
+}
+
+func (r *RecipeRepository) GetBySaladId(ctx context.Context, saladId uuid.UUID) (*domain.Recipe, error) {
+	recipe, err := scanRecipe(r.db.conn(ctx).QueryRowContext(ctx, "SELECT "+recipeColumns+" FROM recipes WHERE salad_id = ?", saladId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("recipe of salad %s not found", saladId.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe: %w", err)
+	}
+	return recipe, nil
+}
+
+func (r *RecipeRepository) GetAll(ctx context.Context, filter *domain.RecipeFilter, page *domain.PageRequest) ([]*domain.Recipe, *domain.PageInfo, error) {
+	matched, info, err := queryRecipes(ctx, r.db.conn(ctx), filter, page, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting recipes: %w", err)
+	}
+	recipes := make([]*domain.Recipe, 0, len(matched))
+	for _, candidate := range matched {
+		recipes = append(recipes, candidate.Recipe)
+	}
+	return recipes, info, nil
+}
+
+// Update keeps the creation time and the salad of the recipe
+func (r *RecipeRepository) Update(ctx context.Context, recipe *domain.Recipe) error {
+	q := r.db.conn(ctx)
+	var saladId uuid.UUID
+	var createdAt time.Time
+	err := q.QueryRowContext(ctx, `UPDATE recipes SET status = ?, number_of_servings = ?, time_to_cook = ?, rating = ?,
+		version = version + 1 WHERE id = ? AND version = ? RETURNING salad_id, created_at`,
+		recipe.Status, recipe.NumberOfServings, recipe.TimeToCook, float64(recipe.Rating), recipe.ID, recipe.Version).
+		Scan(&saladId, timeScanner{&createdAt})
+	if errors.Is(err, sql.ErrNoRows) {
+		return notUpdated(ctx, q, "recipes", "recipe", recipe.ID, recipe.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("updating recipe: %w", err)
+	}
+	recipe.SaladID = saladId
+	recipe.CreatedAt = createdAt
+	recipe.Version++
+	return nil
+}
+
+// DeleteById removes steps and ingredients of the recipe as well
+func (r *RecipeRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return deleteById(ctx, r.db.conn(ctx), "recipes", "recipe", id)
+}
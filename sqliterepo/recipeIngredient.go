@@ -0,0 +1,125 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+type RecipeIngredientRepository struct {
+	db *DB
+}
+
+func NewRecipeIngredientRepository(db *DB) domain.IRecipeIngredientRepository {
+	return &RecipeIngredientRepository{db: db}
+}
+
+const recipeIngredientColumns = "id, recipe_id, ingredient_id, measurement_id, amount, optional, note, position, version"
+
+func scanRecipeIngredient(row scanner) (*domain.RecipeIngredient, error) {
+	link := &domain.RecipeIngredient{}
+	err := row.Scan(&link.ID, &link.RecipeID, &link.IngredientID, nullIdScanner{&link.MeasurementID},
+		&link.Amount, &link.Optional, &link.Note, &link.Order, &link.Version)
+	return link, err
+}
+
+// missingReference tells which of the entities a recipe ingredient refers to is missing
+func missingReference(ctx context.Context, q querier, link *domain.RecipeIngredient) error {
+	var ingredient, recipe, measurement bool
+	err := q.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM ingredients WHERE id = ?),
+		EXISTS (SELECT 1 FROM recipes WHERE id = ?), EXISTS (SELECT 1 FROM measurements WHERE id = ?)`,
+		link.IngredientID, link.RecipeID, link.MeasurementID).Scan(&ingredient, &recipe, &measurement)
+	switch {
+	case err != nil:
+		return err
+	case !ingredient:
+		return fmt.Errorf("ingredient %s not found", link.IngredientID.String())
+	case !recipe:
+		return fmt.Errorf("recipe %s not found", link.RecipeID.String())
+	case !measurement && link.MeasurementID != uuid.Nil:
+		return fmt.Errorf("measurement %s not found", link.MeasurementID.String())
+	}
+	return nil
+}
+
+func (r *RecipeIngredientRepository) Create(ctx context.Context, recipeIngredient *domain.RecipeIngredient) (uuid.UUID, error) {
+	q := r.db.conn(ctx)
+	id := newId(recipeIngredient.ID)
+	_, err := q.ExecContext(ctx, "INSERT INTO recipe_ingredients ("+recipeIngredientColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, 1)",
+		id, recipeIngredient.RecipeID, recipeIngredient.IngredientID, nullId(recipeIngredient.MeasurementID),
+		recipeIngredient.Amount, recipeIngredient.Optional, recipeIngredient.Note, recipeIngredient.Order)
+	if isPrimaryKeyViolation(err) {
+		return uuid.Nil, fmt.Errorf("recipe ingredient %s already exists", id.String())
+	}
+	if isForeignKeyViolation(err) {
+		if missing := missingReference(ctx, q, recipeIngredient); missing != nil {
+			return uuid.Nil, missing
+		}
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("creating recipe ingredient: %w", err)
+	}
+	recipeIngredient.ID = id
+	recipeIngredient.Version = 1
+	return recipeIngredient.ID, nil
+}
+
+func (r *RecipeIngredientRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.RecipeIngredient, error) {
+	link, err := scanRecipeIngredient(r.db.conn(ctx).QueryRowContext(ctx,
+		"SELECT "+recipeIngredientColumns+" FROM recipe_ingredients WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("recipe ingredient %s not found", id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe ingredient: %w", err)
+	}
+	return link, nil
+}
+
+// GetAllByRecipeId returns ingredients of the recipe in display order
+func (r *RecipeIngredientRepository) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeIngredient, error) {
+	links, err := queryAll(ctx, r.db.conn(ctx), scanRecipeIngredient,
+		"SELECT "+recipeIngredientColumns+" FROM recipe_ingredients WHERE recipe_id = ? ORDER BY position, id", recipeId)
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe ingredients: %w", err)
+	}
+	return links, nil
+}
+
+func (r *RecipeIngredientRepository) Update(ctx context.Context, recipeIngredient *domain.RecipeIngredient) error {
+	q := r.db.conn(ctx)
+	result, err := q.ExecContext(ctx, `UPDATE recipe_ingredients SET recipe_id = ?, ingredient_id = ?, measurement_id = ?,
+		amount = ?, optional = ?, note = ?, position = ?, version = version + 1 WHERE id = ? AND version = ?`,
+		recipeIngredient.RecipeID, recipeIngredient.IngredientID, nullId(recipeIngredient.MeasurementID),
+		recipeIngredient.Amount, recipeIngredient.Optional, recipeIngredient.Note, recipeIngredient.Order,
+		recipeIngredient.ID, recipeIngredient.Version)
+	if isForeignKeyViolation(err) {
+		if missing := missingReference(ctx, q, recipeIngredient); missing != nil {
+			return missing
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("updating recipe ingredient: %w", err)
+	}
+	err = checkUpdated(ctx, q, result, "recipe_ingredients", "recipe ingredient", recipeIngredient.ID, recipeIngredient.Version)
+	if err != nil {
+		return err
+	}
+	recipeIngredient.Version++
+	return nil
+}
+
+func (r *RecipeIngredientRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return deleteById(ctx, r.db.conn(ctx), "recipe_ingredients", "recipe ingredient", id)
+}
+
+func (r *RecipeIngredientRepository) DeleteAllByRecipeId(ctx context.Context, recipeId uuid.UUID) error {
+	_, err := r.db.conn(ctx).ExecContext(ctx, "DELETE FROM recipe_ingredients WHERE recipe_id = ?", recipeId)
+	if err != nil {
+		return fmt.Errorf("deleting recipe ingredients: %w", err)
+	}
+	return nil
+}
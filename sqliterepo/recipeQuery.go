@@ -0,0 +1,298 @@
+package sqliterepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"math"
+	"strings"
+	"time"
+)
+
+// queryRecipes pages salads with their recipes by the filter. Columns of salads and
+// recipes are filtered, ordered and paged in SQL. Calories, salad types and diets
+// are computed from ingredients and the type tree, a query with them loads
+// the candidates and evaluates it with domain.QueryRecipes.
+func queryRecipes(ctx context.Context, q querier, filter *domain.RecipeFilter, page *domain.PageRequest,
+	withoutRecipe bool) ([]*domain.RecipeCandidate, *domain.PageInfo, error) {
+	if !pagedInSQL(filter, page) {
+		candidates, err := queryCandidates(ctx, q, filter, withoutRecipe)
+		if err != nil {
+			return nil, nil, err
+		}
+		return domain.QueryRecipes(candidates, filter, page)
+	}
+
+	if err := filter.Validate(math.MaxInt); err != nil {
+		return nil, nil, err
+	}
+	page, err := page.Normalize(domain.RepositoryPageSizes, domain.RecipeSorts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	cursor, _ := page.DecodeCursor()
+
+	conditions, args := recipeConditions(filter, withoutRecipe)
+	selection := selectionOf(conditions)
+	info := &domain.PageInfo{Size: page.Size}
+	err = q.QueryRowContext(ctx, "SELECT COUNT(*) "+selection, args...).Scan(&info.Total)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	const id = "COALESCE(r.id, s.id)"
+	key, keyArgs := recipeSortColumn(page.Sort)
+	order, compare := " ASC", ">"
+	if page.Desc {
+		order, compare = " DESC", "<"
+	}
+
+	if cursor != nil {
+		value, err := recipeSortValue(page.Sort, cursor.Key)
+		if err != nil {
+			return nil, nil, err
+		}
+		if key == "" {
+			conditions = append(conditions, id+" "+compare+" ?")
+			args = append(args, cursor.ID)
+		} else {
+			conditions = append(conditions, fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND %[3]s %[2]s ?))", key, compare, id))
+			args = append(args, keyArgs...)
+			args = append(args, value)
+			args = append(args, keyArgs...)
+			args = append(args, value, cursor.ID)
+		}
+	}
+	selection = selectionOf(conditions)
+
+	orderBy := id + order
+	if key != "" {
+		orderBy = key + order + ", " + orderBy
+		args = append(args, keyArgs...)
+	}
+	query := `SELECT s.id, s.author_id, s.name, s.description, s.version,
+		COALESCE(r.id, s.id), COALESCE(r.status, 0), COALESCE(r.number_of_servings, 0), COALESCE(r.time_to_cook, 0),
+		COALESCE(r.rating, 0), r.created_at, COALESCE(r.version, 0) ` + selection + " ORDER BY " + orderBy + " LIMIT ?"
+	args = append(args, page.Size+1)
+
+	candidates, err := queryAll(ctx, q, scanCandidate, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(candidates) > page.Size {
+		candidates = candidates[:page.Size]
+		last := candidates[len(candidates)-1]
+		lastKey, lastId := domain.RecipeSortKey(last, page.Sort)
+		info.NextCursor = domain.EncodeCursor(&domain.Cursor{Sort: page.Sort, Desc: page.Desc, Key: lastKey, ID: lastId})
+	}
+	return candidates, info, nil
+}
+
+// pagedInSQL reports whether the query needs nothing computed in Go
+func pagedInSQL(filter *domain.RecipeFilter, page *domain.PageRequest) bool {
+	if page != nil && page.Sort == domain.SortByCalories {
+		return false
+	}
+	return filter == nil || (filter.MinCalories == 0 && filter.MaxCalories == 0 &&
+		len(filter.SaladTypes) == 0 && len(filter.Diets) == 0)
+}
+
+// recipeSortColumn is the column ordered by the sort, the same order as
+// domain.RecipeSortKey gives. Salads without a recipe sort as its zero values.
+func recipeSortColumn(sort string) (string, []any) {
+	switch sort {
+	case domain.SortByName:
+		return "s.name", nil
+	case domain.SortByRating:
+		return "COALESCE(r.rating, 0)", nil
+	case domain.SortByTimeToCook:
+		return "COALESCE(r.time_to_cook, 0)", nil
+	case domain.SortByCreatedAt:
+		return "COALESCE(r.created_at, ?)", []any{time.Time{}.UnixNano()}
+	}
+	return "", nil
+}
+
+// recipeSortValue decodes the key of a cursor to the value of recipeSortColumn
+func recipeSortValue(sort string, key string) (any, error) {
+	switch sort {
+	case domain.SortByRating:
+		return domain.ParseSortKeyFloat(key)
+	case domain.SortByTimeToCook:
+		value, err := domain.ParseSortKeyFloat(key)
+		return int64(value), err
+	case domain.SortByCreatedAt:
+		return domain.ParseSortKeyTime(key)
+	}
+	return key, nil
+}
+
+// recipeSelection selects salads with their recipes by the predicates of RecipeFilter
+// on columns, which use the indexes of recipes and recipe_ingredients. Calories, salad
+// types and diets are left to domain.QueryRecipes, which checks the whole filter again.
+// Without withoutRecipe salads without a recipe are skipped, otherwise they get
+// an empty one with the ID of the salad.
+func recipeSelection(filter *domain.RecipeFilter, withoutRecipe bool) (string, []any) {
+	conditions, args := recipeConditions(filter, withoutRecipe)
+	return selectionOf(conditions), args
+}
+
+func recipeConditions(filter *domain.RecipeFilter, withoutRecipe bool) ([]string, []any) {
+	var conditions []string
+	var args []any
+	add := func(condition string, values ...any) {
+		conditions = append(conditions, condition)
+		args = append(args, values...)
+	}
+
+	if !withoutRecipe {
+		add("r.id IS NOT NULL")
+	}
+	if filter != nil {
+		if filter.Status != 0 {
+			add("COALESCE(r.status, 0) = ?", filter.Status)
+		}
+		if filter.MinRate > 0 {
+			add("COALESCE(r.rating, 0) >= ?", filter.MinRate)
+		}
+		if filter.MaxTimeToCook > 0 {
+			add("COALESCE(r.time_to_cook, 0) <= ?", filter.MaxTimeToCook)
+		}
+		if filter.MinServings > 0 {
+			add("COALESCE(r.number_of_servings, 0) >= ?", filter.MinServings)
+		}
+		if filter.MaxServings > 0 {
+			add("COALESCE(r.number_of_servings, 0) <= ?", filter.MaxServings)
+		}
+		if !filter.CreatedAfter.IsZero() {
+			add("r.created_at > ?", timeValue(filter.CreatedAfter))
+		}
+		if filter.AuthorID != uuid.Nil {
+			add("s.author_id = ?", filter.AuthorID)
+		}
+		if len(filter.ExcludedIngredients) != 0 {
+			add(`NOT EXISTS (SELECT 1 FROM recipe_ingredients ri
+				WHERE ri.recipe_id = r.id AND ri.ingredient_id IN (`+placeholders(len(filter.ExcludedIngredients))+`))`,
+				idArgs(filter.ExcludedIngredients)...)
+		}
+		if len(filter.AvailableIngredients) != 0 {
+			add(`NOT EXISTS (SELECT 1 FROM recipe_ingredients ri
+				WHERE ri.recipe_id = r.id AND ri.optional = 0
+				AND ri.ingredient_id NOT IN (`+placeholders(len(filter.AvailableIngredients))+`))`,
+				idArgs(filter.AvailableIngredients)...)
+		}
+	}
+
+	return conditions, args
+}
+
+func selectionOf(conditions []string) string {
+	selection := "FROM salads s LEFT JOIN recipes r ON r.salad_id = s.id"
+	if len(conditions) != 0 {
+		selection += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	return selection
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func idArgs(ids []uuid.UUID) []any {
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	return args
+}
+
+// queryCandidates loads what RecipeFilter looks at for the salads of recipeSelection
+func queryCandidates(ctx context.Context, q querier, filter *domain.RecipeFilter, withoutRecipe bool) ([]*domain.RecipeCandidate, error) {
+	selection, args := recipeSelection(filter, withoutRecipe)
+
+	candidates, err := queryAll(ctx, q, scanCandidate, `SELECT s.id, s.author_id, s.name, s.description, s.version,
+		COALESCE(r.id, s.id), COALESCE(r.status, 0), COALESCE(r.number_of_servings, 0), COALESCE(r.time_to_cook, 0),
+		COALESCE(r.rating, 0), r.created_at, COALESCE(r.version, 0) `+selection, args...)
+	if err != nil || len(candidates) == 0 {
+		return candidates, err
+	}
+
+	ingredients := make(map[uuid.UUID][]*domain.SaladIngredient)
+	rows, err := q.QueryContext(ctx, `SELECT ri.recipe_id, i.id, i.type_id, i.name, i.calories, i.density, i.contents, i.version,
+		m.id, COALESCE(m.name, ''), COALESCE(m.unit, ''), COALESCE(m.grams, 0), COALESCE(m.version, 0),
+		ri.amount, ri.optional, ri.note
+		FROM recipe_ingredients ri JOIN ingredients i ON i.id = ri.ingredient_id
+		LEFT JOIN measurements m ON m.id = ri.measurement_id
+		WHERE ri.recipe_id IN (SELECT r.id `+selection+`) ORDER BY ri.recipe_id, ri.position, ri.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var recipeId uuid.UUID
+		var measurementId uuid.NullUUID
+		ingredient := &domain.Ingredient{}
+		measurement := &domain.Measurement{}
+		item := &domain.SaladIngredient{Ingredient: ingredient}
+		err = rows.Scan(&recipeId, &ingredient.ID, nullIdScanner{&ingredient.TypeID}, &ingredient.Name, &ingredient.Calories,
+			&ingredient.Density, listScanner{&ingredient.Contents}, &ingredient.Version,
+			&measurementId, &measurement.Name, &measurement.Unit, &measurement.Grams, &measurement.Version,
+			&item.Amount, &item.Optional, &item.Note)
+		if err != nil {
+			return nil, err
+		}
+		if measurementId.Valid {
+			measurement.ID = measurementId.UUID
+			item.Measurement = measurement
+		}
+		ingredients[recipeId] = append(ingredients[recipeId], item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	links, err := queryAll(ctx, q, func(row scanner) ([2]uuid.UUID, error) {
+		var link [2]uuid.UUID
+		err := row.Scan(&link[0], &link[1])
+		return link, err
+	}, "SELECT salad_id, salad_type_id FROM salad_type_links WHERE salad_id IN (SELECT s.id "+selection+")", args...)
+	if err != nil {
+		return nil, err
+	}
+	typeIds := make(map[uuid.UUID][]uuid.UUID)
+	for _, link := range links {
+		typeIds[link[0]] = append(typeIds[link[0]], link[1])
+	}
+
+	saladTypes, err := queryAll(ctx, q, scanSaladType, "SELECT "+saladTypeColumns+" FROM salad_types")
+	if err != nil {
+		return nil, err
+	}
+	ingredientTypes, err := queryAll(ctx, q, scanIngredientType, "SELECT "+ingredientTypeColumns+" FROM ingredient_types")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		candidate.Ingredients = ingredients[candidate.Recipe.ID]
+		if candidate.Ingredients == nil {
+			candidate.Ingredients = make([]*domain.SaladIngredient, 0)
+		}
+		candidate.TypeIDs = domain.SaladTypeAncestors(typeIds[candidate.Salad.ID], saladTypes)
+		candidate.IngredientTypes = ingredientTypes
+	}
+	return candidates, nil
+}
+
+func scanCandidate(row scanner) (*domain.RecipeCandidate, error) {
+	salad := &domain.Salad{}
+	recipe := &domain.Recipe{}
+	var rating float64
+	err := row.Scan(&salad.ID, &salad.AuthorID, &salad.Name, &salad.Description, &salad.Version,
+		&recipe.ID, &recipe.Status, &recipe.NumberOfServings, &recipe.TimeToCook,
+		&rating, timeScanner{&recipe.CreatedAt}, &recipe.Version)
+	recipe.SaladID = salad.ID
+	recipe.Rating = float32(rating)
+	return &domain.RecipeCandidate{Salad: salad, Recipe: recipe}, err
+}
@@ -0,0 +1,98 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+type RecipeStepRepository struct {
+	db *DB
+}
+
+func NewRecipeStepRepository(db *DB) domain.IRecipeStepRepository {
+	return &RecipeStepRepository{db: db}
+}
+
+const recipeStepColumns = "id, recipe_id, name, description, step_num, version"
+
+func scanRecipeStep(row scanner) (*domain.RecipeStep, error) {
+	step := &domain.RecipeStep{}
+	err := row.Scan(&step.ID, &step.RecipeID, &step.Name, &step.Description, &step.StepNum, &step.Version)
+	return step, err
+}
+
+func (r *RecipeStepRepository) Create(ctx context.Context, recipeStep *domain.RecipeStep) error {
+	id := newId(recipeStep.ID)
+	_, err := r.db.conn(ctx).ExecContext(ctx, "INSERT INTO recipe_steps ("+recipeStepColumns+") VALUES (?, ?, ?, ?, ?, 1)",
+		id, recipeStep.RecipeID, recipeStep.Name, recipeStep.Description, recipeStep.StepNum)
+	switch {
+	case isPrimaryKeyViolation(err):
+		return fmt.Errorf("recipe step %s already exists", id.String())
+	case isUniqueViolation(err):
+		return fmt.Errorf("step %d of recipe %s already exists", recipeStep.StepNum, recipeStep.RecipeID.String())
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("recipe %s not found", recipeStep.RecipeID.String())
+	case err != nil:
+		return fmt.Errorf("creating recipe step: %w", err)
+	}
+	recipeStep.ID = id
+	recipeStep.Version = 1
+	return nil
+}
+
+func (r *RecipeStepRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.RecipeStep, error) {
+	step, err := scanRecipeStep(r.db.conn(ctx).QueryRowContext(ctx, "SELECT "+recipeStepColumns+" FROM recipe_steps WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("recipe step %s not found", id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe step: %w", err)
+	}
+	return step, nil
+}
+
+// GetAllByRecipeID returns steps ordered by StepNum
+func (r *RecipeStepRepository) GetAllByRecipeID(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeStep, error) {
+	steps, err := queryAll(ctx, r.db.conn(ctx), scanRecipeStep,
+		"SELECT "+recipeStepColumns+" FROM recipe_steps WHERE recipe_id = ? ORDER BY step_num, id", recipeId)
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe steps: %w", err)
+	}
+	return steps, nil
+}
+
+func (r *RecipeStepRepository) Update(ctx context.Context, recipeStep *domain.RecipeStep) error {
+	q := r.db.conn(ctx)
+	result, err := q.ExecContext(ctx, `UPDATE recipe_steps SET recipe_id = ?, name = ?, description = ?, step_num = ?,
+		version = version + 1 WHERE id = ? AND version = ?`,
+		recipeStep.RecipeID, recipeStep.Name, recipeStep.Description, recipeStep.StepNum, recipeStep.ID, recipeStep.Version)
+	switch {
+	case isUniqueViolation(err):
+		return fmt.Errorf("step %d of recipe %s already exists", recipeStep.StepNum, recipeStep.RecipeID.String())
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("recipe %s not found", recipeStep.RecipeID.String())
+	case err != nil:
+		return fmt.Errorf("updating recipe step: %w", err)
+	}
+	if err = checkUpdated(ctx, q, result, "recipe_steps", "recipe step", recipeStep.ID, recipeStep.Version); err != nil {
+		return err
+	}
+	recipeStep.Version++
+	return nil
+}
+
+func (r *RecipeStepRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return deleteById(ctx, r.db.conn(ctx), "recipe_steps", "recipe step", id)
+}
+
+func (r *RecipeStepRepository) DeleteAllByRecipeID(ctx context.Context, recipeId uuid.UUID) error {
+	_, err := r.db.conn(ctx).ExecContext(ctx, "DELETE FROM recipe_steps WHERE recipe_id = ?", recipeId)
+	if err != nil {
+		return fmt.Errorf("deleting recipe steps: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"strings"
+)
+
+type SaladRepository struct {
+	db *DB
+}
+
+func NewSaladRepository(db *DB) domain.ISaladRepository {
+	return &SaladRepository{db: db}
+}
+
+const saladColumns = "id, author_id, name, description, version"
+
+func scanSalad(row scanner) (*domain.Salad, error) {
+	salad := &domain.Salad{}
+	err := row.Scan(&salad.ID, &salad.AuthorID, &salad.Name, &salad.Description, &salad.Version)
+	return salad, err
+}
+
+func (r *SaladRepository) Create(ctx context.Context, salad *domain.Salad) (uuid.UUID, error) {
+	id := newId(salad.ID)
+	_, err := r.db.conn(ctx).ExecContext(ctx, "INSERT INTO salads ("+saladColumns+") VALUES (?, ?, ?, ?, 1)",
+		id, salad.AuthorID, salad.Name, salad.Description)
+	if isPrimaryKeyViolation(err) {
+		return uuid.Nil, fmt.Errorf("salad %s already exists", id.String())
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("creating salad: %w", err)
+	}
+	salad.ID = id
+	salad.Version = 1
+	return salad.ID, nil
+}
+
+func (r *SaladRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Salad, error) {
+	salad, err := scanSalad(r.db.conn(ctx).QueryRowContext(ctx, "SELECT "+saladColumns+" FROM salads WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("salad %s not found", id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting salad: %w", err)
+	}
+	return salad, nil
+}
+
+// GetAll matches salads without a recipe as salads with an empty one
+func (r *SaladRepository) GetAll(ctx context.Context, filter *domain.RecipeFilter, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	matched, info, err := queryRecipes(ctx, r.db.conn(ctx), filter, page, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting salads: %w", err)
+	}
+	salads := make([]*domain.Salad, 0, len(matched))
+	for _, candidate := range matched {
+		salads = append(salads, candidate.Salad)
+	}
+	return salads, info, nil
+}
+
+// saladNameKey orders salads by name ignoring case, SQLite lower() folds only ASCII
+func saladNameKey(salad *domain.Salad, sort string) (string, uuid.UUID) {
+	return strings.ToLower(salad.Name), salad.ID
+}
+
+// GetAllByUserId orders salads of the author by name
+func (r *SaladRepository) GetAllByUserId(ctx context.Context, id uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	salads, err := queryAll(ctx, r.db.conn(ctx), scanSalad,
+		"SELECT "+saladColumns+" FROM salads WHERE author_id = ?", id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting salads of user: %w", err)
+	}
+	return domain.Paginate(salads, page, saladNameKey)
+}
+
+// GetAllRatedByUser returns salads the user commented on
+func (r *SaladRepository) GetAllRatedByUser(ctx context.Context, userId uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	salads, err := queryAll(ctx, r.db.conn(ctx), scanSalad, `SELECT s.id, s.author_id, s.name, s.description, s.version
+		FROM salads s JOIN comments c ON c.salad_id = s.id WHERE c.author_id = ?`, userId)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting salads rated by user: %w", err)
+	}
+
+	return domain.Paginate(salads, page, saladNameKey)
+}
+
+func (r *SaladRepository) Update(ctx context.Context, salad *domain.Salad) error {
+	q := r.db.conn(ctx)
+	result, err := q.ExecContext(ctx, `UPDATE salads SET author_id = ?, name = ?, description = ?, version = version + 1
+		WHERE id = ? AND version = ?`, salad.AuthorID, salad.Name, salad.Description, salad.ID, salad.Version)
+	if err != nil {
+		return fmt.Errorf("updating salad: %w", err)
+	}
+	if err = checkUpdated(ctx, q, result, "salads", "salad", salad.ID, salad.Version); err != nil {
+		return err
+	}
+	salad.Version++
+	return nil
+}
+
+// DeleteById removes the recipe, comments and type links of the salad as well
+func (r *SaladRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return deleteById(ctx, r.db.conn(ctx), "salads", "salad", id)
+}
@@ -0,0 +1,150 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"strings"
+)
+
+type SaladTypeRepository struct {
+	db *DB
+}
+
+func NewSaladTypeRepository(db *DB) domain.ISaladTypeRepository {
+	return &SaladTypeRepository{db: db}
+}
+
+const saladTypeColumns = "id, parent_id, name, description, version"
+
+func scanSaladType(row scanner) (*domain.SaladType, error) {
+	saladType := &domain.SaladType{}
+	err := row.Scan(&saladType.ID, nullIdScanner{&saladType.ParentID}, &saladType.Name, &saladType.Description, &saladType.Version)
+	return saladType, err
+}
+
+func (r *SaladTypeRepository) Create(ctx context.Context, saladType *domain.SaladType) error {
+	id := newId(saladType.ID)
+	_, err := r.db.conn(ctx).ExecContext(ctx, "INSERT INTO salad_types ("+saladTypeColumns+") VALUES (?, ?, ?, ?, 1)",
+		id, nullId(saladType.ParentID), saladType.Name, saladType.Description)
+	switch {
+	case isPrimaryKeyViolation(err):
+		return fmt.Errorf("salad type %s already exists", id.String())
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("salad type %s not found", saladType.ParentID.String())
+	case err != nil:
+		return fmt.Errorf("creating salad type: %w", err)
+	}
+	saladType.ID = id
+	saladType.Version = 1
+	return nil
+}
+
+func (r *SaladTypeRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.SaladType, error) {
+	saladType, err := scanSaladType(r.db.conn(ctx).QueryRowContext(ctx, "SELECT "+saladTypeColumns+" FROM salad_types WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("salad type %s not found", id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting salad type: %w", err)
+	}
+	return saladType, nil
+}
+
+func (r *SaladTypeRepository) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.SaladType, *domain.PageInfo, error) {
+	saladTypes, err := r.query(ctx, "SELECT "+saladTypeColumns+" FROM salad_types")
+	if err != nil {
+		return nil, nil, err
+	}
+	return domain.Paginate(saladTypes, page, func(saladType *domain.SaladType, sort string) (string, uuid.UUID) {
+		return strings.ToLower(saladType.Name), saladType.ID
+	})
+}
+
+func (r *SaladTypeRepository) GetAllBySaladId(ctx context.Context, saladId uuid.UUID) ([]*domain.SaladType, error) {
+	return r.query(ctx, `SELECT t.id, t.parent_id, t.name, t.description, t.version
+		FROM salad_type_links l JOIN salad_types t ON t.id = l.salad_type_id WHERE l.salad_id = ?`, saladId)
+}
+
+func (r *SaladTypeRepository) GetAllByParentId(ctx context.Context, parentId uuid.UUID) ([]*domain.SaladType, error) {
+	return r.query(ctx, "SELECT "+saladTypeColumns+" FROM salad_types WHERE parent_id IS ?", nullId(parentId))
+}
+
+// query returns matching types ordered by name
+func (r *SaladTypeRepository) query(ctx context.Context, query string, args ...any) ([]*domain.SaladType, error) {
+	saladTypes, err := queryAll(ctx, r.db.conn(ctx), scanSaladType, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("getting salad types: %w", err)
+	}
+	sortByName(saladTypes, func(saladType *domain.SaladType) string { return saladType.Name })
+	return saladTypes, nil
+}
+
+func (r *SaladTypeRepository) Update(ctx context.Context, saladType *domain.SaladType) error {
+	q := r.db.conn(ctx)
+	result, err := q.ExecContext(ctx, `UPDATE salad_types SET parent_id = ?, name = ?, description = ?, version = version + 1
+		WHERE id = ? AND version = ?`,
+		nullId(saladType.ParentID), saladType.Name, saladType.Description, saladType.ID, saladType.Version)
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("salad type %s not found", saladType.ParentID.String())
+	}
+	if err != nil {
+		return fmt.Errorf("updating salad type: %w", err)
+	}
+	if err = checkUpdated(ctx, q, result, "salad_types", "salad type", saladType.ID, saladType.Version); err != nil {
+		return err
+	}
+	saladType.Version++
+	return nil
+}
+
+func (r *SaladTypeRepository) Link(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) error {
+	q := r.db.conn(ctx)
+	_, err := q.ExecContext(ctx, "INSERT INTO salad_type_links (salad_id, salad_type_id) VALUES (?, ?)", saladId, saladTypeId)
+	if isPrimaryKeyViolation(err) {
+		return fmt.Errorf("salad type %s already linked to salad %s", saladTypeId.String(), saladId.String())
+	}
+	if isForeignKeyViolation(err) {
+		var found bool
+		if err := q.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM salad_types WHERE id = ?)", saladTypeId).Scan(&found); err != nil {
+			return fmt.Errorf("linking salad type: %w", err)
+		}
+		if !found {
+			return fmt.Errorf("salad type %s not found", saladTypeId.String())
+		}
+		return fmt.Errorf("salad %s not found", saladId.String())
+	}
+	if err != nil {
+		return fmt.Errorf("linking salad type: %w", err)
+	}
+	return nil
+}
+
+func (r *SaladTypeRepository) Unlink(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) error {
+	result, err := r.db.conn(ctx).ExecContext(ctx, "DELETE FROM salad_type_links WHERE salad_id = ? AND salad_type_id = ?",
+		saladId, saladTypeId)
+	if err != nil {
+		return fmt.Errorf("unlinking salad type: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unlinking salad type: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("salad type %s isn't linked to salad %s", saladTypeId.String(), saladId.String())
+	}
+	return nil
+}
+
+// DeleteById removes links of the type as well, it fails with ErrTypeHasChildren
+// while the type has subtypes
+func (r *SaladTypeRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	err := deleteById(ctx, r.db.conn(ctx), "salad_types", "salad type", id)
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("deleting salad type: %w", domain.ErrTypeHasChildren)
+	}
+	return err
+}
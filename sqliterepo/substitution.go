@@ -0,0 +1,109 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+)
+
+type SubstitutionRepository struct {
+	db *DB
+}
+
+func NewSubstitutionRepository(db *DB) domain.ISubstitutionRepository {
+	return &SubstitutionRepository{db: db}
+}
+
+const substitutionColumns = "id, ingredient_id, substitute_id, ratio, context, version"
+
+func scanSubstitution(row scanner) (*domain.Substitution, error) {
+	substitution := &domain.Substitution{}
+	err := row.Scan(&substitution.ID, &substitution.IngredientID, &substitution.SubstituteID,
+		&substitution.Ratio, &substitution.Context, &substitution.Version)
+	return substitution, err
+}
+
+// missingIngredient tells which ingredient of the substitution is missing
+func missingIngredient(ctx context.Context, q querier, substitution *domain.Substitution) error {
+	for _, id := range []uuid.UUID{substitution.IngredientID, substitution.SubstituteID} {
+		var found bool
+		if err := q.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM ingredients WHERE id = ?)", id).Scan(&found); err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("ingredient %s not found", id.String())
+		}
+	}
+	return nil
+}
+
+func (r *SubstitutionRepository) Create(ctx context.Context, substitution *domain.Substitution) (uuid.UUID, error) {
+	q := r.db.conn(ctx)
+	id := newId(substitution.ID)
+	_, err := q.ExecContext(ctx, "INSERT INTO substitutions ("+substitutionColumns+") VALUES (?, ?, ?, ?, ?, 1)",
+		id, substitution.IngredientID, substitution.SubstituteID, substitution.Ratio, substitution.Context)
+	if isPrimaryKeyViolation(err) {
+		return uuid.Nil, fmt.Errorf("substitution %s already exists", id.String())
+	}
+	if isForeignKeyViolation(err) {
+		if missing := missingIngredient(ctx, q, substitution); missing != nil {
+			return uuid.Nil, missing
+		}
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("creating substitution: %w", err)
+	}
+	substitution.ID = id
+	substitution.Version = 1
+	return substitution.ID, nil
+}
+
+func (r *SubstitutionRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Substitution, error) {
+	substitution, err := scanSubstitution(r.db.conn(ctx).QueryRowContext(ctx,
+		"SELECT "+substitutionColumns+" FROM substitutions WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("substitution %s not found", id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting substitution: %w", err)
+	}
+	return substitution, nil
+}
+
+// GetAllByIngredientId returns substitutes of the ingredient in the order they were added
+func (r *SubstitutionRepository) GetAllByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]*domain.Substitution, error) {
+	substitutions, err := queryAll(ctx, r.db.conn(ctx), scanSubstitution,
+		"SELECT "+substitutionColumns+" FROM substitutions WHERE ingredient_id = ? ORDER BY rowid", ingredientId)
+	if err != nil {
+		return nil, fmt.Errorf("getting substitutions: %w", err)
+	}
+	return substitutions, nil
+}
+
+func (r *SubstitutionRepository) Update(ctx context.Context, substitution *domain.Substitution) error {
+	q := r.db.conn(ctx)
+	result, err := q.ExecContext(ctx, `UPDATE substitutions SET ingredient_id = ?, substitute_id = ?, ratio = ?, context = ?,
+		version = version + 1 WHERE id = ? AND version = ?`,
+		substitution.IngredientID, substitution.SubstituteID, substitution.Ratio, substitution.Context,
+		substitution.ID, substitution.Version)
+	if isForeignKeyViolation(err) {
+		if missing := missingIngredient(ctx, q, substitution); missing != nil {
+			return missing
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("updating substitution: %w", err)
+	}
+	if err = checkUpdated(ctx, q, result, "substitutions", "substitution", substitution.ID, substitution.Version); err != nil {
+		return err
+	}
+	substitution.Version++
+	return nil
+}
+
+func (r *SubstitutionRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return deleteById(ctx, r.db.conn(ctx), "substitutions", "substitution", id)
+}
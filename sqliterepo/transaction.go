@@ -0,0 +1,45 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+)
+
+// TransactionManager begins SQLite transactions, which take the write lock of the
+// database at once, so writers wait for each other instead of failing on commit
+type TransactionManager struct {
+	db *DB
+}
+
+func NewTransactionManager(db *DB) domain.ITransactionManager {
+	return &TransactionManager{db: db}
+}
+
+type transaction struct {
+	db *DB
+	tx *sql.Tx
+}
+
+func (m *TransactionManager) Begin(ctx context.Context) (domain.ITransaction, error) {
+	tx, err := m.db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	return &transaction{db: m.db, tx: tx}, nil
+}
+
+func (t *transaction) Commit(ctx context.Context) error {
+	return t.tx.Commit()
+}
+
+// Rollback of a finished transaction does nothing
+func (t *transaction) Rollback(ctx context.Context) error {
+	err := t.tx.Rollback()
+	if errors.Is(err, sql.ErrTxDone) {
+		return nil
+	}
+	return err
+}
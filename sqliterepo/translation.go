@@ -0,0 +1,172 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"strings"
+)
+
+// translatedTables are the tables of translatable entities, their columns are named
+// after the translatable fields
+var translatedTables = map[string]string{
+	domain.TranslationEntityIngredient:     "ingredients",
+	domain.TranslationEntityIngredientType: "ingredient_types",
+	domain.TranslationEntitySaladType:      "salad_types",
+	domain.TranslationEntityMeasurement:    "measurements",
+	domain.TranslationEntitySalad:          "salads",
+}
+
+type TranslationRepository struct {
+	db *DB
+}
+
+func NewTranslationRepository(db *DB) domain.ITranslationRepository {
+	return &TranslationRepository{db: db}
+}
+
+const translationColumns = "id, entity_type, entity_id, locale, field, text, version"
+
+func scanTranslation(row scanner) (*domain.Translation, error) {
+	translation := &domain.Translation{}
+	err := row.Scan(&translation.ID, &translation.EntityType, &translation.EntityID, &translation.Locale,
+		&translation.Field, &translation.Text, &translation.Version)
+	return translation, err
+}
+
+// Create allows one translation of a field per locale
+func (r *TranslationRepository) Create(ctx context.Context, translation *domain.Translation) (uuid.UUID, error) {
+	id := newId(translation.ID)
+	_, err := r.db.conn(ctx).ExecContext(ctx, "INSERT INTO translations ("+translationColumns+") VALUES (?, ?, ?, ?, ?, ?, 1)",
+		id, translation.EntityType, translation.EntityID, translation.Locale, translation.Field, translation.Text)
+	if isPrimaryKeyViolation(err) || isUniqueViolation(err) {
+		return uuid.Nil, fmt.Errorf("%s translation of %s %s %s already exists", translation.Locale,
+			translation.EntityType, translation.EntityID.String(), translation.Field)
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("creating translation: %w", err)
+	}
+	translation.ID = id
+	translation.Version = 1
+	return translation.ID, nil
+}
+
+func (r *TranslationRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Translation, error) {
+	translation, err := scanTranslation(r.db.conn(ctx).QueryRowContext(ctx,
+		"SELECT "+translationColumns+" FROM translations WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("translation %s not found", id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting translation: %w", err)
+	}
+	return translation, nil
+}
+
+// GetAllByEntity returns translations ordered by locale and field
+func (r *TranslationRepository) GetAllByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*domain.Translation, error) {
+	translations, err := queryAll(ctx, r.db.conn(ctx), scanTranslation, "SELECT "+translationColumns+
+		" FROM translations WHERE entity_type = ? AND entity_id = ? ORDER BY locale, field", entityType, entityId)
+	if err != nil {
+		return nil, fmt.Errorf("getting translations: %w", err)
+	}
+	return translations, nil
+}
+
+func (r *TranslationRepository) GetAllByEntities(ctx context.Context, entityType string, entityIds []uuid.UUID, locales []string) ([]*domain.Translation, error) {
+	if len(entityIds) == 0 || len(locales) == 0 {
+		return make([]*domain.Translation, 0), nil
+	}
+
+	args := append([]any{entityType}, idArgs(entityIds)...)
+	for _, locale := range locales {
+		args = append(args, locale)
+	}
+	translations, err := queryAll(ctx, r.db.conn(ctx), scanTranslation, "SELECT "+translationColumns+
+		" FROM translations WHERE entity_type = ? AND entity_id IN ("+placeholders(len(entityIds))+
+		") AND locale IN ("+placeholders(len(locales))+") ORDER BY entity_id, locale, field", args...)
+	if err != nil {
+		return nil, fmt.Errorf("getting translations: %w", err)
+	}
+	return translations, nil
+}
+
+// GetMissing returns fields of entities in the order the entities were created
+func (r *TranslationRepository) GetMissing(ctx context.Context, entityType string, locale string) ([]*domain.MissingTranslation, error) {
+	table, ok := translatedTables[entityType]
+	if !ok {
+		return nil, fmt.Errorf("unknown entity type %s", entityType)
+	}
+	fields := domain.TranslatableFields[entityType]
+
+	// every field is selected with whether it has a translation in the locale
+	columns := make([]string, 0, len(fields))
+	args := make([]any, 0, 3*len(fields))
+	for _, field := range fields {
+		columns = append(columns, "e."+field+`, EXISTS (SELECT 1 FROM translations t
+			WHERE t.entity_type = ? AND t.entity_id = e.id AND t.locale = ? AND t.field = ?)`)
+		args = append(args, entityType, locale, field)
+	}
+	rows, err := r.db.conn(ctx).QueryContext(ctx,
+		"SELECT e.id, "+strings.Join(columns, ", ")+" FROM "+table+" e ORDER BY e.rowid", args...)
+	if err != nil {
+		return nil, fmt.Errorf("getting missing translations: %w", err)
+	}
+	defer rows.Close()
+
+	missing := make([]*domain.MissingTranslation, 0)
+	sources := make([]string, len(fields))
+	translated := make([]bool, len(fields))
+	dest := make([]any, 0, 1+2*len(fields))
+	var entityId uuid.UUID
+	dest = append(dest, &entityId)
+	for i := range fields {
+		dest = append(dest, &sources[i], &translated[i])
+	}
+	for rows.Next() {
+		if err = rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("getting missing translations: %w", err)
+		}
+		for i, field := range fields {
+			if sources[i] != "" && !translated[i] {
+				missing = append(missing, &domain.MissingTranslation{
+					EntityType: entityType,
+					EntityID:   entityId,
+					Field:      field,
+					Source:     sources[i],
+				})
+			}
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("getting missing translations: %w", err)
+	}
+	return missing, nil
+}
+
+func (r *TranslationRepository) Update(ctx context.Context, translation *domain.Translation) error {
+	q := r.db.conn(ctx)
+	result, err := q.ExecContext(ctx, `UPDATE translations SET entity_type = ?, entity_id = ?, locale = ?, field = ?, text = ?,
+		version = version + 1 WHERE id = ? AND version = ?`,
+		translation.EntityType, translation.EntityID, translation.Locale, translation.Field, translation.Text,
+		translation.ID, translation.Version)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("%s translation of %s %s %s already exists", translation.Locale,
+			translation.EntityType, translation.EntityID.String(), translation.Field)
+	}
+	if err != nil {
+		return fmt.Errorf("updating translation: %w", err)
+	}
+	if err = checkUpdated(ctx, q, result, "translations", "translation", translation.ID, translation.Version); err != nil {
+		return err
+	}
+	translation.Version++
+	return nil
+}
+
+func (r *TranslationRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return deleteById(ctx, r.db.conn(ctx), "translations", "translation", id)
+}
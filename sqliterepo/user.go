@@ -0,0 +1,108 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"strings"
+)
+
+type UserRepository struct {
+	db *DB
+}
+
+func NewUserRepository(db *DB) domain.IUserRepository {
+	return &UserRepository{db: db}
+}
+
+const userColumns = "id, name, username, password, email, email_name, role, version"
+
+func scanUser(row scanner) (*domain.User, error) {
+	user := &domain.User{}
+	err := row.Scan(&user.ID, &user.Name, &user.Username, &user.Password,
+		&user.Email.Address, &user.Email.Name, &user.Role, &user.Version)
+	return user, err
+}
+
+// Create gives users without a role the default one, usernames are unique
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
+	id := newId(user.ID)
+	role := user.Role
+	if role == "" {
+		role = domain.DefaultRole
+	}
+
+	_, err := r.db.conn(ctx).ExecContext(ctx, "INSERT INTO users ("+userColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, 1)",
+		id, user.Name, user.Username, user.Password, user.Email.Address, user.Email.Name, role)
+	if isPrimaryKeyViolation(err) || isUniqueViolation(err) {
+		return fmt.Errorf("user %s already exists", user.Username)
+	}
+	if err != nil {
+		return fmt.Errorf("creating user: %w", err)
+	}
+	user.ID = id
+	user.Role = role
+	user.Version = 1
+	return nil
+}
+
+func (r *UserRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	user, err := scanUser(r.db.conn(ctx).QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("user %s not found", id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting user: %w", err)
+	}
+	return user, nil
+}
+
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	user, err := scanUser(r.db.conn(ctx).QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE username = ?", username))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("user %s not found", username)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting user: %w", err)
+	}
+	return user, nil
+}
+
+func (r *UserRepository) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.User, *domain.PageInfo, error) {
+	users, err := queryAll(ctx, r.db.conn(ctx), scanUser, "SELECT "+userColumns+" FROM users")
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting users: %w", err)
+	}
+
+	return domain.Paginate(users, page, func(user *domain.User, sort string) (string, uuid.UUID) {
+		if sort == domain.SortByName {
+			return strings.ToLower(user.Name), user.ID
+		}
+		return user.Username, user.ID
+	})
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
+	q := r.db.conn(ctx)
+	result, err := q.ExecContext(ctx, `UPDATE users SET name = ?, username = ?, password = ?, email = ?, email_name = ?,
+		role = ?, version = version + 1 WHERE id = ? AND version = ?`,
+		user.Name, user.Username, user.Password, user.Email.Address, user.Email.Name, user.Role, user.ID, user.Version)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("user %s already exists", user.Username)
+	}
+	if err != nil {
+		return fmt.Errorf("updating user: %w", err)
+	}
+	if err = checkUpdated(ctx, q, result, "users", "user", user.ID, user.Version); err != nil {
+		return err
+	}
+	user.Version++
+	return nil
+}
+
+func (r *UserRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return deleteById(ctx, r.db.conn(ctx), "users", "user", id)
+}
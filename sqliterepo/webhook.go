@@ -0,0 +1,264 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+type WebhookSubscriptionRepository struct {
+	db *DB
+}
+
+func NewWebhookSubscriptionRepository(db *DB) domain.IWebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{db: db}
+}
+
+const subscriptionColumns = "id, url, event_types, secret, active, created_at, version"
+
+func scanSubscription(row scanner) (*domain.WebhookSubscription, error) {
+	subscription := &domain.WebhookSubscription{}
+	err := row.Scan(&subscription.ID, &subscription.URL, listScanner{&subscription.EventTypes}, &subscription.Secret,
+		&subscription.Active, timeScanner{&subscription.CreatedAt}, &subscription.Version)
+	return subscription, err
+}
+
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	eventTypes, err := listValue(subscription.EventTypes)
+	if err != nil {
+		return fmt.Errorf("creating webhook subscription: %w", err)
+	}
+	_, err = r.db.conn(ctx).ExecContext(ctx, "INSERT INTO webhook_subscriptions ("+subscriptionColumns+") VALUES (?, ?, ?, ?, ?, ?, 1)",
+		subscription.ID, subscription.URL, eventTypes, subscription.Secret, subscription.Active, timeValue(subscription.CreatedAt))
+	if isPrimaryKeyViolation(err) {
+		return fmt.Errorf("webhook subscription %s already exists", subscription.ID.String())
+	}
+	if err != nil {
+		return fmt.Errorf("creating webhook subscription: %w", err)
+	}
+	subscription.Version = 1
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	subscription, err := scanSubscription(r.db.conn(ctx).QueryRowContext(ctx,
+		"SELECT "+subscriptionColumns+" FROM webhook_subscriptions WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrSubscriptionNotFound, id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting webhook subscription: %w", err)
+	}
+	return subscription, nil
+}
+
+// GetAll returns subscriptions in the order they were created
+func (r *WebhookSubscriptionRepository) GetAll(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	subscriptions, err := queryAll(ctx, r.db.conn(ctx), scanSubscription,
+		"SELECT "+subscriptionColumns+" FROM webhook_subscriptions ORDER BY created_at, rowid")
+	if err != nil {
+		return nil, fmt.Errorf("getting webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// GetAllByEventType filters subscriptions in memory, there are few of them
+func (r *WebhookSubscriptionRepository) GetAllByEventType(ctx context.Context, eventType string) ([]*domain.WebhookSubscription, error) {
+	subscriptions, err := r.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subscribed := make([]*domain.WebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if slices.Contains(subscription.EventTypes, eventType) {
+			subscribed = append(subscribed, subscription)
+		}
+	}
+	return subscribed, nil
+}
+
+// Update keeps the time the subscription was created
+func (r *WebhookSubscriptionRepository) Update(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	eventTypes, err := listValue(subscription.EventTypes)
+	if err != nil {
+		return fmt.Errorf("updating webhook subscription: %w", err)
+	}
+	var createdAt time.Time
+	err = r.db.conn(ctx).QueryRowContext(ctx, `UPDATE webhook_subscriptions SET url = ?, event_types = ?, secret = ?, active = ?,
+		version = version + 1 WHERE id = ? AND version = ? RETURNING created_at`,
+		subscription.URL, eventTypes, subscription.Secret, subscription.Active, subscription.ID, subscription.Version).
+		Scan(timeScanner{&createdAt})
+	if errors.Is(err, sql.ErrNoRows) {
+		return notUpdated(ctx, r.db.conn(ctx), "webhook_subscriptions", "webhook subscription", subscription.ID, subscription.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("updating webhook subscription: %w", err)
+	}
+	subscription.Version++
+	subscription.CreatedAt = createdAt
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return deleteById(ctx, r.db.conn(ctx), "webhook_subscriptions", "webhook subscription", id)
+}
+
+const deliveryColumns = "id, subscription_id, event_type, payload, status, attempts, next_attempt_at, created_at"
+
+func scanDelivery(row scanner) (*domain.WebhookDelivery, error) {
+	delivery := &domain.WebhookDelivery{}
+	var payload string
+	err := row.Scan(&delivery.ID, &delivery.SubscriptionID, &delivery.EventType, &payload, &delivery.Status,
+		&delivery.Attempts, timeScanner{&delivery.NextAttemptAt}, timeScanner{&delivery.CreatedAt})
+	delivery.Payload = json.RawMessage(payload)
+	return delivery, err
+}
+
+func deliveryArgs(delivery *domain.WebhookDelivery) []any {
+	return []any{delivery.ID, delivery.SubscriptionID, delivery.EventType, string(delivery.Payload), delivery.Status,
+		delivery.Attempts, timeValue(delivery.NextAttemptAt), timeValue(delivery.CreatedAt)}
+}
+
+type WebhookDeliveryRepository struct {
+	db *DB
+}
+
+func NewWebhookDeliveryRepository(db *DB) domain.IWebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	_, err := r.db.conn(ctx).ExecContext(ctx, "INSERT INTO webhook_deliveries ("+deliveryColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		deliveryArgs(delivery)...)
+	if isPrimaryKeyViolation(err) {
+		return fmt.Errorf("webhook delivery %s already exists", delivery.ID.String())
+	}
+	if err != nil {
+		return fmt.Errorf("creating webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	delivery, err := scanDelivery(r.db.conn(ctx).QueryRowContext(ctx,
+		"SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("webhook delivery %s not found", id.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting webhook delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// GetAllBySubscriptionId returns deliveries in the order they were created
+func (r *WebhookDeliveryRepository) GetAllBySubscriptionId(ctx context.Context, subscriptionId uuid.UUID) ([]*domain.WebhookDelivery, error) {
+	deliveries, err := queryAll(ctx, r.db.conn(ctx), scanDelivery,
+		"SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE subscription_id = ? ORDER BY rowid", subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("getting webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// GetDue returns up to limit due deliveries in the order they were created, limit <= 0 means all of them
+func (r *WebhookDeliveryRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	if limit <= 0 {
+		limit = -1
+	}
+	deliveries, err := queryAll(ctx, r.db.conn(ctx), scanDelivery, "SELECT "+deliveryColumns+
+		" FROM webhook_deliveries WHERE status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?) ORDER BY rowid LIMIT ?",
+		domain.WebhookDeliveryPending, now.UnixNano(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("getting due webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	result, err := r.db.conn(ctx).ExecContext(ctx, `UPDATE webhook_deliveries SET subscription_id = ?, event_type = ?,
+		payload = ?, status = ?, attempts = ?, next_attempt_at = ?, created_at = ? WHERE id = ?`,
+		append(deliveryArgs(delivery)[1:], delivery.ID)...)
+	if err != nil {
+		return fmt.Errorf("updating webhook delivery: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("updating webhook delivery: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("webhook delivery %s not found", delivery.ID.String())
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) CreateAttempt(ctx context.Context, attempt *domain.WebhookAttempt) error {
+	_, err := r.db.conn(ctx).ExecContext(ctx, `INSERT INTO webhook_attempts (id, delivery_id, number, status_code, error, duration, timestamp)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		newId(attempt.ID), attempt.DeliveryID, attempt.Number, attempt.StatusCode, attempt.Error,
+		int64(attempt.Duration), timeValue(attempt.Timestamp))
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("webhook delivery %s not found", attempt.DeliveryID.String())
+	}
+	if err != nil {
+		return fmt.Errorf("creating webhook attempt: %w", err)
+	}
+	return nil
+}
+
+// GetAttempts returns attempts of the delivery in the order they were made
+func (r *WebhookDeliveryRepository) GetAttempts(ctx context.Context, deliveryId uuid.UUID) ([]*domain.WebhookAttempt, error) {
+	attempts, err := queryAll(ctx, r.db.conn(ctx), func(row scanner) (*domain.WebhookAttempt, error) {
+		attempt := &domain.WebhookAttempt{}
+		var duration int64
+		err := row.Scan(&attempt.ID, &attempt.DeliveryID, &attempt.Number, &attempt.StatusCode, &attempt.Error,
+			&duration, timeScanner{&attempt.Timestamp})
+		attempt.Duration = time.Duration(duration)
+		return attempt, err
+	}, `SELECT id, delivery_id, number, status_code, error, duration, timestamp FROM webhook_attempts
+		WHERE delivery_id = ? ORDER BY rowid`, deliveryId)
+	if err != nil {
+		return nil, fmt.Errorf("getting webhook attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+type WebhookDeadLetterRepository struct {
+	db *DB
+}
+
+func NewWebhookDeadLetterRepository(db *DB) domain.IWebhookDeadLetterRepository {
+	return &WebhookDeadLetterRepository{db: db}
+}
+
+func (r *WebhookDeadLetterRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	_, err := r.db.conn(ctx).ExecContext(ctx, "INSERT INTO webhook_dead_letters ("+deliveryColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		deliveryArgs(delivery)...)
+	if isPrimaryKeyViolation(err) {
+		return fmt.Errorf("webhook dead letter %s already exists", delivery.ID.String())
+	}
+	if err != nil {
+		return fmt.Errorf("creating webhook dead letter: %w", err)
+	}
+	return nil
+}
+
+// GetAll returns dead letters in the order they were created
+func (r *WebhookDeadLetterRepository) GetAll(ctx context.Context) ([]*domain.WebhookDelivery, error) {
+	deliveries, err := queryAll(ctx, r.db.conn(ctx), scanDelivery,
+		"SELECT "+deliveryColumns+" FROM webhook_dead_letters ORDER BY rowid")
+	if err != nil {
+		return nil, fmt.Errorf("getting webhook dead letters: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookDeadLetterRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	return deleteById(ctx, r.db.conn(ctx), "webhook_dead_letters", "webhook dead letter", id)
+}
@@ -0,0 +1,291 @@
+// Command gen writes Instrumented* decorators for the I*Service and I*Repository
+// interfaces of the domain package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const domainImport = "github.com/Mx1q/ppo_services/domain"
+
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+	attr     string
+}
+
+type method struct {
+	name    string
+	params  []param
+	results []string
+}
+
+type generator struct {
+	interfaces map[string]*ast.InterfaceType
+	entities   map[string]bool
+	types      map[string]bool
+	imports    map[string]string
+	used       map[string]bool
+}
+
+func main() {
+	domainDir := flag.String("domain", "../domain", "path to the domain package")
+	out := flag.String("out", "instrumented.go", "output file")
+	flag.Parse()
+
+	g := &generator{
+		interfaces: make(map[string]*ast.InterfaceType),
+		entities:   make(map[string]bool),
+		types:      make(map[string]bool),
+		imports:    make(map[string]string),
+		used:       map[string]bool{"context": true, domainImport: true},
+	}
+	if err := g.load(*domainDir); err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := g.generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func (g *generator) load(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			name := importPath[strings.LastIndex(importPath, "/")+1:]
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			g.imports[name] = importPath
+		}
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			typeSpec, ok := node.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			g.types[typeSpec.Name.Name] = true
+			switch typ := typeSpec.Type.(type) {
+			case *ast.InterfaceType:
+				g.interfaces[typeSpec.Name.Name] = typ
+			case *ast.StructType:
+				for _, field := range typ.Fields.List {
+					for _, name := range field.Names {
+						if name.Name == "ID" {
+							g.entities[typeSpec.Name.Name] = true
+						}
+					}
+				}
+			}
+			return false
+		})
+	}
+	return nil
+}
+
+func instrumented(name string) bool {
+	return strings.HasPrefix(name, "I") &&
+		(strings.HasSuffix(name, "Service") || strings.HasSuffix(name, "Repository"))
+}
+
+func (g *generator) typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if g.types[t.Name] {
+			return "domain." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		pkg := t.X.(*ast.Ident).Name
+		g.used[g.imports[pkg]] = true
+		return pkg + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + g.typeString(t.X)
+	case *ast.ArrayType:
+		return "[]" + g.typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + g.typeString(t.Key) + "]" + g.typeString(t.Value)
+	case *ast.Ellipsis:
+		return "..." + g.typeString(t.Elt)
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+	panic(fmt.Sprintf("unsupported type %T", expr))
+}
+
+// attr returns the telemetry attribute expression of a parameter, if it is an ID or an entity
+func (g *generator) attr(name string, expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.SelectorExpr:
+		if t.X.(*ast.Ident).Name == "uuid" && t.Sel.Name == "UUID" {
+			return fmt.Sprintf("telemetry.UUIDAttr(%q, %s)", name, name)
+		}
+	case *ast.StarExpr:
+		if ident, ok := t.X.(*ast.Ident); ok && g.entities[ident.Name] {
+			return fmt.Sprintf("telemetry.EntityAttr(%q, %s)", name, name)
+		}
+	}
+	return ""
+}
+
+func (g *generator) methods(iface *ast.InterfaceType) []method {
+	methods := make([]method, 0)
+	for _, field := range iface.Methods.List {
+		funcType, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			if embedded, ok := g.interfaces[field.Type.(*ast.Ident).Name]; ok {
+				methods = append(methods, g.methods(embedded)...)
+			}
+			continue
+		}
+
+		m := method{name: field.Names[0].Name}
+		for _, p := range funcType.Params.List {
+			names := p.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("p%d", len(m.params)))}
+			}
+			for _, name := range names {
+				_, variadic := p.Type.(*ast.Ellipsis)
+				m.params = append(m.params, param{
+					name:     name.Name,
+					typ:      g.typeString(p.Type),
+					variadic: variadic,
+					attr:     g.attr(name.Name, p.Type),
+				})
+			}
+		}
+		if funcType.Results != nil {
+			for _, r := range funcType.Results.List {
+				count := len(r.Names)
+				if count == 0 {
+					count = 1
+				}
+				for i := 0; i < count; i++ {
+					m.results = append(m.results, g.typeString(r.Type))
+				}
+			}
+		}
+		methods = append(methods, m)
+	}
+	return methods
+}
+
+func (g *generator) generate() ([]byte, error) {
+	names := make([]string, 0)
+	for name := range g.interfaces {
+		if instrumented(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	body := new(bytes.Buffer)
+	for _, name := range names {
+		g.writeDecorator(body, name, g.methods(g.interfaces[name]))
+	}
+
+	out := new(bytes.Buffer)
+	fmt.Fprintf(out, "// Code generated by telemetry/gen. DO NOT EDIT.\n\npackage telemetry\n\nimport (\n")
+	imports := make([]string, 0, len(g.used))
+	for importPath := range g.used {
+		imports = append(imports, importPath)
+	}
+	sort.Strings(imports)
+	for _, importPath := range imports {
+		fmt.Fprintf(out, "\t%q\n", importPath)
+	}
+	fmt.Fprintf(out, ")\n")
+	out.Write(body.Bytes())
+
+	return format.Source(out.Bytes())
+}
+
+func (g *generator) writeDecorator(w *bytes.Buffer, iface string, methods []method) {
+	typeName := "Instrumented" + strings.TrimPrefix(iface, "I")
+	fmt.Fprintf(w, "\ntype %s struct {\n\tnext domain.%s\n\ttelemetry *Telemetry\n}\n\n", typeName, iface)
+	fmt.Fprintf(w, "func New%s(next domain.%s, telemetry *Telemetry) domain.%s {\n", typeName, iface, iface)
+	fmt.Fprintf(w, "\treturn &%s{next: next, telemetry: telemetry}\n}\n", typeName)
+
+	for _, m := range methods {
+		params := make([]string, 0, len(m.params))
+		args := make([]string, 0, len(m.params))
+		attrs := make([]string, 0)
+		for _, p := range m.params {
+			params = append(params, p.name+" "+p.typ)
+			arg := p.name
+			if p.variadic {
+				arg += "..."
+			}
+			args = append(args, arg)
+			if p.attr != "" {
+				attrs = append(attrs, strings.Replace(p.attr, "telemetry.", "", 1))
+			}
+		}
+
+		results := make([]string, len(m.results))
+		endAttrs := make([]string, 0)
+		errName := "nil"
+		for i, r := range m.results {
+			results[i] = fmt.Sprintf("r%d", i)
+			if r == "error" && i == len(m.results)-1 {
+				results[i] = "err"
+				errName = "err"
+			} else if r == "uuid.UUID" {
+				endAttrs = append(endAttrs, fmt.Sprintf("UUIDAttr(\"result\", %s)", results[i]))
+			}
+		}
+
+		resultTypes := strings.Join(m.results, ", ")
+		if len(m.results) > 1 {
+			resultTypes = "(" + resultTypes + ")"
+		}
+		fmt.Fprintf(w, "\nfunc (d *%s) %s(%s) %s {\n", typeName, m.name, strings.Join(params, ", "), resultTypes)
+
+		startArgs := append([]string{"ctx", strconv.Quote(iface), strconv.Quote(m.name)}, attrs...)
+		fmt.Fprintf(w, "\tctx, call := d.telemetry.Start(%s)\n", strings.Join(startArgs, ", "))
+		call := fmt.Sprintf("d.next.%s(%s)", m.name, strings.Join(args, ", "))
+		if len(results) > 0 {
+			fmt.Fprintf(w, "\t%s := %s\n", strings.Join(results, ", "), call)
+		} else {
+			fmt.Fprintf(w, "\t%s\n", call)
+		}
+		fmt.Fprintf(w, "\tcall.End(%s)\n", strings.Join(append([]string{errName}, endAttrs...), ", "))
+		if len(results) > 0 {
+			fmt.Fprintf(w, "\treturn %s\n", strings.Join(results, ", "))
+		}
+		fmt.Fprintf(w, "}\n")
+	}
+}
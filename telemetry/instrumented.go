@@ -0,0 +1,2122 @@
+// Code generated by telemetry/gen. DO NOT EDIT.
+
+package telemetry
+
+import (
+	"context"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"io"
+	"time"
+)
+
+type InstrumentedAuditRepository struct {
+	next      domain.IAuditRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedAuditRepository(next domain.IAuditRepository, telemetry *Telemetry) domain.IAuditRepository {
+	return &InstrumentedAuditRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedAuditRepository) Create(ctx context.Context, record *domain.AuditRecord) error {
+	ctx, call := d.telemetry.Start(ctx, "IAuditRepository", "Create", EntityAttr("record", record))
+	err := d.next.Create(ctx, record)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedAuditRepository) GetByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*domain.AuditRecord, error) {
+	ctx, call := d.telemetry.Start(ctx, "IAuditRepository", "GetByEntity", UUIDAttr("entityId", entityId))
+	r0, err := d.next.GetByEntity(ctx, entityType, entityId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedAuditRepository) GetByActor(ctx context.Context, actorId uuid.UUID) ([]*domain.AuditRecord, error) {
+	ctx, call := d.telemetry.Start(ctx, "IAuditRepository", "GetByActor", UUIDAttr("actorId", actorId))
+	r0, err := d.next.GetByActor(ctx, actorId)
+	call.End(err)
+	return r0, err
+}
+
+type InstrumentedAuditService struct {
+	next      domain.IAuditService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedAuditService(next domain.IAuditService, telemetry *Telemetry) domain.IAuditService {
+	return &InstrumentedAuditService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedAuditService) Record(ctx context.Context, action string, entityType string, entityId uuid.UUID, before interface{}, after interface{}) error {
+	ctx, call := d.telemetry.Start(ctx, "IAuditService", "Record", UUIDAttr("entityId", entityId))
+	err := d.next.Record(ctx, action, entityType, entityId, before, after)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedAuditService) GetByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*domain.AuditRecord, error) {
+	ctx, call := d.telemetry.Start(ctx, "IAuditService", "GetByEntity", UUIDAttr("entityId", entityId))
+	r0, err := d.next.GetByEntity(ctx, entityType, entityId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedAuditService) GetByActor(ctx context.Context, actorId uuid.UUID) ([]*domain.AuditRecord, error) {
+	ctx, call := d.telemetry.Start(ctx, "IAuditService", "GetByActor", UUIDAttr("actorId", actorId))
+	r0, err := d.next.GetByActor(ctx, actorId)
+	call.End(err)
+	return r0, err
+}
+
+type InstrumentedAuthRepository struct {
+	next      domain.IAuthRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedAuthRepository(next domain.IAuthRepository, telemetry *Telemetry) domain.IAuthRepository {
+	return &InstrumentedAuthRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedAuthRepository) Register(ctx context.Context, authInfo *domain.User) (uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "IAuthRepository", "Register", EntityAttr("authInfo", authInfo))
+	r0, err := d.next.Register(ctx, authInfo)
+	call.End(err, UUIDAttr("result", r0))
+	return r0, err
+}
+
+func (d *InstrumentedAuthRepository) GetByUsername(ctx context.Context, username string) (*domain.UserAuth, error) {
+	ctx, call := d.telemetry.Start(ctx, "IAuthRepository", "GetByUsername")
+	r0, err := d.next.GetByUsername(ctx, username)
+	call.End(err)
+	return r0, err
+}
+
+type InstrumentedAuthService struct {
+	next      domain.IAuthService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedAuthService(next domain.IAuthService, telemetry *Telemetry) domain.IAuthService {
+	return &InstrumentedAuthService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedAuthService) Login(ctx context.Context, authInfo *domain.UserAuth) (string, error) {
+	ctx, call := d.telemetry.Start(ctx, "IAuthService", "Login", EntityAttr("authInfo", authInfo))
+	r0, err := d.next.Login(ctx, authInfo)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedAuthService) Register(ctx context.Context, authInfo *domain.User) (string, error) {
+	ctx, call := d.telemetry.Start(ctx, "IAuthService", "Register", EntityAttr("authInfo", authInfo))
+	r0, err := d.next.Register(ctx, authInfo)
+	call.End(err)
+	return r0, err
+}
+
+type InstrumentedCatalogService struct {
+	next      domain.ICatalogService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedCatalogService(next domain.ICatalogService, telemetry *Telemetry) domain.ICatalogService {
+	return &InstrumentedCatalogService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedCatalogService) Import(ctx context.Context, catalog string, r io.Reader, options *domain.CatalogImportOptions) (*domain.CatalogImportReport, error) {
+	ctx, call := d.telemetry.Start(ctx, "ICatalogService", "Import")
+	r0, err := d.next.Import(ctx, catalog, r, options)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedCatalogService) Export(ctx context.Context, catalog string, w io.Writer, format string) error {
+	ctx, call := d.telemetry.Start(ctx, "ICatalogService", "Export")
+	err := d.next.Export(ctx, catalog, w, format)
+	call.End(err)
+	return err
+}
+
+type InstrumentedCommentRepository struct {
+	next      domain.ICommentRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedCommentRepository(next domain.ICommentRepository, telemetry *Telemetry) domain.ICommentRepository {
+	return &InstrumentedCommentRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedCommentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	ctx, call := d.telemetry.Start(ctx, "ICommentRepository", "Create", EntityAttr("comment", comment))
+	err := d.next.Create(ctx, comment)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedCommentRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	ctx, call := d.telemetry.Start(ctx, "ICommentRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedCommentRepository) GetBySaladAndUser(ctx context.Context, saladId uuid.UUID, userId uuid.UUID) (*domain.Comment, error) {
+	ctx, call := d.telemetry.Start(ctx, "ICommentRepository", "GetBySaladAndUser", UUIDAttr("saladId", saladId), UUIDAttr("userId", userId))
+	r0, err := d.next.GetBySaladAndUser(ctx, saladId, userId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedCommentRepository) GetAllBySaladID(ctx context.Context, saladId uuid.UUID, page *domain.PageRequest) ([]*domain.Comment, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "ICommentRepository", "GetAllBySaladID", UUIDAttr("saladId", saladId))
+	r0, r1, err := d.next.GetAllBySaladID(ctx, saladId, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedCommentRepository) Update(ctx context.Context, comment *domain.Comment) error {
+	ctx, call := d.telemetry.Start(ctx, "ICommentRepository", "Update", EntityAttr("comment", comment))
+	err := d.next.Update(ctx, comment)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedCommentRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "ICommentRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedCommentService struct {
+	next      domain.ICommentService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedCommentService(next domain.ICommentService, telemetry *Telemetry) domain.ICommentService {
+	return &InstrumentedCommentService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedCommentService) Create(ctx context.Context, comment *domain.Comment) error {
+	ctx, call := d.telemetry.Start(ctx, "ICommentService", "Create", EntityAttr("comment", comment))
+	err := d.next.Create(ctx, comment)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedCommentService) GetById(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	ctx, call := d.telemetry.Start(ctx, "ICommentService", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedCommentService) GetBySaladAndUser(ctx context.Context, saladId uuid.UUID, userId uuid.UUID) (*domain.Comment, error) {
+	ctx, call := d.telemetry.Start(ctx, "ICommentService", "GetBySaladAndUser", UUIDAttr("saladId", saladId), UUIDAttr("userId", userId))
+	r0, err := d.next.GetBySaladAndUser(ctx, saladId, userId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedCommentService) GetAllBySaladID(ctx context.Context, saladId uuid.UUID, page *domain.PageRequest) ([]*domain.Comment, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "ICommentService", "GetAllBySaladID", UUIDAttr("saladId", saladId))
+	r0, r1, err := d.next.GetAllBySaladID(ctx, saladId, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedCommentService) Update(ctx context.Context, user *domain.Comment) error {
+	ctx, call := d.telemetry.Start(ctx, "ICommentService", "Update", EntityAttr("user", user))
+	err := d.next.Update(ctx, user)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedCommentService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "ICommentService", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedDietService struct {
+	next      domain.IDietService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedDietService(next domain.IDietService, telemetry *Telemetry) domain.IDietService {
+	return &InstrumentedDietService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedDietService) GetRecipeDiets(ctx context.Context, recipeId uuid.UUID) ([]string, error) {
+	ctx, call := d.telemetry.Start(ctx, "IDietService", "GetRecipeDiets", UUIDAttr("recipeId", recipeId))
+	r0, err := d.next.GetRecipeDiets(ctx, recipeId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedDietService) CheckSaladType(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) (*domain.DietConflict, error) {
+	ctx, call := d.telemetry.Start(ctx, "IDietService", "CheckSaladType", UUIDAttr("saladId", saladId), UUIDAttr("saladTypeId", saladTypeId))
+	r0, err := d.next.CheckSaladType(ctx, saladId, saladTypeId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedDietService) GetSaladConflicts(ctx context.Context, saladId uuid.UUID) ([]*domain.DietConflict, error) {
+	ctx, call := d.telemetry.Start(ctx, "IDietService", "GetSaladConflicts", UUIDAttr("saladId", saladId))
+	r0, err := d.next.GetSaladConflicts(ctx, saladId)
+	call.End(err)
+	return r0, err
+}
+
+type InstrumentedIngredientRepository struct {
+	next      domain.IIngredientRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedIngredientRepository(next domain.IIngredientRepository, telemetry *Telemetry) domain.IIngredientRepository {
+	return &InstrumentedIngredientRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedIngredientRepository) Create(ctx context.Context, ingredient *domain.Ingredient) error {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientRepository", "Create", EntityAttr("ingredient", ingredient))
+	err := d.next.Create(ctx, ingredient)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedIngredientRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Ingredient, error) {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedIngredientRepository) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.Ingredient, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientRepository", "GetAll")
+	r0, r1, err := d.next.GetAll(ctx, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedIngredientRepository) GetAllByRecipeId(ctx context.Context, id uuid.UUID) ([]*domain.Ingredient, error) {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientRepository", "GetAllByRecipeId", UUIDAttr("id", id))
+	r0, err := d.next.GetAllByRecipeId(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedIngredientRepository) Link(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) (uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientRepository", "Link", UUIDAttr("recipeId", recipeId), UUIDAttr("ingredientId", ingredientId))
+	r0, err := d.next.Link(ctx, recipeId, ingredientId)
+	call.End(err, UUIDAttr("result", r0))
+	return r0, err
+}
+
+func (d *InstrumentedIngredientRepository) Unlink(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientRepository", "Unlink", UUIDAttr("recipeId", recipeId), UUIDAttr("ingredientId", ingredientId))
+	err := d.next.Unlink(ctx, recipeId, ingredientId)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedIngredientRepository) Update(ctx context.Context, ingredient *domain.Ingredient) error {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientRepository", "Update", EntityAttr("ingredient", ingredient))
+	err := d.next.Update(ctx, ingredient)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedIngredientRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedIngredientService struct {
+	next      domain.IIngredientService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedIngredientService(next domain.IIngredientService, telemetry *Telemetry) domain.IIngredientService {
+	return &InstrumentedIngredientService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedIngredientService) Create(ctx context.Context, salad *domain.Ingredient) error {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientService", "Create", EntityAttr("salad", salad))
+	err := d.next.Create(ctx, salad)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedIngredientService) GetById(ctx context.Context, id uuid.UUID) (*domain.Ingredient, error) {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientService", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedIngredientService) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.Ingredient, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientService", "GetAll")
+	r0, r1, err := d.next.GetAll(ctx, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedIngredientService) GetAllByRecipeId(ctx context.Context, id uuid.UUID) ([]*domain.Ingredient, error) {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientService", "GetAllByRecipeId", UUIDAttr("id", id))
+	r0, err := d.next.GetAllByRecipeId(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedIngredientService) Link(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) (uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientService", "Link", UUIDAttr("recipeId", recipeId), UUIDAttr("ingredientId", ingredientId))
+	r0, err := d.next.Link(ctx, recipeId, ingredientId)
+	call.End(err, UUIDAttr("result", r0))
+	return r0, err
+}
+
+func (d *InstrumentedIngredientService) Unlink(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientService", "Unlink", UUIDAttr("recipeId", recipeId), UUIDAttr("ingredientId", ingredientId))
+	err := d.next.Unlink(ctx, recipeId, ingredientId)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedIngredientService) Update(ctx context.Context, salad *domain.Ingredient) error {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientService", "Update", EntityAttr("salad", salad))
+	err := d.next.Update(ctx, salad)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedIngredientService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientService", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedIngredientTypeRepository struct {
+	next      domain.IIngredientTypeRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedIngredientTypeRepository(next domain.IIngredientTypeRepository, telemetry *Telemetry) domain.IIngredientTypeRepository {
+	return &InstrumentedIngredientTypeRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedIngredientTypeRepository) Create(ctx context.Context, ingredientType *domain.IngredientType) error {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientTypeRepository", "Create", EntityAttr("ingredientType", ingredientType))
+	err := d.next.Create(ctx, ingredientType)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedIngredientTypeRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.IngredientType, error) {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientTypeRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedIngredientTypeRepository) GetAll(ctx context.Context) ([]*domain.IngredientType, error) {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientTypeRepository", "GetAll")
+	r0, err := d.next.GetAll(ctx)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedIngredientTypeRepository) GetAllByParentId(ctx context.Context, parentId uuid.UUID) ([]*domain.IngredientType, error) {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientTypeRepository", "GetAllByParentId", UUIDAttr("parentId", parentId))
+	r0, err := d.next.GetAllByParentId(ctx, parentId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedIngredientTypeRepository) Update(ctx context.Context, measurement *domain.IngredientType) error {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientTypeRepository", "Update", EntityAttr("measurement", measurement))
+	err := d.next.Update(ctx, measurement)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedIngredientTypeRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientTypeRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedIngredientTypeService struct {
+	next      domain.IIngredientTypeService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedIngredientTypeService(next domain.IIngredientTypeService, telemetry *Telemetry) domain.IIngredientTypeService {
+	return &InstrumentedIngredientTypeService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedIngredientTypeService) Create(ctx context.Context, measurement *domain.IngredientType) error {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientTypeService", "Create", EntityAttr("measurement", measurement))
+	err := d.next.Create(ctx, measurement)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedIngredientTypeService) GetById(ctx context.Context, id uuid.UUID) (*domain.IngredientType, error) {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientTypeService", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedIngredientTypeService) GetAll(ctx context.Context) ([]*domain.IngredientType, error) {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientTypeService", "GetAll")
+	r0, err := d.next.GetAll(ctx)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedIngredientTypeService) GetChildren(ctx context.Context, parentId uuid.UUID) ([]*domain.IngredientType, error) {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientTypeService", "GetChildren", UUIDAttr("parentId", parentId))
+	r0, err := d.next.GetChildren(ctx, parentId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedIngredientTypeService) GetSubtree(ctx context.Context, id uuid.UUID) ([]*domain.IngredientType, error) {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientTypeService", "GetSubtree", UUIDAttr("id", id))
+	r0, err := d.next.GetSubtree(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedIngredientTypeService) Move(ctx context.Context, id uuid.UUID, parentId uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientTypeService", "Move", UUIDAttr("id", id), UUIDAttr("parentId", parentId))
+	err := d.next.Move(ctx, id, parentId)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedIngredientTypeService) Update(ctx context.Context, measurement *domain.IngredientType) error {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientTypeService", "Update", EntityAttr("measurement", measurement))
+	err := d.next.Update(ctx, measurement)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedIngredientTypeService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IIngredientTypeService", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedKeywordValidatorRepository struct {
+	next      domain.IKeywordValidatorRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedKeywordValidatorRepository(next domain.IKeywordValidatorRepository, telemetry *Telemetry) domain.IKeywordValidatorRepository {
+	return &InstrumentedKeywordValidatorRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedKeywordValidatorRepository) Create(ctx context.Context, word *domain.KeyWord) error {
+	ctx, call := d.telemetry.Start(ctx, "IKeywordValidatorRepository", "Create", EntityAttr("word", word))
+	err := d.next.Create(ctx, word)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedKeywordValidatorRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.KeyWord, error) {
+	ctx, call := d.telemetry.Start(ctx, "IKeywordValidatorRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedKeywordValidatorRepository) GetAll(ctx context.Context) (map[string]uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "IKeywordValidatorRepository", "GetAll")
+	r0, err := d.next.GetAll(ctx)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedKeywordValidatorRepository) Update(ctx context.Context, word *domain.KeyWord) error {
+	ctx, call := d.telemetry.Start(ctx, "IKeywordValidatorRepository", "Update", EntityAttr("word", word))
+	err := d.next.Update(ctx, word)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedKeywordValidatorRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IKeywordValidatorRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedKeywordValidatorService struct {
+	next      domain.IKeywordValidatorService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedKeywordValidatorService(next domain.IKeywordValidatorService, telemetry *Telemetry) domain.IKeywordValidatorService {
+	return &InstrumentedKeywordValidatorService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedKeywordValidatorService) Verify(ctx context.Context, word string) error {
+	ctx, call := d.telemetry.Start(ctx, "IKeywordValidatorService", "Verify")
+	err := d.next.Verify(ctx, word)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedKeywordValidatorService) Create(ctx context.Context, word *domain.KeyWord) error {
+	ctx, call := d.telemetry.Start(ctx, "IKeywordValidatorService", "Create", EntityAttr("word", word))
+	err := d.next.Create(ctx, word)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedKeywordValidatorService) GetById(ctx context.Context, id uuid.UUID) (*domain.KeyWord, error) {
+	ctx, call := d.telemetry.Start(ctx, "IKeywordValidatorService", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedKeywordValidatorService) GetAll(ctx context.Context) (map[string]uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "IKeywordValidatorService", "GetAll")
+	r0, err := d.next.GetAll(ctx)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedKeywordValidatorService) Update(ctx context.Context, word *domain.KeyWord) error {
+	ctx, call := d.telemetry.Start(ctx, "IKeywordValidatorService", "Update", EntityAttr("word", word))
+	err := d.next.Update(ctx, word)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedKeywordValidatorService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IKeywordValidatorService", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedMeasurementRepository struct {
+	next      domain.IMeasurementRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedMeasurementRepository(next domain.IMeasurementRepository, telemetry *Telemetry) domain.IMeasurementRepository {
+	return &InstrumentedMeasurementRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedMeasurementRepository) Create(ctx context.Context, measurement *domain.Measurement) error {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementRepository", "Create", EntityAttr("measurement", measurement))
+	err := d.next.Create(ctx, measurement)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedMeasurementRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Measurement, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedMeasurementRepository) GetByRecipeId(ctx context.Context, ingredientId uuid.UUID, recipeId uuid.UUID) (*domain.Measurement, int, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementRepository", "GetByRecipeId", UUIDAttr("ingredientId", ingredientId), UUIDAttr("recipeId", recipeId))
+	r0, r1, err := d.next.GetByRecipeId(ctx, ingredientId, recipeId)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedMeasurementRepository) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeMeasurement, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementRepository", "GetAllByRecipeId", UUIDAttr("recipeId", recipeId))
+	r0, err := d.next.GetAllByRecipeId(ctx, recipeId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedMeasurementRepository) GetAll(ctx context.Context) ([]*domain.Measurement, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementRepository", "GetAll")
+	r0, err := d.next.GetAll(ctx)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedMeasurementRepository) UpdateLink(ctx context.Context, linkId uuid.UUID, measurementId uuid.UUID, amount int) error {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementRepository", "UpdateLink", UUIDAttr("linkId", linkId), UUIDAttr("measurementId", measurementId))
+	err := d.next.UpdateLink(ctx, linkId, measurementId, amount)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedMeasurementRepository) Update(ctx context.Context, measurement *domain.Measurement) error {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementRepository", "Update", EntityAttr("measurement", measurement))
+	err := d.next.Update(ctx, measurement)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedMeasurementRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedMeasurementService struct {
+	next      domain.IMeasurementService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedMeasurementService(next domain.IMeasurementService, telemetry *Telemetry) domain.IMeasurementService {
+	return &InstrumentedMeasurementService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedMeasurementService) Create(ctx context.Context, measurement *domain.Measurement) error {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementService", "Create", EntityAttr("measurement", measurement))
+	err := d.next.Create(ctx, measurement)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedMeasurementService) GetById(ctx context.Context, id uuid.UUID) (*domain.Measurement, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementService", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedMeasurementService) GetByRecipeId(ctx context.Context, ingredientId uuid.UUID, recipeId uuid.UUID) (*domain.Measurement, int, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementService", "GetByRecipeId", UUIDAttr("ingredientId", ingredientId), UUIDAttr("recipeId", recipeId))
+	r0, r1, err := d.next.GetByRecipeId(ctx, ingredientId, recipeId)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedMeasurementService) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeMeasurement, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementService", "GetAllByRecipeId", UUIDAttr("recipeId", recipeId))
+	r0, err := d.next.GetAllByRecipeId(ctx, recipeId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedMeasurementService) GetAll(ctx context.Context) ([]*domain.Measurement, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementService", "GetAll")
+	r0, err := d.next.GetAll(ctx)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedMeasurementService) UpdateLink(ctx context.Context, linkId uuid.UUID, measurementId uuid.UUID, amount int) error {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementService", "UpdateLink", UUIDAttr("linkId", linkId), UUIDAttr("measurementId", measurementId))
+	err := d.next.UpdateLink(ctx, linkId, measurementId, amount)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedMeasurementService) Update(ctx context.Context, measurement *domain.Measurement) error {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementService", "Update", EntityAttr("measurement", measurement))
+	err := d.next.Update(ctx, measurement)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedMeasurementService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementService", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedMeasurementService) Convert(ctx context.Context, quantity domain.Quantity, to string, ingredient *domain.Ingredient) (domain.Quantity, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementService", "Convert", EntityAttr("ingredient", ingredient))
+	r0, err := d.next.Convert(ctx, quantity, to, ingredient)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedMeasurementService) ToSystem(ctx context.Context, item *domain.SaladIngredient, system string) (domain.Quantity, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMeasurementService", "ToSystem")
+	r0, err := d.next.ToSystem(ctx, item, system)
+	call.End(err)
+	return r0, err
+}
+
+type InstrumentedMediaRepository struct {
+	next      domain.IMediaRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedMediaRepository(next domain.IMediaRepository, telemetry *Telemetry) domain.IMediaRepository {
+	return &InstrumentedMediaRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedMediaRepository) Create(ctx context.Context, media *domain.Media) (uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMediaRepository", "Create", EntityAttr("media", media))
+	r0, err := d.next.Create(ctx, media)
+	call.End(err, UUIDAttr("result", r0))
+	return r0, err
+}
+
+func (d *InstrumentedMediaRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Media, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMediaRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedMediaRepository) GetAllByOwner(ctx context.Context, ownerType string, ownerId uuid.UUID) ([]*domain.Media, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMediaRepository", "GetAllByOwner", UUIDAttr("ownerId", ownerId))
+	r0, err := d.next.GetAllByOwner(ctx, ownerType, ownerId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedMediaRepository) Update(ctx context.Context, media *domain.Media) error {
+	ctx, call := d.telemetry.Start(ctx, "IMediaRepository", "Update", EntityAttr("media", media))
+	err := d.next.Update(ctx, media)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedMediaRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IMediaRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedMediaService struct {
+	next      domain.IMediaService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedMediaService(next domain.IMediaService, telemetry *Telemetry) domain.IMediaService {
+	return &InstrumentedMediaService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedMediaService) Upload(ctx context.Context, upload *domain.MediaUpload) (*domain.Media, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMediaService", "Upload")
+	r0, err := d.next.Upload(ctx, upload)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedMediaService) GetById(ctx context.Context, id uuid.UUID) (*domain.Media, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMediaService", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedMediaService) GetAllByOwner(ctx context.Context, ownerType string, ownerId uuid.UUID) ([]*domain.Media, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMediaService", "GetAllByOwner", UUIDAttr("ownerId", ownerId))
+	r0, err := d.next.GetAllByOwner(ctx, ownerType, ownerId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedMediaService) GetContent(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMediaService", "GetContent", UUIDAttr("id", id))
+	r0, err := d.next.GetContent(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedMediaService) GetThumbnail(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	ctx, call := d.telemetry.Start(ctx, "IMediaService", "GetThumbnail", UUIDAttr("id", id))
+	r0, err := d.next.GetThumbnail(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedMediaService) Reorder(ctx context.Context, ownerType string, ownerId uuid.UUID, mediaIds []uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IMediaService", "Reorder", UUIDAttr("ownerId", ownerId))
+	err := d.next.Reorder(ctx, ownerType, ownerId, mediaIds)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedMediaService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IMediaService", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedMediaService) DeleteAllByOwner(ctx context.Context, ownerType string, ownerId uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IMediaService", "DeleteAllByOwner", UUIDAttr("ownerId", ownerId))
+	err := d.next.DeleteAllByOwner(ctx, ownerType, ownerId)
+	call.End(err)
+	return err
+}
+
+type InstrumentedModerationService struct {
+	next      domain.IModerationService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedModerationService(next domain.IModerationService, telemetry *Telemetry) domain.IModerationService {
+	return &InstrumentedModerationService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedModerationService) GetQueue(ctx context.Context, page *domain.PageRequest) ([]*domain.Recipe, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "IModerationService", "GetQueue")
+	r0, r1, err := d.next.GetQueue(ctx, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedModerationService) Approve(ctx context.Context, recipeId uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IModerationService", "Approve", UUIDAttr("recipeId", recipeId))
+	err := d.next.Approve(ctx, recipeId)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedModerationService) Reject(ctx context.Context, recipeId uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IModerationService", "Reject", UUIDAttr("recipeId", recipeId))
+	err := d.next.Reject(ctx, recipeId)
+	call.End(err)
+	return err
+}
+
+type InstrumentedRatingService struct {
+	next      domain.IRatingService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedRatingService(next domain.IRatingService, telemetry *Telemetry) domain.IRatingService {
+	return &InstrumentedRatingService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedRatingService) Recompute(ctx context.Context, saladId uuid.UUID) (float32, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRatingService", "Recompute", UUIDAttr("saladId", saladId))
+	r0, err := d.next.Recompute(ctx, saladId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedRatingService) RecomputeAll(ctx context.Context) (int, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRatingService", "RecomputeAll")
+	r0, err := d.next.RecomputeAll(ctx)
+	call.End(err)
+	return r0, err
+}
+
+type InstrumentedRecipeAggregateService struct {
+	next      domain.IRecipeAggregateService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedRecipeAggregateService(next domain.IRecipeAggregateService, telemetry *Telemetry) domain.IRecipeAggregateService {
+	return &InstrumentedRecipeAggregateService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedRecipeAggregateService) Create(ctx context.Context, aggregate *domain.RecipeAggregate) (uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeAggregateService", "Create")
+	r0, err := d.next.Create(ctx, aggregate)
+	call.End(err, UUIDAttr("result", r0))
+	return r0, err
+}
+
+func (d *InstrumentedRecipeAggregateService) Replace(ctx context.Context, aggregate *domain.RecipeAggregate) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeAggregateService", "Replace")
+	err := d.next.Replace(ctx, aggregate)
+	call.End(err)
+	return err
+}
+
+type InstrumentedRecipeExportService struct {
+	next      domain.IRecipeExportService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedRecipeExportService(next domain.IRecipeExportService, telemetry *Telemetry) domain.IRecipeExportService {
+	return &InstrumentedRecipeExportService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedRecipeExportService) Assemble(ctx context.Context, saladId uuid.UUID) (*domain.RecipeExport, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeExportService", "Assemble", UUIDAttr("saladId", saladId))
+	r0, err := d.next.Assemble(ctx, saladId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedRecipeExportService) Export(ctx context.Context, saladId uuid.UUID, format string) ([]byte, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeExportService", "Export", UUIDAttr("saladId", saladId))
+	r0, err := d.next.Export(ctx, saladId, format)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedRecipeExportService) Render(ctx context.Context, export *domain.RecipeExport, format string) ([]byte, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeExportService", "Render")
+	r0, err := d.next.Render(ctx, export, format)
+	call.End(err)
+	return r0, err
+}
+
+type InstrumentedRecipeIngredientRepository struct {
+	next      domain.IRecipeIngredientRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedRecipeIngredientRepository(next domain.IRecipeIngredientRepository, telemetry *Telemetry) domain.IRecipeIngredientRepository {
+	return &InstrumentedRecipeIngredientRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedRecipeIngredientRepository) Create(ctx context.Context, recipeIngredient *domain.RecipeIngredient) (uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeIngredientRepository", "Create", EntityAttr("recipeIngredient", recipeIngredient))
+	r0, err := d.next.Create(ctx, recipeIngredient)
+	call.End(err, UUIDAttr("result", r0))
+	return r0, err
+}
+
+func (d *InstrumentedRecipeIngredientRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.RecipeIngredient, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeIngredientRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedRecipeIngredientRepository) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeIngredient, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeIngredientRepository", "GetAllByRecipeId", UUIDAttr("recipeId", recipeId))
+	r0, err := d.next.GetAllByRecipeId(ctx, recipeId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedRecipeIngredientRepository) Update(ctx context.Context, recipeIngredient *domain.RecipeIngredient) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeIngredientRepository", "Update", EntityAttr("recipeIngredient", recipeIngredient))
+	err := d.next.Update(ctx, recipeIngredient)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedRecipeIngredientRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeIngredientRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedRecipeIngredientRepository) DeleteAllByRecipeId(ctx context.Context, recipeId uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeIngredientRepository", "DeleteAllByRecipeId", UUIDAttr("recipeId", recipeId))
+	err := d.next.DeleteAllByRecipeId(ctx, recipeId)
+	call.End(err)
+	return err
+}
+
+type InstrumentedRecipeIngredientService struct {
+	next      domain.IRecipeIngredientService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedRecipeIngredientService(next domain.IRecipeIngredientService, telemetry *Telemetry) domain.IRecipeIngredientService {
+	return &InstrumentedRecipeIngredientService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedRecipeIngredientService) Add(ctx context.Context, recipeIngredient *domain.RecipeIngredient) (uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeIngredientService", "Add", EntityAttr("recipeIngredient", recipeIngredient))
+	r0, err := d.next.Add(ctx, recipeIngredient)
+	call.End(err, UUIDAttr("result", r0))
+	return r0, err
+}
+
+func (d *InstrumentedRecipeIngredientService) GetById(ctx context.Context, id uuid.UUID) (*domain.RecipeIngredient, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeIngredientService", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedRecipeIngredientService) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeIngredient, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeIngredientService", "GetAllByRecipeId", UUIDAttr("recipeId", recipeId))
+	r0, err := d.next.GetAllByRecipeId(ctx, recipeId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedRecipeIngredientService) Update(ctx context.Context, recipeIngredient *domain.RecipeIngredient) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeIngredientService", "Update", EntityAttr("recipeIngredient", recipeIngredient))
+	err := d.next.Update(ctx, recipeIngredient)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedRecipeIngredientService) Remove(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeIngredientService", "Remove", UUIDAttr("id", id))
+	err := d.next.Remove(ctx, id)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedRecipeIngredientService) Reorder(ctx context.Context, recipeId uuid.UUID, recipeIngredientIds []uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeIngredientService", "Reorder", UUIDAttr("recipeId", recipeId))
+	err := d.next.Reorder(ctx, recipeId, recipeIngredientIds)
+	call.End(err)
+	return err
+}
+
+type InstrumentedRecipeRepository struct {
+	next      domain.IRecipeRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedRecipeRepository(next domain.IRecipeRepository, telemetry *Telemetry) domain.IRecipeRepository {
+	return &InstrumentedRecipeRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedRecipeRepository) Create(ctx context.Context, recipe *domain.Recipe) (uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeRepository", "Create", EntityAttr("recipe", recipe))
+	r0, err := d.next.Create(ctx, recipe)
+	call.End(err, UUIDAttr("result", r0))
+	return r0, err
+}
+
+func (d *InstrumentedRecipeRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Recipe, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedRecipeRepository) GetBySaladId(ctx context.Context, saladId uuid.UUID) (*domain.Recipe, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeRepository", "GetBySaladId", UUIDAttr("saladId", saladId))
+	r0, err := d.next.GetBySaladId(ctx, saladId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedRecipeRepository) GetAll(ctx context.Context, filter *domain.RecipeFilter, page *domain.PageRequest) ([]*domain.Recipe, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeRepository", "GetAll")
+	r0, r1, err := d.next.GetAll(ctx, filter, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedRecipeRepository) Update(ctx context.Context, recipe *domain.Recipe) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeRepository", "Update", EntityAttr("recipe", recipe))
+	err := d.next.Update(ctx, recipe)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedRecipeRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedRecipeService struct {
+	next      domain.IRecipeService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedRecipeService(next domain.IRecipeService, telemetry *Telemetry) domain.IRecipeService {
+	return &InstrumentedRecipeService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedRecipeService) Create(ctx context.Context, user *domain.Recipe) (uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeService", "Create", EntityAttr("user", user))
+	r0, err := d.next.Create(ctx, user)
+	call.End(err, UUIDAttr("result", r0))
+	return r0, err
+}
+
+func (d *InstrumentedRecipeService) GetById(ctx context.Context, id uuid.UUID) (*domain.Recipe, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeService", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedRecipeService) GetBySaladId(ctx context.Context, saladId uuid.UUID) (*domain.Recipe, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeService", "GetBySaladId", UUIDAttr("saladId", saladId))
+	r0, err := d.next.GetBySaladId(ctx, saladId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedRecipeService) GetAll(ctx context.Context, filter *domain.RecipeFilter, page *domain.PageRequest) ([]*domain.Recipe, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeService", "GetAll")
+	r0, r1, err := d.next.GetAll(ctx, filter, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedRecipeService) Update(ctx context.Context, recipe *domain.Recipe) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeService", "Update", EntityAttr("recipe", recipe))
+	err := d.next.Update(ctx, recipe)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedRecipeService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeService", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedRecipeStepRepository struct {
+	next      domain.IRecipeStepRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedRecipeStepRepository(next domain.IRecipeStepRepository, telemetry *Telemetry) domain.IRecipeStepRepository {
+	return &InstrumentedRecipeStepRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedRecipeStepRepository) Create(ctx context.Context, recipeStep *domain.RecipeStep) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeStepRepository", "Create", EntityAttr("recipeStep", recipeStep))
+	err := d.next.Create(ctx, recipeStep)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedRecipeStepRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.RecipeStep, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeStepRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedRecipeStepRepository) GetAllByRecipeID(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeStep, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeStepRepository", "GetAllByRecipeID", UUIDAttr("recipeId", recipeId))
+	r0, err := d.next.GetAllByRecipeID(ctx, recipeId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedRecipeStepRepository) Update(ctx context.Context, recipeStep *domain.RecipeStep) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeStepRepository", "Update", EntityAttr("recipeStep", recipeStep))
+	err := d.next.Update(ctx, recipeStep)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedRecipeStepRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeStepRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedRecipeStepRepository) DeleteAllByRecipeID(ctx context.Context, recipeId uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeStepRepository", "DeleteAllByRecipeID", UUIDAttr("recipeId", recipeId))
+	err := d.next.DeleteAllByRecipeID(ctx, recipeId)
+	call.End(err)
+	return err
+}
+
+type InstrumentedRecipeStepService struct {
+	next      domain.IRecipeStepService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedRecipeStepService(next domain.IRecipeStepService, telemetry *Telemetry) domain.IRecipeStepService {
+	return &InstrumentedRecipeStepService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedRecipeStepService) Create(ctx context.Context, recipeStep *domain.RecipeStep) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeStepService", "Create", EntityAttr("recipeStep", recipeStep))
+	err := d.next.Create(ctx, recipeStep)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedRecipeStepService) GetById(ctx context.Context, id uuid.UUID) (*domain.RecipeStep, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeStepService", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedRecipeStepService) GetAllByRecipeID(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeStep, error) {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeStepService", "GetAllByRecipeID", UUIDAttr("recipeId", recipeId))
+	r0, err := d.next.GetAllByRecipeID(ctx, recipeId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedRecipeStepService) Update(ctx context.Context, recipeStep *domain.RecipeStep) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeStepService", "Update", EntityAttr("recipeStep", recipeStep))
+	err := d.next.Update(ctx, recipeStep)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedRecipeStepService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeStepService", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedRecipeStepService) DeleteAllByRecipeID(ctx context.Context, recipeId uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeStepService", "DeleteAllByRecipeID", UUIDAttr("recipeId", recipeId))
+	err := d.next.DeleteAllByRecipeID(ctx, recipeId)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedRecipeStepService) Reorder(ctx context.Context, recipeId uuid.UUID, stepIds []uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IRecipeStepService", "Reorder", UUIDAttr("recipeId", recipeId))
+	err := d.next.Reorder(ctx, recipeId, stepIds)
+	call.End(err)
+	return err
+}
+
+type InstrumentedSaladDetailsService struct {
+	next      domain.ISaladDetailsService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedSaladDetailsService(next domain.ISaladDetailsService, telemetry *Telemetry) domain.ISaladDetailsService {
+	return &InstrumentedSaladDetailsService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedSaladDetailsService) GetById(ctx context.Context, saladId uuid.UUID, commentsPage *domain.PageRequest) (*domain.SaladDetails, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladDetailsService", "GetById", UUIDAttr("saladId", saladId))
+	r0, err := d.next.GetById(ctx, saladId, commentsPage)
+	call.End(err)
+	return r0, err
+}
+
+type InstrumentedSaladRepository struct {
+	next      domain.ISaladRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedSaladRepository(next domain.ISaladRepository, telemetry *Telemetry) domain.ISaladRepository {
+	return &InstrumentedSaladRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedSaladRepository) Create(ctx context.Context, salad *domain.Salad) (uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladRepository", "Create", EntityAttr("salad", salad))
+	r0, err := d.next.Create(ctx, salad)
+	call.End(err, UUIDAttr("result", r0))
+	return r0, err
+}
+
+func (d *InstrumentedSaladRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Salad, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedSaladRepository) GetAll(ctx context.Context, filter *domain.RecipeFilter, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladRepository", "GetAll")
+	r0, r1, err := d.next.GetAll(ctx, filter, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedSaladRepository) GetAllByUserId(ctx context.Context, id uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladRepository", "GetAllByUserId", UUIDAttr("id", id))
+	r0, r1, err := d.next.GetAllByUserId(ctx, id, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedSaladRepository) GetAllRatedByUser(ctx context.Context, userId uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladRepository", "GetAllRatedByUser", UUIDAttr("userId", userId))
+	r0, r1, err := d.next.GetAllRatedByUser(ctx, userId, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedSaladRepository) Update(ctx context.Context, salad *domain.Salad) error {
+	ctx, call := d.telemetry.Start(ctx, "ISaladRepository", "Update", EntityAttr("salad", salad))
+	err := d.next.Update(ctx, salad)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedSaladRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "ISaladRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedSaladService struct {
+	next      domain.ISaladService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedSaladService(next domain.ISaladService, telemetry *Telemetry) domain.ISaladService {
+	return &InstrumentedSaladService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedSaladService) Create(ctx context.Context, salad *domain.Salad) (uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladService", "Create", EntityAttr("salad", salad))
+	r0, err := d.next.Create(ctx, salad)
+	call.End(err, UUIDAttr("result", r0))
+	return r0, err
+}
+
+func (d *InstrumentedSaladService) GetById(ctx context.Context, id uuid.UUID) (*domain.Salad, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladService", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedSaladService) GetAll(ctx context.Context, filter *domain.RecipeFilter, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladService", "GetAll")
+	r0, r1, err := d.next.GetAll(ctx, filter, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedSaladService) GetAllByUserId(ctx context.Context, id uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladService", "GetAllByUserId", UUIDAttr("id", id))
+	r0, r1, err := d.next.GetAllByUserId(ctx, id, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedSaladService) GetAllRatedByUser(ctx context.Context, userId uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladService", "GetAllRatedByUser", UUIDAttr("userId", userId))
+	r0, r1, err := d.next.GetAllRatedByUser(ctx, userId, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedSaladService) Update(ctx context.Context, salad *domain.Salad) error {
+	ctx, call := d.telemetry.Start(ctx, "ISaladService", "Update", EntityAttr("salad", salad))
+	err := d.next.Update(ctx, salad)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedSaladService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "ISaladService", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedSaladTypeRepository struct {
+	next      domain.ISaladTypeRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedSaladTypeRepository(next domain.ISaladTypeRepository, telemetry *Telemetry) domain.ISaladTypeRepository {
+	return &InstrumentedSaladTypeRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedSaladTypeRepository) Create(ctx context.Context, saladType *domain.SaladType) error {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeRepository", "Create", EntityAttr("saladType", saladType))
+	err := d.next.Create(ctx, saladType)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedSaladTypeRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.SaladType, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedSaladTypeRepository) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.SaladType, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeRepository", "GetAll")
+	r0, r1, err := d.next.GetAll(ctx, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedSaladTypeRepository) GetAllBySaladId(ctx context.Context, saladId uuid.UUID) ([]*domain.SaladType, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeRepository", "GetAllBySaladId", UUIDAttr("saladId", saladId))
+	r0, err := d.next.GetAllBySaladId(ctx, saladId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedSaladTypeRepository) GetAllByParentId(ctx context.Context, parentId uuid.UUID) ([]*domain.SaladType, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeRepository", "GetAllByParentId", UUIDAttr("parentId", parentId))
+	r0, err := d.next.GetAllByParentId(ctx, parentId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedSaladTypeRepository) Update(ctx context.Context, saladType *domain.SaladType) error {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeRepository", "Update", EntityAttr("saladType", saladType))
+	err := d.next.Update(ctx, saladType)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedSaladTypeRepository) Link(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeRepository", "Link", UUIDAttr("saladId", saladId), UUIDAttr("saladTypeId", saladTypeId))
+	err := d.next.Link(ctx, saladId, saladTypeId)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedSaladTypeRepository) Unlink(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeRepository", "Unlink", UUIDAttr("saladId", saladId), UUIDAttr("saladTypeId", saladTypeId))
+	err := d.next.Unlink(ctx, saladId, saladTypeId)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedSaladTypeRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedSaladTypeService struct {
+	next      domain.ISaladTypeService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedSaladTypeService(next domain.ISaladTypeService, telemetry *Telemetry) domain.ISaladTypeService {
+	return &InstrumentedSaladTypeService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedSaladTypeService) Create(ctx context.Context, saladType *domain.SaladType) error {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeService", "Create", EntityAttr("saladType", saladType))
+	err := d.next.Create(ctx, saladType)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedSaladTypeService) GetById(ctx context.Context, id uuid.UUID) (*domain.SaladType, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeService", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedSaladTypeService) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.SaladType, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeService", "GetAll")
+	r0, r1, err := d.next.GetAll(ctx, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedSaladTypeService) GetAllBySaladId(ctx context.Context, saladId uuid.UUID) ([]*domain.SaladType, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeService", "GetAllBySaladId", UUIDAttr("saladId", saladId))
+	r0, err := d.next.GetAllBySaladId(ctx, saladId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedSaladTypeService) GetChildren(ctx context.Context, parentId uuid.UUID) ([]*domain.SaladType, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeService", "GetChildren", UUIDAttr("parentId", parentId))
+	r0, err := d.next.GetChildren(ctx, parentId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedSaladTypeService) GetSubtree(ctx context.Context, id uuid.UUID) ([]*domain.SaladType, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeService", "GetSubtree", UUIDAttr("id", id))
+	r0, err := d.next.GetSubtree(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedSaladTypeService) Move(ctx context.Context, id uuid.UUID, parentId uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeService", "Move", UUIDAttr("id", id), UUIDAttr("parentId", parentId))
+	err := d.next.Move(ctx, id, parentId)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedSaladTypeService) Update(ctx context.Context, measurement *domain.SaladType) error {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeService", "Update", EntityAttr("measurement", measurement))
+	err := d.next.Update(ctx, measurement)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedSaladTypeService) Link(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeService", "Link", UUIDAttr("saladId", saladId), UUIDAttr("saladTypeId", saladTypeId))
+	err := d.next.Link(ctx, saladId, saladTypeId)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedSaladTypeService) Unlink(ctx context.Context, saladId uuid.UUID, saladTypeId uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeService", "Unlink", UUIDAttr("saladId", saladId), UUIDAttr("saladTypeId", saladTypeId))
+	err := d.next.Unlink(ctx, saladId, saladTypeId)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedSaladTypeService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "ISaladTypeService", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedSubstitutionRepository struct {
+	next      domain.ISubstitutionRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedSubstitutionRepository(next domain.ISubstitutionRepository, telemetry *Telemetry) domain.ISubstitutionRepository {
+	return &InstrumentedSubstitutionRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedSubstitutionRepository) Create(ctx context.Context, substitution *domain.Substitution) (uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISubstitutionRepository", "Create", EntityAttr("substitution", substitution))
+	r0, err := d.next.Create(ctx, substitution)
+	call.End(err, UUIDAttr("result", r0))
+	return r0, err
+}
+
+func (d *InstrumentedSubstitutionRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Substitution, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISubstitutionRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedSubstitutionRepository) GetAllByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]*domain.Substitution, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISubstitutionRepository", "GetAllByIngredientId", UUIDAttr("ingredientId", ingredientId))
+	r0, err := d.next.GetAllByIngredientId(ctx, ingredientId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedSubstitutionRepository) Update(ctx context.Context, substitution *domain.Substitution) error {
+	ctx, call := d.telemetry.Start(ctx, "ISubstitutionRepository", "Update", EntityAttr("substitution", substitution))
+	err := d.next.Update(ctx, substitution)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedSubstitutionRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "ISubstitutionRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedSubstitutionService struct {
+	next      domain.ISubstitutionService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedSubstitutionService(next domain.ISubstitutionService, telemetry *Telemetry) domain.ISubstitutionService {
+	return &InstrumentedSubstitutionService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedSubstitutionService) Create(ctx context.Context, substitution *domain.Substitution) (uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISubstitutionService", "Create", EntityAttr("substitution", substitution))
+	r0, err := d.next.Create(ctx, substitution)
+	call.End(err, UUIDAttr("result", r0))
+	return r0, err
+}
+
+func (d *InstrumentedSubstitutionService) GetById(ctx context.Context, id uuid.UUID) (*domain.Substitution, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISubstitutionService", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedSubstitutionService) GetAllByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]*domain.Substitution, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISubstitutionService", "GetAllByIngredientId", UUIDAttr("ingredientId", ingredientId))
+	r0, err := d.next.GetAllByIngredientId(ctx, ingredientId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedSubstitutionService) Update(ctx context.Context, substitution *domain.Substitution) error {
+	ctx, call := d.telemetry.Start(ctx, "ISubstitutionService", "Update", EntityAttr("substitution", substitution))
+	err := d.next.Update(ctx, substitution)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedSubstitutionService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "ISubstitutionService", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedSubstitutionService) GetForRecipeIngredient(ctx context.Context, recipeIngredientId uuid.UUID) ([]*domain.SubstituteOption, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISubstitutionService", "GetForRecipeIngredient", UUIDAttr("recipeIngredientId", recipeIngredientId))
+	r0, err := d.next.GetForRecipeIngredient(ctx, recipeIngredientId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedSubstitutionService) Apply(ctx context.Context, saladId uuid.UUID, choices []*domain.SubstitutionChoice) (*domain.RecipeExport, error) {
+	ctx, call := d.telemetry.Start(ctx, "ISubstitutionService", "Apply", UUIDAttr("saladId", saladId))
+	r0, err := d.next.Apply(ctx, saladId, choices)
+	call.End(err)
+	return r0, err
+}
+
+type InstrumentedTranslationRepository struct {
+	next      domain.ITranslationRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedTranslationRepository(next domain.ITranslationRepository, telemetry *Telemetry) domain.ITranslationRepository {
+	return &InstrumentedTranslationRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedTranslationRepository) Create(ctx context.Context, translation *domain.Translation) (uuid.UUID, error) {
+	ctx, call := d.telemetry.Start(ctx, "ITranslationRepository", "Create", EntityAttr("translation", translation))
+	r0, err := d.next.Create(ctx, translation)
+	call.End(err, UUIDAttr("result", r0))
+	return r0, err
+}
+
+func (d *InstrumentedTranslationRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Translation, error) {
+	ctx, call := d.telemetry.Start(ctx, "ITranslationRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedTranslationRepository) GetAllByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*domain.Translation, error) {
+	ctx, call := d.telemetry.Start(ctx, "ITranslationRepository", "GetAllByEntity", UUIDAttr("entityId", entityId))
+	r0, err := d.next.GetAllByEntity(ctx, entityType, entityId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedTranslationRepository) GetAllByEntities(ctx context.Context, entityType string, entityIds []uuid.UUID, locales []string) ([]*domain.Translation, error) {
+	ctx, call := d.telemetry.Start(ctx, "ITranslationRepository", "GetAllByEntities")
+	r0, err := d.next.GetAllByEntities(ctx, entityType, entityIds, locales)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedTranslationRepository) GetMissing(ctx context.Context, entityType string, locale string) ([]*domain.MissingTranslation, error) {
+	ctx, call := d.telemetry.Start(ctx, "ITranslationRepository", "GetMissing")
+	r0, err := d.next.GetMissing(ctx, entityType, locale)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedTranslationRepository) Update(ctx context.Context, translation *domain.Translation) error {
+	ctx, call := d.telemetry.Start(ctx, "ITranslationRepository", "Update", EntityAttr("translation", translation))
+	err := d.next.Update(ctx, translation)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedTranslationRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "ITranslationRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedTranslationService struct {
+	next      domain.ITranslationService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedTranslationService(next domain.ITranslationService, telemetry *Telemetry) domain.ITranslationService {
+	return &InstrumentedTranslationService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedTranslationService) Set(ctx context.Context, translation *domain.Translation) error {
+	ctx, call := d.telemetry.Start(ctx, "ITranslationService", "Set", EntityAttr("translation", translation))
+	err := d.next.Set(ctx, translation)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedTranslationService) GetAllByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*domain.Translation, error) {
+	ctx, call := d.telemetry.Start(ctx, "ITranslationService", "GetAllByEntity", UUIDAttr("entityId", entityId))
+	r0, err := d.next.GetAllByEntity(ctx, entityType, entityId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedTranslationService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "ITranslationService", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedTranslationService) Localize(ctx context.Context, entityType string, texts []domain.TranslatableText) error {
+	ctx, call := d.telemetry.Start(ctx, "ITranslationService", "Localize")
+	err := d.next.Localize(ctx, entityType, texts)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedTranslationService) GetMissing(ctx context.Context, entityType string, locale string) ([]*domain.MissingTranslation, error) {
+	ctx, call := d.telemetry.Start(ctx, "ITranslationService", "GetMissing")
+	r0, err := d.next.GetMissing(ctx, entityType, locale)
+	call.End(err)
+	return r0, err
+}
+
+type InstrumentedUserRepository struct {
+	next      domain.IUserRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedUserRepository(next domain.IUserRepository, telemetry *Telemetry) domain.IUserRepository {
+	return &InstrumentedUserRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedUserRepository) Create(ctx context.Context, user *domain.User) error {
+	ctx, call := d.telemetry.Start(ctx, "IUserRepository", "Create", EntityAttr("user", user))
+	err := d.next.Create(ctx, user)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedUserRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	ctx, call := d.telemetry.Start(ctx, "IUserRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedUserRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	ctx, call := d.telemetry.Start(ctx, "IUserRepository", "GetByUsername")
+	r0, err := d.next.GetByUsername(ctx, username)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedUserRepository) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.User, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "IUserRepository", "GetAll")
+	r0, r1, err := d.next.GetAll(ctx, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedUserRepository) Update(ctx context.Context, user *domain.User) error {
+	ctx, call := d.telemetry.Start(ctx, "IUserRepository", "Update", EntityAttr("user", user))
+	err := d.next.Update(ctx, user)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedUserRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IUserRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedUserService struct {
+	next      domain.IUserService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedUserService(next domain.IUserService, telemetry *Telemetry) domain.IUserService {
+	return &InstrumentedUserService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedUserService) Create(ctx context.Context, user *domain.User) error {
+	ctx, call := d.telemetry.Start(ctx, "IUserService", "Create", EntityAttr("user", user))
+	err := d.next.Create(ctx, user)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedUserService) GetById(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	ctx, call := d.telemetry.Start(ctx, "IUserService", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedUserService) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	ctx, call := d.telemetry.Start(ctx, "IUserService", "GetByUsername")
+	r0, err := d.next.GetByUsername(ctx, username)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedUserService) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.User, *domain.PageInfo, error) {
+	ctx, call := d.telemetry.Start(ctx, "IUserService", "GetAll")
+	r0, r1, err := d.next.GetAll(ctx, page)
+	call.End(err)
+	return r0, r1, err
+}
+
+func (d *InstrumentedUserService) Update(ctx context.Context, user *domain.User) error {
+	ctx, call := d.telemetry.Start(ctx, "IUserService", "Update", EntityAttr("user", user))
+	err := d.next.Update(ctx, user)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedUserService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IUserService", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedValidatorService struct {
+	next      domain.IValidatorService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedValidatorService(next domain.IValidatorService, telemetry *Telemetry) domain.IValidatorService {
+	return &InstrumentedValidatorService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedValidatorService) Verify(ctx context.Context, word string) error {
+	ctx, call := d.telemetry.Start(ctx, "IValidatorService", "Verify")
+	err := d.next.Verify(ctx, word)
+	call.End(err)
+	return err
+}
+
+type InstrumentedWebhookDeadLetterRepository struct {
+	next      domain.IWebhookDeadLetterRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedWebhookDeadLetterRepository(next domain.IWebhookDeadLetterRepository, telemetry *Telemetry) domain.IWebhookDeadLetterRepository {
+	return &InstrumentedWebhookDeadLetterRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedWebhookDeadLetterRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookDeadLetterRepository", "Create", EntityAttr("delivery", delivery))
+	err := d.next.Create(ctx, delivery)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedWebhookDeadLetterRepository) GetAll(ctx context.Context) ([]*domain.WebhookDelivery, error) {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookDeadLetterRepository", "GetAll")
+	r0, err := d.next.GetAll(ctx)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedWebhookDeadLetterRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookDeadLetterRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
+
+type InstrumentedWebhookDeliveryRepository struct {
+	next      domain.IWebhookDeliveryRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedWebhookDeliveryRepository(next domain.IWebhookDeliveryRepository, telemetry *Telemetry) domain.IWebhookDeliveryRepository {
+	return &InstrumentedWebhookDeliveryRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedWebhookDeliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookDeliveryRepository", "Create", EntityAttr("delivery", delivery))
+	err := d.next.Create(ctx, delivery)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedWebhookDeliveryRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookDeliveryRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedWebhookDeliveryRepository) GetAllBySubscriptionId(ctx context.Context, subscriptionId uuid.UUID) ([]*domain.WebhookDelivery, error) {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookDeliveryRepository", "GetAllBySubscriptionId", UUIDAttr("subscriptionId", subscriptionId))
+	r0, err := d.next.GetAllBySubscriptionId(ctx, subscriptionId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedWebhookDeliveryRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookDeliveryRepository", "GetDue")
+	r0, err := d.next.GetDue(ctx, now, limit)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedWebhookDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookDeliveryRepository", "Update", EntityAttr("delivery", delivery))
+	err := d.next.Update(ctx, delivery)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedWebhookDeliveryRepository) CreateAttempt(ctx context.Context, attempt *domain.WebhookAttempt) error {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookDeliveryRepository", "CreateAttempt", EntityAttr("attempt", attempt))
+	err := d.next.CreateAttempt(ctx, attempt)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedWebhookDeliveryRepository) GetAttempts(ctx context.Context, deliveryId uuid.UUID) ([]*domain.WebhookAttempt, error) {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookDeliveryRepository", "GetAttempts", UUIDAttr("deliveryId", deliveryId))
+	r0, err := d.next.GetAttempts(ctx, deliveryId)
+	call.End(err)
+	return r0, err
+}
+
+type InstrumentedWebhookService struct {
+	next      domain.IWebhookService
+	telemetry *Telemetry
+}
+
+func NewInstrumentedWebhookService(next domain.IWebhookService, telemetry *Telemetry) domain.IWebhookService {
+	return &InstrumentedWebhookService{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedWebhookService) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookService", "CreateSubscription", EntityAttr("subscription", subscription))
+	err := d.next.CreateSubscription(ctx, subscription)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedWebhookService) GetSubscriptionById(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookService", "GetSubscriptionById", UUIDAttr("id", id))
+	r0, err := d.next.GetSubscriptionById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedWebhookService) GetAllSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookService", "GetAllSubscriptions")
+	r0, err := d.next.GetAllSubscriptions(ctx)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedWebhookService) UpdateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookService", "UpdateSubscription", EntityAttr("subscription", subscription))
+	err := d.next.UpdateSubscription(ctx, subscription)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedWebhookService) DeleteSubscriptionById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookService", "DeleteSubscriptionById", UUIDAttr("id", id))
+	err := d.next.DeleteSubscriptionById(ctx, id)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedWebhookService) HandleEvent(ctx context.Context, event domain.IEvent) error {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookService", "HandleEvent")
+	err := d.next.HandleEvent(ctx, event)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedWebhookService) DeliverDue(ctx context.Context, limit int) (int, error) {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookService", "DeliverDue")
+	r0, err := d.next.DeliverDue(ctx, limit)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedWebhookService) Redeliver(ctx context.Context, deliveryId uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookService", "Redeliver", UUIDAttr("deliveryId", deliveryId))
+	err := d.next.Redeliver(ctx, deliveryId)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedWebhookService) GetDeliveries(ctx context.Context, subscriptionId uuid.UUID) ([]*domain.WebhookDelivery, error) {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookService", "GetDeliveries", UUIDAttr("subscriptionId", subscriptionId))
+	r0, err := d.next.GetDeliveries(ctx, subscriptionId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedWebhookService) GetAttempts(ctx context.Context, deliveryId uuid.UUID) ([]*domain.WebhookAttempt, error) {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookService", "GetAttempts", UUIDAttr("deliveryId", deliveryId))
+	r0, err := d.next.GetAttempts(ctx, deliveryId)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedWebhookService) GetDeadLetters(ctx context.Context) ([]*domain.WebhookDelivery, error) {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookService", "GetDeadLetters")
+	r0, err := d.next.GetDeadLetters(ctx)
+	call.End(err)
+	return r0, err
+}
+
+type InstrumentedWebhookSubscriptionRepository struct {
+	next      domain.IWebhookSubscriptionRepository
+	telemetry *Telemetry
+}
+
+func NewInstrumentedWebhookSubscriptionRepository(next domain.IWebhookSubscriptionRepository, telemetry *Telemetry) domain.IWebhookSubscriptionRepository {
+	return &InstrumentedWebhookSubscriptionRepository{next: next, telemetry: telemetry}
+}
+
+func (d *InstrumentedWebhookSubscriptionRepository) Create(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookSubscriptionRepository", "Create", EntityAttr("subscription", subscription))
+	err := d.next.Create(ctx, subscription)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedWebhookSubscriptionRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookSubscriptionRepository", "GetById", UUIDAttr("id", id))
+	r0, err := d.next.GetById(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedWebhookSubscriptionRepository) GetAll(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookSubscriptionRepository", "GetAll")
+	r0, err := d.next.GetAll(ctx)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedWebhookSubscriptionRepository) GetAllByEventType(ctx context.Context, eventType string) ([]*domain.WebhookSubscription, error) {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookSubscriptionRepository", "GetAllByEventType")
+	r0, err := d.next.GetAllByEventType(ctx, eventType)
+	call.End(err)
+	return r0, err
+}
+
+func (d *InstrumentedWebhookSubscriptionRepository) Update(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookSubscriptionRepository", "Update", EntityAttr("subscription", subscription))
+	err := d.next.Update(ctx, subscription)
+	call.End(err)
+	return err
+}
+
+func (d *InstrumentedWebhookSubscriptionRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ctx, call := d.telemetry.Start(ctx, "IWebhookSubscriptionRepository", "DeleteById", UUIDAttr("id", id))
+	err := d.next.DeleteById(ctx, id)
+	call.End(err)
+	return err
+}
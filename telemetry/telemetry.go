@@ -0,0 +1,62 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run ./gen -domain ../domain -out instrumented.go
+
+const (
+	CallsMetric    = "ppo_calls_total"
+	ErrorsMetric   = "ppo_call_errors_total"
+	DurationMetric = "ppo_call_duration_seconds"
+)
+
+// Telemetry records metrics and spans of interface method calls,
+// the Instrumented* decorators use it
+type Telemetry struct {
+	tracer   *Tracer
+	calls    *CounterVec
+	errors   *CounterVec
+	duration *HistogramVec
+}
+
+func New(registry *Registry, tracer *Tracer) *Telemetry {
+	return &Telemetry{
+		tracer:   tracer,
+		calls:    registry.NewCounterVec(CallsMetric, "Number of calls.", "interface", "method"),
+		errors:   registry.NewCounterVec(ErrorsMetric, "Number of calls returned an error.", "interface", "method"),
+		duration: registry.NewHistogramVec(DurationMetric, "Call latency in seconds.", DefaultBuckets, "interface", "method"),
+	}
+}
+
+type Call struct {
+	telemetry *Telemetry
+	span      *Span
+	iface     string
+	method    string
+	start     time.Time
+}
+
+func (t *Telemetry) Start(ctx context.Context, iface string, method string, attrs ...Attribute) (context.Context, *Call) {
+	ctx, span := t.tracer.Start(ctx, iface+"."+method, attrs...)
+	return ctx, &Call{
+		telemetry: t,
+		span:      span,
+		iface:     iface,
+		method:    method,
+		start:     time.Now(),
+	}
+}
+
+func (c *Call) End(err error, attrs ...Attribute) {
+	c.telemetry.calls.Inc(c.iface, c.method)
+	if err != nil {
+		c.telemetry.errors.Inc(c.iface, c.method)
+	}
+	c.telemetry.duration.Observe(time.Since(c.start).Seconds(), c.iface, c.method)
+
+	c.span.SetAttributes(attrs...)
+	c.span.End(err)
+}
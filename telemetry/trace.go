@@ -0,0 +1,222 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	StatusUnset = "UNSET"
+	StatusOk    = "OK"
+	StatusError = "ERROR"
+)
+
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+func StringAttr(key string, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func UUIDAttr(key string, value uuid.UUID) Attribute {
+	return Attribute{Key: key, Value: value.String()}
+}
+
+// EntityAttr reads the ID field of a struct or a pointer to it, the attribute key
+// is name + ".id". Values without an ID give an attribute with an empty value.
+func EntityAttr(name string, entity interface{}) Attribute {
+	attr := Attribute{Key: name + ".id"}
+
+	value := reflect.ValueOf(entity)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return attr
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return attr
+	}
+	field := value.FieldByName("ID")
+	if !field.IsValid() || !field.CanInterface() {
+		return attr
+	}
+	if id, ok := field.Interface().(uuid.UUID); ok {
+		attr.Value = id.String()
+	}
+	return attr
+}
+
+// Span follows the OpenTelemetry span model: IDs are hex encoded, ParentSpanID is
+// empty for root spans
+type Span struct {
+	TraceID       string
+	SpanID        string
+	ParentSpanID  string
+	Name          string
+	StartTime     time.Time
+	EndTime       time.Time
+	Attributes    []Attribute
+	Status        string
+	StatusMessage string
+
+	mu     sync.Mutex
+	tracer *Tracer
+	ended  bool
+}
+
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Attributes = append(s.Attributes, attrs...)
+}
+
+// End finishes the span with an error status when err is not nil and exports it,
+// next calls do nothing
+func (s *Span) End(err error) {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.EndTime = time.Now()
+	if err != nil {
+		s.Status = StatusError
+		s.StatusMessage = err.Error()
+	} else {
+		s.Status = StatusOk
+	}
+	s.mu.Unlock()
+
+	s.tracer.export(s)
+}
+
+type SpanExporter interface {
+	ExportSpan(span *Span) error
+}
+
+type Tracer struct {
+	exporter SpanExporter
+	onError  func(err error)
+}
+
+// NewTracer creates a tracer, spans are dropped when exporter is nil
+func NewTracer(exporter SpanExporter) *Tracer {
+	return &Tracer{exporter: exporter}
+}
+
+// OnError sets the handler of export errors
+func (t *Tracer) OnError(handler func(err error)) *Tracer {
+	t.onError = handler
+	return t
+}
+
+type spanKey struct{}
+
+func SpanFromContext(ctx context.Context) (*Span, bool) {
+	span, ok := ctx.Value(spanKey{}).(*Span)
+	return span, ok
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Start begins a span, a span in ctx becomes its parent
+func (t *Tracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	span := &Span{
+		SpanID:     randomHex(8),
+		Name:       name,
+		StartTime:  time.Now(),
+		Attributes: attrs,
+		Status:     StatusUnset,
+		tracer:     t,
+	}
+	if parent, ok := SpanFromContext(ctx); ok {
+		span.TraceID = parent.TraceID
+		span.ParentSpanID = parent.SpanID
+	} else {
+		span.TraceID = randomHex(16)
+	}
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (t *Tracer) export(span *Span) {
+	if t.exporter == nil {
+		return
+	}
+	if err := t.exporter.ExportSpan(span); err != nil && t.onError != nil {
+		t.onError(fmt.Errorf("exporting span %s: %w", span.Name, err))
+	}
+}
+
+// StdoutExporter writes a JSON line per span, pass os.Stdout for local use
+type StdoutExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewStdoutExporter(w io.Writer) *StdoutExporter {
+	return &StdoutExporter{w: w}
+}
+
+type exportedSpan struct {
+	Name         string            `json:"name"`
+	TraceID      string            `json:"traceId"`
+	SpanID       string            `json:"spanId"`
+	ParentSpanID string            `json:"parentSpanId,omitempty"`
+	StartTime    time.Time         `json:"startTime"`
+	EndTime      time.Time         `json:"endTime"`
+	Duration     string            `json:"duration"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+	Status       struct {
+		Code    string `json:"code"`
+		Message string `json:"message,omitempty"`
+	} `json:"status"`
+}
+
+func (e *StdoutExporter) ExportSpan(span *Span) error {
+	exported := exportedSpan{
+		Name:         span.Name,
+		TraceID:      span.TraceID,
+		SpanID:       span.SpanID,
+		ParentSpanID: span.ParentSpanID,
+		StartTime:    span.StartTime.UTC(),
+		EndTime:      span.EndTime.UTC(),
+		Duration:     span.EndTime.Sub(span.StartTime).String(),
+	}
+	exported.Status.Code = span.Status
+	exported.Status.Message = span.StatusMessage
+	if len(span.Attributes) > 0 {
+		exported.Attributes = make(map[string]string, len(span.Attributes))
+		for _, attr := range span.Attributes {
+			exported.Attributes[attr.Key] = attr.Value
+		}
+	}
+
+	line, err := json.Marshal(exported)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	_, err = e.w.Write(line)
+	return err
+}
@@ -0,0 +1,199 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/Mx1q/ppo_services/app"
+	"github.com/Mx1q/ppo_services/auditrepo"
+	"github.com/Mx1q/ppo_services/config"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+	"time"
+)
+
+func newAppConfig() *config.Config {
+	cfg := config.Default()
+	cfg.Auth.JWTKey = configTestKey
+	cfg.Storage.Backend = app.BackendMemory
+	cfg.Workers.OutboxInterval = config.Duration(10 * time.Millisecond)
+	return cfg
+}
+
+func newTestApp(t *testing.T, cfg *config.Config, repos *app.Repositories) *app.App {
+	a, err := app.New(context.Background(), cfg, repos, logger.NewLogger(logger.LoggerErrorLevel, io.Discard))
+	require.NoError(t, err)
+	return a
+}
+
+func openMemoryRepositories(t *testing.T) *app.Repositories {
+	repos, err := app.OpenBackend(config.StorageConfig{Backend: app.BackendMemory})
+	require.NoError(t, err)
+	return repos
+}
+
+func TestApp_New(t *testing.T) {
+	cfg := newAppConfig()
+	a := newTestApp(t, cfg, openMemoryRepositories(t))
+
+	require.NotNil(t, a.Auth)
+	require.Nil(t, a.Audit)
+	require.Nil(t, a.Webhooks)
+
+	created := make(chan uuid.UUID, 1)
+	a.Events.Subscribe(domain.SaladCreatedEvent, func(ctx context.Context, event domain.IEvent) error {
+		created <- event.AggregateID()
+		return nil
+	})
+
+	id, err := a.Salads.Create(context.Background(), &domain.Salad{Name: "greek", AuthorID: uuid.New()})
+	require.NoError(t, err)
+	require.NoError(t, a.Stop(context.Background()))
+	require.Equal(t, id, <-created)
+
+	cfg.Auth.JWTKey = ""
+	a = newTestApp(t, cfg, openMemoryRepositories(t))
+	require.Nil(t, a.Auth)
+	require.NoError(t, a.Stop(context.Background()))
+
+	_, err = app.New(context.Background(), cfg, &app.Repositories{}, logger.NewLogger(logger.LoggerErrorLevel, io.Discard))
+	require.EqualError(t, err, "backend misses core repositories")
+}
+
+func TestApp_Decorators(t *testing.T) {
+	ctx := context.Background()
+	cfg := newAppConfig()
+	cfg.Telemetry.Enabled = true
+	cfg.Cache.Enabled = true
+	repos := openMemoryRepositories(t)
+	repos.Audit = auditrepo.NewMemoryAuditRepository()
+	a := newTestApp(t, cfg, repos)
+	defer a.Stop(ctx)
+
+	measurement := &domain.Measurement{Name: "стакан", Grams: 200}
+	require.NoError(t, a.Measurements.Create(ctx, measurement))
+	for i := 0; i < 2; i++ {
+		_, err := a.Measurements.GetById(ctx, measurement.ID)
+		require.NoError(t, err)
+	}
+
+	var metrics bytes.Buffer
+	require.NoError(t, a.Metrics().WriteText(&metrics))
+	require.Contains(t, metrics.String(), `ppo_calls_total{interface="IMeasurementService",method="GetById"} 2`)
+	require.Contains(t, metrics.String(), `ppo_calls_total{interface="IMeasurementRepository",method="GetById"} 1`)
+
+	saladId, err := a.Salads.Create(ctx, &domain.Salad{Name: "greek", AuthorID: uuid.New()})
+	require.NoError(t, err)
+	recipeId, err := a.Recipes.Create(ctx, &domain.Recipe{SaladID: saladId, Status: domain.ModerationSaladStatus,
+		NumberOfServings: 2, TimeToCook: 15})
+	require.NoError(t, err)
+	require.NoError(t, a.Moderation.Approve(ctx, recipeId))
+	_, err = a.Ratings.Recompute(ctx, saladId)
+	require.NoError(t, err)
+
+	records, err := a.Audit.GetByEntity(ctx, domain.AuditEntityRecipe, recipeId)
+	require.NoError(t, err)
+	actions := make([]string, 0, len(records))
+	for _, record := range records {
+		actions = append(actions, record.Action)
+	}
+	require.Contains(t, actions, domain.AuditActionApprove)
+	records, err = a.Audit.GetByEntity(ctx, domain.AuditEntitySalad, saladId)
+	require.NoError(t, err)
+	require.Equal(t, domain.AuditActionRecompute, records[len(records)-1].Action)
+}
+
+func TestApp_OpenBackend(t *testing.T) {
+	_, err := app.OpenBackend(config.StorageConfig{Backend: "unknown"})
+	require.EqualError(t, err, "unknown backend unknown, registered: [file memory sqlite]")
+
+	_, err = app.OpenBackend(config.StorageConfig{Backend: app.BackendFile})
+	require.EqualError(t, err, "opening backend file: empty file name")
+}
+
+func TestApp_Workers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	outbox := mocks.NewMockIEventOutbox(ctrl)
+	repos := openMemoryRepositories(t)
+	repos.Outbox = outbox
+
+	entry, _ := domain.NewOutboxEntry(&domain.SaladDeleted{SaladID: uuid.New()}, time.Now())
+	relayed := make(chan uuid.UUID, 1)
+	gomock.InOrder(
+		outbox.EXPECT().
+			GetPending(gomock.Any(), 100).
+			Return([]*domain.OutboxEntry{entry}, nil),
+		outbox.EXPECT().
+			MarkPublished(gomock.Any(), []uuid.UUID{entry.ID}, gomock.Any()).
+			Return(nil),
+		outbox.EXPECT().
+			GetPending(gomock.Any(), 100).
+			Return(nil, errors.New("database is locked")).
+			MinTimes(1),
+	)
+
+	a := newTestApp(t, newAppConfig(), repos)
+	a.Events.Subscribe(domain.SaladDeletedEvent, func(ctx context.Context, event domain.IEvent) error {
+		relayed <- event.AggregateID()
+		return nil
+	})
+
+	require.NoError(t, a.Start(context.Background()))
+	require.Error(t, a.Start(context.Background()))
+	require.Equal(t, entry.AggregateID, <-relayed)
+
+	outbox.EXPECT().
+		GetPending(gomock.Any(), 1).
+		Return(nil, nil).
+		AnyTimes()
+	require.Eventually(t, func() bool {
+		return a.Healthy(context.Background()) != nil
+	}, time.Second, 10*time.Millisecond)
+	statuses := a.Health(context.Background())
+	require.Len(t, statuses, 3)
+	require.Equal(t, app.HealthStatus{Name: "storage"}, statuses[0])
+	require.Equal(t, app.HealthStatus{Name: "outbox"}, statuses[1])
+	require.Equal(t, "worker outbox-relay", statuses[2].Name)
+	require.EqualError(t, statuses[2].Err, "relaying outbox: database is locked")
+
+	require.NoError(t, a.Stop(context.Background()))
+	require.NoError(t, a.Stop(context.Background()))
+}
+
+func TestApp_Run(t *testing.T) {
+	repos := openMemoryRepositories(t)
+	var saved []bool
+	repos.Ping = func(ctx context.Context) error {
+		return errors.New("disk is full")
+	}
+	repos.Close = func(save bool) error {
+		saved = append(saved, save)
+		return nil
+	}
+	a := newTestApp(t, newAppConfig(), repos)
+
+	require.EqualError(t, a.Healthy(context.Background()), "storage: disk is full")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- a.Run(ctx)
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("app did not stop")
+	}
+	require.Equal(t, []bool{true}, saved)
+}
@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"context"
+	"github.com/Mx1q/ppo_services/auditrepo"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/sqliterepo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAuditRepositories(t *testing.T) {
+	db, err := sqliterepo.Open(filepath.Join(t.TempDir(), "salads.db"))
+	require.Nil(t, err)
+	defer db.Close()
+
+	repos := []struct {
+		name string
+		repo domain.IAuditRepository
+	}{
+		{
+			name: "в памяти",
+			repo: auditrepo.NewMemoryAuditRepository(),
+		}, // в памяти
+		{
+			name: "файл JSONL",
+			repo: auditrepo.NewFileAuditRepository(filepath.Join(t.TempDir(), "audit.jsonl")),
+		}, // файл JSONL
+		{
+			name: "SQLite",
+			repo: sqliterepo.NewAuditRepository(db),
+		}, // SQLite
+	}
+
+	for _, tt := range repos {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			actorId := uuid.New()
+			saladId := uuid.New()
+			timestamp := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+			records := []*domain.AuditRecord{
+				{
+					ID:         uuid.New(),
+					ActorID:    actorId,
+					ActorRole:  "user",
+					Action:     domain.AuditActionCreate,
+					EntityType: domain.AuditEntitySalad,
+					EntityID:   saladId,
+					After:      []byte(`{"Name":"salad"}`),
+					Timestamp:  timestamp,
+				},
+				{
+					ID:         uuid.New(),
+					Action:     domain.AuditActionCreate,
+					EntityType: domain.AuditEntityComment,
+					EntityID:   uuid.New(),
+					Timestamp:  timestamp.Add(time.Second),
+				},
+				{
+					ID:         uuid.New(),
+					ActorID:    actorId,
+					ActorRole:  "user",
+					Action:     domain.AuditActionDelete,
+					EntityType: domain.AuditEntitySalad,
+					EntityID:   saladId,
+					Before:     []byte(`{"Name":"salad"}`),
+					Timestamp:  timestamp.Add(2 * time.Second),
+				},
+			}
+
+			empty, err := tt.repo.GetByActor(ctx, actorId)
+			require.Nil(t, err)
+			require.Empty(t, empty)
+
+			for _, record := range records {
+				require.Nil(t, tt.repo.Create(ctx, record))
+			}
+
+			byEntity, err := tt.repo.GetByEntity(ctx, domain.AuditEntitySalad, saladId)
+			require.Nil(t, err)
+			require.Equal(t, []*domain.AuditRecord{records[0], records[2]}, byEntity)
+
+			byActor, err := tt.repo.GetByActor(ctx, actorId)
+			require.Nil(t, err)
+			require.Equal(t, []*domain.AuditRecord{records[0], records[2]}, byActor)
+		})
+	}
+}
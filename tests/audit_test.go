@@ -0,0 +1,284 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAuditService_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auditRepo := mocks.NewMockIAuditRepository(ctrl)
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	svc := services.NewAuditService(auditRepo, logger)
+
+	actor := &domain.Actor{ID: uuid.New(), Role: "admin"}
+	userId := uuid.New()
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		before     interface{}
+		after      interface{}
+		beforeTest func(auditRepo mocks.MockIAuditRepository)
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:   "запись с автором и скрытием пароля",
+			ctx:    domain.ContextWithActor(context.Background(), actor),
+			before: nil,
+			after:  &domain.User{ID: userId, Username: "user", Password: "secret"},
+			beforeTest: func(auditRepo mocks.MockIAuditRepository) {
+				auditRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, record *domain.AuditRecord) error {
+						require.Equal(t, actor.ID, record.ActorID)
+						require.Equal(t, actor.Role, record.ActorRole)
+						require.Equal(t, domain.AuditActionCreate, record.Action)
+						require.Equal(t, domain.AuditEntityUser, record.EntityType)
+						require.Equal(t, userId, record.EntityID)
+						require.Nil(t, record.Before)
+						require.False(t, record.Timestamp.IsZero())
+
+						var after map[string]interface{}
+						require.NoError(t, json.Unmarshal(record.After, &after))
+						require.Equal(t, "***", after["Password"])
+						require.Equal(t, "user", after["Username"])
+						return nil
+					})
+			},
+			wantErr: false,
+		}, // запись с автором и скрытием пароля
+		{
+			name:   "скрытие вложенных секретов",
+			ctx:    context.Background(),
+			before: nil,
+			after: map[string]interface{}{
+				"user":          &domain.User{ID: userId, Username: "user", Password: "hash"},
+				"subscriptions": []*domain.WebhookSubscription{{URL: "https://partner.example", Secret: "key"}},
+			},
+			beforeTest: func(auditRepo mocks.MockIAuditRepository) {
+				auditRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, record *domain.AuditRecord) error {
+						var after struct {
+							User          map[string]interface{}
+							Subscriptions []map[string]interface{}
+						}
+						require.NoError(t, json.Unmarshal(record.After, &after))
+						require.Equal(t, "***", after.User["Password"])
+						require.Equal(t, "user", after.User["Username"])
+						require.Equal(t, "***", after.Subscriptions[0]["Secret"])
+						require.Equal(t, "https://partner.example", after.Subscriptions[0]["URL"])
+						return nil
+					})
+			},
+			wantErr: false,
+		}, // скрытие вложенных секретов
+		{
+			name:   "запись без автора",
+			ctx:    context.Background(),
+			before: &domain.User{ID: userId, Username: "user"},
+			after:  nil,
+			beforeTest: func(auditRepo mocks.MockIAuditRepository) {
+				auditRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, record *domain.AuditRecord) error {
+						require.Equal(t, uuid.Nil, record.ActorID)
+						require.NotNil(t, record.Before)
+						require.Nil(t, record.After)
+						return nil
+					})
+			},
+			wantErr: false,
+		}, // запись без автора
+		{
+			name:   "ошибка сохранения записи",
+			ctx:    context.Background(),
+			before: nil,
+			after:  &domain.User{ID: userId},
+			beforeTest: func(auditRepo mocks.MockIAuditRepository) {
+				auditRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("repo error"))
+			},
+			wantErr: true,
+			errStr:  fmt.Errorf("recording audit: repo error"),
+		}, // ошибка сохранения записи
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*auditRepo)
+			}
+
+			err := svc.Record(tt.ctx, domain.AuditActionCreate, domain.AuditEntityUser, userId, tt.before, tt.after)
+
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestAuditService_GetByEntity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auditRepo := mocks.NewMockIAuditRepository(ctrl)
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	svc := services.NewAuditService(auditRepo, logger)
+
+	saladId := uuid.New()
+
+	tests := []struct {
+		name       string
+		beforeTest func(auditRepo mocks.MockIAuditRepository)
+		want       []*domain.AuditRecord
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешное получение записей",
+			beforeTest: func(auditRepo mocks.MockIAuditRepository) {
+				auditRepo.EXPECT().
+					GetByEntity(context.Background(), domain.AuditEntitySalad, saladId).
+					Return([]*domain.AuditRecord{{EntityType: domain.AuditEntitySalad, EntityID: saladId}}, nil)
+			},
+			want:    []*domain.AuditRecord{{EntityType: domain.AuditEntitySalad, EntityID: saladId}},
+			wantErr: false,
+		}, // успешное получение записей
+		{
+			name: "ошибка получения записей",
+			beforeTest: func(auditRepo mocks.MockIAuditRepository) {
+				auditRepo.EXPECT().
+					GetByEntity(context.Background(), domain.AuditEntitySalad, saladId).
+					Return(nil, fmt.Errorf("repo error"))
+			},
+			wantErr: true,
+			errStr:  fmt.Errorf("getting audit records by entity: repo error"),
+		}, // ошибка получения записей
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*auditRepo)
+			}
+
+			records, err := svc.GetByEntity(context.Background(), domain.AuditEntitySalad, saladId)
+
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tt.want, records)
+			}
+		})
+	}
+}
+
+func TestAuditedSaladService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	saladService := mocks.NewMockISaladService(ctrl)
+	audit := mocks.NewMockIAuditService(ctrl)
+	svc := services.NewAuditedSaladService(saladService, newTxManagerMock(ctrl), audit)
+
+	before := &domain.Salad{ID: uuid.New(), Name: "before"}
+	after := &domain.Salad{ID: before.ID, Name: "after"}
+
+	tests := []struct {
+		name       string
+		beforeTest func(saladService mocks.MockISaladService, audit mocks.MockIAuditService)
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешное обновление записывается в журнал",
+			beforeTest: func(saladService mocks.MockISaladService, audit mocks.MockIAuditService) {
+				saladService.EXPECT().
+					GetById(inTransaction{}, before.ID).
+					Return(before, nil)
+				saladService.EXPECT().
+					Update(inTransaction{}, after).
+					Return(nil)
+				audit.EXPECT().
+					Record(inTransaction{}, domain.AuditActionUpdate, domain.AuditEntitySalad, before.ID, before, after).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // успешное обновление записывается в журнал
+		{
+			name: "ошибка журнала отменяет обновление",
+			beforeTest: func(saladService mocks.MockISaladService, audit mocks.MockIAuditService) {
+				saladService.EXPECT().
+					GetById(inTransaction{}, before.ID).
+					Return(before, nil)
+				saladService.EXPECT().
+					Update(inTransaction{}, after).
+					Return(nil)
+				audit.EXPECT().
+					Record(inTransaction{}, domain.AuditActionUpdate, domain.AuditEntitySalad, before.ID, before, after).
+					Return(fmt.Errorf("recording audit: repo error"))
+			},
+			wantErr: true,
+			errStr:  fmt.Errorf("recording audit: repo error"),
+		}, // ошибка журнала отменяет обновление
+		{
+			name: "неудачное обновление не записывается",
+			beforeTest: func(saladService mocks.MockISaladService, audit mocks.MockIAuditService) {
+				saladService.EXPECT().
+					GetById(inTransaction{}, before.ID).
+					Return(before, nil)
+				saladService.EXPECT().
+					Update(inTransaction{}, after).
+					Return(fmt.Errorf("updating salad: repo error"))
+			},
+			wantErr: true,
+			errStr:  fmt.Errorf("updating salad: repo error"),
+		}, // неудачное обновление не записывается
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*saladService, *audit)
+			}
+
+			err := svc.Update(context.Background(), after)
+
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
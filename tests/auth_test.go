@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/Mx1q/ppo_services/config"
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/services"
 	"github.com/Mx1q/ppo_services/tests/mocks"
@@ -34,7 +35,7 @@ func TestAuthService_Login(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewAuthService(repo, logger, crypto, jwtKey)
+	svc := services.NewAuthService(repo, logger, crypto, config.AuthConfig{JWTKey: config.Secret(jwtKey), TokenTTL: config.Default().Auth.TokenTTL})
 
 	tests := []struct {
 		name       string
@@ -191,7 +192,7 @@ func TestAuthService_Register(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewAuthService(repo, logger, crypto, "abcdefgh123")
+	svc := services.NewAuthService(repo, logger, crypto, config.AuthConfig{JWTKey: "abcdefgh123", TokenTTL: config.Default().Auth.TokenTTL})
 
 	tests := []struct {
 		name       string
@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"context"
+	"github.com/Mx1q/ppo_services/blobstore"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBlobStorages(t *testing.T) {
+	storages := []struct {
+		name    string
+		storage domain.IBlobStorage
+	}{
+		{
+			name:    "в памяти",
+			storage: blobstore.NewMemoryBlobStorage(),
+		}, // в памяти
+		{
+			name:    "локальная файловая система",
+			storage: blobstore.NewLocalBlobStorage(t.TempDir()),
+		}, // локальная файловая система
+	}
+
+	for _, tt := range storages {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			require.Nil(t, tt.storage.Put(ctx, "salads/caesar.png", []byte("first")))
+			require.Nil(t, tt.storage.Put(ctx, "salads/caesar.png", []byte("second")))
+
+			data, err := tt.storage.Get(ctx, "salads/caesar.png")
+			require.Nil(t, err)
+			require.Equal(t, []byte("second"), data)
+
+			require.Nil(t, tt.storage.Delete(ctx, "salads/caesar.png"))
+			require.Nil(t, tt.storage.Delete(ctx, "salads/caesar.png"))
+
+			_, err = tt.storage.Get(ctx, "salads/caesar.png")
+			require.ErrorIs(t, err, domain.ErrBlobNotFound)
+
+			require.ErrorIs(t, tt.storage.Put(ctx, "", []byte("data")), domain.ErrInvalidBlobKey)
+		})
+	}
+}
+
+func TestLocalBlobStorage_KeyOutsideRoot(t *testing.T) {
+	storage := blobstore.NewLocalBlobStorage(t.TempDir())
+
+	for _, key := range []string{"../escape", "/etc/passwd", "a/../../escape"} {
+		err := storage.Put(context.Background(), key, []byte("data"))
+		require.ErrorIs(t, err, domain.ErrInvalidBlobKey, key)
+	}
+}
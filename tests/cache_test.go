@@ -0,0 +1,253 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/cache"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache_LRU(t *testing.T) {
+	c := cache.New[string, int](cache.Options{Size: 2})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	_, ok := c.Get("a")
+	require.True(t, ok)
+	c.Set("c", 3)
+
+	_, ok = c.Get("b")
+	require.False(t, ok)
+	value, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+
+	stats := c.Stats()
+	require.Equal(t, uint64(2), stats.Hits)
+	require.Equal(t, uint64(1), stats.Misses)
+	require.Equal(t, uint64(1), stats.Evictions)
+	require.Equal(t, 2, stats.Size)
+}
+
+func TestCache_TTL(t *testing.T) {
+	c := cache.New[string, int](cache.Options{Size: 10, TTL: 10 * time.Millisecond})
+
+	c.Set("a", 1)
+	_, ok := c.Get("a")
+	require.True(t, ok)
+
+	time.Sleep(20 * time.Millisecond)
+	_, ok = c.Get("a")
+	require.False(t, ok)
+	require.Equal(t, uint64(1), c.Stats().Expirations)
+}
+
+func TestCache_GetOrLoad(t *testing.T) {
+	c := cache.New[string, int](cache.Options{Size: 10})
+	var loads int32
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := c.GetOrLoad(context.Background(), "key", func(ctx context.Context) (int, error) {
+				atomic.AddInt32(&loads, 1)
+				<-release
+				return 42, nil
+			})
+			require.Nil(t, err)
+			require.Equal(t, 42, value)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	require.Equal(t, int32(1), atomic.LoadInt32(&loads))
+
+	_, err := c.GetOrLoad(context.Background(), "failing", func(ctx context.Context) (int, error) {
+		return 0, fmt.Errorf("load error")
+	})
+	require.Equal(t, "load error", err.Error())
+	_, ok := c.Get("failing")
+	require.False(t, ok)
+
+	_, err = c.GetOrLoad(context.Background(), "stale", func(ctx context.Context) (int, error) {
+		c.Delete("stale")
+		return 1, nil
+	})
+	require.Nil(t, err)
+	_, ok = c.Get("stale")
+	require.False(t, ok)
+}
+
+func TestCachedMeasurementRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurementRepo := mocks.NewMockIMeasurementRepository(ctrl)
+	repo := cache.NewCachedMeasurementRepository(measurementRepo, cache.DefaultOptions)
+	ctx := context.Background()
+
+	measurement := &domain.Measurement{ID: uuid.New(), Name: "грамм", Grams: 1}
+	measurementRepo.EXPECT().
+		GetAll(ctx).
+		Return([]*domain.Measurement{measurement}, nil).
+		Times(2)
+	measurementRepo.EXPECT().
+		GetById(ctx, measurement.ID).
+		Return(measurement, nil).
+		Times(2)
+	measurementRepo.EXPECT().
+		Update(ctx, gomock.Any()).
+		Return(nil)
+
+	all, err := repo.GetAll(ctx)
+	require.Nil(t, err)
+	all[0].Name = "changed"
+	all, err = repo.GetAll(ctx)
+	require.Nil(t, err)
+	require.Equal(t, "грамм", all[0].Name)
+
+	_, err = repo.GetById(ctx, measurement.ID)
+	require.Nil(t, err)
+	_, err = repo.GetById(ctx, measurement.ID)
+	require.Nil(t, err)
+
+	require.Nil(t, repo.Update(ctx, &domain.Measurement{ID: measurement.ID, Name: "г"}))
+
+	_, err = repo.GetAll(ctx)
+	require.Nil(t, err)
+	_, err = repo.GetById(ctx, measurement.ID)
+	require.Nil(t, err)
+
+	stats := repo.(cache.IStats).Stats()
+	require.Equal(t, uint64(2), stats.Hits)
+	require.Equal(t, uint64(4), stats.Misses)
+}
+
+func TestCachedIngredientRepository_Transaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ingredientRepo := mocks.NewMockIIngredientRepository(ctrl)
+	repo := cache.NewCachedIngredientRepository(ingredientRepo, cache.DefaultOptions)
+	txCtx := domain.ContextWithTransaction(context.Background(), mocks.NewMockITransaction(ctrl))
+
+	recipeId := uuid.New()
+	ingredientRepo.EXPECT().
+		GetAllByRecipeId(txCtx, recipeId).
+		Return([]*domain.Ingredient{}, nil).
+		Times(2)
+
+	_, err := repo.GetAllByRecipeId(txCtx, recipeId)
+	require.Nil(t, err)
+	_, err = repo.GetAllByRecipeId(txCtx, recipeId)
+	require.Nil(t, err)
+	require.Zero(t, repo.Stats().Size)
+}
+
+func TestCachedRecipeIngredientRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ingredientRepo := mocks.NewMockIIngredientRepository(ctrl)
+	recipeIngredientRepo := mocks.NewMockIRecipeIngredientRepository(ctrl)
+	ingredients := cache.NewCachedIngredientRepository(ingredientRepo, cache.DefaultOptions)
+	repo := cache.NewCachedRecipeIngredientRepository(recipeIngredientRepo, ingredients)
+	ctx := context.Background()
+
+	recipeId := uuid.New()
+	ingredientRepo.EXPECT().
+		GetAllByRecipeId(ctx, recipeId).
+		Return([]*domain.Ingredient{}, nil).
+		Times(3)
+	recipeIngredientRepo.EXPECT().
+		Create(ctx, gomock.Any()).
+		Return(uuid.New(), nil)
+	recipeIngredientRepo.EXPECT().
+		DeleteById(ctx, gomock.Any()).
+		Return(nil)
+
+	_, err := ingredients.GetAllByRecipeId(ctx, recipeId)
+	require.Nil(t, err)
+	_, err = repo.Create(ctx, &domain.RecipeIngredient{RecipeID: recipeId})
+	require.Nil(t, err)
+	_, err = ingredients.GetAllByRecipeId(ctx, recipeId)
+	require.Nil(t, err)
+	require.Nil(t, repo.DeleteById(ctx, uuid.New()))
+	_, err = ingredients.GetAllByRecipeId(ctx, recipeId)
+	require.Nil(t, err)
+}
+
+func TestCachedIngredientTypeRepository_DeleteById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ingredientRepo := mocks.NewMockIIngredientRepository(ctrl)
+	ingredientTypeRepo := mocks.NewMockIIngredientTypeRepository(ctrl)
+	ingredients := cache.NewCachedIngredientRepository(ingredientRepo, cache.DefaultOptions)
+	repo := cache.NewCachedIngredientTypeRepository(ingredientTypeRepo, ingredients, cache.DefaultOptions)
+	ctx := context.Background()
+
+	typeId := uuid.New()
+	ingredient := &domain.Ingredient{ID: uuid.New(), Name: "курица", TypeID: typeId}
+	gomock.InOrder(
+		ingredientRepo.EXPECT().
+			GetById(ctx, ingredient.ID).
+			Return(ingredient, nil),
+		ingredientTypeRepo.EXPECT().
+			DeleteById(ctx, typeId).
+			Return(nil),
+		ingredientRepo.EXPECT().
+			GetById(ctx, ingredient.ID).
+			Return(&domain.Ingredient{ID: ingredient.ID, Name: "курица"}, nil),
+	)
+
+	_, err := ingredients.GetById(ctx, ingredient.ID)
+	require.Nil(t, err)
+	require.Nil(t, repo.DeleteById(ctx, typeId))
+	got, err := ingredients.GetById(ctx, ingredient.ID)
+	require.Nil(t, err)
+	require.Equal(t, uuid.Nil, got.TypeID)
+}
+
+func TestCachedMeasurementRepository_Transaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurementRepo := mocks.NewMockIMeasurementRepository(ctrl)
+	repo := cache.NewCachedMeasurementRepository(measurementRepo, cache.DefaultOptions)
+	ctx := context.Background()
+
+	measurement := &domain.Measurement{ID: uuid.New(), Name: "грамм", Grams: 1}
+	measurementRepo.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		Return(nil)
+	measurementRepo.EXPECT().
+		GetById(ctx, measurement.ID).
+		Return(measurement, nil).
+		Times(2)
+
+	err := domain.RunInTransaction(ctx, newTxManagerMock(ctrl), func(txCtx context.Context) error {
+		if err := repo.Update(txCtx, &domain.Measurement{ID: measurement.ID, Name: "г"}); err != nil {
+			return err
+		}
+		// a read outside the transaction loads the old row before the commit
+		_, err := repo.GetById(ctx, measurement.ID)
+		return err
+	})
+	require.Nil(t, err)
+
+	_, err = repo.GetById(ctx, measurement.ID)
+	require.Nil(t, err)
+}
@@ -0,0 +1,193 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"github.com/Mx1q/ppo_services/config"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/memrepo"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newCatalogService(t *testing.T, ctrl *gomock.Controller, store *memrepo.Store) domain.ICatalogService {
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Warnf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+
+	keywordService, err := services.NewKeywordValidatorService(context.Background(), memrepo.NewKeywordRepository(store), logger)
+	require.NoError(t, err)
+	return services.NewCatalogService(
+		services.NewIngredientTypeService(memrepo.NewIngredientTypeRepository(store), memrepo.NewTransactionManager(store), logger),
+		services.NewIngredientService(memrepo.NewIngredientRepository(store), logger, config.Default().Pages),
+		services.NewMeasurementService(memrepo.NewMeasurementRepository(store), logger),
+		services.NewSaladTypeService(memrepo.NewSaladTypeRepository(store), memrepo.NewTransactionManager(store), logger, config.Default().Pages),
+		keywordService,
+		logger,
+	)
+}
+
+func TestCatalogService_Import(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name    string
+		catalog string
+		seed    []string
+		input   string
+		options *domain.CatalogImportOptions
+		want    *domain.CatalogImportReport
+		rowErrs []string
+		export  string
+		wantErr bool
+	}{
+		{
+			name:    "типы ингредиентов с родителем после потомка",
+			catalog: domain.CatalogIngredientTypes,
+			input:   "name,parent,contents\nTomato,Vegetables,\nVegetables,,\nDairy,,lactose\n",
+			options: &domain.CatalogImportOptions{Format: domain.CatalogFormatCSV},
+			want:    &domain.CatalogImportReport{Created: 3},
+			export:  "name,parent,description,contents\nDairy,,,lactose\nTomato,Vegetables,,\nVegetables,,,\n",
+		}, // типы ингредиентов с родителем после потомка
+		{
+			name:    "ошибки строк не прерывают импорт",
+			catalog: domain.CatalogIngredientTypes,
+			input:   "name,parent,contents\n,,\nFruits,Unknown,\nNuts,,nuts;pepper\nBerries,,\n",
+			options: &domain.CatalogImportOptions{Format: domain.CatalogFormatCSV},
+			want:    &domain.CatalogImportReport{Created: 1},
+			rowErrs: []string{"row 1 (): empty name", "row 2 (Fruits): unknown parent Unknown", "row 3 (Nuts): unknown content pepper"},
+			export:  "name,parent,description,contents\nBerries,,,\n",
+		}, // ошибки строк не прерывают импорт
+		{
+			name:    "дубликат без upsert",
+			catalog: domain.CatalogMeasurements,
+			seed:    []string{domain.CatalogMeasurements, "name,unit,grams\nGram,g,\n"},
+			input:   `[{"name":"gram","unit":"g"},{"name":"Cup","grams":240}]`,
+			options: &domain.CatalogImportOptions{Format: domain.CatalogFormatJSON},
+			want:    &domain.CatalogImportReport{Created: 1},
+			rowErrs: []string{"row 1 (gram): already exists, use upsert to update it"},
+			export:  "name,unit,grams\nCup,,240\nGram,g,1\n",
+		}, // дубликат без upsert
+		{
+			name:    "upsert по имени",
+			catalog: domain.CatalogIngredients,
+			seed: []string{
+				domain.CatalogIngredientTypes, "name\nVegetables\nGreens\n",
+				domain.CatalogIngredients, "name,type,calories\nTomato,Vegetables,18\nLettuce,Greens,15\n",
+			},
+			input:   "name,type,calories,density\ntomato,Vegetables,20,\nLettuce,Greens,15,\nCucumber,greens,16,0.95\n",
+			options: &domain.CatalogImportOptions{Format: domain.CatalogFormatCSV, Upsert: true},
+			want:    &domain.CatalogImportReport{Created: 1, Updated: 1, Unchanged: 1},
+			export:  "name,type,calories,density,contents\nCucumber,Greens,16,0.95,\nLettuce,Greens,15,0,\ntomato,Vegetables,20,0,\n",
+		}, // upsert по имени
+		{
+			name:    "пробный запуск ничего не сохраняет",
+			catalog: domain.CatalogSaladTypes,
+			input:   "name,parent\nSpring,Seasonal\nSeasonal,\n",
+			options: &domain.CatalogImportOptions{Format: domain.CatalogFormatCSV, DryRun: true},
+			want:    &domain.CatalogImportReport{Created: 2},
+			export:  "name,parent,description\n",
+		}, // пробный запуск ничего не сохраняет
+		{
+			name:    "ключевые слова в нижнем регистре",
+			catalog: domain.CatalogKeywords,
+			seed:    []string{domain.CatalogKeywords, "word\nbad\n"},
+			input:   "word\nBAD\nUgly\ntwo words\n",
+			options: &domain.CatalogImportOptions{Format: domain.CatalogFormatCSV, Upsert: true},
+			want:    &domain.CatalogImportReport{Created: 1, Unchanged: 1},
+			rowErrs: []string{"row 3 (two words): accepts only 1 word"},
+			export:  "word\nbad\nugly\n",
+		}, // ключевые слова в нижнем регистре
+		{
+			name:    "неизвестная колонка",
+			catalog: domain.CatalogMeasurements,
+			input:   "name,calories\nGram,1\n",
+			options: &domain.CatalogImportOptions{Format: domain.CatalogFormatCSV},
+			wantErr: true,
+		}, // неизвестная колонка
+		{
+			name:    "неизвестный каталог",
+			catalog: "recipes",
+			input:   "[]",
+			options: &domain.CatalogImportOptions{Format: domain.CatalogFormatJSON},
+			wantErr: true,
+		}, // неизвестный каталог
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := newCatalogService(t, ctrl, memrepo.NewStore())
+			for i := 0; i < len(tt.seed); i += 2 {
+				report, err := svc.Import(ctx, tt.seed[i], strings.NewReader(tt.seed[i+1]), &domain.CatalogImportOptions{Format: domain.CatalogFormatCSV})
+				require.NoError(t, err)
+				require.Empty(t, report.Errors)
+			}
+
+			report, err := svc.Import(ctx, tt.catalog, strings.NewReader(tt.input), tt.options)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			rowErrs := make([]string, 0)
+			for _, rowErr := range report.Errors {
+				rowErrs = append(rowErrs, rowErr.Error())
+			}
+			if tt.rowErrs == nil {
+				tt.rowErrs = []string{}
+			}
+			require.Equal(t, tt.rowErrs, rowErrs)
+			require.Equal(t, tt.want.Created, report.Created)
+			require.Equal(t, tt.want.Updated, report.Updated)
+			require.Equal(t, tt.want.Unchanged, report.Unchanged)
+
+			var buf bytes.Buffer
+			require.NoError(t, svc.Export(ctx, tt.catalog, &buf, domain.CatalogFormatCSV))
+			require.Equal(t, tt.export, buf.String())
+		})
+	}
+}
+
+func TestCatalogService_ExportRoundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "store.json")
+	store := memrepo.NewStore()
+	svc := newCatalogService(t, ctrl, store)
+	input := `[
+		{"name":"Dressings"},
+		{"name":"Creamy","parent":"Dressings","description":"mayonnaise or sour cream"}
+	]`
+	report, err := svc.Import(ctx, domain.CatalogSaladTypes, strings.NewReader(input), &domain.CatalogImportOptions{Format: domain.CatalogFormatJSON})
+	require.NoError(t, err)
+	require.Equal(t, 2, report.Created)
+	require.NoError(t, store.Save(path))
+
+	reopened, err := memrepo.OpenStore(path)
+	require.NoError(t, err)
+	var exported bytes.Buffer
+	require.NoError(t, newCatalogService(t, ctrl, reopened).Export(ctx, domain.CatalogSaladTypes, &exported, domain.CatalogFormatJSON))
+
+	report, err = newCatalogService(t, ctrl, reopened).Import(ctx, domain.CatalogSaladTypes, &exported,
+		&domain.CatalogImportOptions{Format: domain.CatalogFormatJSON, Upsert: true})
+	require.NoError(t, err)
+	require.Empty(t, report.Errors)
+	require.Equal(t, 2, report.Unchanged)
+}
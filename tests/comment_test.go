@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/Mx1q/ppo_services/config"
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/services"
 	"github.com/Mx1q/ppo_services/tests/mocks"
@@ -31,7 +32,7 @@ func TestCommentService_Create(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewCommentService(commentRepo, logger)
+	svc := services.NewCommentService(commentRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	commentId := uuid.New()
 	authorId := uuid.New()
@@ -148,7 +149,7 @@ func TestCommentService_DeleteById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewCommentService(commentRepo, logger)
+	svc := services.NewCommentService(commentRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	commentId := uuid.New()
 
@@ -215,15 +216,15 @@ func TestCommentService_GetAllBySaladID(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewCommentService(commentRepo, logger)
+	svc := services.NewCommentService(commentRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	saladId := uuid.New()
-	page := 1
+	page := &domain.PageRequest{Size: 4}
 
 	tests := []struct {
 		name       string
 		saladId    uuid.UUID
-		page       int
+		page       *domain.PageRequest
 		beforeTest func(commentRepo mocks.MockICommentRepository)
 		expected   []*domain.Comment
 		wantErr    bool
@@ -265,7 +266,7 @@ func TestCommentService_GetAllBySaladID(t *testing.T) {
 							Text:     "some text",
 							Rating:   5,
 						},
-					}, 1, nil)
+					}, &domain.PageInfo{Size: 4, Total: 4}, nil)
 			},
 			expected: []*domain.Comment{
 				{
@@ -306,11 +307,18 @@ func TestCommentService_GetAllBySaladID(t *testing.T) {
 			beforeTest: func(commentRepo mocks.MockICommentRepository) {
 				commentRepo.EXPECT().
 					GetAllBySaladID(context.Background(), saladId, page).
-					Return(nil, 0, fmt.Errorf("getting comments err"))
+					Return(nil, nil, fmt.Errorf("getting comments err"))
 			},
 			wantErr: true,
 			errStr:  errors.New("getting all comments by salad id: getting comments err"),
 		}, // ошибка выполнения запроса в репозитории
+		{
+			name:    "неподдерживаемая сортировка",
+			saladId: saladId,
+			page:    &domain.PageRequest{Sort: domain.SortByName},
+			wantErr: true,
+			errStr:  errors.New("getting all comments by salad id: unsupported sort name"),
+		}, // неподдерживаемая сортировка
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -347,7 +355,7 @@ func TestCommentService_GetById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewCommentService(commentRepo, logger)
+	svc := services.NewCommentService(commentRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	commentId := uuid.New()
 
@@ -429,7 +437,7 @@ func TestCommentService_GetBySaladAndUser(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewCommentService(commentRepo, logger)
+	svc := services.NewCommentService(commentRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	commentId := uuid.New()
 	userId := uuid.New()
@@ -516,7 +524,7 @@ func TestCommentService_Update(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewCommentService(commentRepo, logger)
+	svc := services.NewCommentService(commentRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	commentId := uuid.New()
 	authorId := uuid.New()
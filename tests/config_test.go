@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"fmt"
+	"github.com/Mx1q/ppo_services/config"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const configTestKey = "0123456789abcdef0123456789abcdef"
+
+func writeConfigFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestConfig_Load(t *testing.T) {
+	keyFile := writeConfigFile(t, "jwt.key", configTestKey+"\n")
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		env     map[string]string
+		check   func(t *testing.T, cfg *config.Config)
+		wantErr bool
+		errStr  string
+	}{
+		{
+			name: "значения по умолчанию и ключ из окружения",
+			env:  map[string]string{"SALAD_AUTH_JWT_KEY": configTestKey},
+			check: func(t *testing.T, cfg *config.Config) {
+				expected := config.Default()
+				expected.Auth.JWTKey = configTestKey
+				require.Equal(t, expected, cfg)
+			},
+		}, // значения по умолчанию и ключ из окружения
+		{
+			name:    "yaml файл",
+			file:    "config.yaml",
+			content: "log:\n  level: debug\nauth:\n  jwtKey: " + configTestKey + "\n  tokenTTL: 15m\n  bcryptCost: 4\npages:\n  defaultSize: 5\n  maxSize: 10\n",
+			check: func(t *testing.T, cfg *config.Config) {
+				require.Equal(t, "debug", cfg.Log.Level)
+				require.Equal(t, config.Duration(15*time.Minute), cfg.Auth.TokenTTL)
+				require.Equal(t, 4, cfg.Auth.BcryptCost)
+				require.Equal(t, config.PageConfig{DefaultSize: 5, MaxSize: 10}, cfg.Pages)
+				require.Equal(t, config.Default().Rating, cfg.Rating)
+			},
+		}, // yaml файл
+		{
+			name:    "окружение важнее json файла",
+			file:    "config.json",
+			content: `{"auth":{"jwtKey":"` + configTestKey + `","tokenTTL":"1h"},"storage":{"backend":"file","dsn":"file.json"}}`,
+			env: map[string]string{
+				"SALAD_AUTH_TOKEN_TTL":  "2h",
+				"SALAD_STORAGE_DSN":     "env.json",
+				"SALAD_RATING_MAX_RATE": "10",
+			},
+			check: func(t *testing.T, cfg *config.Config) {
+				require.Equal(t, config.Duration(2*time.Hour), cfg.Auth.TokenTTL)
+				require.Equal(t, config.StorageConfig{Backend: "file", DSN: "env.json"}, cfg.Storage)
+				require.Equal(t, 10, cfg.Rating.MaxRate)
+			},
+		}, // окружение важнее json файла
+		{
+			name: "ключ из файла секрета",
+			env:  map[string]string{"SALAD_AUTH_JWT_KEY_FILE": keyFile},
+			check: func(t *testing.T, cfg *config.Config) {
+				require.Equal(t, config.Secret(configTestKey), cfg.Auth.JWTKey)
+			},
+		}, // ключ из файла секрета
+		{
+			name: "ключ и файл ключа одновременно",
+			env: map[string]string{
+				"SALAD_AUTH_JWT_KEY":      configTestKey,
+				"SALAD_AUTH_JWT_KEY_FILE": keyFile,
+			},
+			wantErr: true,
+			errStr:  "auth.jwtKey and auth.jwtKeyFile are both set",
+		}, // ключ и файл ключа одновременно
+		{
+			name:    "неизвестное поле",
+			file:    "config.yml",
+			content: "auth:\n  jwtSecret: x\n",
+			wantErr: true,
+			errStr:  "field jwtSecret not found",
+		}, // неизвестное поле
+		{
+			name:    "неверное значение в окружении",
+			env:     map[string]string{"SALAD_AUTH_BCRYPT_COST": "high"},
+			wantErr: true,
+			errStr:  "invalid SALAD_AUTH_BCRYPT_COST",
+		}, // неверное значение в окружении
+		{
+			name:    "все ошибки проверки",
+			file:    "config.yaml",
+			content: "log:\n  level: verbose\nauth:\n  jwtKey: short\nrating:\n  minRate: 5\n  maxRate: 5\n",
+			wantErr: true,
+			errStr: "invalid config: log.level must be one of [error warn info debug]\n" +
+				"auth.jwtKey must have at least 32 bytes\n" +
+				"rating.minRate must be from 0 to below rating.maxRate",
+		}, // все ошибки проверки
+		{
+			name: "телеметрия и кэш",
+			env: map[string]string{
+				"SALAD_AUTH_JWT_KEY":     configTestKey,
+				"SALAD_TELEMETRY_TRACES": "true",
+				"SALAD_CACHE_ENABLED":    "true",
+				"SALAD_CACHE_SIZE":       "0",
+			},
+			wantErr: true,
+			errStr: "invalid config: telemetry.traces needs telemetry.enabled\n" +
+				"cache.size must be positive",
+		}, // телеметрия и кэш
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := ""
+			if tt.file != "" {
+				path = writeConfigFile(t, tt.file, tt.content)
+			}
+			lookupEnv := func(key string) (string, bool) {
+				value, ok := tt.env[key]
+				return value, ok
+			}
+
+			cfg, err := config.LoadWithEnv(path, lookupEnv)
+			if tt.wantErr {
+				require.ErrorContains(t, err, tt.errStr)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestSecret_Format(t *testing.T) {
+	cfg := config.AuthConfig{JWTKey: configTestKey}
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s"} {
+		require.NotContains(t, fmt.Sprintf(verb, cfg), configTestKey, verb)
+	}
+}
@@ -0,0 +1,299 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type dietMocks struct {
+	recipeRepo         *mocks.MockIRecipeRepository
+	ingredientRepo     *mocks.MockIIngredientRepository
+	ingredientTypeRepo *mocks.MockIIngredientTypeRepository
+	saladTypeRepo      *mocks.MockISaladTypeRepository
+}
+
+var (
+	dietSaladId   = uuid.UUID{1}
+	dietRecipeId  = uuid.UUID{2}
+	dietDairyType = &domain.IngredientType{
+		ID:       uuid.UUID{3},
+		Name:     "dairy",
+		Contents: []string{domain.ContentDairy, domain.ContentLactose},
+	}
+	dietTomato = &domain.Ingredient{ID: uuid.UUID{4}, Name: "tomato"}
+	dietCheese = &domain.Ingredient{ID: uuid.UUID{5}, TypeID: dietDairyType.ID, Name: "mozzarella"}
+	dietBread  = &domain.Ingredient{ID: uuid.UUID{6}, Name: "croutons", Contents: []string{domain.ContentGluten}}
+)
+
+func newDietService(t *testing.T) (domain.IDietService, *dietMocks) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	m := &dietMocks{
+		recipeRepo:         mocks.NewMockIRecipeRepository(ctrl),
+		ingredientRepo:     mocks.NewMockIIngredientRepository(ctrl),
+		ingredientTypeRepo: mocks.NewMockIIngredientTypeRepository(ctrl),
+		saladTypeRepo:      mocks.NewMockISaladTypeRepository(ctrl),
+	}
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+
+	svc := services.NewDietService(m.recipeRepo, m.ingredientRepo, m.ingredientTypeRepo, m.saladTypeRepo, logger)
+	return svc, m
+}
+
+func expectDietIngredients(m *dietMocks, ingredients ...*domain.Ingredient) {
+	m.recipeRepo.EXPECT().
+		GetBySaladId(gomock.Any(), dietSaladId).
+		Return(&domain.Recipe{ID: dietRecipeId, SaladID: dietSaladId}, nil).
+		AnyTimes()
+	m.ingredientRepo.EXPECT().
+		GetAllByRecipeId(gomock.Any(), dietRecipeId).
+		Return(ingredients, nil)
+	m.ingredientTypeRepo.EXPECT().
+		GetById(gomock.Any(), dietDairyType.ID).
+		Return(dietDairyType, nil).
+		MaxTimes(1)
+}
+
+func TestDietsOf(t *testing.T) {
+	require.Equal(t, domain.AllDiets, domain.DietsOf(nil))
+	require.Equal(t,
+		[]string{domain.DietVegetarian, domain.DietGlutenFree, domain.DietNutFree},
+		domain.DietsOf([]string{domain.ContentDairy, domain.ContentLactose}))
+	require.Equal(t,
+		[]string{domain.DietGlutenFree, domain.DietLactoseFree},
+		domain.DietsOf([]string{domain.ContentFish, domain.ContentNuts}))
+
+	diet, ok := domain.DietOfName(" Vegan ")
+	require.True(t, ok)
+	require.Equal(t, domain.DietVegan, diet)
+	_, ok = domain.DietOfName("italian")
+	require.False(t, ok)
+}
+
+func TestDietService_GetRecipeDiets(t *testing.T) {
+	tests := []struct {
+		name       string
+		beforeTest func(m *dietMocks)
+		expected   []string
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "состав из ингредиента и его типа",
+			beforeTest: func(m *dietMocks) {
+				m.ingredientRepo.EXPECT().
+					GetAllByRecipeId(gomock.Any(), dietRecipeId).
+					Return([]*domain.Ingredient{dietTomato, dietCheese, dietBread}, nil)
+				m.ingredientTypeRepo.EXPECT().
+					GetById(gomock.Any(), dietDairyType.ID).
+					Return(dietDairyType, nil)
+			},
+			expected: []string{domain.DietVegetarian, domain.DietNutFree},
+			wantErr:  false,
+		}, // состав из ингредиента и его типа
+		{
+			name: "состав родительского типа",
+			beforeTest: func(m *dietMocks) {
+				meat := &domain.IngredientType{ID: uuid.UUID{7}, Name: "meat", Contents: []string{domain.ContentMeat}}
+				poultry := &domain.IngredientType{ID: uuid.UUID{8}, ParentID: meat.ID, Name: "poultry"}
+				m.ingredientRepo.EXPECT().
+					GetAllByRecipeId(gomock.Any(), dietRecipeId).
+					Return([]*domain.Ingredient{
+						{ID: uuid.UUID{9}, TypeID: poultry.ID, Name: "chicken"},
+						{ID: uuid.UUID{10}, TypeID: poultry.ID, Name: "turkey"},
+					}, nil)
+				m.ingredientTypeRepo.EXPECT().
+					GetById(gomock.Any(), poultry.ID).
+					Return(poultry, nil)
+				m.ingredientTypeRepo.EXPECT().
+					GetById(gomock.Any(), meat.ID).
+					Return(meat, nil)
+			},
+			expected: []string{domain.DietGlutenFree, domain.DietLactoseFree, domain.DietNutFree},
+			wantErr:  false,
+		}, // состав родительского типа
+		{
+			name: "рецепт без ингредиентов",
+			beforeTest: func(m *dietMocks) {
+				m.ingredientRepo.EXPECT().
+					GetAllByRecipeId(gomock.Any(), dietRecipeId).
+					Return([]*domain.Ingredient{}, nil)
+			},
+			expected: domain.AllDiets,
+			wantErr:  false,
+		}, // рецепт без ингредиентов
+		{
+			name: "ошибка получения типа ингредиента",
+			beforeTest: func(m *dietMocks) {
+				m.ingredientRepo.EXPECT().
+					GetAllByRecipeId(gomock.Any(), dietRecipeId).
+					Return([]*domain.Ingredient{dietCheese}, nil)
+				m.ingredientTypeRepo.EXPECT().
+					GetById(gomock.Any(), dietDairyType.ID).
+					Return(nil, fmt.Errorf("getting type err"))
+			},
+			wantErr: true,
+			errStr:  errors.New("getting diets of recipe: getting type err"),
+		}, // ошибка получения типа ингредиента
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newDietService(t)
+			tt.beforeTest(m)
+
+			diets, err := svc.GetRecipeDiets(context.Background(), dietRecipeId)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tt.expected, diets)
+			}
+		})
+	}
+}
+
+func TestDietService_CheckSaladType(t *testing.T) {
+	veganType := &domain.SaladType{ID: uuid.UUID{7}, Name: "Vegan"}
+	italianType := &domain.SaladType{ID: uuid.UUID{8}, Name: "Italian"}
+
+	tests := []struct {
+		name        string
+		saladTypeId uuid.UUID
+		beforeTest  func(m *dietMocks)
+		expected    *domain.DietConflict
+		wantErr     bool
+		errStr      error
+	}{
+		{
+			name:        "тип противоречит ингредиентам",
+			saladTypeId: veganType.ID,
+			beforeTest: func(m *dietMocks) {
+				m.saladTypeRepo.EXPECT().
+					GetById(gomock.Any(), veganType.ID).
+					Return(veganType, nil)
+				expectDietIngredients(m, dietTomato, dietCheese)
+			},
+			expected: &domain.DietConflict{
+				SaladID:     dietSaladId,
+				SaladTypeID: veganType.ID,
+				Diet:        domain.DietVegan,
+				Ingredients: []uuid.UUID{dietCheese.ID},
+			},
+			wantErr: false,
+		}, // тип противоречит ингредиентам
+		{
+			name:        "тип соответствует ингредиентам",
+			saladTypeId: veganType.ID,
+			beforeTest: func(m *dietMocks) {
+				m.saladTypeRepo.EXPECT().
+					GetById(gomock.Any(), veganType.ID).
+					Return(veganType, nil)
+				expectDietIngredients(m, dietTomato, dietBread)
+			},
+			expected: nil,
+			wantErr:  false,
+		}, // тип соответствует ингредиентам
+		{
+			name:        "тип не обозначает диету",
+			saladTypeId: italianType.ID,
+			beforeTest: func(m *dietMocks) {
+				m.saladTypeRepo.EXPECT().
+					GetById(gomock.Any(), italianType.ID).
+					Return(italianType, nil)
+			},
+			expected: nil,
+			wantErr:  false,
+		}, // тип не обозначает диету
+		{
+			name:        "ошибка получения рецепта",
+			saladTypeId: veganType.ID,
+			beforeTest: func(m *dietMocks) {
+				m.saladTypeRepo.EXPECT().
+					GetById(gomock.Any(), veganType.ID).
+					Return(veganType, nil)
+				m.recipeRepo.EXPECT().
+					GetBySaladId(gomock.Any(), dietSaladId).
+					Return(nil, fmt.Errorf("getting recipe err"))
+			},
+			wantErr: true,
+			errStr:  errors.New("checking salad type: getting recipe err"),
+		}, // ошибка получения рецепта
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newDietService(t)
+			tt.beforeTest(m)
+
+			found, err := svc.CheckSaladType(context.Background(), dietSaladId, tt.saladTypeId)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tt.expected, found)
+			}
+		})
+	}
+}
+
+func TestDietService_GetSaladConflicts(t *testing.T) {
+	svc, m := newDietService(t)
+	m.saladTypeRepo.EXPECT().
+		GetAllBySaladId(gomock.Any(), dietSaladId).
+		Return([]*domain.SaladType{
+			{ID: uuid.UUID{7}, Name: "vegan"},
+			{ID: uuid.UUID{8}, Name: "gluten-free"},
+			{ID: uuid.UUID{9}, Name: "nut-free"},
+		}, nil)
+	expectDietIngredients(m, dietCheese, dietBread)
+
+	conflicts, err := svc.GetSaladConflicts(context.Background(), dietSaladId)
+	require.Nil(t, err)
+	require.Len(t, conflicts, 2)
+	require.Equal(t, domain.DietVegan, conflicts[0].Diet)
+	require.Equal(t, []uuid.UUID{dietCheese.ID}, conflicts[0].Ingredients)
+	require.Equal(t, domain.DietGlutenFree, conflicts[1].Diet)
+	require.Equal(t, []uuid.UUID{dietBread.ID}, conflicts[1].Ingredients)
+}
+
+func TestDietCheckedSaladTypeService_Link(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mocks.NewMockISaladTypeService(ctrl)
+	diets := mocks.NewMockIDietService(ctrl)
+	logger := mocks.NewMockILogger(ctrl)
+	svc := services.NewDietCheckedSaladTypeService(inner, diets, logger)
+
+	saladTypeId := uuid.UUID{7}
+	inner.EXPECT().
+		Link(gomock.Any(), dietSaladId, saladTypeId).
+		Return(nil)
+	diets.EXPECT().
+		CheckSaladType(gomock.Any(), dietSaladId, saladTypeId).
+		Return(&domain.DietConflict{Diet: domain.DietVegan, Ingredients: []uuid.UUID{dietCheese.ID}}, nil)
+	logger.EXPECT().
+		Warn("salad tagged with contradicting diet", gomock.Any()).
+		Times(1)
+
+	require.Nil(t, svc.Link(context.Background(), dietSaladId, saladTypeId))
+
+	inner.EXPECT().
+		Link(gomock.Any(), dietSaladId, saladTypeId).
+		Return(fmt.Errorf("linking err"))
+	require.EqualError(t, svc.Link(context.Background(), dietSaladId, saladTypeId), "linking err")
+}
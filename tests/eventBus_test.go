@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventBus_SyncPublish(t *testing.T) {
+	saladId := uuid.New()
+
+	tests := []struct {
+		name      string
+		subscribe func(bus domain.IEventBus, got *[]string)
+		wantGot   []string
+		wantErr   bool
+		errStr    error
+	}{
+		{
+			name: "доставка подписчикам события и всех событий",
+			subscribe: func(bus domain.IEventBus, got *[]string) {
+				bus.Subscribe(domain.SaladCreatedEvent, func(ctx context.Context, event domain.IEvent) error {
+					*got = append(*got, "created")
+					return nil
+				})
+				bus.Subscribe(domain.SaladDeletedEvent, func(ctx context.Context, event domain.IEvent) error {
+					*got = append(*got, "deleted")
+					return nil
+				})
+				bus.Subscribe(domain.AllEvents, func(ctx context.Context, event domain.IEvent) error {
+					*got = append(*got, "all")
+					return nil
+				})
+			},
+			wantGot: []string{"created", "all"},
+			wantErr: false,
+		}, // доставка подписчикам события и всех событий
+		{
+			name: "отписка",
+			subscribe: func(bus domain.IEventBus, got *[]string) {
+				unsubscribe := bus.Subscribe(domain.SaladCreatedEvent, func(ctx context.Context, event domain.IEvent) error {
+					*got = append(*got, "first")
+					return nil
+				})
+				bus.Subscribe(domain.SaladCreatedEvent, func(ctx context.Context, event domain.IEvent) error {
+					*got = append(*got, "second")
+					return nil
+				})
+				unsubscribe()
+			},
+			wantGot: []string{"second"},
+			wantErr: false,
+		}, // отписка
+		{
+			name: "ошибка обработчика не останавливает остальных",
+			subscribe: func(bus domain.IEventBus, got *[]string) {
+				bus.Subscribe(domain.SaladCreatedEvent, func(ctx context.Context, event domain.IEvent) error {
+					return fmt.Errorf("handler error")
+				})
+				bus.Subscribe(domain.SaladCreatedEvent, func(ctx context.Context, event domain.IEvent) error {
+					panic("boom")
+				})
+				bus.Subscribe(domain.SaladCreatedEvent, func(ctx context.Context, event domain.IEvent) error {
+					*got = append(*got, "third")
+					return nil
+				})
+			},
+			wantGot: []string{"third"},
+			wantErr: true,
+			errStr: fmt.Errorf("handling salad.created: handler error\n" +
+				"handling salad.created: handler panic: boom"),
+		}, // ошибка обработчика не останавливает остальных
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := domain.NewEventBus(domain.EventBusConfig{Mode: domain.SyncDelivery})
+			defer bus.Close()
+
+			got := make([]string, 0)
+			tt.subscribe(bus, &got)
+
+			err := bus.Publish(context.Background(), &domain.SaladCreated{Salad: &domain.Salad{ID: saladId}})
+
+			require.Equal(t, tt.wantGot, got)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestEventBus_AsyncOrderPerAggregate(t *testing.T) {
+	var mu sync.Mutex
+	got := make(map[uuid.UUID][]int)
+	failed := make([]domain.IEvent, 0)
+
+	bus := domain.NewEventBus(domain.EventBusConfig{
+		Mode:      domain.AsyncDelivery,
+		Workers:   4,
+		QueueSize: 2,
+		OnError: func(event domain.IEvent, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			failed = append(failed, event)
+		},
+	})
+	bus.Subscribe(domain.RecipeStatusChangedEvent, func(ctx context.Context, event domain.IEvent) error {
+		changed := event.(*domain.RecipeStatusChanged)
+		mu.Lock()
+		defer mu.Unlock()
+		got[changed.SaladID] = append(got[changed.SaladID], changed.NewStatus)
+		if changed.NewStatus == 0 {
+			return fmt.Errorf("zero status")
+		}
+		return nil
+	})
+
+	saladIds := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	const eventsPerSalad = 50
+	for i := 0; i < eventsPerSalad; i++ {
+		for _, saladId := range saladIds {
+			err := bus.Publish(context.Background(), &domain.RecipeStatusChanged{SaladID: saladId, NewStatus: i})
+			require.Nil(t, err)
+		}
+	}
+	bus.Close()
+
+	for _, saladId := range saladIds {
+		require.Len(t, got[saladId], eventsPerSalad)
+		for i, status := range got[saladId] {
+			require.Equal(t, i, status)
+		}
+	}
+	require.Len(t, failed, len(saladIds))
+
+	err := bus.Publish(context.Background(), &domain.SaladDeleted{SaladID: saladIds[0]})
+	require.True(t, errors.Is(err, domain.ErrEventBusClosed))
+}
+
+func TestOutboxEntry_Event(t *testing.T) {
+	comment := &domain.Comment{ID: uuid.New(), SaladID: uuid.New(), Text: "text", Rating: 5}
+
+	entry, err := domain.NewOutboxEntry(&domain.CommentAdded{Comment: comment}, time.Now())
+	require.Nil(t, err)
+	require.Equal(t, domain.CommentAddedEvent, entry.EventName)
+	require.Equal(t, comment.SaladID, entry.AggregateID)
+
+	event, err := entry.Event()
+	require.Nil(t, err)
+	require.Equal(t, &domain.CommentAdded{Comment: comment}, event)
+
+	entry.EventName = "unknown"
+	_, err = entry.Event()
+	require.Equal(t, "unknown event unknown", err.Error())
+}
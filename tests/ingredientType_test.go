@@ -31,7 +31,7 @@ func TestIngredientTypeService_Create(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewIngredientTypeService(ingredientTypeRepo, logger)
+	svc := services.NewIngredientTypeService(ingredientTypeRepo, newTxManagerMock(ctrl), logger)
 
 	ingredientTypeId := uuid.New()
 
@@ -51,7 +51,7 @@ func TestIngredientTypeService_Create(t *testing.T) {
 			},
 			beforeTest: func(ingredientTypeRepo mocks.MockIIngredientTypeRepository) {
 				ingredientTypeRepo.EXPECT().
-					Create(context.Background(), &domain.IngredientType{
+					Create(gomock.Any(), &domain.IngredientType{
 						ID:          ingredientTypeId,
 						Name:        "meat",
 						Description: "",
@@ -79,7 +79,7 @@ func TestIngredientTypeService_Create(t *testing.T) {
 			},
 			beforeTest: func(ingredientTypeRepo mocks.MockIIngredientTypeRepository) {
 				ingredientTypeRepo.EXPECT().
-					Create(context.Background(), &domain.IngredientType{
+					Create(gomock.Any(), &domain.IngredientType{
 						ID:          ingredientTypeId,
 						Name:        "meat",
 						Description: "",
@@ -124,7 +124,7 @@ func TestIngredientTypeService_DeleteById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewIngredientTypeService(ingredientTypeRepo, logger)
+	svc := services.NewIngredientTypeService(ingredientTypeRepo, newTxManagerMock(ctrl), logger)
 
 	ingredientTypeId := uuid.New()
 
@@ -140,7 +140,10 @@ func TestIngredientTypeService_DeleteById(t *testing.T) {
 			ingredientId: ingredientTypeId,
 			beforeTest: func(ingredientTypeRepo mocks.MockIIngredientTypeRepository) {
 				ingredientTypeRepo.EXPECT().
-					DeleteById(context.Background(), ingredientTypeId).
+					GetAllByParentId(gomock.Any(), ingredientTypeId).
+					Return(nil, nil)
+				ingredientTypeRepo.EXPECT().
+					DeleteById(gomock.Any(), ingredientTypeId).
 					Return(nil)
 			},
 			wantErr: false,
@@ -150,12 +153,26 @@ func TestIngredientTypeService_DeleteById(t *testing.T) {
 			ingredientId: ingredientTypeId,
 			beforeTest: func(ingredientTypeRepo mocks.MockIIngredientTypeRepository) {
 				ingredientTypeRepo.EXPECT().
-					DeleteById(context.Background(), ingredientTypeId).
+					GetAllByParentId(gomock.Any(), ingredientTypeId).
+					Return(nil, nil)
+				ingredientTypeRepo.EXPECT().
+					DeleteById(gomock.Any(), ingredientTypeId).
 					Return(fmt.Errorf("deleting err"))
 			},
 			wantErr: true,
 			errStr:  errors.New("deleting ingredient type by id: deleting err"),
 		}, // ошибка выполнения запроса в репозитории
+		{
+			name:         "есть подтипы",
+			ingredientId: ingredientTypeId,
+			beforeTest: func(ingredientTypeRepo mocks.MockIIngredientTypeRepository) {
+				ingredientTypeRepo.EXPECT().
+					GetAllByParentId(gomock.Any(), ingredientTypeId).
+					Return([]*domain.IngredientType{{ID: uuid.New(), ParentID: ingredientTypeId}}, nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("deleting ingredient type by id: type has subtypes"),
+		}, // есть подтипы
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -191,7 +208,7 @@ func TestIngredientTypeService_GetAll(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewIngredientTypeService(ingredientTypeRepo, logger)
+	svc := services.NewIngredientTypeService(ingredientTypeRepo, newTxManagerMock(ctrl), logger)
 
 	tests := []struct {
 		name       string
@@ -298,7 +315,7 @@ func TestIngredientTypeService_GetById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewIngredientTypeService(ingredientTypeRepo, logger)
+	svc := services.NewIngredientTypeService(ingredientTypeRepo, newTxManagerMock(ctrl), logger)
 
 	ingredientTypeId := uuid.New()
 
@@ -376,7 +393,7 @@ func TestIngredientTypeService_Update(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewIngredientTypeService(ingredientTypeRepo, logger)
+	svc := services.NewIngredientTypeService(ingredientTypeRepo, newTxManagerMock(ctrl), logger)
 
 	ingredientTypeId := uuid.New()
 
@@ -396,7 +413,7 @@ func TestIngredientTypeService_Update(t *testing.T) {
 			},
 			beforeTest: func(ingredientTypeRepo mocks.MockIIngredientTypeRepository) {
 				ingredientTypeRepo.EXPECT().
-					Update(context.Background(), &domain.IngredientType{
+					Update(gomock.Any(), &domain.IngredientType{
 						ID:          ingredientTypeId,
 						Name:        "meat",
 						Description: "",
@@ -424,7 +441,7 @@ func TestIngredientTypeService_Update(t *testing.T) {
 			},
 			beforeTest: func(ingredientTypeRepo mocks.MockIIngredientTypeRepository) {
 				ingredientTypeRepo.EXPECT().
-					Update(context.Background(), &domain.IngredientType{
+					Update(gomock.Any(), &domain.IngredientType{
 						ID:          ingredientTypeId,
 						Name:        "meat",
 						Description: "",
@@ -450,3 +467,98 @@ func TestIngredientTypeService_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestIngredientTypeService_Move(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ingredientTypeRepo := mocks.NewMockIIngredientTypeRepository(ctrl)
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Warnf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	svc := services.NewIngredientTypeService(ingredientTypeRepo, newTxManagerMock(ctrl), logger)
+
+	// Vegetables -> Leafy greens -> Lettuce
+	vegetables := &domain.IngredientType{ID: uuid.UUID{1}, Name: "Vegetables"}
+	greens := &domain.IngredientType{ID: uuid.UUID{2}, ParentID: vegetables.ID, Name: "Leafy greens"}
+	lettuce := &domain.IngredientType{ID: uuid.UUID{3}, ParentID: greens.ID, Name: "Lettuce"}
+	byId := map[uuid.UUID]*domain.IngredientType{vegetables.ID: vegetables, greens.ID: greens, lettuce.ID: lettuce}
+	getById := func(ctx context.Context, id uuid.UUID) (*domain.IngredientType, error) {
+		copied := *byId[id]
+		return &copied, nil
+	}
+
+	tests := []struct {
+		name       string
+		id         uuid.UUID
+		parentId   uuid.UUID
+		beforeTest func(ingredientTypeRepo mocks.MockIIngredientTypeRepository)
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:     "проверка и запись в транзакции",
+			id:       lettuce.ID,
+			parentId: vegetables.ID,
+			beforeTest: func(ingredientTypeRepo mocks.MockIIngredientTypeRepository) {
+				ingredientTypeRepo.EXPECT().
+					GetById(inTransaction{}, gomock.Any()).
+					DoAndReturn(getById).
+					Times(2)
+				ingredientTypeRepo.EXPECT().
+					Update(inTransaction{}, gomock.Any()).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // проверка и запись в транзакции
+		{
+			name:     "перенос на уровень выше",
+			id:       lettuce.ID,
+			parentId: vegetables.ID,
+			beforeTest: func(ingredientTypeRepo mocks.MockIIngredientTypeRepository) {
+				ingredientTypeRepo.EXPECT().
+					GetById(gomock.Any(), gomock.Any()).
+					DoAndReturn(getById).
+					Times(2)
+				ingredientTypeRepo.EXPECT().
+					Update(gomock.Any(), &domain.IngredientType{ID: lettuce.ID, ParentID: vegetables.ID, Name: "Lettuce"}).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // перенос на уровень выше
+		{
+			name:     "перенос в своего потомка",
+			id:       vegetables.ID,
+			parentId: lettuce.ID,
+			beforeTest: func(ingredientTypeRepo mocks.MockIIngredientTypeRepository) {
+				ingredientTypeRepo.EXPECT().
+					GetById(gomock.Any(), gomock.Any()).
+					DoAndReturn(getById).
+					Times(3)
+			},
+			wantErr: true,
+			errStr:  errors.New("moving ingredient type: type can't be moved under itself or its descendant"),
+		}, // перенос в своего потомка
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*ingredientTypeRepo)
+			}
+
+			err := svc.Move(context.Background(), tt.id, tt.parentId)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/Mx1q/ppo_services/config"
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/services"
 	"github.com/Mx1q/ppo_services/tests/mocks"
@@ -31,7 +32,7 @@ func TestIngredientService_Create(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewIngredientService(ingredientRepo, logger)
+	svc := services.NewIngredientService(ingredientRepo, logger, config.Default().Pages)
 
 	ingredientId := uuid.New()
 	typeId := uuid.New()
@@ -85,6 +86,18 @@ func TestIngredientService_Create(t *testing.T) {
 			wantErr: true,
 			errStr:  errors.New("creating ingredient: negative calories"),
 		}, // число калорий <0
+		{
+			name: "неизвестный состав",
+			ingredient: &domain.Ingredient{
+				ID:       ingredientId,
+				TypeID:   typeId,
+				Name:     "tomato",
+				Calories: 10,
+				Contents: []string{"plastic"},
+			},
+			wantErr: true,
+			errStr:  errors.New("creating ingredient: unknown content plastic"),
+		}, // неизвестный состав
 		{
 			name: "ошибка выполнения запроса в репозитории",
 			ingredient: &domain.Ingredient{
@@ -141,7 +154,7 @@ func TestIngredientService_DeleteById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewIngredientService(ingredientRepo, logger)
+	svc := services.NewIngredientService(ingredientRepo, logger, config.Default().Pages)
 
 	ingredientId := uuid.New()
 
@@ -208,7 +221,7 @@ func TestIngredientService_Link(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewIngredientService(ingredientRepo, logger)
+	svc := services.NewIngredientService(ingredientRepo, logger, config.Default().Pages)
 
 	recipeId := uuid.New()
 	ingredientId := uuid.New()
@@ -279,7 +292,7 @@ func TestIngredientService_Unlink(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewIngredientService(ingredientRepo, logger)
+	svc := services.NewIngredientService(ingredientRepo, logger, config.Default().Pages)
 
 	recipeId := uuid.New()
 	ingredientId := uuid.New()
@@ -350,13 +363,13 @@ func TestIngredientService_GetAll(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewIngredientService(ingredientRepo, logger)
+	svc := services.NewIngredientService(ingredientRepo, logger, config.Default().Pages)
 
-	page := 1
+	page := &domain.PageRequest{Size: 10}
 
 	tests := []struct {
 		name       string
-		page       int
+		page       *domain.PageRequest
 		beforeTest func(ingredientRepo mocks.MockIIngredientRepository)
 		expected   []*domain.Ingredient
 		wantErr    bool
@@ -393,7 +406,7 @@ func TestIngredientService_GetAll(t *testing.T) {
 							Name:     "cucumber",
 							Calories: 10,
 						},
-					}, 1, nil)
+					}, &domain.PageInfo{Size: 10, Total: 4}, nil)
 			},
 			expected: []*domain.Ingredient{
 				{
@@ -429,11 +442,17 @@ func TestIngredientService_GetAll(t *testing.T) {
 			beforeTest: func(ingredientRepo mocks.MockIIngredientRepository) {
 				ingredientRepo.EXPECT().
 					GetAll(context.Background(), page).
-					Return(nil, 0, fmt.Errorf("getting ingredients err"))
+					Return(nil, nil, fmt.Errorf("getting ingredients err"))
 			},
 			wantErr: true,
 			errStr:  errors.New("getting all ingredients: getting ingredients err"),
 		}, // ошибка выполнения запроса в репозитории
+		{
+			name:    "неподдерживаемая сортировка",
+			page:    &domain.PageRequest{Sort: "rating"},
+			wantErr: true,
+			errStr:  errors.New("getting all ingredients: unsupported sort rating"),
+		}, // неподдерживаемая сортировка
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -470,7 +489,7 @@ func TestIngredientService_GetAllIngredientsByTypeId(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewIngredientService(ingredientRepo, logger)
+	svc := services.NewIngredientService(ingredientRepo, logger, config.Default().Pages)
 
 	recipeId := uuid.New()
 
@@ -590,7 +609,7 @@ func TestIngredientService_GetById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewIngredientService(ingredientRepo, logger)
+	svc := services.NewIngredientService(ingredientRepo, logger, config.Default().Pages)
 
 	ingredientId := uuid.New()
 
@@ -670,7 +689,7 @@ func TestIngredientService_Update(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewIngredientService(ingredientRepo, logger)
+	svc := services.NewIngredientService(ingredientRepo, logger, config.Default().Pages)
 
 	ingredientId := uuid.New()
 	typeId := uuid.New()
@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func decodeLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	records := make([]map[string]interface{}, 0)
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLogger_Levels(t *testing.T) {
+	globalLevel := zerolog.GlobalLevel()
+
+	tests := []struct {
+		name      string
+		level     string
+		wantCount int
+	}{
+		{
+			name:      "уровень debug",
+			level:     logger.LoggerDebugLevel,
+			wantCount: 4,
+		}, // уровень debug
+		{
+			name:      "уровень info",
+			level:     logger.LoggerInfoLevel,
+			wantCount: 3,
+		}, // уровень info
+		{
+			name:      "уровень error",
+			level:     logger.LoggerErrorLevel,
+			wantCount: 1,
+		}, // уровень error
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			l := logger.NewLogger(tt.level, buf)
+
+			l.Debugf("debug %d", 1)
+			l.Info("info")
+			l.Warnf("warn")
+			l.Error("error", logger.Err(fmt.Errorf("failed")))
+
+			require.Len(t, decodeLogRecords(t, buf), tt.wantCount)
+			require.Equal(t, globalLevel, zerolog.GlobalLevel())
+		})
+	}
+}
+
+func TestLogger_Fields(t *testing.T) {
+	buf := new(bytes.Buffer)
+	actor := &domain.Actor{ID: uuid.New(), Role: "admin"}
+	ctx := logger.ContextWithRequestID(domain.ContextWithActor(context.Background(), actor), "req-1")
+	saladId := uuid.New()
+
+	l := logger.NewLogger(logger.LoggerInfoLevel, buf).
+		Component("salads").
+		WithContext(ctx)
+	l.Info("salad created",
+		logger.UUID("salad_id", saladId),
+		logger.Int("steps", 3),
+		logger.Err(fmt.Errorf("partial")))
+
+	records := decodeLogRecords(t, buf)
+	require.Len(t, records, 1)
+	require.Equal(t, "salad created", records[0]["message"])
+	require.Equal(t, "salads", records[0][logger.ComponentKey])
+	require.Equal(t, "req-1", records[0][logger.RequestIDKey])
+	require.Equal(t, actor.ID.String(), records[0][logger.UserIDKey])
+	require.Equal(t, saladId.String(), records[0]["salad_id"])
+	require.Equal(t, float64(3), records[0]["steps"])
+	require.Equal(t, "partial", records[0][logger.ErrorKey])
+}
+
+func TestLogger_Redaction(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := logger.NewLogger(logger.LoggerInfoLevel, buf)
+	user := &domain.User{Username: "chef", Password: "topsecret"}
+	userAuth := domain.UserAuth{Username: "chef", HashedPass: "$2a$hash"}
+
+	l.Infof("creating user: %v", user)
+	l.Infof("logging in: %+v", userAuth)
+	l.Infof("importing users: %v", []*domain.User{user})
+	l.Infof("subscriptions: %v", map[string]domain.WebhookSubscription{"orders": {URL: "http://hook", Secret: "hooksecret"}})
+	l.Infof("request: %+v", struct{ Auth *domain.UserAuth }{&userAuth})
+	l.Info("user", logger.Any("user", user), logger.String("password", "topsecret"))
+
+	require.NotContains(t, buf.String(), "topsecret")
+	require.NotContains(t, buf.String(), "$2a$hash")
+	require.NotContains(t, buf.String(), "hooksecret")
+	require.Contains(t, buf.String(), "chef")
+	require.Contains(t, buf.String(), "http://hook")
+	require.Equal(t, "topsecret", user.Password)
+
+	records := decodeLogRecords(t, buf)
+	require.Len(t, records, 6)
+	require.Equal(t, "***", records[5]["password"])
+	require.Equal(t, "***", records[5]["user"].(map[string]interface{})["Password"])
+}
@@ -89,6 +89,53 @@ func TestMeasurementService_Create(t *testing.T) {
 			wantErr: true,
 			errStr:  errors.New("creating measurement unit: negative or zero grams count"),
 		}, // количество граммов <0
+		{
+			name: "граммы единицы массы",
+			measurement: &domain.Measurement{
+				ID:   measurementId,
+				Name: "kilogram",
+				Unit: domain.UnitKilogram,
+			},
+			beforeTest: func(measurementRepo mocks.MockIMeasurementRepository) {
+				measurementRepo.EXPECT().
+					Create(context.Background(), &domain.Measurement{
+						ID:    measurementId,
+						Name:  "kilogram",
+						Unit:  domain.UnitKilogram,
+						Grams: 1000,
+					}).Return(nil)
+			},
+			wantErr: false,
+		}, // граммы единицы массы
+		{
+			name: "дробные граммы единицы массы",
+			measurement: &domain.Measurement{
+				ID:   measurementId,
+				Name: "ounce",
+				Unit: domain.UnitOunce,
+			},
+			beforeTest: func(measurementRepo mocks.MockIMeasurementRepository) {
+				measurementRepo.EXPECT().
+					Create(context.Background(), &domain.Measurement{
+						ID:    measurementId,
+						Name:  "ounce",
+						Unit:  domain.UnitOunce,
+						Grams: 28.349523125,
+					}).Return(nil)
+			},
+			wantErr: false,
+		}, // дробные граммы единицы массы
+		{
+			name: "неизвестная единица",
+			measurement: &domain.Measurement{
+				ID:    measurementId,
+				Name:  "bucket",
+				Unit:  "bucket",
+				Grams: 1,
+			},
+			wantErr: true,
+			errStr:  errors.New(`creating measurement unit: unknown unit "bucket"`),
+		}, // неизвестная единица
 		{
 			name: "ошибка выполнения запроса в репозитории",
 			measurement: &domain.Measurement{
@@ -462,6 +509,82 @@ func TestMeasurementService_GetByRecipeId(t *testing.T) {
 	}
 }
 
+func TestMeasurementService_GetAllByRecipeId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurementRepo := mocks.NewMockIMeasurementRepository(ctrl)
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	svc := services.NewMeasurementService(measurementRepo, logger)
+
+	recipeId := uuid.New()
+
+	tests := []struct {
+		name                 string
+		recipeId             uuid.UUID
+		beforeTest           func(measurementRepo mocks.MockIMeasurementRepository)
+		expectedMeasurements []*domain.RecipeMeasurement
+		wantErr              bool
+		errStr               error
+	}{
+		{
+			name:     "успешное получение",
+			recipeId: recipeId,
+			beforeTest: func(measurementRepo mocks.MockIMeasurementRepository) {
+				measurementRepo.EXPECT().
+					GetAllByRecipeId(context.Background(), recipeId).
+					Return([]*domain.RecipeMeasurement{
+						{
+							IngredientID: uuid.UUID{2},
+							Measurement:  &domain.Measurement{ID: uuid.UUID{1}, Name: "spoon", Grams: 1},
+							Amount:       3,
+						},
+					}, nil)
+			},
+			expectedMeasurements: []*domain.RecipeMeasurement{
+				{
+					IngredientID: uuid.UUID{2},
+					Measurement:  &domain.Measurement{ID: uuid.UUID{1}, Name: "spoon", Grams: 1},
+					Amount:       3,
+				},
+			},
+			wantErr: false,
+		}, // успешное получение
+		{
+			name:     "ошибка выполнения запроса в репозитории",
+			recipeId: recipeId,
+			beforeTest: func(measurementRepo mocks.MockIMeasurementRepository) {
+				measurementRepo.EXPECT().
+					GetAllByRecipeId(context.Background(), recipeId).
+					Return(nil, fmt.Errorf("getting measurements err"))
+			},
+			wantErr: true,
+			errStr:  errors.New("getting all measurement units by recipe id: getting measurements err"),
+		}, // ошибка выполнения запроса в репозитории
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*measurementRepo)
+			}
+
+			measurements, err := svc.GetAllByRecipeId(context.Background(), tt.recipeId)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tt.expectedMeasurements, measurements)
+			}
+		})
+	}
+}
+
 func TestMeasurementService_Update(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -662,3 +785,169 @@ func TestMeasurementService_UpdateLink(t *testing.T) {
 		})
 	}
 }
+
+func TestMeasurementService_Convert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurementRepo := mocks.NewMockIMeasurementRepository(ctrl)
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Warnf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	svc := services.NewMeasurementService(measurementRepo, logger)
+
+	honey := &domain.Ingredient{Name: "honey", Density: 1.42}
+
+	tests := []struct {
+		name       string
+		quantity   domain.Quantity
+		to         string
+		ingredient *domain.Ingredient
+		expected   domain.Quantity
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:     "объем в объем",
+			quantity: domain.Quantity{Amount: 3, Unit: domain.UnitTeaspoon},
+			to:       domain.UnitTablespoon,
+			expected: domain.Quantity{Amount: 1, Unit: domain.UnitTablespoon},
+			wantErr:  false,
+		}, // объем в объем
+		{
+			name:       "объем в массу по плотности",
+			quantity:   domain.Quantity{Amount: 100, Unit: domain.UnitMilliliter},
+			to:         domain.UnitGram,
+			ingredient: honey,
+			expected:   domain.Quantity{Amount: 142, Unit: domain.UnitGram},
+			wantErr:    false,
+		}, // объем в массу по плотности
+		{
+			name:     "неизвестная плотность",
+			quantity: domain.Quantity{Amount: 1, Unit: domain.UnitCup},
+			to:       domain.UnitGram,
+			wantErr:  true,
+			errStr:   errors.New("converting quantity: density is required to convert between mass and volume"),
+		}, // неизвестная плотность
+		{
+			name:       "штуки в массу",
+			quantity:   domain.Quantity{Amount: 2, Unit: domain.UnitPiece},
+			to:         domain.UnitGram,
+			ingredient: honey,
+			wantErr:    true,
+			errStr:     errors.New("converting quantity: incompatible units: piece to g"),
+		}, // штуки в массу
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, err := svc.Convert(context.Background(), tt.quantity, tt.to, tt.ingredient)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tt.expected.Unit, converted.Unit)
+				require.InDelta(t, tt.expected.Amount, converted.Amount, 1e-9)
+			}
+		})
+	}
+}
+
+func TestMeasurementService_ToSystem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurementRepo := mocks.NewMockIMeasurementRepository(ctrl)
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Warnf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Warnf(gomock.Any()).
+		AnyTimes()
+	svc := services.NewMeasurementService(measurementRepo, logger)
+
+	tests := []struct {
+		name     string
+		item     *domain.SaladIngredient
+		system   string
+		expected string
+		wantErr  bool
+		errStr   error
+	}{
+		{
+			name: "граммы в имперскую систему",
+			item: &domain.SaladIngredient{
+				Measurement: &domain.Measurement{Name: "gram", Unit: domain.UnitGram, Grams: 1},
+				Amount:      500,
+			},
+			system:   domain.ImperialSystem,
+			expected: "1.1 lb",
+			wantErr:  false,
+		}, // граммы в имперскую систему
+		{
+			name: "мера без единицы в граммах",
+			item: &domain.SaladIngredient{
+				Measurement: &domain.Measurement{Name: "handful", Grams: 10},
+				Amount:      2,
+			},
+			system:   domain.ImperialSystem,
+			expected: "0.71 oz",
+			wantErr:  false,
+		}, // мера без единицы в граммах
+		{
+			name: "чашки в метрическую систему",
+			item: &domain.SaladIngredient{
+				Measurement: &domain.Measurement{Name: "cup", Unit: domain.UnitCup, Grams: 240},
+				Amount:      5,
+			},
+			system:   domain.MetricSystem,
+			expected: "1.18 l",
+			wantErr:  false,
+		}, // чашки в метрическую систему
+		{
+			name: "ложки не меняются",
+			item: &domain.SaladIngredient{
+				Measurement: &domain.Measurement{Name: "tablespoon", Unit: domain.UnitTablespoon, Grams: 15},
+				Amount:      2,
+			},
+			system:   domain.MetricSystem,
+			expected: "2 tbsp",
+			wantErr:  false,
+		}, // ложки не меняются
+		{
+			name:    "без меры",
+			item:    &domain.SaladIngredient{Amount: 2},
+			system:  domain.MetricSystem,
+			wantErr: true,
+			errStr:  errors.New("converting ingredient: empty measurement"),
+		}, // без меры
+		{
+			name: "неизвестная система",
+			item: &domain.SaladIngredient{
+				Measurement: &domain.Measurement{Name: "gram", Unit: domain.UnitGram, Grams: 1},
+				Amount:      2,
+			},
+			system:  "roman",
+			wantErr: true,
+			errStr:  errors.New("converting ingredient: unknown unit system roman"),
+		}, // неизвестная система
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, err := svc.ToSystem(context.Background(), tt.item, tt.system)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tt.expected, converted.String())
+			}
+		})
+	}
+}
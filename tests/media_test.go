@@ -0,0 +1,470 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/blobstore"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+type mediaMocks struct {
+	mediaRepo      *mocks.MockIMediaRepository
+	saladRepo      *mocks.MockISaladRepository
+	recipeStepRepo *mocks.MockIRecipeStepRepository
+}
+
+func newLoggerMock(ctrl *gomock.Controller) *mocks.MockILogger {
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Warnf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	return logger
+}
+
+func newMediaService(ctrl *gomock.Controller, storage domain.IBlobStorage) (domain.IMediaService, mediaMocks) {
+	m := mediaMocks{
+		mediaRepo:      mocks.NewMockIMediaRepository(ctrl),
+		saladRepo:      mocks.NewMockISaladRepository(ctrl),
+		recipeStepRepo: mocks.NewMockIRecipeStepRepository(ctrl),
+	}
+	limits := domain.MediaLimits{MaxSize: 64 << 10, MaxWidth: 1000, MaxHeight: 1000, ThumbnailSize: 100}
+	return services.NewMediaService(m.mediaRepo, m.saladRepo, m.recipeStepRepo, storage,
+		newTxManagerMock(ctrl), limits, newLoggerMock(ctrl)), m
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 100, A: 255})
+		}
+	}
+	buf := new(bytes.Buffer)
+	require.Nil(t, png.Encode(buf, img))
+	return buf.Bytes()
+}
+
+func TestMediaService_Upload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	saladId := uuid.UUID{1}
+	mediaId := uuid.UUID{2}
+	picture := encodePNG(t, 400, 200)
+
+	tests := []struct {
+		name       string
+		upload     *domain.MediaUpload
+		beforeTest func(m mediaMocks)
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:   "успешная загрузка в конец",
+			upload: &domain.MediaUpload{OwnerType: domain.MediaOwnerSalad, OwnerID: saladId, Data: picture},
+			beforeTest: func(m mediaMocks) {
+				m.saladRepo.EXPECT().
+					GetById(gomock.Any(), saladId).
+					Return(&domain.Salad{ID: saladId}, nil)
+				m.mediaRepo.EXPECT().
+					GetAllByOwner(gomock.Any(), domain.MediaOwnerSalad, saladId).
+					Return([]*domain.Media{{ID: uuid.UUID{3}, Order: 1}}, nil)
+				m.mediaRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(mediaId, nil)
+			},
+			wantErr: false,
+		}, // успешная загрузка в конец
+		{
+			name:    "неподдерживаемый тип",
+			upload:  &domain.MediaUpload{OwnerType: domain.MediaOwnerSalad, OwnerID: saladId, Data: []byte("plain text")},
+			wantErr: true,
+			errStr:  errors.New("uploading media: unsupported media type text/plain; charset=utf-8"),
+		}, // неподдерживаемый тип
+		{
+			name:    "слишком большое изображение",
+			upload:  &domain.MediaUpload{OwnerType: domain.MediaOwnerSalad, OwnerID: saladId, Data: encodePNG(t, 1001, 10)},
+			wantErr: true,
+			errStr:  errors.New("uploading media: image is larger than 1000x1000"),
+		}, // слишком большое изображение
+		{
+			name:    "слишком большой файл",
+			upload:  &domain.MediaUpload{OwnerType: domain.MediaOwnerSalad, OwnerID: saladId, Data: make([]byte, 64<<10+1)},
+			wantErr: true,
+			errStr:  fmt.Errorf("uploading media: file is larger than %d bytes", 64<<10),
+		}, // слишком большой файл
+		{
+			name:    "неизвестный владелец",
+			upload:  &domain.MediaUpload{OwnerType: "comment", OwnerID: saladId, Data: picture},
+			wantErr: true,
+			errStr:  errors.New("uploading media: unknown owner type comment"),
+		}, // неизвестный владелец
+		{
+			name:   "шаг не найден",
+			upload: &domain.MediaUpload{OwnerType: domain.MediaOwnerRecipeStep, OwnerID: saladId, Data: picture},
+			beforeTest: func(m mediaMocks) {
+				m.recipeStepRepo.EXPECT().
+					GetById(gomock.Any(), saladId).
+					Return(nil, fmt.Errorf("step not found"))
+			},
+			wantErr: true,
+			errStr:  errors.New("uploading media: step not found"),
+		}, // шаг не найден
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := blobstore.NewMemoryBlobStorage()
+			svc, m := newMediaService(ctrl, storage)
+			if tt.beforeTest != nil {
+				tt.beforeTest(m)
+			}
+
+			media, err := svc.Upload(context.Background(), tt.upload)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, mediaId, media.ID)
+			require.Equal(t, "image/png", media.MimeType)
+			require.Equal(t, 400, media.Width)
+			require.Equal(t, 200, media.Height)
+			require.Equal(t, 2, media.Order)
+
+			data, err := storage.Get(context.Background(), media.Key)
+			require.Nil(t, err)
+			require.Equal(t, picture, data)
+
+			data, err = storage.Get(context.Background(), media.ThumbnailKey)
+			require.Nil(t, err)
+			thumbnail, err := png.DecodeConfig(bytes.NewReader(data))
+			require.Nil(t, err)
+			require.Equal(t, 100, thumbnail.Width)
+			require.Equal(t, 50, thumbnail.Height)
+		})
+	}
+}
+
+func TestMediaService_Upload_RemovesBlobsOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	storage := mocks.NewMockIBlobStorage(ctrl)
+	svc, m := newMediaService(ctrl, storage)
+
+	saladId := uuid.UUID{1}
+	var keys []string
+	storage.EXPECT().
+		Put(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, data []byte) error {
+			keys = append(keys, key)
+			return nil
+		}).
+		Times(2)
+	m.saladRepo.EXPECT().
+		GetById(gomock.Any(), saladId).
+		Return(nil, fmt.Errorf("salad not found"))
+	storage.EXPECT().
+		Delete(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string) error {
+			require.Contains(t, keys, key)
+			return nil
+		}).
+		Times(2)
+
+	_, err := svc.Upload(context.Background(), &domain.MediaUpload{
+		OwnerType: domain.MediaOwnerSalad,
+		OwnerID:   saladId,
+		Data:      encodePNG(t, 10, 10),
+	})
+	require.Equal(t, "uploading media: salad not found", err.Error())
+}
+
+func TestMediaService_DeleteById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	storage := blobstore.NewMemoryBlobStorage()
+	svc, m := newMediaService(ctrl, storage)
+
+	saladId := uuid.UUID{1}
+	deleted := &domain.Media{ID: uuid.UUID{2}, OwnerType: domain.MediaOwnerSalad, OwnerID: saladId,
+		Key: "deleted", ThumbnailKey: "deleted.thumbnail", Order: 1}
+	require.Nil(t, storage.Put(context.Background(), deleted.Key, []byte("image")))
+	require.Nil(t, storage.Put(context.Background(), deleted.ThumbnailKey, []byte("thumbnail")))
+
+	m.mediaRepo.EXPECT().
+		GetById(gomock.Any(), deleted.ID).
+		Return(deleted, nil)
+	m.mediaRepo.EXPECT().
+		DeleteById(gomock.Any(), deleted.ID).
+		Return(nil)
+	m.mediaRepo.EXPECT().
+		GetAllByOwner(gomock.Any(), domain.MediaOwnerSalad, saladId).
+		Return([]*domain.Media{{ID: uuid.UUID{3}, OwnerType: domain.MediaOwnerSalad, OwnerID: saladId, Order: 2}}, nil)
+	m.mediaRepo.EXPECT().
+		Update(gomock.Any(), &domain.Media{ID: uuid.UUID{3}, OwnerType: domain.MediaOwnerSalad, OwnerID: saladId, Order: 1}).
+		Return(nil)
+
+	require.Nil(t, svc.DeleteById(context.Background(), deleted.ID))
+
+	_, err := storage.Get(context.Background(), deleted.Key)
+	require.ErrorIs(t, err, domain.ErrBlobNotFound)
+	_, err = storage.Get(context.Background(), deleted.ThumbnailKey)
+	require.ErrorIs(t, err, domain.ErrBlobNotFound)
+}
+
+func TestAuditedMediaService_DeleteById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	storage := blobstore.NewMemoryBlobStorage()
+	media, m := newMediaService(ctrl, storage)
+	audit := mocks.NewMockIAuditService(ctrl)
+	svc := services.NewAuditedMediaService(media, newTxManagerMock(ctrl), audit)
+
+	saladId := uuid.UUID{1}
+	deleted := &domain.Media{ID: uuid.UUID{2}, OwnerType: domain.MediaOwnerSalad, OwnerID: saladId,
+		Key: "deleted", ThumbnailKey: "deleted.thumbnail", Order: 1}
+	require.Nil(t, storage.Put(context.Background(), deleted.Key, []byte("image")))
+	require.Nil(t, storage.Put(context.Background(), deleted.ThumbnailKey, []byte("thumbnail")))
+
+	m.mediaRepo.EXPECT().
+		GetById(inTransaction{}, deleted.ID).
+		Return(deleted, nil).
+		Times(2)
+	m.mediaRepo.EXPECT().
+		DeleteById(inTransaction{}, deleted.ID).
+		Return(nil)
+	m.mediaRepo.EXPECT().
+		GetAllByOwner(inTransaction{}, domain.MediaOwnerSalad, saladId).
+		Return(nil, nil)
+	audit.EXPECT().
+		Record(inTransaction{}, domain.AuditActionDelete, domain.AuditEntityMedia, deleted.ID, deleted, nil).
+		Return(fmt.Errorf("recording audit: repo error"))
+
+	err := svc.DeleteById(context.Background(), deleted.ID)
+	require.Equal(t, "recording audit: repo error", err.Error())
+
+	// the deletion is rolled back, so are its blobs
+	_, err = storage.Get(context.Background(), deleted.Key)
+	require.Nil(t, err)
+	_, err = storage.Get(context.Background(), deleted.ThumbnailKey)
+	require.Nil(t, err)
+}
+
+func TestMediaCleanupSaladService_DeleteById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	saladService := mocks.NewMockISaladService(ctrl)
+	recipeRepo := mocks.NewMockIRecipeRepository(ctrl)
+	recipeStepRepo := mocks.NewMockIRecipeStepRepository(ctrl)
+	media := mocks.NewMockIMediaService(ctrl)
+	svc := services.NewMediaCleanupSaladService(saladService, recipeRepo, recipeStepRepo, media, newLoggerMock(ctrl))
+
+	saladId := uuid.UUID{1}
+	recipeId := uuid.UUID{2}
+	stepId := uuid.UUID{3}
+
+	tests := []struct {
+		name       string
+		beforeTest func()
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "медиа салата и шагов удаляются",
+			beforeTest: func() {
+				recipeRepo.EXPECT().
+					GetBySaladId(gomock.Any(), saladId).
+					Return(&domain.Recipe{ID: recipeId, SaladID: saladId}, nil)
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return([]*domain.RecipeStep{{ID: stepId, RecipeID: recipeId}}, nil)
+				saladService.EXPECT().
+					DeleteById(gomock.Any(), saladId).
+					Return(nil)
+				media.EXPECT().
+					DeleteAllByOwner(gomock.Any(), domain.MediaOwnerSalad, saladId).
+					Return(nil)
+				media.EXPECT().
+					DeleteAllByOwner(gomock.Any(), domain.MediaOwnerRecipeStep, stepId).
+					Return(fmt.Errorf("storage err"))
+			},
+			wantErr: false,
+		}, // медиа салата и шагов удаляются
+		{
+			name: "медиа не удаляются при ошибке удаления салата",
+			beforeTest: func() {
+				recipeRepo.EXPECT().
+					GetBySaladId(gomock.Any(), saladId).
+					Return(nil, fmt.Errorf("recipe not found"))
+				saladService.EXPECT().
+					DeleteById(gomock.Any(), saladId).
+					Return(fmt.Errorf("deleting salad err"))
+			},
+			wantErr: true,
+			errStr:  errors.New("deleting salad err"),
+		}, // медиа не удаляются при ошибке удаления салата
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest()
+
+			err := svc.DeleteById(context.Background(), saladId)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestMediaCleanupRecipeService_DeleteById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	recipeService := mocks.NewMockIRecipeService(ctrl)
+	recipeStepRepo := mocks.NewMockIRecipeStepRepository(ctrl)
+	media := mocks.NewMockIMediaService(ctrl)
+	svc := services.NewMediaCleanupRecipeService(recipeService, recipeStepRepo, media, newLoggerMock(ctrl))
+
+	recipeId := uuid.UUID{2}
+	stepId := uuid.UUID{3}
+
+	tests := []struct {
+		name       string
+		beforeTest func()
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "медиа шагов удаляются",
+			beforeTest: func() {
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return([]*domain.RecipeStep{{ID: stepId, RecipeID: recipeId}}, nil)
+				recipeService.EXPECT().
+					DeleteById(gomock.Any(), recipeId).
+					Return(nil)
+				media.EXPECT().
+					DeleteAllByOwner(gomock.Any(), domain.MediaOwnerRecipeStep, stepId).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // медиа шагов удаляются
+		{
+			name: "медиа не удаляются при ошибке удаления рецепта",
+			beforeTest: func() {
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return([]*domain.RecipeStep{{ID: stepId, RecipeID: recipeId}}, nil)
+				recipeService.EXPECT().
+					DeleteById(gomock.Any(), recipeId).
+					Return(fmt.Errorf("deleting recipe err"))
+			},
+			wantErr: true,
+			errStr:  errors.New("deleting recipe err"),
+		}, // медиа не удаляются при ошибке удаления рецепта
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest()
+
+			err := svc.DeleteById(context.Background(), recipeId)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestMediaCleanupRecipeAggregateService_Replace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	aggregateService := mocks.NewMockIRecipeAggregateService(ctrl)
+	recipeRepo := mocks.NewMockIRecipeRepository(ctrl)
+	recipeStepRepo := mocks.NewMockIRecipeStepRepository(ctrl)
+	media := mocks.NewMockIMediaService(ctrl)
+	svc := services.NewMediaCleanupRecipeAggregateService(aggregateService, recipeRepo, recipeStepRepo, media, newLoggerMock(ctrl))
+
+	saladId := uuid.UUID{1}
+	recipeId := uuid.UUID{2}
+	keptStepId := uuid.UUID{3}
+	removedStepId := uuid.UUID{4}
+	aggregate := &domain.RecipeAggregate{
+		Salad:  &domain.Salad{ID: saladId},
+		Recipe: &domain.Recipe{},
+		Steps:  []*domain.RecipeStep{{ID: keptStepId, Name: "cut", Description: "cut", StepNum: 1}},
+	}
+
+	tests := []struct {
+		name       string
+		beforeTest func()
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "медиа убранных шагов удаляются",
+			beforeTest: func() {
+				recipeRepo.EXPECT().
+					GetBySaladId(gomock.Any(), saladId).
+					Return(&domain.Recipe{ID: recipeId, SaladID: saladId}, nil)
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return([]*domain.RecipeStep{{ID: keptStepId}, {ID: removedStepId}}, nil)
+				aggregateService.EXPECT().
+					Replace(gomock.Any(), aggregate).
+					Return(nil)
+				media.EXPECT().
+					DeleteAllByOwner(gomock.Any(), domain.MediaOwnerRecipeStep, removedStepId).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // медиа убранных шагов удаляются
+		{
+			name: "медиа не удаляются при ошибке замены",
+			beforeTest: func() {
+				recipeRepo.EXPECT().
+					GetBySaladId(gomock.Any(), saladId).
+					Return(&domain.Recipe{ID: recipeId, SaladID: saladId}, nil)
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return([]*domain.RecipeStep{{ID: removedStepId}}, nil)
+				aggregateService.EXPECT().
+					Replace(gomock.Any(), aggregate).
+					Return(fmt.Errorf("replacing err"))
+			},
+			wantErr: true,
+			errStr:  errors.New("replacing err"),
+		}, // медиа не удаляются при ошибке замены
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest()
+
+			err := svc.Replace(context.Background(), aggregate)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/catalog.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	domain "github.com/Mx1q/ppo_services/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockICatalogService is a mock of ICatalogService interface.
+type MockICatalogService struct {
+	ctrl     *gomock.Controller
+	recorder *MockICatalogServiceMockRecorder
+}
+
+// MockICatalogServiceMockRecorder is the mock recorder for MockICatalogService.
+type MockICatalogServiceMockRecorder struct {
+	mock *MockICatalogService
+}
+
+// NewMockICatalogService creates a new mock instance.
+func NewMockICatalogService(ctrl *gomock.Controller) *MockICatalogService {
+	mock := &MockICatalogService{ctrl: ctrl}
+	mock.recorder = &MockICatalogServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICatalogService) EXPECT() *MockICatalogServiceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockICatalogService) Export(ctx context.Context, catalog string, w io.Writer, format string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, catalog, w, format)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockICatalogServiceMockRecorder) Export(ctx, catalog, w, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockICatalogService)(nil).Export), ctx, catalog, w, format)
+}
+
+// Import mocks base method.
+func (m *MockICatalogService) Import(ctx context.Context, catalog string, r io.Reader, options *domain.CatalogImportOptions) (*domain.CatalogImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, catalog, r, options)
+	ret0, _ := ret[0].(*domain.CatalogImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockICatalogServiceMockRecorder) Import(ctx, catalog, r, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockICatalogService)(nil).Import), ctx, catalog, r, options)
+}
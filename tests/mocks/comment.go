@@ -65,11 +65,11 @@ func (mr *MockICommentRepositoryMockRecorder) DeleteById(ctx, id interface{}) *g
 }
 
 // GetAllBySaladID mocks base method.
-func (m *MockICommentRepository) GetAllBySaladID(ctx context.Context, saladId uuid.UUID, page int) ([]*domain.Comment, int, error) {
+func (m *MockICommentRepository) GetAllBySaladID(ctx context.Context, saladId uuid.UUID, page *domain.PageRequest) ([]*domain.Comment, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAllBySaladID", ctx, saladId, page)
 	ret0, _ := ret[0].([]*domain.Comment)
-	ret1, _ := ret[1].(int)
+	ret1, _ := ret[1].(*domain.PageInfo)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
@@ -176,11 +176,11 @@ func (mr *MockICommentServiceMockRecorder) DeleteById(ctx, id interface{}) *gomo
 }
 
 // GetAllBySaladID mocks base method.
-func (m *MockICommentService) GetAllBySaladID(ctx context.Context, saladId uuid.UUID, page int) ([]*domain.Comment, int, error) {
+func (m *MockICommentService) GetAllBySaladID(ctx context.Context, saladId uuid.UUID, page *domain.PageRequest) ([]*domain.Comment, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAllBySaladID", ctx, saladId, page)
 	ret0, _ := ret[0].([]*domain.Comment)
-	ret1, _ := ret[1].(int)
+	ret1, _ := ret[1].(*domain.PageInfo)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/diet.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "github.com/Mx1q/ppo_services/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockIDietService is a mock of IDietService interface.
+type MockIDietService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDietServiceMockRecorder
+}
+
+// MockIDietServiceMockRecorder is the mock recorder for MockIDietService.
+type MockIDietServiceMockRecorder struct {
+	mock *MockIDietService
+}
+
+// NewMockIDietService creates a new mock instance.
+func NewMockIDietService(ctrl *gomock.Controller) *MockIDietService {
+	mock := &MockIDietService{ctrl: ctrl}
+	mock.recorder = &MockIDietServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDietService) EXPECT() *MockIDietServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckSaladType mocks base method.
+func (m *MockIDietService) CheckSaladType(ctx context.Context, saladId, saladTypeId uuid.UUID) (*domain.DietConflict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSaladType", ctx, saladId, saladTypeId)
+	ret0, _ := ret[0].(*domain.DietConflict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckSaladType indicates an expected call of CheckSaladType.
+func (mr *MockIDietServiceMockRecorder) CheckSaladType(ctx, saladId, saladTypeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSaladType", reflect.TypeOf((*MockIDietService)(nil).CheckSaladType), ctx, saladId, saladTypeId)
+}
+
+// GetRecipeDiets mocks base method.
+func (m *MockIDietService) GetRecipeDiets(ctx context.Context, recipeId uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeDiets", ctx, recipeId)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeDiets indicates an expected call of GetRecipeDiets.
+func (mr *MockIDietServiceMockRecorder) GetRecipeDiets(ctx, recipeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeDiets", reflect.TypeOf((*MockIDietService)(nil).GetRecipeDiets), ctx, recipeId)
+}
+
+// GetSaladConflicts mocks base method.
+func (m *MockIDietService) GetSaladConflicts(ctx context.Context, saladId uuid.UUID) ([]*domain.DietConflict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSaladConflicts", ctx, saladId)
+	ret0, _ := ret[0].([]*domain.DietConflict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSaladConflicts indicates an expected call of GetSaladConflicts.
+func (mr *MockIDietServiceMockRecorder) GetSaladConflicts(ctx, saladId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSaladConflicts", reflect.TypeOf((*MockIDietService)(nil).GetSaladConflicts), ctx, saladId)
+}
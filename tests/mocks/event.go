@@ -0,0 +1,299 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/event.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "github.com/Mx1q/ppo_services/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockIEvent is a mock of IEvent interface.
+type MockIEvent struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEventMockRecorder
+}
+
+// MockIEventMockRecorder is the mock recorder for MockIEvent.
+type MockIEventMockRecorder struct {
+	mock *MockIEvent
+}
+
+// NewMockIEvent creates a new mock instance.
+func NewMockIEvent(ctrl *gomock.Controller) *MockIEvent {
+	mock := &MockIEvent{ctrl: ctrl}
+	mock.recorder = &MockIEventMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEvent) EXPECT() *MockIEventMockRecorder {
+	return m.recorder
+}
+
+// AggregateID mocks base method.
+func (m *MockIEvent) AggregateID() uuid.UUID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateID")
+	ret0, _ := ret[0].(uuid.UUID)
+	return ret0
+}
+
+// AggregateID indicates an expected call of AggregateID.
+func (mr *MockIEventMockRecorder) AggregateID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateID", reflect.TypeOf((*MockIEvent)(nil).AggregateID))
+}
+
+// EventName mocks base method.
+func (m *MockIEvent) EventName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// EventName indicates an expected call of EventName.
+func (mr *MockIEventMockRecorder) EventName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventName", reflect.TypeOf((*MockIEvent)(nil).EventName))
+}
+
+// MockIEventPublisher is a mock of IEventPublisher interface.
+type MockIEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEventPublisherMockRecorder
+}
+
+// MockIEventPublisherMockRecorder is the mock recorder for MockIEventPublisher.
+type MockIEventPublisherMockRecorder struct {
+	mock *MockIEventPublisher
+}
+
+// NewMockIEventPublisher creates a new mock instance.
+func NewMockIEventPublisher(ctrl *gomock.Controller) *MockIEventPublisher {
+	mock := &MockIEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockIEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEventPublisher) EXPECT() *MockIEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockIEventPublisher) Publish(ctx context.Context, events ...domain.IEvent) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Publish", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockIEventPublisherMockRecorder) Publish(ctx interface{}, events ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockIEventPublisher)(nil).Publish), varargs...)
+}
+
+// MockIEventBus is a mock of IEventBus interface.
+type MockIEventBus struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEventBusMockRecorder
+}
+
+// MockIEventBusMockRecorder is the mock recorder for MockIEventBus.
+type MockIEventBusMockRecorder struct {
+	mock *MockIEventBus
+}
+
+// NewMockIEventBus creates a new mock instance.
+func NewMockIEventBus(ctrl *gomock.Controller) *MockIEventBus {
+	mock := &MockIEventBus{ctrl: ctrl}
+	mock.recorder = &MockIEventBusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEventBus) EXPECT() *MockIEventBusMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockIEventBus) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockIEventBusMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIEventBus)(nil).Close))
+}
+
+// Publish mocks base method.
+func (m *MockIEventBus) Publish(ctx context.Context, events ...domain.IEvent) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Publish", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockIEventBusMockRecorder) Publish(ctx interface{}, events ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockIEventBus)(nil).Publish), varargs...)
+}
+
+// Subscribe mocks base method.
+func (m *MockIEventBus) Subscribe(eventName string, handler domain.EventHandler) func() {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", eventName, handler)
+	ret0, _ := ret[0].(func())
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockIEventBusMockRecorder) Subscribe(eventName, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockIEventBus)(nil).Subscribe), eventName, handler)
+}
+
+// MockIEventOutbox is a mock of IEventOutbox interface.
+type MockIEventOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEventOutboxMockRecorder
+}
+
+// MockIEventOutboxMockRecorder is the mock recorder for MockIEventOutbox.
+type MockIEventOutboxMockRecorder struct {
+	mock *MockIEventOutbox
+}
+
+// NewMockIEventOutbox creates a new mock instance.
+func NewMockIEventOutbox(ctrl *gomock.Controller) *MockIEventOutbox {
+	mock := &MockIEventOutbox{ctrl: ctrl}
+	mock.recorder = &MockIEventOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEventOutbox) EXPECT() *MockIEventOutboxMockRecorder {
+	return m.recorder
+}
+
+// GetPending mocks base method.
+func (m *MockIEventOutbox) GetPending(ctx context.Context, limit int) ([]*domain.OutboxEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPending", ctx, limit)
+	ret0, _ := ret[0].([]*domain.OutboxEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPending indicates an expected call of GetPending.
+func (mr *MockIEventOutboxMockRecorder) GetPending(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPending", reflect.TypeOf((*MockIEventOutbox)(nil).GetPending), ctx, limit)
+}
+
+// MarkFailed mocks base method.
+func (m *MockIEventOutbox) MarkFailed(ctx context.Context, id uuid.UUID, reason string, failedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, reason, failedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockIEventOutboxMockRecorder) MarkFailed(ctx, id, reason, failedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockIEventOutbox)(nil).MarkFailed), ctx, id, reason, failedAt)
+}
+
+// MarkPublished mocks base method.
+func (m *MockIEventOutbox) MarkPublished(ctx context.Context, ids []uuid.UUID, publishedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPublished", ctx, ids, publishedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *MockIEventOutboxMockRecorder) MarkPublished(ctx, ids, publishedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockIEventOutbox)(nil).MarkPublished), ctx, ids, publishedAt)
+}
+
+// Save mocks base method.
+func (m *MockIEventOutbox) Save(ctx context.Context, entries ...*domain.OutboxEntry) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range entries {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Save", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockIEventOutboxMockRecorder) Save(ctx interface{}, entries ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, entries...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockIEventOutbox)(nil).Save), varargs...)
+}
+
+// MockIOutboxRelay is a mock of IOutboxRelay interface.
+type MockIOutboxRelay struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOutboxRelayMockRecorder
+}
+
+// MockIOutboxRelayMockRecorder is the mock recorder for MockIOutboxRelay.
+type MockIOutboxRelayMockRecorder struct {
+	mock *MockIOutboxRelay
+}
+
+// NewMockIOutboxRelay creates a new mock instance.
+func NewMockIOutboxRelay(ctrl *gomock.Controller) *MockIOutboxRelay {
+	mock := &MockIOutboxRelay{ctrl: ctrl}
+	mock.recorder = &MockIOutboxRelayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOutboxRelay) EXPECT() *MockIOutboxRelayMockRecorder {
+	return m.recorder
+}
+
+// Relay mocks base method.
+func (m *MockIOutboxRelay) Relay(ctx context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Relay", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Relay indicates an expected call of Relay.
+func (mr *MockIOutboxRelayMockRecorder) Relay(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Relay", reflect.TypeOf((*MockIOutboxRelay)(nil).Relay), ctx, limit)
+}
@@ -65,11 +65,11 @@ func (mr *MockIIngredientRepositoryMockRecorder) DeleteById(ctx, id interface{})
 }
 
 // GetAll mocks base method.
-func (m *MockIIngredientRepository) GetAll(ctx context.Context, page int) ([]*domain.Ingredient, int, error) {
+func (m *MockIIngredientRepository) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.Ingredient, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", ctx, page)
 	ret0, _ := ret[0].([]*domain.Ingredient)
-	ret1, _ := ret[1].(int)
+	ret1, _ := ret[1].(*domain.PageInfo)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
@@ -205,11 +205,11 @@ func (mr *MockIIngredientServiceMockRecorder) DeleteById(ctx, id interface{}) *g
 }
 
 // GetAll mocks base method.
-func (m *MockIIngredientService) GetAll(ctx context.Context, page int) ([]*domain.Ingredient, int, error) {
+func (m *MockIIngredientService) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.Ingredient, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", ctx, page)
 	ret0, _ := ret[0].([]*domain.Ingredient)
-	ret1, _ := ret[1].(int)
+	ret1, _ := ret[1].(*domain.PageInfo)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
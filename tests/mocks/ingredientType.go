@@ -37,17 +37,17 @@ func (m *MockIIngredientTypeRepository) EXPECT() *MockIIngredientTypeRepositoryM
 }
 
 // Create mocks base method.
-func (m *MockIIngredientTypeRepository) Create(ctx context.Context, measurement *domain.IngredientType) error {
+func (m *MockIIngredientTypeRepository) Create(ctx context.Context, ingredientType *domain.IngredientType) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, measurement)
+	ret := m.ctrl.Call(m, "Create", ctx, ingredientType)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIIngredientTypeRepositoryMockRecorder) Create(ctx, measurement interface{}) *gomock.Call {
+func (mr *MockIIngredientTypeRepositoryMockRecorder) Create(ctx, ingredientType interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIIngredientTypeRepository)(nil).Create), ctx, measurement)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIIngredientTypeRepository)(nil).Create), ctx, ingredientType)
 }
 
 // DeleteById mocks base method.
@@ -79,6 +79,21 @@ func (mr *MockIIngredientTypeRepositoryMockRecorder) GetAll(ctx interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockIIngredientTypeRepository)(nil).GetAll), ctx)
 }
 
+// GetAllByParentId mocks base method.
+func (m *MockIIngredientTypeRepository) GetAllByParentId(ctx context.Context, parentId uuid.UUID) ([]*domain.IngredientType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByParentId", ctx, parentId)
+	ret0, _ := ret[0].([]*domain.IngredientType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByParentId indicates an expected call of GetAllByParentId.
+func (mr *MockIIngredientTypeRepositoryMockRecorder) GetAllByParentId(ctx, parentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByParentId", reflect.TypeOf((*MockIIngredientTypeRepository)(nil).GetAllByParentId), ctx, parentId)
+}
+
 // GetById mocks base method.
 func (m *MockIIngredientTypeRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.IngredientType, error) {
 	m.ctrl.T.Helper()
@@ -189,6 +204,50 @@ func (mr *MockIIngredientTypeServiceMockRecorder) GetById(ctx, id interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockIIngredientTypeService)(nil).GetById), ctx, id)
 }
 
+// GetChildren mocks base method.
+func (m *MockIIngredientTypeService) GetChildren(ctx context.Context, parentId uuid.UUID) ([]*domain.IngredientType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChildren", ctx, parentId)
+	ret0, _ := ret[0].([]*domain.IngredientType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChildren indicates an expected call of GetChildren.
+func (mr *MockIIngredientTypeServiceMockRecorder) GetChildren(ctx, parentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildren", reflect.TypeOf((*MockIIngredientTypeService)(nil).GetChildren), ctx, parentId)
+}
+
+// GetSubtree mocks base method.
+func (m *MockIIngredientTypeService) GetSubtree(ctx context.Context, id uuid.UUID) ([]*domain.IngredientType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtree", ctx, id)
+	ret0, _ := ret[0].([]*domain.IngredientType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtree indicates an expected call of GetSubtree.
+func (mr *MockIIngredientTypeServiceMockRecorder) GetSubtree(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtree", reflect.TypeOf((*MockIIngredientTypeService)(nil).GetSubtree), ctx, id)
+}
+
+// Move mocks base method.
+func (m *MockIIngredientTypeService) Move(ctx context.Context, id, parentId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, id, parentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockIIngredientTypeServiceMockRecorder) Move(ctx, id, parentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockIIngredientTypeService)(nil).Move), ctx, id, parentId)
+}
+
 // Update mocks base method.
 func (m *MockIIngredientTypeService) Update(ctx context.Context, measurement *domain.IngredientType) error {
 	m.ctrl.T.Helper()
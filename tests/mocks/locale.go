@@ -0,0 +1,235 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/locale.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "github.com/Mx1q/ppo_services/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockITranslationRepository is a mock of ITranslationRepository interface.
+type MockITranslationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockITranslationRepositoryMockRecorder
+}
+
+// MockITranslationRepositoryMockRecorder is the mock recorder for MockITranslationRepository.
+type MockITranslationRepositoryMockRecorder struct {
+	mock *MockITranslationRepository
+}
+
+// NewMockITranslationRepository creates a new mock instance.
+func NewMockITranslationRepository(ctrl *gomock.Controller) *MockITranslationRepository {
+	mock := &MockITranslationRepository{ctrl: ctrl}
+	mock.recorder = &MockITranslationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITranslationRepository) EXPECT() *MockITranslationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockITranslationRepository) Create(ctx context.Context, translation *domain.Translation) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, translation)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockITranslationRepositoryMockRecorder) Create(ctx, translation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockITranslationRepository)(nil).Create), ctx, translation)
+}
+
+// DeleteById mocks base method.
+func (m *MockITranslationRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockITranslationRepositoryMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockITranslationRepository)(nil).DeleteById), ctx, id)
+}
+
+// GetAllByEntities mocks base method.
+func (m *MockITranslationRepository) GetAllByEntities(ctx context.Context, entityType string, entityIds []uuid.UUID, locales []string) ([]*domain.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByEntities", ctx, entityType, entityIds, locales)
+	ret0, _ := ret[0].([]*domain.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByEntities indicates an expected call of GetAllByEntities.
+func (mr *MockITranslationRepositoryMockRecorder) GetAllByEntities(ctx, entityType, entityIds, locales interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByEntities", reflect.TypeOf((*MockITranslationRepository)(nil).GetAllByEntities), ctx, entityType, entityIds, locales)
+}
+
+// GetAllByEntity mocks base method.
+func (m *MockITranslationRepository) GetAllByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*domain.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByEntity", ctx, entityType, entityId)
+	ret0, _ := ret[0].([]*domain.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByEntity indicates an expected call of GetAllByEntity.
+func (mr *MockITranslationRepositoryMockRecorder) GetAllByEntity(ctx, entityType, entityId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByEntity", reflect.TypeOf((*MockITranslationRepository)(nil).GetAllByEntity), ctx, entityType, entityId)
+}
+
+// GetById mocks base method.
+func (m *MockITranslationRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockITranslationRepositoryMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockITranslationRepository)(nil).GetById), ctx, id)
+}
+
+// GetMissing mocks base method.
+func (m *MockITranslationRepository) GetMissing(ctx context.Context, entityType, locale string) ([]*domain.MissingTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissing", ctx, entityType, locale)
+	ret0, _ := ret[0].([]*domain.MissingTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMissing indicates an expected call of GetMissing.
+func (mr *MockITranslationRepositoryMockRecorder) GetMissing(ctx, entityType, locale interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissing", reflect.TypeOf((*MockITranslationRepository)(nil).GetMissing), ctx, entityType, locale)
+}
+
+// Update mocks base method.
+func (m *MockITranslationRepository) Update(ctx context.Context, translation *domain.Translation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, translation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockITranslationRepositoryMockRecorder) Update(ctx, translation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockITranslationRepository)(nil).Update), ctx, translation)
+}
+
+// MockITranslationService is a mock of ITranslationService interface.
+type MockITranslationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockITranslationServiceMockRecorder
+}
+
+// MockITranslationServiceMockRecorder is the mock recorder for MockITranslationService.
+type MockITranslationServiceMockRecorder struct {
+	mock *MockITranslationService
+}
+
+// NewMockITranslationService creates a new mock instance.
+func NewMockITranslationService(ctrl *gomock.Controller) *MockITranslationService {
+	mock := &MockITranslationService{ctrl: ctrl}
+	mock.recorder = &MockITranslationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITranslationService) EXPECT() *MockITranslationServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteById mocks base method.
+func (m *MockITranslationService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockITranslationServiceMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockITranslationService)(nil).DeleteById), ctx, id)
+}
+
+// GetAllByEntity mocks base method.
+func (m *MockITranslationService) GetAllByEntity(ctx context.Context, entityType string, entityId uuid.UUID) ([]*domain.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByEntity", ctx, entityType, entityId)
+	ret0, _ := ret[0].([]*domain.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByEntity indicates an expected call of GetAllByEntity.
+func (mr *MockITranslationServiceMockRecorder) GetAllByEntity(ctx, entityType, entityId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByEntity", reflect.TypeOf((*MockITranslationService)(nil).GetAllByEntity), ctx, entityType, entityId)
+}
+
+// GetMissing mocks base method.
+func (m *MockITranslationService) GetMissing(ctx context.Context, entityType, locale string) ([]*domain.MissingTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissing", ctx, entityType, locale)
+	ret0, _ := ret[0].([]*domain.MissingTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMissing indicates an expected call of GetMissing.
+func (mr *MockITranslationServiceMockRecorder) GetMissing(ctx, entityType, locale interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissing", reflect.TypeOf((*MockITranslationService)(nil).GetMissing), ctx, entityType, locale)
+}
+
+// Localize mocks base method.
+func (m *MockITranslationService) Localize(ctx context.Context, entityType string, texts []domain.TranslatableText) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Localize", ctx, entityType, texts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Localize indicates an expected call of Localize.
+func (mr *MockITranslationServiceMockRecorder) Localize(ctx, entityType, texts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Localize", reflect.TypeOf((*MockITranslationService)(nil).Localize), ctx, entityType, texts)
+}
+
+// Set mocks base method.
+func (m *MockITranslationService) Set(ctx context.Context, translation *domain.Translation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, translation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockITranslationServiceMockRecorder) Set(ctx, translation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockITranslationService)(nil).Set), ctx, translation)
+}
@@ -5,6 +5,8 @@
 package mocks
 
 import (
+	context "context"
+	logger "github.com/Mx1q/ppo_services/logger"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -33,6 +35,37 @@ func (m *MockILogger) EXPECT() *MockILoggerMockRecorder {
 	return m.recorder
 }
 
+// Component mocks base method.
+func (m *MockILogger) Component(name string) logger.ILogger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Component", name)
+	ret0, _ := ret[0].(logger.ILogger)
+	return ret0
+}
+
+// Component indicates an expected call of Component.
+func (mr *MockILoggerMockRecorder) Component(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Component", reflect.TypeOf((*MockILogger)(nil).Component), name)
+}
+
+// Debug mocks base method.
+func (m *MockILogger) Debug(message string, fields ...logger.Field) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{message}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Debug", varargs...)
+}
+
+// Debug indicates an expected call of Debug.
+func (mr *MockILoggerMockRecorder) Debug(message interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{message}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debug", reflect.TypeOf((*MockILogger)(nil).Debug), varargs...)
+}
+
 // Debugf mocks base method.
 func (m *MockILogger) Debugf(message string, args ...interface{}) {
 	m.ctrl.T.Helper()
@@ -50,6 +83,23 @@ func (mr *MockILoggerMockRecorder) Debugf(message interface{}, args ...interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debugf", reflect.TypeOf((*MockILogger)(nil).Debugf), varargs...)
 }
 
+// Error mocks base method.
+func (m *MockILogger) Error(message string, fields ...logger.Field) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{message}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockILoggerMockRecorder) Error(message interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{message}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockILogger)(nil).Error), varargs...)
+}
+
 // Errorf mocks base method.
 func (m *MockILogger) Errorf(message string, args ...interface{}) {
 	m.ctrl.T.Helper()
@@ -84,6 +134,23 @@ func (mr *MockILoggerMockRecorder) Fatalf(message interface{}, args ...interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fatalf", reflect.TypeOf((*MockILogger)(nil).Fatalf), varargs...)
 }
 
+// Info mocks base method.
+func (m *MockILogger) Info(message string, fields ...logger.Field) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{message}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockILoggerMockRecorder) Info(message interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{message}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockILogger)(nil).Info), varargs...)
+}
+
 // Infof mocks base method.
 func (m *MockILogger) Infof(message string, args ...interface{}) {
 	m.ctrl.T.Helper()
@@ -101,6 +168,23 @@ func (mr *MockILoggerMockRecorder) Infof(message interface{}, args ...interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Infof", reflect.TypeOf((*MockILogger)(nil).Infof), varargs...)
 }
 
+// Warn mocks base method.
+func (m *MockILogger) Warn(message string, fields ...logger.Field) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{message}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Warn", varargs...)
+}
+
+// Warn indicates an expected call of Warn.
+func (mr *MockILoggerMockRecorder) Warn(message interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{message}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warn", reflect.TypeOf((*MockILogger)(nil).Warn), varargs...)
+}
+
 // Warnf mocks base method.
 func (m *MockILogger) Warnf(message string, args ...interface{}) {
 	m.ctrl.T.Helper()
@@ -117,3 +201,35 @@ func (mr *MockILoggerMockRecorder) Warnf(message interface{}, args ...interface{
 	varargs := append([]interface{}{message}, args...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warnf", reflect.TypeOf((*MockILogger)(nil).Warnf), varargs...)
 }
+
+// With mocks base method.
+func (m *MockILogger) With(fields ...logger.Field) logger.ILogger {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "With", varargs...)
+	ret0, _ := ret[0].(logger.ILogger)
+	return ret0
+}
+
+// With indicates an expected call of With.
+func (mr *MockILoggerMockRecorder) With(fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "With", reflect.TypeOf((*MockILogger)(nil).With), fields...)
+}
+
+// WithContext mocks base method.
+func (m *MockILogger) WithContext(ctx context.Context) logger.ILogger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithContext", ctx)
+	ret0, _ := ret[0].(logger.ILogger)
+	return ret0
+}
+
+// WithContext indicates an expected call of WithContext.
+func (mr *MockILoggerMockRecorder) WithContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContext", reflect.TypeOf((*MockILogger)(nil).WithContext), ctx)
+}
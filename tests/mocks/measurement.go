@@ -79,6 +79,21 @@ func (mr *MockIMeasurementRepositoryMockRecorder) GetAll(ctx interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockIMeasurementRepository)(nil).GetAll), ctx)
 }
 
+// GetAllByRecipeId mocks base method.
+func (m *MockIMeasurementRepository) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeMeasurement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByRecipeId", ctx, recipeId)
+	ret0, _ := ret[0].([]*domain.RecipeMeasurement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByRecipeId indicates an expected call of GetAllByRecipeId.
+func (mr *MockIMeasurementRepositoryMockRecorder) GetAllByRecipeId(ctx, recipeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByRecipeId", reflect.TypeOf((*MockIMeasurementRepository)(nil).GetAllByRecipeId), ctx, recipeId)
+}
+
 // GetById mocks base method.
 func (m *MockIMeasurementRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Measurement, error) {
 	m.ctrl.T.Helper()
@@ -161,6 +176,21 @@ func (m *MockIMeasurementService) EXPECT() *MockIMeasurementServiceMockRecorder
 	return m.recorder
 }
 
+// Convert mocks base method.
+func (m *MockIMeasurementService) Convert(ctx context.Context, quantity domain.Quantity, to string, ingredient *domain.Ingredient) (domain.Quantity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Convert", ctx, quantity, to, ingredient)
+	ret0, _ := ret[0].(domain.Quantity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Convert indicates an expected call of Convert.
+func (mr *MockIMeasurementServiceMockRecorder) Convert(ctx, quantity, to, ingredient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Convert", reflect.TypeOf((*MockIMeasurementService)(nil).Convert), ctx, quantity, to, ingredient)
+}
+
 // Create mocks base method.
 func (m *MockIMeasurementService) Create(ctx context.Context, measurement *domain.Measurement) error {
 	m.ctrl.T.Helper()
@@ -204,6 +234,21 @@ func (mr *MockIMeasurementServiceMockRecorder) GetAll(ctx interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockIMeasurementService)(nil).GetAll), ctx)
 }
 
+// GetAllByRecipeId mocks base method.
+func (m *MockIMeasurementService) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeMeasurement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByRecipeId", ctx, recipeId)
+	ret0, _ := ret[0].([]*domain.RecipeMeasurement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByRecipeId indicates an expected call of GetAllByRecipeId.
+func (mr *MockIMeasurementServiceMockRecorder) GetAllByRecipeId(ctx, recipeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByRecipeId", reflect.TypeOf((*MockIMeasurementService)(nil).GetAllByRecipeId), ctx, recipeId)
+}
+
 // GetById mocks base method.
 func (m *MockIMeasurementService) GetById(ctx context.Context, id uuid.UUID) (*domain.Measurement, error) {
 	m.ctrl.T.Helper()
@@ -235,6 +280,21 @@ func (mr *MockIMeasurementServiceMockRecorder) GetByRecipeId(ctx, ingredientId,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRecipeId", reflect.TypeOf((*MockIMeasurementService)(nil).GetByRecipeId), ctx, ingredientId, recipeId)
 }
 
+// ToSystem mocks base method.
+func (m *MockIMeasurementService) ToSystem(ctx context.Context, item *domain.SaladIngredient, system string) (domain.Quantity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToSystem", ctx, item, system)
+	ret0, _ := ret[0].(domain.Quantity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ToSystem indicates an expected call of ToSystem.
+func (mr *MockIMeasurementServiceMockRecorder) ToSystem(ctx, item, system interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToSystem", reflect.TypeOf((*MockIMeasurementService)(nil).ToSystem), ctx, item, system)
+}
+
 // Update mocks base method.
 func (m *MockIMeasurementService) Update(ctx context.Context, measurement *domain.Measurement) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,316 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/media.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "github.com/Mx1q/ppo_services/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockIBlobStorage is a mock of IBlobStorage interface.
+type MockIBlobStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIBlobStorageMockRecorder
+}
+
+// MockIBlobStorageMockRecorder is the mock recorder for MockIBlobStorage.
+type MockIBlobStorageMockRecorder struct {
+	mock *MockIBlobStorage
+}
+
+// NewMockIBlobStorage creates a new mock instance.
+func NewMockIBlobStorage(ctrl *gomock.Controller) *MockIBlobStorage {
+	mock := &MockIBlobStorage{ctrl: ctrl}
+	mock.recorder = &MockIBlobStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIBlobStorage) EXPECT() *MockIBlobStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockIBlobStorage) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIBlobStorageMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIBlobStorage)(nil).Delete), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockIBlobStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIBlobStorageMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIBlobStorage)(nil).Get), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockIBlobStorage) Put(ctx context.Context, key string, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockIBlobStorageMockRecorder) Put(ctx, key, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockIBlobStorage)(nil).Put), ctx, key, data)
+}
+
+// MockIMediaRepository is a mock of IMediaRepository interface.
+type MockIMediaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMediaRepositoryMockRecorder
+}
+
+// MockIMediaRepositoryMockRecorder is the mock recorder for MockIMediaRepository.
+type MockIMediaRepositoryMockRecorder struct {
+	mock *MockIMediaRepository
+}
+
+// NewMockIMediaRepository creates a new mock instance.
+func NewMockIMediaRepository(ctrl *gomock.Controller) *MockIMediaRepository {
+	mock := &MockIMediaRepository{ctrl: ctrl}
+	mock.recorder = &MockIMediaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMediaRepository) EXPECT() *MockIMediaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIMediaRepository) Create(ctx context.Context, media *domain.Media) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, media)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIMediaRepositoryMockRecorder) Create(ctx, media interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIMediaRepository)(nil).Create), ctx, media)
+}
+
+// DeleteById mocks base method.
+func (m *MockIMediaRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockIMediaRepositoryMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockIMediaRepository)(nil).DeleteById), ctx, id)
+}
+
+// GetAllByOwner mocks base method.
+func (m *MockIMediaRepository) GetAllByOwner(ctx context.Context, ownerType string, ownerId uuid.UUID) ([]*domain.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByOwner", ctx, ownerType, ownerId)
+	ret0, _ := ret[0].([]*domain.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByOwner indicates an expected call of GetAllByOwner.
+func (mr *MockIMediaRepositoryMockRecorder) GetAllByOwner(ctx, ownerType, ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByOwner", reflect.TypeOf((*MockIMediaRepository)(nil).GetAllByOwner), ctx, ownerType, ownerId)
+}
+
+// GetById mocks base method.
+func (m *MockIMediaRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockIMediaRepositoryMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockIMediaRepository)(nil).GetById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockIMediaRepository) Update(ctx context.Context, media *domain.Media) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, media)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIMediaRepositoryMockRecorder) Update(ctx, media interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIMediaRepository)(nil).Update), ctx, media)
+}
+
+// MockIMediaService is a mock of IMediaService interface.
+type MockIMediaService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMediaServiceMockRecorder
+}
+
+// MockIMediaServiceMockRecorder is the mock recorder for MockIMediaService.
+type MockIMediaServiceMockRecorder struct {
+	mock *MockIMediaService
+}
+
+// NewMockIMediaService creates a new mock instance.
+func NewMockIMediaService(ctrl *gomock.Controller) *MockIMediaService {
+	mock := &MockIMediaService{ctrl: ctrl}
+	mock.recorder = &MockIMediaServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMediaService) EXPECT() *MockIMediaServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteAllByOwner mocks base method.
+func (m *MockIMediaService) DeleteAllByOwner(ctx context.Context, ownerType string, ownerId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllByOwner", ctx, ownerType, ownerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAllByOwner indicates an expected call of DeleteAllByOwner.
+func (mr *MockIMediaServiceMockRecorder) DeleteAllByOwner(ctx, ownerType, ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllByOwner", reflect.TypeOf((*MockIMediaService)(nil).DeleteAllByOwner), ctx, ownerType, ownerId)
+}
+
+// DeleteById mocks base method.
+func (m *MockIMediaService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockIMediaServiceMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockIMediaService)(nil).DeleteById), ctx, id)
+}
+
+// GetAllByOwner mocks base method.
+func (m *MockIMediaService) GetAllByOwner(ctx context.Context, ownerType string, ownerId uuid.UUID) ([]*domain.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByOwner", ctx, ownerType, ownerId)
+	ret0, _ := ret[0].([]*domain.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByOwner indicates an expected call of GetAllByOwner.
+func (mr *MockIMediaServiceMockRecorder) GetAllByOwner(ctx, ownerType, ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByOwner", reflect.TypeOf((*MockIMediaService)(nil).GetAllByOwner), ctx, ownerType, ownerId)
+}
+
+// GetById mocks base method.
+func (m *MockIMediaService) GetById(ctx context.Context, id uuid.UUID) (*domain.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockIMediaServiceMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockIMediaService)(nil).GetById), ctx, id)
+}
+
+// GetContent mocks base method.
+func (m *MockIMediaService) GetContent(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContent", ctx, id)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContent indicates an expected call of GetContent.
+func (mr *MockIMediaServiceMockRecorder) GetContent(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContent", reflect.TypeOf((*MockIMediaService)(nil).GetContent), ctx, id)
+}
+
+// GetThumbnail mocks base method.
+func (m *MockIMediaService) GetThumbnail(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThumbnail", ctx, id)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThumbnail indicates an expected call of GetThumbnail.
+func (mr *MockIMediaServiceMockRecorder) GetThumbnail(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThumbnail", reflect.TypeOf((*MockIMediaService)(nil).GetThumbnail), ctx, id)
+}
+
+// Reorder mocks base method.
+func (m *MockIMediaService) Reorder(ctx context.Context, ownerType string, ownerId uuid.UUID, mediaIds []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, ownerType, ownerId, mediaIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockIMediaServiceMockRecorder) Reorder(ctx, ownerType, ownerId, mediaIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockIMediaService)(nil).Reorder), ctx, ownerType, ownerId, mediaIds)
+}
+
+// Upload mocks base method.
+func (m *MockIMediaService) Upload(ctx context.Context, upload *domain.MediaUpload) (*domain.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, upload)
+	ret0, _ := ret[0].(*domain.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockIMediaServiceMockRecorder) Upload(ctx, upload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockIMediaService)(nil).Upload), ctx, upload)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/moderation.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/Mx1q/ppo_services/domain"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockIModerationService is a mock of IModerationService interface.
+type MockIModerationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIModerationServiceMockRecorder
+}
+
+// MockIModerationServiceMockRecorder is the mock recorder for MockIModerationService.
+type MockIModerationServiceMockRecorder struct {
+	mock *MockIModerationService
+}
+
+// NewMockIModerationService creates a new mock instance.
+func NewMockIModerationService(ctrl *gomock.Controller) *MockIModerationService {
+	mock := &MockIModerationService{ctrl: ctrl}
+	mock.recorder = &MockIModerationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIModerationService) EXPECT() *MockIModerationServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockIModerationService) Approve(ctx context.Context, recipeId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, recipeId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockIModerationServiceMockRecorder) Approve(ctx, recipeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockIModerationService)(nil).Approve), ctx, recipeId)
+}
+
+// GetQueue mocks base method.
+func (m *MockIModerationService) GetQueue(ctx context.Context, page *domain.PageRequest) ([]*domain.Recipe, *domain.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueue", ctx, page)
+	ret0, _ := ret[0].([]*domain.Recipe)
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetQueue indicates an expected call of GetQueue.
+func (mr *MockIModerationServiceMockRecorder) GetQueue(ctx, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueue", reflect.TypeOf((*MockIModerationService)(nil).GetQueue), ctx, page)
+}
+
+// Reject mocks base method.
+func (m *MockIModerationService) Reject(ctx context.Context, recipeId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, recipeId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockIModerationServiceMockRecorder) Reject(ctx, recipeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockIModerationService)(nil).Reject), ctx, recipeId)
+}
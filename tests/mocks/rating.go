@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/rating.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockIRatingService is a mock of IRatingService interface.
+type MockIRatingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRatingServiceMockRecorder
+}
+
+// MockIRatingServiceMockRecorder is the mock recorder for MockIRatingService.
+type MockIRatingServiceMockRecorder struct {
+	mock *MockIRatingService
+}
+
+// NewMockIRatingService creates a new mock instance.
+func NewMockIRatingService(ctrl *gomock.Controller) *MockIRatingService {
+	mock := &MockIRatingService{ctrl: ctrl}
+	mock.recorder = &MockIRatingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRatingService) EXPECT() *MockIRatingServiceMockRecorder {
+	return m.recorder
+}
+
+// Recompute mocks base method.
+func (m *MockIRatingService) Recompute(ctx context.Context, saladId uuid.UUID) (float32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recompute", ctx, saladId)
+	ret0, _ := ret[0].(float32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recompute indicates an expected call of Recompute.
+func (mr *MockIRatingServiceMockRecorder) Recompute(ctx, saladId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recompute", reflect.TypeOf((*MockIRatingService)(nil).Recompute), ctx, saladId)
+}
+
+// RecomputeAll mocks base method.
+func (m *MockIRatingService) RecomputeAll(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecomputeAll", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecomputeAll indicates an expected call of RecomputeAll.
+func (mr *MockIRatingServiceMockRecorder) RecomputeAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecomputeAll", reflect.TypeOf((*MockIRatingService)(nil).RecomputeAll), ctx)
+}
@@ -66,12 +66,13 @@ func (mr *MockIRecipeRepositoryMockRecorder) DeleteById(ctx, id interface{}) *go
 }
 
 // GetAll mocks base method.
-func (m *MockIRecipeRepository) GetAll(ctx context.Context, filter *domain.RecipeFilter, page int) ([]*domain.Recipe, error) {
+func (m *MockIRecipeRepository) GetAll(ctx context.Context, filter *domain.RecipeFilter, page *domain.PageRequest) ([]*domain.Recipe, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", ctx, filter, page)
 	ret0, _ := ret[0].([]*domain.Recipe)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
@@ -177,12 +178,13 @@ func (mr *MockIRecipeServiceMockRecorder) DeleteById(ctx, id interface{}) *gomoc
 }
 
 // GetAll mocks base method.
-func (m *MockIRecipeService) GetAll(ctx context.Context, filter *domain.RecipeFilter, page int) ([]*domain.Recipe, error) {
+func (m *MockIRecipeService) GetAll(ctx context.Context, filter *domain.RecipeFilter, page *domain.PageRequest) ([]*domain.Recipe, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", ctx, filter, page)
 	ret0, _ := ret[0].([]*domain.Recipe)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/recipeAggregate.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "github.com/Mx1q/ppo_services/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockIRecipeAggregateService is a mock of IRecipeAggregateService interface.
+type MockIRecipeAggregateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRecipeAggregateServiceMockRecorder
+}
+
+// MockIRecipeAggregateServiceMockRecorder is the mock recorder for MockIRecipeAggregateService.
+type MockIRecipeAggregateServiceMockRecorder struct {
+	mock *MockIRecipeAggregateService
+}
+
+// NewMockIRecipeAggregateService creates a new mock instance.
+func NewMockIRecipeAggregateService(ctrl *gomock.Controller) *MockIRecipeAggregateService {
+	mock := &MockIRecipeAggregateService{ctrl: ctrl}
+	mock.recorder = &MockIRecipeAggregateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRecipeAggregateService) EXPECT() *MockIRecipeAggregateServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIRecipeAggregateService) Create(ctx context.Context, aggregate *domain.RecipeAggregate) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, aggregate)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIRecipeAggregateServiceMockRecorder) Create(ctx, aggregate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIRecipeAggregateService)(nil).Create), ctx, aggregate)
+}
+
+// Replace mocks base method.
+func (m *MockIRecipeAggregateService) Replace(ctx context.Context, aggregate *domain.RecipeAggregate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, aggregate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockIRecipeAggregateServiceMockRecorder) Replace(ctx, aggregate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockIRecipeAggregateService)(nil).Replace), ctx, aggregate)
+}
@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/recipeExport.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "github.com/Mx1q/ppo_services/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockIRecipeExportService is a mock of IRecipeExportService interface.
+type MockIRecipeExportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRecipeExportServiceMockRecorder
+}
+
+// MockIRecipeExportServiceMockRecorder is the mock recorder for MockIRecipeExportService.
+type MockIRecipeExportServiceMockRecorder struct {
+	mock *MockIRecipeExportService
+}
+
+// NewMockIRecipeExportService creates a new mock instance.
+func NewMockIRecipeExportService(ctrl *gomock.Controller) *MockIRecipeExportService {
+	mock := &MockIRecipeExportService{ctrl: ctrl}
+	mock.recorder = &MockIRecipeExportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRecipeExportService) EXPECT() *MockIRecipeExportServiceMockRecorder {
+	return m.recorder
+}
+
+// Assemble mocks base method.
+func (m *MockIRecipeExportService) Assemble(ctx context.Context, saladId uuid.UUID) (*domain.RecipeExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assemble", ctx, saladId)
+	ret0, _ := ret[0].(*domain.RecipeExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assemble indicates an expected call of Assemble.
+func (mr *MockIRecipeExportServiceMockRecorder) Assemble(ctx, saladId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assemble", reflect.TypeOf((*MockIRecipeExportService)(nil).Assemble), ctx, saladId)
+}
+
+// Export mocks base method.
+func (m *MockIRecipeExportService) Export(ctx context.Context, saladId uuid.UUID, format string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, saladId, format)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockIRecipeExportServiceMockRecorder) Export(ctx, saladId, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockIRecipeExportService)(nil).Export), ctx, saladId, format)
+}
+
+// Render mocks base method.
+func (m *MockIRecipeExportService) Render(ctx context.Context, export *domain.RecipeExport, format string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Render", ctx, export, format)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockIRecipeExportServiceMockRecorder) Render(ctx, export, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockIRecipeExportService)(nil).Render), ctx, export, format)
+}
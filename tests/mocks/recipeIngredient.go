@@ -0,0 +1,234 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/recipeIngredient.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "github.com/Mx1q/ppo_services/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockIRecipeIngredientRepository is a mock of IRecipeIngredientRepository interface.
+type MockIRecipeIngredientRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRecipeIngredientRepositoryMockRecorder
+}
+
+// MockIRecipeIngredientRepositoryMockRecorder is the mock recorder for MockIRecipeIngredientRepository.
+type MockIRecipeIngredientRepositoryMockRecorder struct {
+	mock *MockIRecipeIngredientRepository
+}
+
+// NewMockIRecipeIngredientRepository creates a new mock instance.
+func NewMockIRecipeIngredientRepository(ctrl *gomock.Controller) *MockIRecipeIngredientRepository {
+	mock := &MockIRecipeIngredientRepository{ctrl: ctrl}
+	mock.recorder = &MockIRecipeIngredientRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRecipeIngredientRepository) EXPECT() *MockIRecipeIngredientRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIRecipeIngredientRepository) Create(ctx context.Context, recipeIngredient *domain.RecipeIngredient) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, recipeIngredient)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIRecipeIngredientRepositoryMockRecorder) Create(ctx, recipeIngredient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIRecipeIngredientRepository)(nil).Create), ctx, recipeIngredient)
+}
+
+// DeleteAllByRecipeId mocks base method.
+func (m *MockIRecipeIngredientRepository) DeleteAllByRecipeId(ctx context.Context, recipeId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllByRecipeId", ctx, recipeId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAllByRecipeId indicates an expected call of DeleteAllByRecipeId.
+func (mr *MockIRecipeIngredientRepositoryMockRecorder) DeleteAllByRecipeId(ctx, recipeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllByRecipeId", reflect.TypeOf((*MockIRecipeIngredientRepository)(nil).DeleteAllByRecipeId), ctx, recipeId)
+}
+
+// DeleteById mocks base method.
+func (m *MockIRecipeIngredientRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockIRecipeIngredientRepositoryMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockIRecipeIngredientRepository)(nil).DeleteById), ctx, id)
+}
+
+// GetAllByRecipeId mocks base method.
+func (m *MockIRecipeIngredientRepository) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByRecipeId", ctx, recipeId)
+	ret0, _ := ret[0].([]*domain.RecipeIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByRecipeId indicates an expected call of GetAllByRecipeId.
+func (mr *MockIRecipeIngredientRepositoryMockRecorder) GetAllByRecipeId(ctx, recipeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByRecipeId", reflect.TypeOf((*MockIRecipeIngredientRepository)(nil).GetAllByRecipeId), ctx, recipeId)
+}
+
+// GetById mocks base method.
+func (m *MockIRecipeIngredientRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.RecipeIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.RecipeIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockIRecipeIngredientRepositoryMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockIRecipeIngredientRepository)(nil).GetById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockIRecipeIngredientRepository) Update(ctx context.Context, recipeIngredient *domain.RecipeIngredient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, recipeIngredient)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIRecipeIngredientRepositoryMockRecorder) Update(ctx, recipeIngredient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIRecipeIngredientRepository)(nil).Update), ctx, recipeIngredient)
+}
+
+// MockIRecipeIngredientService is a mock of IRecipeIngredientService interface.
+type MockIRecipeIngredientService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRecipeIngredientServiceMockRecorder
+}
+
+// MockIRecipeIngredientServiceMockRecorder is the mock recorder for MockIRecipeIngredientService.
+type MockIRecipeIngredientServiceMockRecorder struct {
+	mock *MockIRecipeIngredientService
+}
+
+// NewMockIRecipeIngredientService creates a new mock instance.
+func NewMockIRecipeIngredientService(ctrl *gomock.Controller) *MockIRecipeIngredientService {
+	mock := &MockIRecipeIngredientService{ctrl: ctrl}
+	mock.recorder = &MockIRecipeIngredientServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRecipeIngredientService) EXPECT() *MockIRecipeIngredientServiceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockIRecipeIngredientService) Add(ctx context.Context, recipeIngredient *domain.RecipeIngredient) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, recipeIngredient)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockIRecipeIngredientServiceMockRecorder) Add(ctx, recipeIngredient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockIRecipeIngredientService)(nil).Add), ctx, recipeIngredient)
+}
+
+// GetAllByRecipeId mocks base method.
+func (m *MockIRecipeIngredientService) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]*domain.RecipeIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByRecipeId", ctx, recipeId)
+	ret0, _ := ret[0].([]*domain.RecipeIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByRecipeId indicates an expected call of GetAllByRecipeId.
+func (mr *MockIRecipeIngredientServiceMockRecorder) GetAllByRecipeId(ctx, recipeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByRecipeId", reflect.TypeOf((*MockIRecipeIngredientService)(nil).GetAllByRecipeId), ctx, recipeId)
+}
+
+// GetById mocks base method.
+func (m *MockIRecipeIngredientService) GetById(ctx context.Context, id uuid.UUID) (*domain.RecipeIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.RecipeIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockIRecipeIngredientServiceMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockIRecipeIngredientService)(nil).GetById), ctx, id)
+}
+
+// Remove mocks base method.
+func (m *MockIRecipeIngredientService) Remove(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockIRecipeIngredientServiceMockRecorder) Remove(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockIRecipeIngredientService)(nil).Remove), ctx, id)
+}
+
+// Reorder mocks base method.
+func (m *MockIRecipeIngredientService) Reorder(ctx context.Context, recipeId uuid.UUID, recipeIngredientIds []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, recipeId, recipeIngredientIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockIRecipeIngredientServiceMockRecorder) Reorder(ctx, recipeId, recipeIngredientIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockIRecipeIngredientService)(nil).Reorder), ctx, recipeId, recipeIngredientIds)
+}
+
+// Update mocks base method.
+func (m *MockIRecipeIngredientService) Update(ctx context.Context, recipeIngredient *domain.RecipeIngredient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, recipeIngredient)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIRecipeIngredientServiceMockRecorder) Update(ctx, recipeIngredient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIRecipeIngredientService)(nil).Update), ctx, recipeIngredient)
+}
@@ -37,17 +37,17 @@ func (m *MockIRecipeStepRepository) EXPECT() *MockIRecipeStepRepositoryMockRecor
 }
 
 // Create mocks base method.
-func (m *MockIRecipeStepRepository) Create(ctx context.Context, ingredient *domain.RecipeStep) error {
+func (m *MockIRecipeStepRepository) Create(ctx context.Context, recipeStep *domain.RecipeStep) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, ingredient)
+	ret := m.ctrl.Call(m, "Create", ctx, recipeStep)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIRecipeStepRepositoryMockRecorder) Create(ctx, ingredient interface{}) *gomock.Call {
+func (mr *MockIRecipeStepRepositoryMockRecorder) Create(ctx, recipeStep interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIRecipeStepRepository)(nil).Create), ctx, ingredient)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIRecipeStepRepository)(nil).Create), ctx, recipeStep)
 }
 
 // DeleteAllByRecipeID mocks base method.
@@ -109,17 +109,17 @@ func (mr *MockIRecipeStepRepositoryMockRecorder) GetById(ctx, id interface{}) *g
 }
 
 // Update mocks base method.
-func (m *MockIRecipeStepRepository) Update(ctx context.Context, ingredient *domain.RecipeStep) error {
+func (m *MockIRecipeStepRepository) Update(ctx context.Context, recipeStep *domain.RecipeStep) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, ingredient)
+	ret := m.ctrl.Call(m, "Update", ctx, recipeStep)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockIRecipeStepRepositoryMockRecorder) Update(ctx, ingredient interface{}) *gomock.Call {
+func (mr *MockIRecipeStepRepositoryMockRecorder) Update(ctx, recipeStep interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIRecipeStepRepository)(nil).Update), ctx, ingredient)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIRecipeStepRepository)(nil).Update), ctx, recipeStep)
 }
 
 // MockIRecipeStepService is a mock of IRecipeStepService interface.
@@ -217,6 +217,20 @@ func (mr *MockIRecipeStepServiceMockRecorder) GetById(ctx, id interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockIRecipeStepService)(nil).GetById), ctx, id)
 }
 
+// Reorder mocks base method.
+func (m *MockIRecipeStepService) Reorder(ctx context.Context, recipeId uuid.UUID, stepIds []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, recipeId, stepIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockIRecipeStepServiceMockRecorder) Reorder(ctx, recipeId, stepIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockIRecipeStepService)(nil).Reorder), ctx, recipeId, stepIds)
+}
+
 // Update mocks base method.
 func (m *MockIRecipeStepService) Update(ctx context.Context, recipeStep *domain.RecipeStep) error {
 	m.ctrl.T.Helper()
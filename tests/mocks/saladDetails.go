@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/saladDetails.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "github.com/Mx1q/ppo_services/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockISaladDetailsService is a mock of ISaladDetailsService interface.
+type MockISaladDetailsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockISaladDetailsServiceMockRecorder
+}
+
+// MockISaladDetailsServiceMockRecorder is the mock recorder for MockISaladDetailsService.
+type MockISaladDetailsServiceMockRecorder struct {
+	mock *MockISaladDetailsService
+}
+
+// NewMockISaladDetailsService creates a new mock instance.
+func NewMockISaladDetailsService(ctrl *gomock.Controller) *MockISaladDetailsService {
+	mock := &MockISaladDetailsService{ctrl: ctrl}
+	mock.recorder = &MockISaladDetailsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISaladDetailsService) EXPECT() *MockISaladDetailsServiceMockRecorder {
+	return m.recorder
+}
+
+// GetById mocks base method.
+func (m *MockISaladDetailsService) GetById(ctx context.Context, saladId uuid.UUID, commentsPage *domain.PageRequest) (*domain.SaladDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, saladId, commentsPage)
+	ret0, _ := ret[0].(*domain.SaladDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockISaladDetailsServiceMockRecorder) GetById(ctx, saladId, commentsPage interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockISaladDetailsService)(nil).GetById), ctx, saladId, commentsPage)
+}
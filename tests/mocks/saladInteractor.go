@@ -66,11 +66,11 @@ func (mr *MockISaladInteractorMockRecorder) DeleteById(ctx, id interface{}) *gom
 }
 
 // GetAll mocks base method.
-func (m *MockISaladInteractor) GetAll(ctx context.Context, filter *domain.RecipeFilter, page int) ([]*domain.Salad, int, error) {
+func (m *MockISaladInteractor) GetAll(ctx context.Context, filter *domain.RecipeFilter, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", ctx, filter, page)
 	ret0, _ := ret[0].([]*domain.Salad)
-	ret1, _ := ret[1].(int)
+	ret1, _ := ret[1].(*domain.PageInfo)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
@@ -82,26 +82,27 @@ func (mr *MockISaladInteractorMockRecorder) GetAll(ctx, filter, page interface{}
 }
 
 // GetAllByUserId mocks base method.
-func (m *MockISaladInteractor) GetAllByUserId(ctx context.Context, id uuid.UUID) ([]*domain.Salad, error) {
+func (m *MockISaladInteractor) GetAllByUserId(ctx context.Context, id uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllByUserId", ctx, id)
+	ret := m.ctrl.Call(m, "GetAllByUserId", ctx, id, page)
 	ret0, _ := ret[0].([]*domain.Salad)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAllByUserId indicates an expected call of GetAllByUserId.
-func (mr *MockISaladInteractorMockRecorder) GetAllByUserId(ctx, id interface{}) *gomock.Call {
+func (mr *MockISaladInteractorMockRecorder) GetAllByUserId(ctx, id, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserId", reflect.TypeOf((*MockISaladInteractor)(nil).GetAllByUserId), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserId", reflect.TypeOf((*MockISaladInteractor)(nil).GetAllByUserId), ctx, id, page)
 }
 
 // GetAllRatedByUser mocks base method.
-func (m *MockISaladInteractor) GetAllRatedByUser(ctx context.Context, userId uuid.UUID, page int) ([]*domain.Salad, int, error) {
+func (m *MockISaladInteractor) GetAllRatedByUser(ctx context.Context, userId uuid.UUID, page *domain.PageRequest) ([]*domain.Salad, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAllRatedByUser", ctx, userId, page)
 	ret0, _ := ret[0].([]*domain.Salad)
-	ret1, _ := ret[1].(int)
+	ret1, _ := ret[1].(*domain.PageInfo)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
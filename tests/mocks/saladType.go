@@ -65,11 +65,11 @@ func (mr *MockISaladTypeRepositoryMockRecorder) DeleteById(ctx, id interface{})
 }
 
 // GetAll mocks base method.
-func (m *MockISaladTypeRepository) GetAll(ctx context.Context, page int) ([]*domain.SaladType, int, error) {
+func (m *MockISaladTypeRepository) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.SaladType, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", ctx, page)
 	ret0, _ := ret[0].([]*domain.SaladType)
-	ret1, _ := ret[1].(int)
+	ret1, _ := ret[1].(*domain.PageInfo)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
@@ -80,6 +80,21 @@ func (mr *MockISaladTypeRepositoryMockRecorder) GetAll(ctx, page interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockISaladTypeRepository)(nil).GetAll), ctx, page)
 }
 
+// GetAllByParentId mocks base method.
+func (m *MockISaladTypeRepository) GetAllByParentId(ctx context.Context, parentId uuid.UUID) ([]*domain.SaladType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByParentId", ctx, parentId)
+	ret0, _ := ret[0].([]*domain.SaladType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByParentId indicates an expected call of GetAllByParentId.
+func (mr *MockISaladTypeRepositoryMockRecorder) GetAllByParentId(ctx, parentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByParentId", reflect.TypeOf((*MockISaladTypeRepository)(nil).GetAllByParentId), ctx, parentId)
+}
+
 // GetAllBySaladId mocks base method.
 func (m *MockISaladTypeRepository) GetAllBySaladId(ctx context.Context, saladId uuid.UUID) ([]*domain.SaladType, error) {
 	m.ctrl.T.Helper()
@@ -204,11 +219,11 @@ func (mr *MockISaladTypeServiceMockRecorder) DeleteById(ctx, id interface{}) *go
 }
 
 // GetAll mocks base method.
-func (m *MockISaladTypeService) GetAll(ctx context.Context, page int) ([]*domain.SaladType, int, error) {
+func (m *MockISaladTypeService) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.SaladType, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", ctx, page)
 	ret0, _ := ret[0].([]*domain.SaladType)
-	ret1, _ := ret[1].(int)
+	ret1, _ := ret[1].(*domain.PageInfo)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
@@ -249,6 +264,36 @@ func (mr *MockISaladTypeServiceMockRecorder) GetById(ctx, id interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockISaladTypeService)(nil).GetById), ctx, id)
 }
 
+// GetChildren mocks base method.
+func (m *MockISaladTypeService) GetChildren(ctx context.Context, parentId uuid.UUID) ([]*domain.SaladType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChildren", ctx, parentId)
+	ret0, _ := ret[0].([]*domain.SaladType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChildren indicates an expected call of GetChildren.
+func (mr *MockISaladTypeServiceMockRecorder) GetChildren(ctx, parentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildren", reflect.TypeOf((*MockISaladTypeService)(nil).GetChildren), ctx, parentId)
+}
+
+// GetSubtree mocks base method.
+func (m *MockISaladTypeService) GetSubtree(ctx context.Context, id uuid.UUID) ([]*domain.SaladType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtree", ctx, id)
+	ret0, _ := ret[0].([]*domain.SaladType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtree indicates an expected call of GetSubtree.
+func (mr *MockISaladTypeServiceMockRecorder) GetSubtree(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtree", reflect.TypeOf((*MockISaladTypeService)(nil).GetSubtree), ctx, id)
+}
+
 // Link mocks base method.
 func (m *MockISaladTypeService) Link(ctx context.Context, saladId, saladTypeId uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -263,6 +308,20 @@ func (mr *MockISaladTypeServiceMockRecorder) Link(ctx, saladId, saladTypeId inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Link", reflect.TypeOf((*MockISaladTypeService)(nil).Link), ctx, saladId, saladTypeId)
 }
 
+// Move mocks base method.
+func (m *MockISaladTypeService) Move(ctx context.Context, id, parentId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, id, parentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockISaladTypeServiceMockRecorder) Move(ctx, id, parentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockISaladTypeService)(nil).Move), ctx, id, parentId)
+}
+
 // Unlink mocks base method.
 func (m *MockISaladTypeService) Unlink(ctx context.Context, saladId, saladTypeId uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,236 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/substitution.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "github.com/Mx1q/ppo_services/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockISubstitutionRepository is a mock of ISubstitutionRepository interface.
+type MockISubstitutionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockISubstitutionRepositoryMockRecorder
+}
+
+// MockISubstitutionRepositoryMockRecorder is the mock recorder for MockISubstitutionRepository.
+type MockISubstitutionRepositoryMockRecorder struct {
+	mock *MockISubstitutionRepository
+}
+
+// NewMockISubstitutionRepository creates a new mock instance.
+func NewMockISubstitutionRepository(ctrl *gomock.Controller) *MockISubstitutionRepository {
+	mock := &MockISubstitutionRepository{ctrl: ctrl}
+	mock.recorder = &MockISubstitutionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISubstitutionRepository) EXPECT() *MockISubstitutionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockISubstitutionRepository) Create(ctx context.Context, substitution *domain.Substitution) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, substitution)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockISubstitutionRepositoryMockRecorder) Create(ctx, substitution interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockISubstitutionRepository)(nil).Create), ctx, substitution)
+}
+
+// DeleteById mocks base method.
+func (m *MockISubstitutionRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockISubstitutionRepositoryMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockISubstitutionRepository)(nil).DeleteById), ctx, id)
+}
+
+// GetAllByIngredientId mocks base method.
+func (m *MockISubstitutionRepository) GetAllByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]*domain.Substitution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByIngredientId", ctx, ingredientId)
+	ret0, _ := ret[0].([]*domain.Substitution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByIngredientId indicates an expected call of GetAllByIngredientId.
+func (mr *MockISubstitutionRepositoryMockRecorder) GetAllByIngredientId(ctx, ingredientId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByIngredientId", reflect.TypeOf((*MockISubstitutionRepository)(nil).GetAllByIngredientId), ctx, ingredientId)
+}
+
+// GetById mocks base method.
+func (m *MockISubstitutionRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Substitution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.Substitution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockISubstitutionRepositoryMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockISubstitutionRepository)(nil).GetById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockISubstitutionRepository) Update(ctx context.Context, substitution *domain.Substitution) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, substitution)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockISubstitutionRepositoryMockRecorder) Update(ctx, substitution interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockISubstitutionRepository)(nil).Update), ctx, substitution)
+}
+
+// MockISubstitutionService is a mock of ISubstitutionService interface.
+type MockISubstitutionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockISubstitutionServiceMockRecorder
+}
+
+// MockISubstitutionServiceMockRecorder is the mock recorder for MockISubstitutionService.
+type MockISubstitutionServiceMockRecorder struct {
+	mock *MockISubstitutionService
+}
+
+// NewMockISubstitutionService creates a new mock instance.
+func NewMockISubstitutionService(ctrl *gomock.Controller) *MockISubstitutionService {
+	mock := &MockISubstitutionService{ctrl: ctrl}
+	mock.recorder = &MockISubstitutionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISubstitutionService) EXPECT() *MockISubstitutionServiceMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockISubstitutionService) Apply(ctx context.Context, saladId uuid.UUID, choices []*domain.SubstitutionChoice) (*domain.RecipeExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", ctx, saladId, choices)
+	ret0, _ := ret[0].(*domain.RecipeExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockISubstitutionServiceMockRecorder) Apply(ctx, saladId, choices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockISubstitutionService)(nil).Apply), ctx, saladId, choices)
+}
+
+// Create mocks base method.
+func (m *MockISubstitutionService) Create(ctx context.Context, substitution *domain.Substitution) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, substitution)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockISubstitutionServiceMockRecorder) Create(ctx, substitution interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockISubstitutionService)(nil).Create), ctx, substitution)
+}
+
+// DeleteById mocks base method.
+func (m *MockISubstitutionService) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockISubstitutionServiceMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockISubstitutionService)(nil).DeleteById), ctx, id)
+}
+
+// GetAllByIngredientId mocks base method.
+func (m *MockISubstitutionService) GetAllByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]*domain.Substitution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByIngredientId", ctx, ingredientId)
+	ret0, _ := ret[0].([]*domain.Substitution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByIngredientId indicates an expected call of GetAllByIngredientId.
+func (mr *MockISubstitutionServiceMockRecorder) GetAllByIngredientId(ctx, ingredientId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByIngredientId", reflect.TypeOf((*MockISubstitutionService)(nil).GetAllByIngredientId), ctx, ingredientId)
+}
+
+// GetById mocks base method.
+func (m *MockISubstitutionService) GetById(ctx context.Context, id uuid.UUID) (*domain.Substitution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.Substitution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockISubstitutionServiceMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockISubstitutionService)(nil).GetById), ctx, id)
+}
+
+// GetForRecipeIngredient mocks base method.
+func (m *MockISubstitutionService) GetForRecipeIngredient(ctx context.Context, recipeIngredientId uuid.UUID) ([]*domain.SubstituteOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForRecipeIngredient", ctx, recipeIngredientId)
+	ret0, _ := ret[0].([]*domain.SubstituteOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForRecipeIngredient indicates an expected call of GetForRecipeIngredient.
+func (mr *MockISubstitutionServiceMockRecorder) GetForRecipeIngredient(ctx, recipeIngredientId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForRecipeIngredient", reflect.TypeOf((*MockISubstitutionService)(nil).GetForRecipeIngredient), ctx, recipeIngredientId)
+}
+
+// Update mocks base method.
+func (m *MockISubstitutionService) Update(ctx context.Context, substitution *domain.Substitution) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, substitution)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockISubstitutionServiceMockRecorder) Update(ctx, substitution interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockISubstitutionService)(nil).Update), ctx, substitution)
+}
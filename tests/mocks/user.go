@@ -65,12 +65,13 @@ func (mr *MockIUserRepositoryMockRecorder) DeleteById(ctx, id interface{}) *gomo
 }
 
 // GetAll mocks base method.
-func (m *MockIUserRepository) GetAll(ctx context.Context, page int) ([]*domain.User, error) {
+func (m *MockIUserRepository) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.User, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", ctx, page)
 	ret0, _ := ret[0].([]*domain.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
@@ -175,12 +176,13 @@ func (mr *MockIUserServiceMockRecorder) DeleteById(ctx, id interface{}) *gomock.
 }
 
 // GetAll mocks base method.
-func (m *MockIUserService) GetAll(ctx context.Context, page int) ([]*domain.User, error) {
+func (m *MockIUserService) GetAll(ctx context.Context, page *domain.PageRequest) ([]*domain.User, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", ctx, page)
 	ret0, _ := ret[0].([]*domain.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
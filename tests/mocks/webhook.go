@@ -0,0 +1,499 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/webhook.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "github.com/Mx1q/ppo_services/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockIWebhookSubscriptionRepository is a mock of IWebhookSubscriptionRepository interface.
+type MockIWebhookSubscriptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookSubscriptionRepositoryMockRecorder
+}
+
+// MockIWebhookSubscriptionRepositoryMockRecorder is the mock recorder for MockIWebhookSubscriptionRepository.
+type MockIWebhookSubscriptionRepositoryMockRecorder struct {
+	mock *MockIWebhookSubscriptionRepository
+}
+
+// NewMockIWebhookSubscriptionRepository creates a new mock instance.
+func NewMockIWebhookSubscriptionRepository(ctrl *gomock.Controller) *MockIWebhookSubscriptionRepository {
+	mock := &MockIWebhookSubscriptionRepository{ctrl: ctrl}
+	mock.recorder = &MockIWebhookSubscriptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhookSubscriptionRepository) EXPECT() *MockIWebhookSubscriptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIWebhookSubscriptionRepository) Create(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIWebhookSubscriptionRepositoryMockRecorder) Create(ctx, subscription interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIWebhookSubscriptionRepository)(nil).Create), ctx, subscription)
+}
+
+// DeleteById mocks base method.
+func (m *MockIWebhookSubscriptionRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockIWebhookSubscriptionRepositoryMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockIWebhookSubscriptionRepository)(nil).DeleteById), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockIWebhookSubscriptionRepository) GetAll(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*domain.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockIWebhookSubscriptionRepositoryMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockIWebhookSubscriptionRepository)(nil).GetAll), ctx)
+}
+
+// GetAllByEventType mocks base method.
+func (m *MockIWebhookSubscriptionRepository) GetAllByEventType(ctx context.Context, eventType string) ([]*domain.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByEventType", ctx, eventType)
+	ret0, _ := ret[0].([]*domain.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByEventType indicates an expected call of GetAllByEventType.
+func (mr *MockIWebhookSubscriptionRepositoryMockRecorder) GetAllByEventType(ctx, eventType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByEventType", reflect.TypeOf((*MockIWebhookSubscriptionRepository)(nil).GetAllByEventType), ctx, eventType)
+}
+
+// GetById mocks base method.
+func (m *MockIWebhookSubscriptionRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockIWebhookSubscriptionRepositoryMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockIWebhookSubscriptionRepository)(nil).GetById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockIWebhookSubscriptionRepository) Update(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIWebhookSubscriptionRepositoryMockRecorder) Update(ctx, subscription interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIWebhookSubscriptionRepository)(nil).Update), ctx, subscription)
+}
+
+// MockIWebhookDeliveryRepository is a mock of IWebhookDeliveryRepository interface.
+type MockIWebhookDeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookDeliveryRepositoryMockRecorder
+}
+
+// MockIWebhookDeliveryRepositoryMockRecorder is the mock recorder for MockIWebhookDeliveryRepository.
+type MockIWebhookDeliveryRepositoryMockRecorder struct {
+	mock *MockIWebhookDeliveryRepository
+}
+
+// NewMockIWebhookDeliveryRepository creates a new mock instance.
+func NewMockIWebhookDeliveryRepository(ctrl *gomock.Controller) *MockIWebhookDeliveryRepository {
+	mock := &MockIWebhookDeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockIWebhookDeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhookDeliveryRepository) EXPECT() *MockIWebhookDeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIWebhookDeliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIWebhookDeliveryRepositoryMockRecorder) Create(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIWebhookDeliveryRepository)(nil).Create), ctx, delivery)
+}
+
+// CreateAttempt mocks base method.
+func (m *MockIWebhookDeliveryRepository) CreateAttempt(ctx context.Context, attempt *domain.WebhookAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttempt", ctx, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAttempt indicates an expected call of CreateAttempt.
+func (mr *MockIWebhookDeliveryRepositoryMockRecorder) CreateAttempt(ctx, attempt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttempt", reflect.TypeOf((*MockIWebhookDeliveryRepository)(nil).CreateAttempt), ctx, attempt)
+}
+
+// GetAllBySubscriptionId mocks base method.
+func (m *MockIWebhookDeliveryRepository) GetAllBySubscriptionId(ctx context.Context, subscriptionId uuid.UUID) ([]*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllBySubscriptionId", ctx, subscriptionId)
+	ret0, _ := ret[0].([]*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllBySubscriptionId indicates an expected call of GetAllBySubscriptionId.
+func (mr *MockIWebhookDeliveryRepositoryMockRecorder) GetAllBySubscriptionId(ctx, subscriptionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllBySubscriptionId", reflect.TypeOf((*MockIWebhookDeliveryRepository)(nil).GetAllBySubscriptionId), ctx, subscriptionId)
+}
+
+// GetAttempts mocks base method.
+func (m *MockIWebhookDeliveryRepository) GetAttempts(ctx context.Context, deliveryId uuid.UUID) ([]*domain.WebhookAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttempts", ctx, deliveryId)
+	ret0, _ := ret[0].([]*domain.WebhookAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttempts indicates an expected call of GetAttempts.
+func (mr *MockIWebhookDeliveryRepositoryMockRecorder) GetAttempts(ctx, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttempts", reflect.TypeOf((*MockIWebhookDeliveryRepository)(nil).GetAttempts), ctx, deliveryId)
+}
+
+// GetById mocks base method.
+func (m *MockIWebhookDeliveryRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockIWebhookDeliveryRepositoryMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockIWebhookDeliveryRepository)(nil).GetById), ctx, id)
+}
+
+// GetDue mocks base method.
+func (m *MockIWebhookDeliveryRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDue", ctx, now, limit)
+	ret0, _ := ret[0].([]*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDue indicates an expected call of GetDue.
+func (mr *MockIWebhookDeliveryRepositoryMockRecorder) GetDue(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDue", reflect.TypeOf((*MockIWebhookDeliveryRepository)(nil).GetDue), ctx, now, limit)
+}
+
+// Update mocks base method.
+func (m *MockIWebhookDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIWebhookDeliveryRepositoryMockRecorder) Update(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIWebhookDeliveryRepository)(nil).Update), ctx, delivery)
+}
+
+// MockIWebhookDeadLetterRepository is a mock of IWebhookDeadLetterRepository interface.
+type MockIWebhookDeadLetterRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookDeadLetterRepositoryMockRecorder
+}
+
+// MockIWebhookDeadLetterRepositoryMockRecorder is the mock recorder for MockIWebhookDeadLetterRepository.
+type MockIWebhookDeadLetterRepositoryMockRecorder struct {
+	mock *MockIWebhookDeadLetterRepository
+}
+
+// NewMockIWebhookDeadLetterRepository creates a new mock instance.
+func NewMockIWebhookDeadLetterRepository(ctrl *gomock.Controller) *MockIWebhookDeadLetterRepository {
+	mock := &MockIWebhookDeadLetterRepository{ctrl: ctrl}
+	mock.recorder = &MockIWebhookDeadLetterRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhookDeadLetterRepository) EXPECT() *MockIWebhookDeadLetterRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIWebhookDeadLetterRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIWebhookDeadLetterRepositoryMockRecorder) Create(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIWebhookDeadLetterRepository)(nil).Create), ctx, delivery)
+}
+
+// DeleteById mocks base method.
+func (m *MockIWebhookDeadLetterRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockIWebhookDeadLetterRepositoryMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockIWebhookDeadLetterRepository)(nil).DeleteById), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockIWebhookDeadLetterRepository) GetAll(ctx context.Context) ([]*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockIWebhookDeadLetterRepositoryMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockIWebhookDeadLetterRepository)(nil).GetAll), ctx)
+}
+
+// MockIWebhookService is a mock of IWebhookService interface.
+type MockIWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookServiceMockRecorder
+}
+
+// MockIWebhookServiceMockRecorder is the mock recorder for MockIWebhookService.
+type MockIWebhookServiceMockRecorder struct {
+	mock *MockIWebhookService
+}
+
+// NewMockIWebhookService creates a new mock instance.
+func NewMockIWebhookService(ctrl *gomock.Controller) *MockIWebhookService {
+	mock := &MockIWebhookService{ctrl: ctrl}
+	mock.recorder = &MockIWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhookService) EXPECT() *MockIWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateSubscription mocks base method.
+func (m *MockIWebhookService) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockIWebhookServiceMockRecorder) CreateSubscription(ctx, subscription interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockIWebhookService)(nil).CreateSubscription), ctx, subscription)
+}
+
+// DeleteSubscriptionById mocks base method.
+func (m *MockIWebhookService) DeleteSubscriptionById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscriptionById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscriptionById indicates an expected call of DeleteSubscriptionById.
+func (mr *MockIWebhookServiceMockRecorder) DeleteSubscriptionById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscriptionById", reflect.TypeOf((*MockIWebhookService)(nil).DeleteSubscriptionById), ctx, id)
+}
+
+// DeliverDue mocks base method.
+func (m *MockIWebhookService) DeliverDue(ctx context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverDue", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverDue indicates an expected call of DeliverDue.
+func (mr *MockIWebhookServiceMockRecorder) DeliverDue(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverDue", reflect.TypeOf((*MockIWebhookService)(nil).DeliverDue), ctx, limit)
+}
+
+// GetAllSubscriptions mocks base method.
+func (m *MockIWebhookService) GetAllSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSubscriptions", ctx)
+	ret0, _ := ret[0].([]*domain.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllSubscriptions indicates an expected call of GetAllSubscriptions.
+func (mr *MockIWebhookServiceMockRecorder) GetAllSubscriptions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSubscriptions", reflect.TypeOf((*MockIWebhookService)(nil).GetAllSubscriptions), ctx)
+}
+
+// GetAttempts mocks base method.
+func (m *MockIWebhookService) GetAttempts(ctx context.Context, deliveryId uuid.UUID) ([]*domain.WebhookAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttempts", ctx, deliveryId)
+	ret0, _ := ret[0].([]*domain.WebhookAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttempts indicates an expected call of GetAttempts.
+func (mr *MockIWebhookServiceMockRecorder) GetAttempts(ctx, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttempts", reflect.TypeOf((*MockIWebhookService)(nil).GetAttempts), ctx, deliveryId)
+}
+
+// GetDeadLetters mocks base method.
+func (m *MockIWebhookService) GetDeadLetters(ctx context.Context) ([]*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetters", ctx)
+	ret0, _ := ret[0].([]*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetters indicates an expected call of GetDeadLetters.
+func (mr *MockIWebhookServiceMockRecorder) GetDeadLetters(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetters", reflect.TypeOf((*MockIWebhookService)(nil).GetDeadLetters), ctx)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockIWebhookService) GetDeliveries(ctx context.Context, subscriptionId uuid.UUID) ([]*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, subscriptionId)
+	ret0, _ := ret[0].([]*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockIWebhookServiceMockRecorder) GetDeliveries(ctx, subscriptionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockIWebhookService)(nil).GetDeliveries), ctx, subscriptionId)
+}
+
+// GetSubscriptionById mocks base method.
+func (m *MockIWebhookService) GetSubscriptionById(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionById", ctx, id)
+	ret0, _ := ret[0].(*domain.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionById indicates an expected call of GetSubscriptionById.
+func (mr *MockIWebhookServiceMockRecorder) GetSubscriptionById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionById", reflect.TypeOf((*MockIWebhookService)(nil).GetSubscriptionById), ctx, id)
+}
+
+// HandleEvent mocks base method.
+func (m *MockIWebhookService) HandleEvent(ctx context.Context, event domain.IEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleEvent indicates an expected call of HandleEvent.
+func (mr *MockIWebhookServiceMockRecorder) HandleEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEvent", reflect.TypeOf((*MockIWebhookService)(nil).HandleEvent), ctx, event)
+}
+
+// Redeliver mocks base method.
+func (m *MockIWebhookService) Redeliver(ctx context.Context, deliveryId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, deliveryId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockIWebhookServiceMockRecorder) Redeliver(ctx, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockIWebhookService)(nil).Redeliver), ctx, deliveryId)
+}
+
+// UpdateSubscription mocks base method.
+func (m *MockIWebhookService) UpdateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", ctx, subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockIWebhookServiceMockRecorder) UpdateSubscription(ctx, subscription interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockIWebhookService)(nil).UpdateSubscription), ctx, subscription)
+}
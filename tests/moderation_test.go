@@ -0,0 +1,125 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newModerationService(ctrl *gomock.Controller) (domain.IModerationService, *mocks.MockIRecipeService) {
+	recipeService := mocks.NewMockIRecipeService(ctrl)
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Warnf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	return services.NewModerationService(recipeService, logger), recipeService
+}
+
+func TestModerationService_GetQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, recipeService := newModerationService(ctrl)
+
+	recipes := []*domain.Recipe{{ID: uuid.UUID{1}, Status: domain.ModerationSaladStatus}}
+	recipeService.EXPECT().
+		GetAll(gomock.Any(), &domain.RecipeFilter{Status: domain.ModerationSaladStatus},
+			&domain.PageRequest{Size: 5, Cursor: "next", Sort: domain.SortByCreatedAt}).
+		Return(recipes, &domain.PageInfo{Size: 5, Total: 1}, nil)
+
+	got, info, err := svc.GetQueue(context.Background(), &domain.PageRequest{Size: 5, Cursor: "next", Sort: domain.SortByName, Desc: true})
+	require.NoError(t, err)
+	require.Equal(t, recipes, got)
+	require.Equal(t, 1, info.Total)
+}
+
+func TestModerationService_Moderate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, recipeService := newModerationService(ctrl)
+
+	recipeId := uuid.New()
+
+	tests := []struct {
+		name       string
+		approve    bool
+		beforeTest func(recipeService *mocks.MockIRecipeService)
+		wantErr    error
+	}{
+		{
+			name:    "одобрение публикует рецепт",
+			approve: true,
+			beforeTest: func(recipeService *mocks.MockIRecipeService) {
+				recipeService.EXPECT().
+					GetById(gomock.Any(), recipeId).
+					Return(&domain.Recipe{ID: recipeId, Status: domain.ModerationSaladStatus, Version: 2}, nil)
+				recipeService.EXPECT().
+					Update(gomock.Any(), &domain.Recipe{ID: recipeId, Status: domain.PublishedSaladStatus, Version: 2}).
+					Return(nil)
+			},
+		}, // одобрение публикует рецепт
+		{
+			name: "отклонение",
+			beforeTest: func(recipeService *mocks.MockIRecipeService) {
+				recipeService.EXPECT().
+					GetById(gomock.Any(), recipeId).
+					Return(&domain.Recipe{ID: recipeId, Status: domain.ModerationSaladStatus}, nil)
+				recipeService.EXPECT().
+					Update(gomock.Any(), &domain.Recipe{ID: recipeId, Status: domain.RejectedSaladStatus}).
+					Return(nil)
+			},
+		}, // отклонение
+		{
+			name:    "рецепт не на модерации",
+			approve: true,
+			beforeTest: func(recipeService *mocks.MockIRecipeService) {
+				recipeService.EXPECT().
+					GetById(gomock.Any(), recipeId).
+					Return(&domain.Recipe{ID: recipeId, Status: domain.EditingSaladStatus}, nil)
+			},
+			wantErr: domain.ErrNotInModeration,
+		}, // рецепт не на модерации
+		{
+			name: "ошибка получения рецепта",
+			beforeTest: func(recipeService *mocks.MockIRecipeService) {
+				recipeService.EXPECT().
+					GetById(gomock.Any(), recipeId).
+					Return(nil, errors.New("not found"))
+			},
+			wantErr: errors.New("rejecting recipe: not found"),
+		}, // ошибка получения рецепта
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest(recipeService)
+
+			var err error
+			if tt.approve {
+				err = svc.Approve(context.Background(), recipeId)
+			} else {
+				err = svc.Reject(context.Background(), recipeId)
+			}
+
+			switch {
+			case tt.wantErr == nil:
+				require.NoError(t, err)
+			case errors.Is(tt.wantErr, domain.ErrNotInModeration):
+				require.ErrorIs(t, err, domain.ErrNotInModeration)
+			default:
+				require.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
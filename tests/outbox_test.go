@@ -0,0 +1,298 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestOutboxRelay_Relay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	outbox := mocks.NewMockIEventOutbox(ctrl)
+	bus := mocks.NewMockIEventPublisher(ctrl)
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	svc := services.NewOutboxRelay(outbox, bus, logger)
+
+	first, _ := domain.NewOutboxEntry(&domain.SaladDeleted{SaladID: uuid.New()}, time.Now())
+	second, _ := domain.NewOutboxEntry(&domain.KeywordDeleted{KeywordID: uuid.New()}, time.Now())
+	firstAgain, _ := domain.NewOutboxEntry(&domain.SaladDeleted{SaladID: first.AggregateID}, time.Now())
+	unknown := &domain.OutboxEntry{ID: uuid.New(), EventName: "salad.archived", AggregateID: first.AggregateID,
+		Payload: []byte(`{}`), CreatedAt: time.Now()}
+
+	tests := []struct {
+		name       string
+		beforeTest func(outbox mocks.MockIEventOutbox, bus mocks.MockIEventPublisher)
+		want       int
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешная пересылка",
+			beforeTest: func(outbox mocks.MockIEventOutbox, bus mocks.MockIEventPublisher) {
+				outbox.EXPECT().
+					GetPending(context.Background(), 10).
+					Return([]*domain.OutboxEntry{first, second}, nil)
+				bus.EXPECT().
+					Publish(context.Background(), &domain.SaladDeleted{SaladID: first.AggregateID}).
+					Return(nil)
+				bus.EXPECT().
+					Publish(context.Background(), &domain.KeywordDeleted{KeywordID: second.AggregateID}).
+					Return(nil)
+				outbox.EXPECT().
+					MarkPublished(context.Background(), []uuid.UUID{first.ID, second.ID}, gomock.Any()).
+					Return(nil)
+			},
+			want:    2,
+			wantErr: false,
+		}, // успешная пересылка
+		{
+			name: "остановка на первой ошибке публикации",
+			beforeTest: func(outbox mocks.MockIEventOutbox, bus mocks.MockIEventPublisher) {
+				outbox.EXPECT().
+					GetPending(context.Background(), 10).
+					Return([]*domain.OutboxEntry{first, second}, nil)
+				bus.EXPECT().
+					Publish(context.Background(), &domain.SaladDeleted{SaladID: first.AggregateID}).
+					Return(nil)
+				bus.EXPECT().
+					Publish(context.Background(), &domain.KeywordDeleted{KeywordID: second.AggregateID}).
+					Return(fmt.Errorf("bus error"))
+				outbox.EXPECT().
+					MarkPublished(context.Background(), []uuid.UUID{first.ID}, gomock.Any()).
+					Return(nil)
+			},
+			want:    1,
+			wantErr: true,
+			errStr:  fmt.Errorf("relaying outbox: bus error"),
+		}, // остановка на первой ошибке публикации
+		{
+			name: "ошибка публикации задерживает только свой агрегат",
+			beforeTest: func(outbox mocks.MockIEventOutbox, bus mocks.MockIEventPublisher) {
+				outbox.EXPECT().
+					GetPending(context.Background(), 10).
+					Return([]*domain.OutboxEntry{first, second, firstAgain}, nil)
+				bus.EXPECT().
+					Publish(context.Background(), &domain.SaladDeleted{SaladID: first.AggregateID}).
+					Return(fmt.Errorf("bus error"))
+				bus.EXPECT().
+					Publish(context.Background(), &domain.KeywordDeleted{KeywordID: second.AggregateID}).
+					Return(nil)
+				outbox.EXPECT().
+					MarkPublished(context.Background(), []uuid.UUID{second.ID}, gomock.Any()).
+					Return(nil)
+			},
+			want:    1,
+			wantErr: true,
+			errStr:  fmt.Errorf("relaying outbox: bus error"),
+		}, // ошибка публикации задерживает только свой агрегат
+		{
+			name: "нераспознанная запись помечается ошибочной",
+			beforeTest: func(outbox mocks.MockIEventOutbox, bus mocks.MockIEventPublisher) {
+				outbox.EXPECT().
+					GetPending(context.Background(), 10).
+					Return([]*domain.OutboxEntry{unknown, first}, nil)
+				outbox.EXPECT().
+					MarkFailed(context.Background(), unknown.ID, "unknown event salad.archived", gomock.Any()).
+					Return(nil)
+				bus.EXPECT().
+					Publish(context.Background(), &domain.SaladDeleted{SaladID: first.AggregateID}).
+					Return(nil)
+				outbox.EXPECT().
+					MarkPublished(context.Background(), []uuid.UUID{first.ID}, gomock.Any()).
+					Return(nil)
+			},
+			want:    1,
+			wantErr: false,
+		}, // нераспознанная запись помечается ошибочной
+		{
+			name: "ошибка получения записей",
+			beforeTest: func(outbox mocks.MockIEventOutbox, bus mocks.MockIEventPublisher) {
+				outbox.EXPECT().
+					GetPending(context.Background(), 10).
+					Return(nil, fmt.Errorf("outbox error"))
+			},
+			want:    0,
+			wantErr: true,
+			errStr:  fmt.Errorf("relaying outbox: outbox error"),
+		}, // ошибка получения записей
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*outbox, *bus)
+			}
+
+			n, err := svc.Relay(context.Background(), 10)
+
+			require.Equal(t, tt.want, n)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestEventedRecipeService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recipeService := mocks.NewMockIRecipeService(ctrl)
+	publisher := mocks.NewMockIEventPublisher(ctrl)
+	svc := services.NewEventedRecipeService(recipeService, newTxManagerMock(ctrl), publisher)
+
+	recipeId := uuid.New()
+	saladId := uuid.New()
+
+	tests := []struct {
+		name       string
+		recipe     *domain.Recipe
+		beforeTest func(recipeService mocks.MockIRecipeService, publisher mocks.MockIEventPublisher)
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:   "смена статуса публикует два события",
+			recipe: &domain.Recipe{ID: recipeId, SaladID: saladId, Status: domain.PublishedSaladStatus},
+			beforeTest: func(recipeService mocks.MockIRecipeService, publisher mocks.MockIEventPublisher) {
+				recipeService.EXPECT().
+					GetById(gomock.Any(), recipeId).
+					Return(&domain.Recipe{ID: recipeId, SaladID: saladId, Status: domain.ModerationSaladStatus}, nil)
+				recipeService.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil)
+				publisher.EXPECT().
+					Publish(gomock.Any(),
+						&domain.RecipeUpdated{Recipe: &domain.Recipe{ID: recipeId, SaladID: saladId, Status: domain.PublishedSaladStatus}},
+						&domain.RecipeStatusChanged{
+							RecipeID:  recipeId,
+							SaladID:   saladId,
+							OldStatus: domain.ModerationSaladStatus,
+							NewStatus: domain.PublishedSaladStatus,
+						}).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // смена статуса публикует два события
+		{
+			name:   "обновление без смены статуса",
+			recipe: &domain.Recipe{ID: recipeId, SaladID: saladId, Status: domain.EditingSaladStatus},
+			beforeTest: func(recipeService mocks.MockIRecipeService, publisher mocks.MockIEventPublisher) {
+				recipeService.EXPECT().
+					GetById(gomock.Any(), recipeId).
+					Return(&domain.Recipe{ID: recipeId, SaladID: saladId, Status: domain.EditingSaladStatus}, nil)
+				recipeService.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil)
+				publisher.EXPECT().
+					Publish(gomock.Any(),
+						&domain.RecipeUpdated{Recipe: &domain.Recipe{ID: recipeId, SaladID: saladId, Status: domain.EditingSaladStatus}}).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // обновление без смены статуса
+		{
+			name:   "ошибка обновления",
+			recipe: &domain.Recipe{ID: recipeId, SaladID: saladId, Status: domain.EditingSaladStatus},
+			beforeTest: func(recipeService mocks.MockIRecipeService, publisher mocks.MockIEventPublisher) {
+				recipeService.EXPECT().
+					GetById(gomock.Any(), recipeId).
+					Return(&domain.Recipe{ID: recipeId, SaladID: saladId, Status: domain.EditingSaladStatus}, nil)
+				recipeService.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("updating recipe: repo error"))
+			},
+			wantErr: true,
+			errStr:  fmt.Errorf("updating recipe: repo error"),
+		}, // ошибка обновления
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*recipeService, *publisher)
+			}
+
+			err := svc.Update(context.Background(), tt.recipe)
+
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestEventedRecipeAggregateService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	aggregateService := mocks.NewMockIRecipeAggregateService(ctrl)
+	recipeRepo := mocks.NewMockIRecipeRepository(ctrl)
+	publisher := mocks.NewMockIEventPublisher(ctrl)
+	svc := services.NewEventedRecipeAggregateService(aggregateService, recipeRepo, newTxManagerMock(ctrl), publisher)
+	ctx := context.Background()
+
+	salad := &domain.Salad{ID: uuid.New(), Name: "greek"}
+	recipe := &domain.Recipe{ID: uuid.New(), SaladID: salad.ID, Status: domain.ModerationSaladStatus}
+	aggregate := &domain.RecipeAggregate{Salad: salad, Recipe: recipe}
+
+	var published []domain.IEvent
+	aggregateService.EXPECT().
+		Create(gomock.Any(), aggregate).
+		Return(salad.ID, nil)
+	publisher.EXPECT().
+		Publish(gomock.Any(), &domain.SaladCreated{Salad: salad}, &domain.RecipeCreated{Recipe: recipe}).
+		DoAndReturn(func(ctx context.Context, events ...domain.IEvent) error {
+			published = events
+			return nil
+		})
+	id, err := svc.Create(ctx, aggregate)
+	require.NoError(t, err)
+	require.Equal(t, salad.ID, id)
+	require.NotSame(t, salad, published[0].(*domain.SaladCreated).Salad)
+	require.NotSame(t, recipe, published[1].(*domain.RecipeCreated).Recipe)
+
+	recipeRepo.EXPECT().
+		GetBySaladId(gomock.Any(), salad.ID).
+		Return(&domain.Recipe{ID: recipe.ID, SaladID: salad.ID, Status: domain.EditingSaladStatus}, nil)
+	aggregateService.EXPECT().
+		Replace(gomock.Any(), aggregate).
+		Return(nil)
+	publisher.EXPECT().
+		Publish(gomock.Any(),
+			&domain.SaladUpdated{Salad: salad},
+			&domain.RecipeUpdated{Recipe: recipe},
+			&domain.RecipeStatusChanged{
+				RecipeID:  recipe.ID,
+				SaladID:   salad.ID,
+				OldStatus: domain.EditingSaladStatus,
+				NewStatus: domain.ModerationSaladStatus,
+			}).
+		Return(nil)
+	require.NoError(t, svc.Replace(ctx, aggregate))
+
+	aggregateService.EXPECT().
+		Create(gomock.Any(), aggregate).
+		Return(uuid.Nil, fmt.Errorf("creating recipe aggregate: empty salad name"))
+	_, err = svc.Create(ctx, aggregate)
+	require.EqualError(t, err, "creating recipe aggregate: empty salad name")
+}
@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"errors"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func paginationSalads() []*domain.Salad {
+	return []*domain.Salad{
+		{ID: uuid.UUID{3}, Name: "caesar"},
+		{ID: uuid.UUID{1}, Name: "greek"},
+		{ID: uuid.UUID{4}, Name: "caprese"},
+		{ID: uuid.UUID{2}, Name: "greek"},
+		{ID: uuid.UUID{5}, Name: "olivier"},
+	}
+}
+
+func saladSortKey(salad *domain.Salad, sort string) (string, uuid.UUID) {
+	if sort == domain.SortByName {
+		return salad.Name, salad.ID
+	}
+	return "", salad.ID
+}
+
+func TestPageRequest_Normalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     *domain.PageRequest
+		expected *domain.PageRequest
+		wantErr  bool
+		errStr   error
+	}{
+		{
+			name:     "пустой запрос",
+			page:     nil,
+			expected: &domain.PageRequest{Size: domain.DefaultPageSize},
+			wantErr:  false,
+		}, // пустой запрос
+		{
+			name:     "поддерживаемая сортировка",
+			page:     &domain.PageRequest{Size: 5, Sort: domain.SortByName, Desc: true},
+			expected: &domain.PageRequest{Size: 5, Sort: domain.SortByName, Desc: true},
+			wantErr:  false,
+		}, // поддерживаемая сортировка
+		{
+			name:    "слишком большой размер страницы",
+			page:    &domain.PageRequest{Size: domain.MaxPageSize + 1},
+			wantErr: true,
+			errStr:  errors.New("page size must be from 1 to 100"),
+		}, // слишком большой размер страницы
+		{
+			name:    "отрицательный размер страницы",
+			page:    &domain.PageRequest{Size: -1},
+			wantErr: true,
+			errStr:  errors.New("page size must be from 1 to 100"),
+		}, // отрицательный размер страницы
+		{
+			name:    "неподдерживаемая сортировка",
+			page:    &domain.PageRequest{Sort: domain.SortByCalories},
+			wantErr: true,
+			errStr:  errors.New("unsupported sort calories"),
+		}, // неподдерживаемая сортировка
+		{
+			name:    "некорректный курсор",
+			page:    &domain.PageRequest{Cursor: "!"},
+			wantErr: true,
+			errStr:  errors.New("invalid cursor"),
+		}, // некорректный курсор
+		{
+			name: "курсор другой сортировки",
+			page: &domain.PageRequest{
+				Sort:   domain.SortByName,
+				Cursor: domain.EncodeCursor(&domain.Cursor{Sort: domain.SortByRating, ID: uuid.UUID{1}}),
+			},
+			wantErr: true,
+			errStr:  errors.New("invalid cursor: cursor of another sort"),
+		}, // курсор другой сортировки
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := tt.page.Normalize(domain.DefaultPageSizes, domain.SortByName, domain.SortByRating)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+				if tt.page.Cursor != "" {
+					require.ErrorIs(t, err, domain.ErrInvalidCursor)
+				}
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tt.expected, page)
+			}
+		})
+	}
+}
+
+func TestPageRequest_NormalizeSizes(t *testing.T) {
+	sizes := domain.PageSizes{Default: 5, Max: 10}
+
+	page, err := (*domain.PageRequest)(nil).Normalize(sizes)
+	require.Nil(t, err)
+	require.Equal(t, &domain.PageRequest{Size: 5}, page)
+
+	_, err = (&domain.PageRequest{Size: 11}).Normalize(sizes)
+	require.EqualError(t, err, "page size must be from 1 to 10")
+}
+
+func TestPaginate(t *testing.T) {
+	page := &domain.PageRequest{Size: 2, Sort: domain.SortByName}
+
+	var names []string
+	var ids []uuid.UUID
+	for {
+		salads, info, err := domain.Paginate(paginationSalads(), page, saladSortKey)
+		require.Nil(t, err)
+		require.Equal(t, 5, info.Total)
+		for _, salad := range salads {
+			names = append(names, salad.Name)
+			ids = append(ids, salad.ID)
+		}
+		if info.NextCursor == "" {
+			break
+		}
+		page.Cursor = info.NextCursor
+	}
+
+	require.Equal(t, []string{"caesar", "caprese", "greek", "greek", "olivier"}, names)
+	require.Equal(t, []uuid.UUID{{3}, {4}, {1}, {2}, {5}}, ids)
+}
+
+func TestPaginate_Desc(t *testing.T) {
+	salads, info, err := domain.Paginate(paginationSalads(),
+		&domain.PageRequest{Size: 3, Sort: domain.SortByName, Desc: true}, saladSortKey)
+	require.Nil(t, err)
+	require.Equal(t, "olivier", salads[0].Name)
+	require.Equal(t, uuid.UUID{2}, salads[1].ID)
+	require.Equal(t, uuid.UUID{1}, salads[2].ID)
+	require.NotEmpty(t, info.NextCursor)
+
+	salads, info, err = domain.Paginate(paginationSalads(),
+		&domain.PageRequest{Size: 3, Sort: domain.SortByName, Desc: true, Cursor: info.NextCursor}, saladSortKey)
+	require.Nil(t, err)
+	require.Len(t, salads, 2)
+	require.Equal(t, "caprese", salads[0].Name)
+	require.Equal(t, "caesar", salads[1].Name)
+	require.Empty(t, info.NextCursor)
+}
+
+func TestPaginate_StableUnderInserts(t *testing.T) {
+	items := paginationSalads()
+	page := &domain.PageRequest{Size: 2, Sort: domain.SortByName}
+
+	first, info, err := domain.Paginate(items, page, saladSortKey)
+	require.Nil(t, err)
+	require.Equal(t, "caprese", first[1].Name)
+
+	// an item sorted before the cursor must not shift the next page
+	items = append(items, &domain.Salad{ID: uuid.UUID{6}, Name: "borscht"})
+	page.Cursor = info.NextCursor
+	second, _, err := domain.Paginate(items, page, saladSortKey)
+	require.Nil(t, err)
+	require.Equal(t, []uuid.UUID{{1}, {2}}, []uuid.UUID{second[0].ID, second[1].ID})
+}
+
+func TestSortKeyFloat(t *testing.T) {
+	values := []float64{-10.5, -1, 0, 0.25, 3, 100}
+	for i := 1; i < len(values); i++ {
+		require.Less(t, domain.SortKeyFloat(values[i-1]), domain.SortKeyFloat(values[i]))
+	}
+	require.Less(t, domain.SortKeyInt(9), domain.SortKeyInt(10))
+}
@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"context"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type ratingMocks struct {
+	recipeService  *mocks.MockIRecipeService
+	commentService *mocks.MockICommentService
+}
+
+func newRatingService(ctrl *gomock.Controller) (domain.IRatingService, ratingMocks) {
+	m := ratingMocks{
+		recipeService:  mocks.NewMockIRecipeService(ctrl),
+		commentService: mocks.NewMockICommentService(ctrl),
+	}
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Infof(gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	return services.NewRatingService(m.recipeService, m.commentService, logger), m
+}
+
+func TestRatingService_Recompute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newRatingService(ctrl)
+
+	saladId := uuid.New()
+	recipeId := uuid.New()
+
+	tests := []struct {
+		name       string
+		beforeTest func(m ratingMocks)
+		want       float32
+	}{
+		{
+			name: "среднее по всем страницам комментариев",
+			beforeTest: func(m ratingMocks) {
+				m.recipeService.EXPECT().
+					GetBySaladId(gomock.Any(), saladId).
+					Return(&domain.Recipe{ID: recipeId, SaladID: saladId, Rating: 5}, nil)
+				m.commentService.EXPECT().
+					GetAllBySaladID(gomock.Any(), saladId, &domain.PageRequest{}).
+					Return([]*domain.Comment{{Rating: 5}, {Rating: 4}}, &domain.PageInfo{NextCursor: "next"}, nil)
+				m.commentService.EXPECT().
+					GetAllBySaladID(gomock.Any(), saladId, &domain.PageRequest{Cursor: "next"}).
+					Return([]*domain.Comment{{Rating: 3}}, &domain.PageInfo{}, nil)
+				m.recipeService.EXPECT().
+					Update(gomock.Any(), &domain.Recipe{ID: recipeId, SaladID: saladId, Rating: 4}).
+					Return(nil)
+			},
+			want: 4,
+		}, // среднее по всем страницам комментариев
+		{
+			name: "рейтинг не изменился",
+			beforeTest: func(m ratingMocks) {
+				m.recipeService.EXPECT().
+					GetBySaladId(gomock.Any(), saladId).
+					Return(&domain.Recipe{ID: recipeId, SaladID: saladId, Rating: 0}, nil)
+				m.commentService.EXPECT().
+					GetAllBySaladID(gomock.Any(), saladId, gomock.Any()).
+					Return([]*domain.Comment{}, &domain.PageInfo{}, nil)
+			},
+			want: 0,
+		}, // рейтинг не изменился
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest(m)
+
+			rating, err := svc.Recompute(context.Background(), saladId)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, rating)
+		})
+	}
+}
+
+func TestRatingService_RecomputeAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newRatingService(ctrl)
+
+	first := &domain.Recipe{ID: uuid.UUID{1}, SaladID: uuid.UUID{11}, Rating: 2}
+	second := &domain.Recipe{ID: uuid.UUID{2}, SaladID: uuid.UUID{12}, Rating: 3}
+	m.recipeService.EXPECT().
+		GetAll(gomock.Any(), nil, gomock.Any()).
+		Return([]*domain.Recipe{first, second}, &domain.PageInfo{}, nil)
+	m.commentService.EXPECT().
+		GetAllBySaladID(gomock.Any(), first.SaladID, gomock.Any()).
+		Return([]*domain.Comment{{Rating: 1}, {Rating: 2}}, &domain.PageInfo{}, nil)
+	m.commentService.EXPECT().
+		GetAllBySaladID(gomock.Any(), second.SaladID, gomock.Any()).
+		Return([]*domain.Comment{{Rating: 3}}, &domain.PageInfo{}, nil)
+	m.recipeService.EXPECT().
+		Update(gomock.Any(), &domain.Recipe{ID: first.ID, SaladID: first.SaladID, Rating: 1.5}).
+		Return(nil)
+
+	changed, err := svc.RecomputeAll(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, changed)
+}
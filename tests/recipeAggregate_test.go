@@ -0,0 +1,394 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type recipeAggregateMocks struct {
+	txManager            *mocks.MockITransactionManager
+	tx                   *mocks.MockITransaction
+	saladRepo            *mocks.MockISaladRepository
+	recipeRepo           *mocks.MockIRecipeRepository
+	recipeStepRepo       *mocks.MockIRecipeStepRepository
+	recipeIngredientRepo *mocks.MockIRecipeIngredientRepository
+	saladTypeRepo        *mocks.MockISaladTypeRepository
+	validator            *mocks.MockIValidatorService
+}
+
+func newRecipeAggregateService(ctrl *gomock.Controller) (domain.IRecipeAggregateService, *recipeAggregateMocks) {
+	m := &recipeAggregateMocks{
+		txManager:            mocks.NewMockITransactionManager(ctrl),
+		tx:                   mocks.NewMockITransaction(ctrl),
+		saladRepo:            mocks.NewMockISaladRepository(ctrl),
+		recipeRepo:           mocks.NewMockIRecipeRepository(ctrl),
+		recipeStepRepo:       mocks.NewMockIRecipeStepRepository(ctrl),
+		recipeIngredientRepo: mocks.NewMockIRecipeIngredientRepository(ctrl),
+		saladTypeRepo:        mocks.NewMockISaladTypeRepository(ctrl),
+		validator:            mocks.NewMockIValidatorService(ctrl),
+	}
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Warnf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+
+	svc := services.NewRecipeAggregateService(m.txManager, m.saladRepo, m.recipeRepo, m.recipeStepRepo,
+		m.recipeIngredientRepo, m.saladTypeRepo, []domain.IValidatorService{m.validator}, logger)
+	return svc, m
+}
+
+func newAggregate() *domain.RecipeAggregate {
+	return &domain.RecipeAggregate{
+		Salad: &domain.Salad{
+			ID:       uuid.UUID{1},
+			AuthorID: uuid.UUID{2},
+			Name:     "salad",
+		},
+		Recipe: &domain.Recipe{
+			NumberOfServings: 2,
+			TimeToCook:       10,
+			Status:           domain.EditingSaladStatus,
+			Version:          2,
+		},
+		Steps: []*domain.RecipeStep{
+			{Name: "cut", Description: "cut", StepNum: 1},
+			{Name: "mix", Description: "mix", StepNum: 2},
+		},
+		Ingredients: []*domain.RecipeAggregateIngredient{
+			{IngredientID: uuid.UUID{5}, MeasurementID: uuid.UUID{6}, Amount: 2, Note: "sliced"},
+		},
+		TypeIDs: []uuid.UUID{{7}},
+	}
+}
+
+func TestRecipeAggregateService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, m := newRecipeAggregateService(ctrl)
+
+	saladId := uuid.UUID{1}
+	recipeId := uuid.UUID{3}
+	linkId := uuid.UUID{4}
+
+	tests := []struct {
+		name       string
+		aggregate  func() *domain.RecipeAggregate
+		beforeTest func(m *recipeAggregateMocks)
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:      "запрещенное слово в названии",
+			aggregate: newAggregate,
+			beforeTest: func(m *recipeAggregateMocks) {
+				m.validator.EXPECT().
+					Verify(gomock.Any(), "salad").
+					Return(fmt.Errorf("found salad"))
+			},
+			wantErr: true,
+			errStr:  errors.New("creating recipe aggregate: salad name: found salad"),
+		}, // запрещенное слово в названии
+		{
+			name:      "успешное создание",
+			aggregate: newAggregate,
+			beforeTest: func(m *recipeAggregateMocks) {
+				m.validator.EXPECT().
+					Verify(gomock.Any(), gomock.Any()).
+					Return(nil).
+					AnyTimes()
+				m.txManager.EXPECT().
+					Begin(context.Background()).
+					Return(m.tx, nil)
+				m.saladRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(saladId, nil)
+				m.recipeRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(recipeId, nil)
+				m.recipeStepRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+				m.recipeIngredientRepo.EXPECT().
+					Create(gomock.Any(), &domain.RecipeIngredient{
+						RecipeID:      recipeId,
+						IngredientID:  uuid.UUID{5},
+						MeasurementID: uuid.UUID{6},
+						Amount:        2,
+						Note:          "sliced",
+						Order:         1,
+					}).
+					Return(linkId, nil)
+				m.saladTypeRepo.EXPECT().
+					Link(gomock.Any(), saladId, uuid.UUID{7}).
+					Return(nil)
+				m.tx.EXPECT().
+					Commit(context.Background()).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // успешное создание
+		{
+			name: "ошибка валидации до начала транзакции",
+			aggregate: func() *domain.RecipeAggregate {
+				aggregate := newAggregate()
+				aggregate.Steps[1].StepNum = 3
+				return aggregate
+			},
+			beforeTest: func(m *recipeAggregateMocks) {
+				m.validator.EXPECT().
+					Verify(gomock.Any(), gomock.Any()).
+					Return(nil).
+					AnyTimes()
+			},
+			wantErr: true,
+			errStr:  errors.New("creating recipe aggregate: step 3: step nums must be unique and go from 1 to 2"),
+		}, // ошибка валидации до начала транзакции
+		{
+			name: "ингредиент без единицы измерения",
+			aggregate: func() *domain.RecipeAggregate {
+				aggregate := newAggregate()
+				aggregate.Ingredients[0].MeasurementID = uuid.Nil
+				return aggregate
+			},
+			beforeTest: func(m *recipeAggregateMocks) {
+				m.validator.EXPECT().
+					Verify(gomock.Any(), gomock.Any()).
+					Return(nil).
+					AnyTimes()
+			},
+			wantErr: true,
+			errStr:  errors.New("creating recipe aggregate: ingredient 05000000-0000-0000-0000-000000000000: empty measurement"),
+		}, // ингредиент без единицы измерения
+		{
+			name: "повторяющийся тип салата",
+			aggregate: func() *domain.RecipeAggregate {
+				aggregate := newAggregate()
+				aggregate.TypeIDs = append(aggregate.TypeIDs, aggregate.TypeIDs[0])
+				return aggregate
+			},
+			beforeTest: func(m *recipeAggregateMocks) {
+				m.validator.EXPECT().
+					Verify(gomock.Any(), gomock.Any()).
+					Return(nil).
+					AnyTimes()
+			},
+			wantErr: true,
+			errStr:  errors.New("creating recipe aggregate: duplicate salad type 07000000-0000-0000-0000-000000000000"),
+		}, // повторяющийся тип салата
+		{
+			name:      "откат при ошибке в середине",
+			aggregate: newAggregate,
+			beforeTest: func(m *recipeAggregateMocks) {
+				m.validator.EXPECT().
+					Verify(gomock.Any(), gomock.Any()).
+					Return(nil).
+					AnyTimes()
+				m.txManager.EXPECT().
+					Begin(context.Background()).
+					Return(m.tx, nil)
+				m.saladRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(saladId, nil)
+				m.recipeRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(uuid.Nil, fmt.Errorf("recipe err"))
+				m.tx.EXPECT().
+					Rollback(context.Background()).
+					Return(nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("creating recipe aggregate: recipe err"),
+		}, // откат при ошибке в середине
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(m)
+			}
+
+			id, err := svc.Create(context.Background(), tt.aggregate())
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, saladId, id)
+			}
+		})
+	}
+}
+
+func TestRecipeAggregateService_Replace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, m := newRecipeAggregateService(ctrl)
+
+	saladId := uuid.UUID{1}
+	recipeId := uuid.UUID{3}
+	linkId := uuid.UUID{4}
+
+	tests := []struct {
+		name       string
+		aggregate  func() *domain.RecipeAggregate
+		beforeTest func(m *recipeAggregateMocks)
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешная замена",
+			beforeTest: func(m *recipeAggregateMocks) {
+				m.validator.EXPECT().
+					Verify(gomock.Any(), gomock.Any()).
+					Return(nil).
+					AnyTimes()
+				m.txManager.EXPECT().
+					Begin(context.Background()).
+					Return(m.tx, nil)
+				m.saladRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil)
+				m.recipeRepo.EXPECT().
+					GetBySaladId(gomock.Any(), saladId).
+					Return(&domain.Recipe{ID: recipeId, SaladID: saladId}, nil)
+				m.recipeRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil)
+				m.recipeStepRepo.EXPECT().
+					DeleteAllByRecipeID(gomock.Any(), recipeId).
+					Return(nil)
+				m.recipeIngredientRepo.EXPECT().
+					DeleteAllByRecipeId(gomock.Any(), recipeId).
+					Return(nil)
+				m.saladTypeRepo.EXPECT().
+					GetAllBySaladId(gomock.Any(), saladId).
+					Return([]*domain.SaladType{{ID: uuid.UUID{9}}}, nil)
+				m.saladTypeRepo.EXPECT().
+					Unlink(gomock.Any(), saladId, uuid.UUID{9}).
+					Return(nil)
+				m.recipeStepRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+				m.recipeIngredientRepo.EXPECT().
+					Create(gomock.Any(), &domain.RecipeIngredient{
+						RecipeID:      recipeId,
+						IngredientID:  uuid.UUID{5},
+						MeasurementID: uuid.UUID{6},
+						Amount:        2,
+						Note:          "sliced",
+						Order:         1,
+					}).
+					Return(linkId, nil)
+				m.saladTypeRepo.EXPECT().
+					Link(gomock.Any(), saladId, uuid.UUID{7}).
+					Return(nil)
+				m.tx.EXPECT().
+					Commit(context.Background()).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // успешная замена
+		{
+			name: "рецепт салата не найден",
+			beforeTest: func(m *recipeAggregateMocks) {
+				m.validator.EXPECT().
+					Verify(gomock.Any(), gomock.Any()).
+					Return(nil).
+					AnyTimes()
+				m.txManager.EXPECT().
+					Begin(context.Background()).
+					Return(m.tx, nil)
+				m.saladRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil)
+				m.recipeRepo.EXPECT().
+					GetBySaladId(gomock.Any(), saladId).
+					Return(nil, fmt.Errorf("not found"))
+				m.tx.EXPECT().
+					Rollback(context.Background()).
+					Return(nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("replacing recipe aggregate: not found"),
+		}, // рецепт салата не найден
+		{
+			name: "устаревшая версия рецепта",
+			beforeTest: func(m *recipeAggregateMocks) {
+				m.validator.EXPECT().
+					Verify(gomock.Any(), gomock.Any()).
+					Return(nil).
+					AnyTimes()
+				m.txManager.EXPECT().
+					Begin(context.Background()).
+					Return(m.tx, nil)
+				m.saladRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil)
+				m.recipeRepo.EXPECT().
+					GetBySaladId(gomock.Any(), saladId).
+					Return(&domain.Recipe{ID: recipeId, SaladID: saladId, Version: 3}, nil)
+				m.recipeRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, recipe *domain.Recipe) error {
+						return &domain.VersionConflictError{Entity: "recipe", ID: recipe.ID, Version: recipe.Version}
+					})
+				m.tx.EXPECT().
+					Rollback(context.Background()).
+					Return(nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("replacing recipe aggregate: recipe 03000000-0000-0000-0000-000000000000: version 2 is stale"),
+		}, // устаревшая версия рецепта
+		{
+			name: "салат без идентификатора",
+			aggregate: func() *domain.RecipeAggregate {
+				aggregate := newAggregate()
+				aggregate.Salad.ID = uuid.Nil
+				return aggregate
+			},
+			beforeTest: func(m *recipeAggregateMocks) {
+				m.validator.EXPECT().
+					Verify(gomock.Any(), gomock.Any()).
+					Return(nil).
+					AnyTimes()
+			},
+			wantErr: true,
+			errStr:  errors.New("replacing recipe aggregate: empty salad id"),
+		}, // салат без идентификатора
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(m)
+			}
+
+			aggregate := newAggregate()
+			if tt.aggregate != nil {
+				aggregate = tt.aggregate()
+			}
+
+			err := svc.Replace(context.Background(), aggregate)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
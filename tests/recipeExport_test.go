@@ -0,0 +1,201 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/config"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func newRecipeExportService(ctrl *gomock.Controller) (domain.IRecipeExportService, *mocks.MockISaladDetailsService) {
+	saladDetailsService := mocks.NewMockISaladDetailsService(ctrl)
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Warnf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+
+	return services.NewRecipeExportService(saladDetailsService, logger, config.Default().Rating), saladDetailsService
+}
+
+var exportSaladId = uuid.UUID{1}
+
+func expectFullRecipe(saladDetailsService *mocks.MockISaladDetailsService) {
+	saladDetailsService.EXPECT().
+		GetById(context.Background(), exportSaladId, nil).
+		Return(&domain.SaladDetails{
+			Salad: &domain.Salad{
+				ID:          exportSaladId,
+				AuthorID:    uuid.UUID{2},
+				Name:        "Greek salad",
+				Description: "fresh & tasty",
+			},
+			Author: &domain.User{ID: uuid.UUID{2}, Name: "Ivan", Username: "ivan"},
+			Recipe: &domain.Recipe{
+				ID:               uuid.UUID{3},
+				SaladID:          exportSaladId,
+				Status:           domain.PublishedSaladStatus,
+				NumberOfServings: 2,
+				TimeToCook:       15,
+				Rating:           4.5,
+			},
+			Steps: []*domain.RecipeStep{
+				{ID: uuid.UUID{11}, RecipeID: uuid.UUID{3}, Name: "cut", Description: "cut tomatoes", StepNum: 1},
+				{ID: uuid.UUID{12}, RecipeID: uuid.UUID{3}, Name: "mix", Description: "mix everything", StepNum: 2},
+			},
+			Ingredients: []*domain.SaladIngredient{
+				{
+					Ingredient:  &domain.Ingredient{ID: uuid.UUID{4}, Name: "tomato", Calories: 20},
+					Measurement: &domain.Measurement{ID: uuid.UUID{5}, Name: "piece", Grams: 100},
+					Amount:      3,
+				},
+			},
+			Types: []*domain.SaladType{{ID: uuid.UUID{6}, Name: "vegetarian"}},
+		}, nil)
+}
+
+func TestRecipeExportService_Assemble(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, saladDetailsService := newRecipeExportService(ctrl)
+
+	tests := []struct {
+		name             string
+		beforeTest       func(saladDetailsService *mocks.MockISaladDetailsService)
+		expectedCalories int
+		wantErr          bool
+		errStr           error
+	}{
+		{
+			name:             "успешная сборка рецепта",
+			beforeTest:       expectFullRecipe,
+			expectedCalories: 60,
+			wantErr:          false,
+		}, // успешная сборка рецепта
+		{
+			name: "салат не найден",
+			beforeTest: func(saladDetailsService *mocks.MockISaladDetailsService) {
+				saladDetailsService.EXPECT().
+					GetById(context.Background(), exportSaladId, nil).
+					Return(nil, fmt.Errorf("not found"))
+			},
+			wantErr: true,
+			errStr:  errors.New("assembling recipe export: not found"),
+		}, // салат не найден
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(saladDetailsService)
+			}
+
+			export, err := svc.Assemble(context.Background(), exportSaladId)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tt.expectedCalories, export.Calories)
+				require.Equal(t, "Greek salad", export.Salad.Name)
+			}
+		})
+	}
+}
+
+func TestRecipeExportService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, saladDetailsService := newRecipeExportService(ctrl)
+
+	tests := []struct {
+		name       string
+		format     string
+		beforeTest func(saladDetailsService *mocks.MockISaladDetailsService)
+		check      func(t *testing.T, data []byte)
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:       "экспорт в json-ld",
+			format:     domain.ExportFormatJSONLD,
+			beforeTest: expectFullRecipe,
+			check: func(t *testing.T, data []byte) {
+				var doc map[string]interface{}
+				require.Nil(t, json.Unmarshal(data, &doc))
+				require.Equal(t, "https://schema.org", doc["@context"])
+				require.Equal(t, "Recipe", doc["@type"])
+				require.Equal(t, "PT15M", doc["totalTime"])
+				require.Equal(t, []interface{}{"tomato — 3 piece (300 g)"}, doc["recipeIngredient"])
+				require.Equal(t, "60 kcal", doc["nutrition"].(map[string]interface{})["calories"])
+				require.Equal(t, float64(config.Default().Rating.MaxRate), doc["aggregateRating"].(map[string]interface{})["bestRating"])
+				steps := doc["recipeInstructions"].([]interface{})
+				require.Equal(t, "cut", steps[0].(map[string]interface{})["name"])
+			},
+			wantErr: false,
+		}, // экспорт в json-ld
+		{
+			name:       "экспорт в markdown",
+			format:     domain.ExportFormatMarkdown,
+			beforeTest: expectFullRecipe,
+			check: func(t *testing.T, data []byte) {
+				md := string(data)
+				require.True(t, strings.HasPrefix(md, "# Greek salad\n"))
+				require.Contains(t, md, "- **Types:** vegetarian\n")
+				require.Contains(t, md, "- tomato — 3 piece (300 g)\n")
+				require.Contains(t, md, "- **Rating:** 4.5/5\n")
+				require.Contains(t, md, "1. **cut** — cut tomatoes\n2. **mix** — mix everything\n")
+			},
+			wantErr: false,
+		}, // экспорт в markdown
+		{
+			name:       "экспорт в html",
+			format:     domain.ExportFormatHTML,
+			beforeTest: expectFullRecipe,
+			check: func(t *testing.T, data []byte) {
+				page := string(data)
+				require.Contains(t, page, "<title>Greek salad</title>")
+				require.Contains(t, page, "fresh &amp; tasty")
+				require.Contains(t, page, "<span>vegetarian</span>")
+				require.Contains(t, page, "<td>60 kcal</td>")
+				require.Contains(t, page, "@media print")
+			},
+			wantErr: false,
+		}, // экспорт в html
+		{
+			name:    "неизвестный формат",
+			format:  "pdf",
+			wantErr: true,
+			errStr:  errors.New("exporting recipe: unknown format pdf"),
+		}, // неизвестный формат
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(saladDetailsService)
+			}
+
+			data, err := svc.Export(context.Background(), exportSaladId, tt.format)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				tt.check(t, data)
+			}
+		})
+	}
+}
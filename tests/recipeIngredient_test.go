@@ -0,0 +1,390 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newRecipeIngredientService(ctrl *gomock.Controller) (domain.IRecipeIngredientService, *mocks.MockIRecipeIngredientRepository) {
+	recipeIngredientRepo := mocks.NewMockIRecipeIngredientRepository(ctrl)
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Warnf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	return services.NewRecipeIngredientService(recipeIngredientRepo, newTxManagerMock(ctrl), logger), recipeIngredientRepo
+}
+
+func TestRecipeIngredientService_Add(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, recipeIngredientRepo := newRecipeIngredientService(ctrl)
+
+	recipeId := uuid.UUID{1}
+	tomatoId := uuid.UUID{2}
+	cupId := uuid.UUID{3}
+	linkId := uuid.UUID{4}
+	linked := func() []*domain.RecipeIngredient {
+		return []*domain.RecipeIngredient{
+			{ID: uuid.UUID{12}, RecipeID: recipeId, IngredientID: uuid.UUID{22}, MeasurementID: cupId, Amount: 1, Order: 2},
+			{ID: uuid.UUID{11}, RecipeID: recipeId, IngredientID: uuid.UUID{21}, MeasurementID: cupId, Amount: 1, Order: 1},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		recipeIngredient *domain.RecipeIngredient
+		beforeTest       func(recipeIngredientRepo mocks.MockIRecipeIngredientRepository)
+		wantErr          bool
+		errStr           error
+	}{
+		{
+			name: "добавление в конец",
+			recipeIngredient: &domain.RecipeIngredient{
+				RecipeID:      recipeId,
+				IngredientID:  tomatoId,
+				MeasurementID: cupId,
+				Amount:        0.5,
+				Note:          "finely chopped",
+			},
+			beforeTest: func(recipeIngredientRepo mocks.MockIRecipeIngredientRepository) {
+				recipeIngredientRepo.EXPECT().
+					GetAllByRecipeId(gomock.Any(), recipeId).
+					Return(linked(), nil)
+				recipeIngredientRepo.EXPECT().
+					Create(gomock.Any(), &domain.RecipeIngredient{
+						RecipeID:      recipeId,
+						IngredientID:  tomatoId,
+						MeasurementID: cupId,
+						Amount:        0.5,
+						Note:          "finely chopped",
+						Order:         3,
+					}).
+					Return(linkId, nil)
+			},
+			wantErr: false,
+		}, // добавление в конец
+		{
+			name: "вставка в начало со сдвигом",
+			recipeIngredient: &domain.RecipeIngredient{
+				RecipeID:      recipeId,
+				IngredientID:  tomatoId,
+				MeasurementID: cupId,
+				Amount:        2,
+				Optional:      true,
+				Order:         1,
+			},
+			beforeTest: func(recipeIngredientRepo mocks.MockIRecipeIngredientRepository) {
+				recipeIngredientRepo.EXPECT().
+					GetAllByRecipeId(gomock.Any(), recipeId).
+					Return(linked(), nil)
+				gomock.InOrder(
+					recipeIngredientRepo.EXPECT().
+						Update(gomock.Any(), &domain.RecipeIngredient{
+							ID: uuid.UUID{11}, RecipeID: recipeId, IngredientID: uuid.UUID{21},
+							MeasurementID: cupId, Amount: 1, Order: 2,
+						}).
+						Return(nil),
+					recipeIngredientRepo.EXPECT().
+						Update(gomock.Any(), &domain.RecipeIngredient{
+							ID: uuid.UUID{12}, RecipeID: recipeId, IngredientID: uuid.UUID{22},
+							MeasurementID: cupId, Amount: 1, Order: 3,
+						}).
+						Return(nil),
+					recipeIngredientRepo.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(linkId, nil),
+				)
+			},
+			wantErr: false,
+		}, // вставка в начало со сдвигом
+		{
+			name: "ингредиент уже добавлен",
+			recipeIngredient: &domain.RecipeIngredient{
+				RecipeID:      recipeId,
+				IngredientID:  uuid.UUID{21},
+				MeasurementID: cupId,
+				Amount:        1,
+			},
+			beforeTest: func(recipeIngredientRepo mocks.MockIRecipeIngredientRepository) {
+				recipeIngredientRepo.EXPECT().
+					GetAllByRecipeId(gomock.Any(), recipeId).
+					Return(linked(), nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("adding recipe ingredient: ingredient already added"),
+		}, // ингредиент уже добавлен
+		{
+			name: "некорректная позиция",
+			recipeIngredient: &domain.RecipeIngredient{
+				RecipeID:      recipeId,
+				IngredientID:  tomatoId,
+				MeasurementID: cupId,
+				Amount:        1,
+				Order:         5,
+			},
+			beforeTest: func(recipeIngredientRepo mocks.MockIRecipeIngredientRepository) {
+				recipeIngredientRepo.EXPECT().
+					GetAllByRecipeId(gomock.Any(), recipeId).
+					Return(linked(), nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("adding recipe ingredient: invalid order"),
+		}, // некорректная позиция
+		{
+			name: "нулевое количество",
+			recipeIngredient: &domain.RecipeIngredient{
+				RecipeID:      recipeId,
+				IngredientID:  tomatoId,
+				MeasurementID: cupId,
+			},
+			wantErr: true,
+			errStr:  errors.New("adding recipe ingredient: negative or zero amount"),
+		}, // нулевое количество
+		{
+			name: "без меры",
+			recipeIngredient: &domain.RecipeIngredient{
+				RecipeID:     recipeId,
+				IngredientID: tomatoId,
+				Amount:       1,
+			},
+			wantErr: true,
+			errStr:  errors.New("adding recipe ingredient: empty measurement"),
+		}, // без меры
+		{
+			name: "ошибка выполнения запроса в репозитории",
+			recipeIngredient: &domain.RecipeIngredient{
+				RecipeID:      recipeId,
+				IngredientID:  tomatoId,
+				MeasurementID: cupId,
+				Amount:        1,
+			},
+			beforeTest: func(recipeIngredientRepo mocks.MockIRecipeIngredientRepository) {
+				recipeIngredientRepo.EXPECT().
+					GetAllByRecipeId(gomock.Any(), recipeId).
+					Return(nil, fmt.Errorf("getting ingredients err"))
+			},
+			wantErr: true,
+			errStr:  errors.New("adding recipe ingredient: getting ingredients err"),
+		}, // ошибка выполнения запроса в репозитории
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*recipeIngredientRepo)
+			}
+
+			id, err := svc.Add(context.Background(), tt.recipeIngredient)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, linkId, id)
+				require.Equal(t, linkId, tt.recipeIngredient.ID)
+			}
+		})
+	}
+}
+
+func TestRecipeIngredientService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, recipeIngredientRepo := newRecipeIngredientService(ctrl)
+
+	stored := &domain.RecipeIngredient{
+		ID:            uuid.UUID{1},
+		RecipeID:      uuid.UUID{2},
+		IngredientID:  uuid.UUID{3},
+		MeasurementID: uuid.UUID{4},
+		Amount:        1,
+		Order:         2,
+		Version:       1,
+	}
+
+	tests := []struct {
+		name             string
+		recipeIngredient *domain.RecipeIngredient
+		beforeTest       func(recipeIngredientRepo mocks.MockIRecipeIngredientRepository)
+		wantErr          bool
+		errStr           error
+	}{
+		{
+			name: "успешное обновление",
+			recipeIngredient: &domain.RecipeIngredient{
+				ID:            stored.ID,
+				IngredientID:  stored.IngredientID,
+				MeasurementID: stored.MeasurementID,
+				Amount:        1.5,
+				Note:          "diced",
+				Version:       1,
+			},
+			beforeTest: func(recipeIngredientRepo mocks.MockIRecipeIngredientRepository) {
+				recipeIngredientRepo.EXPECT().
+					GetById(gomock.Any(), stored.ID).
+					Return(stored, nil)
+				recipeIngredientRepo.EXPECT().
+					Update(gomock.Any(), &domain.RecipeIngredient{
+						ID:            stored.ID,
+						RecipeID:      stored.RecipeID,
+						IngredientID:  stored.IngredientID,
+						MeasurementID: stored.MeasurementID,
+						Amount:        1.5,
+						Note:          "diced",
+						Order:         2,
+						Version:       1,
+					}).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // успешное обновление
+		{
+			name: "замена ингредиента",
+			recipeIngredient: &domain.RecipeIngredient{
+				ID:            stored.ID,
+				IngredientID:  uuid.UUID{9},
+				MeasurementID: stored.MeasurementID,
+				Amount:        1,
+				Version:       1,
+			},
+			beforeTest: func(recipeIngredientRepo mocks.MockIRecipeIngredientRepository) {
+				recipeIngredientRepo.EXPECT().
+					GetById(gomock.Any(), stored.ID).
+					Return(stored, nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("updating recipe ingredient: changing recipe or ingredient of link"),
+		}, // замена ингредиента
+		{
+			name: "устаревшая версия",
+			recipeIngredient: &domain.RecipeIngredient{
+				ID:            stored.ID,
+				IngredientID:  stored.IngredientID,
+				MeasurementID: stored.MeasurementID,
+				Amount:        1,
+				Version:       0,
+			},
+			beforeTest: func(recipeIngredientRepo mocks.MockIRecipeIngredientRepository) {
+				recipeIngredientRepo.EXPECT().
+					GetById(gomock.Any(), stored.ID).
+					Return(stored, nil)
+			},
+			wantErr: true,
+			errStr: fmt.Errorf("updating recipe ingredient: %w", &domain.VersionConflictError{
+				Entity: "recipe ingredient", ID: stored.ID, Version: 0}),
+		}, // устаревшая версия
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*recipeIngredientRepo)
+			}
+
+			err := svc.Update(context.Background(), tt.recipeIngredient)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestRecipeIngredientService_Remove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, recipeIngredientRepo := newRecipeIngredientService(ctrl)
+
+	recipeId := uuid.UUID{1}
+	removedId := uuid.UUID{11}
+	recipeIngredientRepo.EXPECT().
+		GetById(gomock.Any(), removedId).
+		Return(&domain.RecipeIngredient{ID: removedId, RecipeID: recipeId, Order: 1}, nil)
+	recipeIngredientRepo.EXPECT().
+		DeleteById(gomock.Any(), removedId).
+		Return(nil)
+	recipeIngredientRepo.EXPECT().
+		GetAllByRecipeId(gomock.Any(), recipeId).
+		Return([]*domain.RecipeIngredient{{ID: uuid.UUID{12}, RecipeID: recipeId, Order: 2}}, nil)
+	recipeIngredientRepo.EXPECT().
+		Update(gomock.Any(), &domain.RecipeIngredient{ID: uuid.UUID{12}, RecipeID: recipeId, Order: 1}).
+		Return(nil)
+
+	require.Nil(t, svc.Remove(context.Background(), removedId))
+}
+
+func TestRecipeIngredientService_Reorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, recipeIngredientRepo := newRecipeIngredientService(ctrl)
+
+	recipeId := uuid.UUID{1}
+	linked := func() []*domain.RecipeIngredient {
+		return []*domain.RecipeIngredient{
+			{ID: uuid.UUID{11}, RecipeID: recipeId, Order: 1},
+			{ID: uuid.UUID{12}, RecipeID: recipeId, Order: 2},
+		}
+	}
+
+	recipeIngredientRepo.EXPECT().
+		GetAllByRecipeId(gomock.Any(), recipeId).
+		Return(linked(), nil)
+	recipeIngredientRepo.EXPECT().
+		Update(gomock.Any(), &domain.RecipeIngredient{ID: uuid.UUID{12}, RecipeID: recipeId, Order: 1}).
+		Return(nil)
+	recipeIngredientRepo.EXPECT().
+		Update(gomock.Any(), &domain.RecipeIngredient{ID: uuid.UUID{11}, RecipeID: recipeId, Order: 2}).
+		Return(nil)
+	require.Nil(t, svc.Reorder(context.Background(), recipeId, []uuid.UUID{{12}, {11}}))
+
+	recipeIngredientRepo.EXPECT().
+		GetAllByRecipeId(gomock.Any(), recipeId).
+		Return(linked(), nil)
+	err := svc.Reorder(context.Background(), recipeId, []uuid.UUID{{12}, {13}})
+	require.ErrorIs(t, err, domain.ErrIngredientsChanged)
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected float64
+		wantErr  bool
+	}{
+		{text: "2", expected: 2},
+		{text: "0.5", expected: 0.5},
+		{text: "1/2", expected: 0.5},
+		{text: " 1 3/4 ", expected: 1.75},
+		{text: "", wantErr: true},
+		{text: "1/0", wantErr: true},
+		{text: "1.5 1/2", wantErr: true},
+		{text: "half", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			amount, err := domain.ParseAmount(tt.text)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tt.expected, amount)
+			}
+		})
+	}
+
+	require.Equal(t, "1/2", domain.FormatAmount(0.5))
+	require.Equal(t, "1 1/3", domain.FormatAmount(4.0/3))
+	require.Equal(t, "2", domain.FormatAmount(2))
+	require.Equal(t, "0.15", domain.FormatAmount(0.15))
+}
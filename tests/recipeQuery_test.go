@@ -0,0 +1,327 @@
+package tests
+
+import (
+	"errors"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var (
+	queryTomato  = &domain.Ingredient{ID: uuid.UUID{101}, Name: "tomato", Calories: 20}
+	queryCheese  = &domain.Ingredient{ID: uuid.UUID{102}, TypeID: uuid.UUID{111}, Name: "cheese", Calories: 350}
+	queryPeanut  = &domain.Ingredient{ID: uuid.UUID{103}, Name: "peanut", Calories: 560, Contents: []string{domain.ContentNuts}}
+	queryDairy   = &domain.IngredientType{ID: uuid.UUID{111}, Name: "dairy", Contents: []string{domain.ContentDairy}}
+	queryGram    = &domain.Measurement{ID: uuid.UUID{110}, Name: "gram", Grams: 1}
+	queryVegan   = uuid.UUID{120}
+	queryItalian = uuid.UUID{121}
+	// queryTaxonomy: Mediterranean -> Italian, Vegan
+	queryMediterranean = uuid.UUID{122}
+	queryTaxonomy      = []*domain.SaladType{
+		{ID: queryMediterranean, Name: "Mediterranean"},
+		{ID: queryItalian, ParentID: queryMediterranean, Name: "Italian"},
+		{ID: queryVegan, Name: "Vegan"},
+	}
+	queryAuthor   = uuid.UUID{130}
+	queryBaseTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+)
+
+func queryCandidate(id byte, name string, rating float32, timeToCook, servings int, createdDays int,
+	types []uuid.UUID, ingredients ...*domain.SaladIngredient) *domain.RecipeCandidate {
+	return &domain.RecipeCandidate{
+		Salad: &domain.Salad{ID: uuid.UUID{id, 1}, AuthorID: uuid.UUID{id, 2}, Name: name},
+		Recipe: &domain.Recipe{
+			ID:               uuid.UUID{id},
+			SaladID:          uuid.UUID{id, 1},
+			Status:           domain.PublishedSaladStatus,
+			NumberOfServings: servings,
+			TimeToCook:       timeToCook,
+			Rating:           rating,
+			CreatedAt:        queryBaseTime.AddDate(0, 0, createdDays),
+		},
+		Ingredients:     ingredients,
+		TypeIDs:         types,
+		IngredientTypes: []*domain.IngredientType{queryDairy},
+	}
+}
+
+func grams(ingredient *domain.Ingredient, amount float64) *domain.SaladIngredient {
+	return &domain.SaladIngredient{Ingredient: ingredient, Measurement: queryGram, Amount: amount}
+}
+
+// queryCandidates calories: caprese 400, tomatoes 60, satay 1120, draft 200.
+// Peanuts of satay are optional.
+func queryCandidates() []*domain.RecipeCandidate {
+	satay := queryCandidate(3, "satay", 3.5, 40, 4, 2, nil,
+		grams(queryTomato, 100), grams(queryPeanut, 196))
+	satay.Salad.AuthorID = queryAuthor
+	satay.Ingredients[1].Optional = true
+	draft := queryCandidate(4, "draft", 0, 5, 1, 3, nil, grams(queryTomato, 1000))
+	draft.Recipe.Status = domain.EditingSaladStatus
+
+	return []*domain.RecipeCandidate{
+		queryCandidate(1, "caprese", 4.5, 15, 2, 0,
+			domain.SaladTypeAncestors([]uuid.UUID{queryVegan, queryItalian}, queryTaxonomy),
+			grams(queryTomato, 200), grams(queryCheese, 103)),
+		queryCandidate(2, "tomatoes", 4, 10, 1, 1, []uuid.UUID{queryVegan},
+			grams(queryTomato, 300)),
+		satay,
+		draft,
+	}
+}
+
+func recipeIds(candidates []*domain.RecipeCandidate) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(candidates))
+	for _, candidate := range candidates {
+		ids = append(ids, candidate.Recipe.ID)
+	}
+	return ids
+}
+
+func TestQueryRecipes(t *testing.T) {
+	published := domain.PublishedSaladStatus
+
+	tests := []struct {
+		name     string
+		filter   *domain.RecipeFilter
+		page     *domain.PageRequest
+		expected []uuid.UUID
+		wantErr  bool
+		errStr   error
+	}{
+		{
+			name:     "без фильтра",
+			filter:   nil,
+			expected: []uuid.UUID{{1}, {2}, {3}, {4}},
+			wantErr:  false,
+		}, // без фильтра
+		{
+			name:     "по статусу и рейтингу",
+			filter:   &domain.RecipeFilter{Status: published, MinRate: 4},
+			expected: []uuid.UUID{{1}, {2}},
+			wantErr:  false,
+		}, // по статусу и рейтингу
+		{
+			name:     "по времени приготовления",
+			filter:   &domain.RecipeFilter{MaxTimeToCook: 15},
+			expected: []uuid.UUID{{1}, {2}, {4}},
+			wantErr:  false,
+		}, // по времени приготовления
+		{
+			name:     "по количеству порций",
+			filter:   &domain.RecipeFilter{MinServings: 2, MaxServings: 3},
+			expected: []uuid.UUID{{1}},
+			wantErr:  false,
+		}, // по количеству порций
+		{
+			name:     "по калорийности",
+			filter:   &domain.RecipeFilter{MinCalories: 100, MaxCalories: 500},
+			expected: []uuid.UUID{{1}, {4}},
+			wantErr:  false,
+		}, // по калорийности
+		{
+			name:     "исключенные ингредиенты",
+			filter:   &domain.RecipeFilter{ExcludedIngredients: []uuid.UUID{queryPeanut.ID, queryCheese.ID}},
+			expected: []uuid.UUID{{2}, {4}},
+			wantErr:  false,
+		}, // исключенные ингредиенты
+		{
+			name:     "доступные ингредиенты",
+			filter:   &domain.RecipeFilter{AvailableIngredients: []uuid.UUID{queryTomato.ID, queryCheese.ID}},
+			expected: []uuid.UUID{{1}, {2}, {3}, {4}},
+			wantErr:  false,
+		}, // доступные ингредиенты
+		{
+			name:     "необязательные ингредиенты не нужны в наличии",
+			filter:   &domain.RecipeFilter{AvailableIngredients: []uuid.UUID{queryTomato.ID}},
+			expected: []uuid.UUID{{2}, {3}, {4}},
+			wantErr:  false,
+		}, // необязательные ингредиенты не нужны в наличии
+		{
+			name:     "по типам салата",
+			filter:   &domain.RecipeFilter{SaladTypes: []uuid.UUID{queryVegan, queryItalian}},
+			expected: []uuid.UUID{{1}},
+			wantErr:  false,
+		}, // по типам салата
+		{
+			name:     "родительский тип включает подтипы",
+			filter:   &domain.RecipeFilter{SaladTypes: []uuid.UUID{queryMediterranean}},
+			expected: []uuid.UUID{{1}},
+			wantErr:  false,
+		}, // родительский тип включает подтипы
+		{
+			name:     "по диетам",
+			filter:   &domain.RecipeFilter{Diets: []string{domain.DietVegan, domain.DietNutFree}},
+			expected: []uuid.UUID{{2}, {4}},
+			wantErr:  false,
+		}, // по диетам
+		{
+			name:     "вегетарианские",
+			filter:   &domain.RecipeFilter{Diets: []string{domain.DietVegetarian}},
+			expected: []uuid.UUID{{1}, {2}, {3}, {4}},
+			wantErr:  false,
+		}, // вегетарианские
+		{
+			name:     "по автору",
+			filter:   &domain.RecipeFilter{AuthorID: queryAuthor},
+			expected: []uuid.UUID{{3}},
+			wantErr:  false,
+		}, // по автору
+		{
+			name:     "созданные после даты",
+			filter:   &domain.RecipeFilter{CreatedAfter: queryBaseTime.AddDate(0, 0, 1)},
+			expected: []uuid.UUID{{3}, {4}},
+			wantErr:  false,
+		}, // созданные после даты
+		{
+			name:     "сортировка по рейтингу",
+			filter:   &domain.RecipeFilter{Status: published},
+			page:     &domain.PageRequest{Sort: domain.SortByRating, Desc: true},
+			expected: []uuid.UUID{{1}, {2}, {3}},
+			wantErr:  false,
+		}, // сортировка по рейтингу
+		{
+			name:     "сначала новые",
+			page:     &domain.PageRequest{Sort: domain.SortByCreatedAt, Desc: true},
+			expected: []uuid.UUID{{4}, {3}, {2}, {1}},
+			wantErr:  false,
+		}, // сначала новые
+		{
+			name:     "сначала быстрые",
+			page:     &domain.PageRequest{Sort: domain.SortByTimeToCook},
+			expected: []uuid.UUID{{4}, {2}, {1}, {3}},
+			wantErr:  false,
+		}, // сначала быстрые
+		{
+			name:     "сортировка по калорийности",
+			page:     &domain.PageRequest{Sort: domain.SortByCalories},
+			expected: []uuid.UUID{{2}, {4}, {1}, {3}},
+			wantErr:  false,
+		}, // сортировка по калорийности
+		{
+			name:    "ингредиент доступен и исключен",
+			filter:  &domain.RecipeFilter{AvailableIngredients: []uuid.UUID{queryTomato.ID}, ExcludedIngredients: []uuid.UUID{queryTomato.ID}},
+			wantErr: true,
+			errStr:  errors.New("ingredient 65000000-0000-0000-0000-000000000000 is both available and excluded"),
+		}, // ингредиент доступен и исключен
+		{
+			name:    "некорректный диапазон калорийности",
+			filter:  &domain.RecipeFilter{MinCalories: 500, MaxCalories: 100},
+			wantErr: true,
+			errStr:  errors.New("min calories greater than max calories"),
+		}, // некорректный диапазон калорийности
+		{
+			name:    "отрицательное время приготовления",
+			filter:  &domain.RecipeFilter{MaxTimeToCook: -1},
+			wantErr: true,
+			errStr:  errors.New("negative max time to cook"),
+		}, // отрицательное время приготовления
+		{
+			name:    "неизвестная диета",
+			filter:  &domain.RecipeFilter{Diets: []string{"paleo"}},
+			wantErr: true,
+			errStr:  errors.New("unknown diet paleo"),
+		}, // неизвестная диета
+		{
+			name:    "неподдерживаемая сортировка",
+			page:    &domain.PageRequest{Sort: domain.SortByUsername},
+			wantErr: true,
+			errStr:  errors.New("unsupported sort username"),
+		}, // неподдерживаемая сортировка
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates, info, err := domain.QueryRecipes(queryCandidates(), tt.filter, tt.page)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tt.expected, recipeIds(candidates))
+				require.Equal(t, len(tt.expected), info.Total)
+			}
+		})
+	}
+}
+
+func TestQueryRecipes_Pages(t *testing.T) {
+	page := &domain.PageRequest{Size: 1, Sort: domain.SortByCalories, Desc: true}
+
+	var ids []uuid.UUID
+	for {
+		candidates, info, err := domain.QueryRecipes(queryCandidates(),
+			&domain.RecipeFilter{Status: domain.PublishedSaladStatus}, page)
+		require.Nil(t, err)
+		ids = append(ids, recipeIds(candidates)...)
+		if info.NextCursor == "" {
+			break
+		}
+		page.Cursor = info.NextCursor
+	}
+	require.Equal(t, []uuid.UUID{{3}, {1}, {2}}, ids)
+}
+
+func TestSaladTypeAncestors(t *testing.T) {
+	greek := uuid.UUID{123}
+	taxonomy := append([]*domain.SaladType{{ID: greek, ParentID: queryMediterranean}}, queryTaxonomy...)
+
+	ancestors := domain.SaladTypeAncestors([]uuid.UUID{queryItalian, greek, queryVegan}, taxonomy)
+	require.Equal(t, []uuid.UUID{queryItalian, queryMediterranean, greek, queryVegan}, ancestors)
+}
+
+func TestCountCalories(t *testing.T) {
+	cup := &domain.Measurement{ID: uuid.UUID{112}, Name: "cup", Unit: domain.UnitCup, Grams: 240}
+	piece := &domain.Measurement{ID: uuid.UUID{113}, Name: "piece", Unit: domain.UnitPiece, Grams: 100}
+	oil := &domain.Ingredient{ID: uuid.UUID{104}, Name: "oil", Calories: 900, Density: 0.92}
+	lettuce := &domain.Ingredient{ID: uuid.UUID{105}, Name: "lettuce", Calories: 15, Density: 0.2}
+
+	tests := []struct {
+		name        string
+		ingredients []*domain.SaladIngredient
+		want        int
+	}{
+		{
+			name:        "объём с плотностью",
+			ingredients: []*domain.SaladIngredient{{Ingredient: oil, Measurement: cup, Amount: 1}},
+			want:        1959,
+		}, // объём с плотностью
+		{
+			name:        "объём другой плотности",
+			ingredients: []*domain.SaladIngredient{{Ingredient: lettuce, Measurement: cup, Amount: 1}},
+			want:        7,
+		}, // объём другой плотности
+		{
+			name:        "объём без плотности",
+			ingredients: []*domain.SaladIngredient{{Ingredient: queryTomato, Measurement: cup, Amount: 1}},
+			want:        48,
+		}, // объём без плотности
+		{
+			name:        "штуки",
+			ingredients: []*domain.SaladIngredient{{Ingredient: queryTomato, Measurement: piece, Amount: 2}},
+			want:        40,
+		}, // штуки
+		{
+			name:        "без меры",
+			ingredients: []*domain.SaladIngredient{{Ingredient: queryTomato, Amount: 2}},
+			want:        0,
+		}, // без меры
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, domain.CountCalories(tt.ingredients))
+		})
+	}
+}
+
+func TestRecipeCandidate_Diets(t *testing.T) {
+	meat := &domain.IngredientType{ID: uuid.UUID{114}, Name: "meat", Contents: []string{domain.ContentMeat}}
+	poultry := &domain.IngredientType{ID: uuid.UUID{115}, ParentID: meat.ID, Name: "poultry"}
+	chicken := &domain.Ingredient{ID: uuid.UUID{106}, TypeID: poultry.ID, Name: "chicken"}
+
+	candidate := queryCandidate(5, "chicken", 4, 20, 2, 0, nil, grams(chicken, 200), grams(queryTomato, 100))
+	candidate.IngredientTypes = []*domain.IngredientType{poultry, meat}
+
+	require.Equal(t, []string{domain.DietGlutenFree, domain.DietLactoseFree, domain.DietNutFree}, candidate.Diets())
+	require.False(t, (&domain.RecipeFilter{Diets: []string{domain.DietVegetarian}}).Match(candidate))
+}
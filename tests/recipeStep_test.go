@@ -31,7 +31,7 @@ func TestRecipeStepService_Create(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewRecipeStepService(recipeStepRepo, logger)
+	svc := services.NewRecipeStepService(recipeStepRepo, newTxManagerMock(ctrl), logger)
 
 	stepId := uuid.New()
 	recipeId := uuid.New()
@@ -54,7 +54,10 @@ func TestRecipeStepService_Create(t *testing.T) {
 			},
 			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
 				recipeStepRepo.EXPECT().
-					Create(context.Background(), &domain.RecipeStep{
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return(nil, nil)
+				recipeStepRepo.EXPECT().
+					Create(gomock.Any(), &domain.RecipeStep{
 						ID:          stepId,
 						RecipeID:    recipeId,
 						Name:        "first recipe step",
@@ -76,7 +79,18 @@ func TestRecipeStepService_Create(t *testing.T) {
 			},
 			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
 				recipeStepRepo.EXPECT().
-					Create(context.Background(), &domain.RecipeStep{
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return([]*domain.RecipeStep{
+						{
+							ID:          uuid.UUID{1},
+							RecipeID:    recipeId,
+							Name:        "first step",
+							Description: "description",
+							StepNum:     1,
+						},
+					}, nil)
+				recipeStepRepo.EXPECT().
+					Create(gomock.Any(), &domain.RecipeStep{
 						ID:          stepId,
 						RecipeID:    recipeId,
 						Name:        "second recipe step",
@@ -87,6 +101,43 @@ func TestRecipeStepService_Create(t *testing.T) {
 			},
 			wantErr: false,
 		}, // успешное создание второго шага
+		{
+			name: "вставка шага в середину со сдвигом",
+			recipeStep: &domain.RecipeStep{
+				ID:          stepId,
+				RecipeID:    recipeId,
+				Name:        "inserted step",
+				Description: "description",
+				StepNum:     2,
+			},
+			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return([]*domain.RecipeStep{
+						{ID: uuid.UUID{2}, RecipeID: recipeId, Name: "second", Description: "d", StepNum: 2},
+						{ID: uuid.UUID{1}, RecipeID: recipeId, Name: "first", Description: "d", StepNum: 1},
+					}, nil)
+				recipeStepRepo.EXPECT().
+					Update(gomock.Any(), &domain.RecipeStep{
+						ID:          uuid.UUID{2},
+						RecipeID:    recipeId,
+						Name:        "second",
+						Description: "d",
+						StepNum:     3,
+					}).
+					Return(nil)
+				recipeStepRepo.EXPECT().
+					Create(gomock.Any(), &domain.RecipeStep{
+						ID:          stepId,
+						RecipeID:    recipeId,
+						Name:        "inserted step",
+						Description: "description",
+						StepNum:     2,
+					}).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // вставка шага в середину со сдвигом
 		{
 			name: "пустое имя шага",
 			recipeStep: &domain.RecipeStep{
@@ -135,31 +186,31 @@ func TestRecipeStepService_Create(t *testing.T) {
 			wantErr: true,
 			errStr:  errors.New("creating recipe step: negative or zero step num"),
 		}, // невалидный номер шага - отрицательный
-		//{
-		//	name: "невалидный номер шага - уже существует",
-		//	recipeStep: &domain.RecipeStep{
-		//		ID:          stepId,
-		//		RecipeID:    recipeId,
-		//		Name:        "first recipe step",
-		//		Description: "description",
-		//		StepNum:     1,
-		//	},
-		//	beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
-		//		recipeStepRepo.EXPECT().
-		//			GetAllByRecipeID(context.Background(), recipeId).
-		//			Return([]*domain.RecipeStep{
-		//				{
-		//					ID:          uuid.UUID{1},
-		//					RecipeID:    recipeId,
-		//					Name:        "first step",
-		//					Description: "",
-		//					StepNum:     1,
-		//				},
-		//			}, nil)
-		//	},
-		//	wantErr: true,
-		//	errStr:  errors.New("creating recipe step: invalid step num"),
-		//}, // невалидный номер шага - уже существует
+		{
+			name: "невалидный номер шага - после конца",
+			recipeStep: &domain.RecipeStep{
+				ID:          stepId,
+				RecipeID:    recipeId,
+				Name:        "third recipe step",
+				Description: "description",
+				StepNum:     3,
+			},
+			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return([]*domain.RecipeStep{
+						{
+							ID:          uuid.UUID{1},
+							RecipeID:    recipeId,
+							Name:        "first step",
+							Description: "",
+							StepNum:     1,
+						},
+					}, nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("creating recipe step: invalid step num"),
+		}, // невалидный номер шага - после конца
 		{
 			name: "ошибка выполнения запроса в репозитории (create)",
 			recipeStep: &domain.RecipeStep{
@@ -171,7 +222,10 @@ func TestRecipeStepService_Create(t *testing.T) {
 			},
 			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
 				recipeStepRepo.EXPECT().
-					Create(context.Background(), &domain.RecipeStep{
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return(nil, nil)
+				recipeStepRepo.EXPECT().
+					Create(gomock.Any(), &domain.RecipeStep{
 						ID:          stepId,
 						RecipeID:    recipeId,
 						Name:        "first recipe step",
@@ -183,23 +237,23 @@ func TestRecipeStepService_Create(t *testing.T) {
 			wantErr: true,
 			errStr:  errors.New("creating recipe step: creating step err"),
 		}, // ошибка выполнения запроса в репозитории (create)
-		//{
-		//	name: "ошибка выполнения запроса в репозитории (get all by recipe id)",
-		//	recipeStep: &domain.RecipeStep{
-		//		ID:          stepId,
-		//		RecipeID:    recipeId,
-		//		Name:        "first recipe step",
-		//		Description: "description",
-		//		StepNum:     1,
-		//	},
-		//	beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
-		//		recipeStepRepo.EXPECT().
-		//			GetAllByRecipeID(context.Background(), recipeId).
-		//			Return(nil, fmt.Errorf("getting steps err"))
-		//	},
-		//	wantErr: true,
-		//	errStr:  errors.New("creating recipe step: getting steps err"),
-		//}, // ошибка выполнения запроса в репозитории (get all by recipe id)
+		{
+			name: "ошибка выполнения запроса в репозитории (get all by recipe id)",
+			recipeStep: &domain.RecipeStep{
+				ID:          stepId,
+				RecipeID:    recipeId,
+				Name:        "first recipe step",
+				Description: "description",
+				StepNum:     1,
+			},
+			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return(nil, fmt.Errorf("getting steps err"))
+			},
+			wantErr: true,
+			errStr:  errors.New("creating recipe step: getting steps err"),
+		}, // ошибка выполнения запроса в репозитории (get all by recipe id)
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -235,7 +289,7 @@ func TestRecipeStepService_DeleteAllByRecipeID(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewRecipeStepService(recipeStepRepo, logger)
+	svc := services.NewRecipeStepService(recipeStepRepo, newTxManagerMock(ctrl), logger)
 
 	recipeId := uuid.New()
 
@@ -302,7 +356,9 @@ func TestRecipeStepService_DeleteById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewRecipeStepService(recipeStepRepo, logger)
+	svc := services.NewRecipeStepService(recipeStepRepo, newTxManagerMock(ctrl), logger)
+
+	recipeId := uuid.UUID{10}
 
 	tests := []struct {
 		name       string
@@ -316,8 +372,14 @@ func TestRecipeStepService_DeleteById(t *testing.T) {
 			stepId: uuid.UUID{1},
 			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
 				recipeStepRepo.EXPECT().
-					DeleteById(context.Background(), uuid.UUID{1}).
+					GetById(gomock.Any(), uuid.UUID{1}).
+					Return(&domain.RecipeStep{ID: uuid.UUID{1}, RecipeID: recipeId, StepNum: 1}, nil)
+				recipeStepRepo.EXPECT().
+					DeleteById(gomock.Any(), uuid.UUID{1}).
 					Return(nil)
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return(nil, nil)
 			},
 		}, // успешное удаление единственного шага
 		{
@@ -325,25 +387,63 @@ func TestRecipeStepService_DeleteById(t *testing.T) {
 			stepId: uuid.UUID{3},
 			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
 				recipeStepRepo.EXPECT().
-					DeleteById(context.Background(), uuid.UUID{3}).
+					GetById(gomock.Any(), uuid.UUID{3}).
+					Return(&domain.RecipeStep{ID: uuid.UUID{3}, RecipeID: recipeId, StepNum: 3}, nil)
+				recipeStepRepo.EXPECT().
+					DeleteById(gomock.Any(), uuid.UUID{3}).
 					Return(nil)
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return([]*domain.RecipeStep{
+						{ID: uuid.UUID{1}, RecipeID: recipeId, StepNum: 1},
+						{ID: uuid.UUID{2}, RecipeID: recipeId, StepNum: 2},
+					}, nil)
 			},
 		}, // успешное удаление последнего шага
 		{
-			name:   "успешное удаление первого шага",
+			name:   "успешное удаление первого шага со сдвигом",
 			stepId: uuid.UUID{1},
 			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
 				recipeStepRepo.EXPECT().
-					DeleteById(context.Background(), uuid.UUID{1}).
+					GetById(gomock.Any(), uuid.UUID{1}).
+					Return(&domain.RecipeStep{ID: uuid.UUID{1}, RecipeID: recipeId, StepNum: 1}, nil)
+				recipeStepRepo.EXPECT().
+					DeleteById(gomock.Any(), uuid.UUID{1}).
+					Return(nil)
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return([]*domain.RecipeStep{
+						{ID: uuid.UUID{3}, RecipeID: recipeId, StepNum: 3},
+						{ID: uuid.UUID{2}, RecipeID: recipeId, StepNum: 2},
+					}, nil)
+				recipeStepRepo.EXPECT().
+					Update(gomock.Any(), &domain.RecipeStep{ID: uuid.UUID{2}, RecipeID: recipeId, StepNum: 1}).
+					Return(nil)
+				recipeStepRepo.EXPECT().
+					Update(gomock.Any(), &domain.RecipeStep{ID: uuid.UUID{3}, RecipeID: recipeId, StepNum: 2}).
 					Return(nil)
 			},
-		}, // успешное удаление первого шага
+		}, // успешное удаление первого шага со сдвигом
+		{
+			name:   "шаг не найден",
+			stepId: uuid.UUID{1},
+			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
+				recipeStepRepo.EXPECT().
+					GetById(gomock.Any(), uuid.UUID{1}).
+					Return(nil, fmt.Errorf("getting step err"))
+			},
+			wantErr: true,
+			errStr:  errors.New("deleting step by id: getting step err"),
+		}, // шаг не найден
 		{
 			name:   "ошибка удаления шага в репозитории",
 			stepId: uuid.UUID{1},
 			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
 				recipeStepRepo.EXPECT().
-					DeleteById(context.Background(), uuid.UUID{1}).
+					GetById(gomock.Any(), uuid.UUID{1}).
+					Return(&domain.RecipeStep{ID: uuid.UUID{1}, RecipeID: recipeId, StepNum: 1}, nil)
+				recipeStepRepo.EXPECT().
+					DeleteById(gomock.Any(), uuid.UUID{1}).
 					Return(fmt.Errorf("deleting step err"))
 			},
 			wantErr: true,
@@ -385,7 +485,7 @@ func TestRecipeStepService_GetAllByRecipeID(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewRecipeStepService(recipeStepRepo, logger)
+	svc := services.NewRecipeStepService(recipeStepRepo, newTxManagerMock(ctrl), logger)
 
 	recipeId := uuid.New()
 
@@ -500,7 +600,7 @@ func TestRecipeStepService_GetById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewRecipeStepService(recipeStepRepo, logger)
+	svc := services.NewRecipeStepService(recipeStepRepo, newTxManagerMock(ctrl), logger)
 
 	stepId := uuid.New()
 
@@ -583,7 +683,7 @@ func TestRecipeStepService_Update(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewRecipeStepService(recipeStepRepo, logger)
+	svc := services.NewRecipeStepService(recipeStepRepo, newTxManagerMock(ctrl), logger)
 
 	stepId := uuid.UUID{1}
 	recipeId := uuid.UUID{0}
@@ -606,7 +706,10 @@ func TestRecipeStepService_Update(t *testing.T) {
 			},
 			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
 				recipeStepRepo.EXPECT().
-					Update(context.Background(), &domain.RecipeStep{
+					GetById(gomock.Any(), stepId).
+					Return(&domain.RecipeStep{ID: stepId, RecipeID: recipeId, StepNum: 1}, nil)
+				recipeStepRepo.EXPECT().
+					Update(gomock.Any(), &domain.RecipeStep{
 						ID:          stepId,
 						RecipeID:    recipeId,
 						Name:        "updated first step",
@@ -627,11 +730,93 @@ func TestRecipeStepService_Update(t *testing.T) {
 			},
 			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
 				recipeStepRepo.EXPECT().
-					Update(context.Background(), gomock.Any()).
-					Return(nil)
+					GetById(gomock.Any(), stepId).
+					Return(&domain.RecipeStep{ID: stepId, RecipeID: recipeId, StepNum: 1}, nil)
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return([]*domain.RecipeStep{
+						{ID: stepId, RecipeID: recipeId, StepNum: 1},
+						{ID: uuid.UUID{2}, RecipeID: recipeId, StepNum: 2},
+					}, nil)
+				gomock.InOrder(
+					recipeStepRepo.EXPECT().
+						Update(gomock.Any(), &domain.RecipeStep{ID: stepId, RecipeID: recipeId, StepNum: -1}).
+						Return(nil),
+					recipeStepRepo.EXPECT().
+						Update(gomock.Any(), &domain.RecipeStep{ID: uuid.UUID{2}, RecipeID: recipeId, StepNum: 1}).
+						Return(nil),
+					recipeStepRepo.EXPECT().
+						Update(gomock.Any(), &domain.RecipeStep{
+							ID:          stepId,
+							RecipeID:    recipeId,
+							Name:        "updated first step",
+							Description: "description",
+							StepNum:     2,
+						}).
+						Return(nil),
+				)
 			},
 			wantErr: false,
 		}, // успешное обновление шага (с изменением номера)
+		{
+			name: "невалидный номер шага - после конца",
+			step: &domain.RecipeStep{
+				ID:          stepId,
+				RecipeID:    recipeId,
+				Name:        "updated first step",
+				Description: "description",
+				StepNum:     3,
+			},
+			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
+				recipeStepRepo.EXPECT().
+					GetById(gomock.Any(), stepId).
+					Return(&domain.RecipeStep{ID: stepId, RecipeID: recipeId, StepNum: 1}, nil)
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return([]*domain.RecipeStep{
+						{ID: stepId, RecipeID: recipeId, StepNum: 1},
+						{ID: uuid.UUID{2}, RecipeID: recipeId, StepNum: 2},
+					}, nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("updating recipe step: invalid step num"),
+		}, // невалидный номер шага - после конца
+		{
+			name: "перенос шага в другой рецепт",
+			step: &domain.RecipeStep{
+				ID:          stepId,
+				RecipeID:    uuid.UUID{9},
+				Name:        "updated first step",
+				Description: "description",
+				StepNum:     1,
+			},
+			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
+				recipeStepRepo.EXPECT().
+					GetById(gomock.Any(), stepId).
+					Return(&domain.RecipeStep{ID: stepId, RecipeID: recipeId, StepNum: 1}, nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("updating recipe step: moving step to another recipe"),
+		}, // перенос шага в другой рецепт
+		{
+			name: "устаревшая версия шага",
+			step: &domain.RecipeStep{
+				ID:          stepId,
+				RecipeID:    recipeId,
+				Name:        "updated first step",
+				Description: "description",
+				StepNum:     1,
+				Version:     1,
+			},
+			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
+				recipeStepRepo.EXPECT().
+					GetById(gomock.Any(), stepId).
+					Return(&domain.RecipeStep{ID: stepId, RecipeID: recipeId, StepNum: 1, Version: 2}, nil)
+			},
+			wantErr: true,
+			errStr: errors.New("updating recipe step: recipe step 01000000-0000-0000-0000-000000000000: " +
+				"version 1 is stale"),
+		}, // устаревшая версия шага
 		{
 			name: "пустое имя шага",
 			step: &domain.RecipeStep{
@@ -691,7 +876,10 @@ func TestRecipeStepService_Update(t *testing.T) {
 			},
 			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
 				recipeStepRepo.EXPECT().
-					Update(context.Background(), &domain.RecipeStep{
+					GetById(gomock.Any(), stepId).
+					Return(&domain.RecipeStep{ID: stepId, RecipeID: recipeId, StepNum: 2}, nil)
+				recipeStepRepo.EXPECT().
+					Update(gomock.Any(), &domain.RecipeStep{
 						ID:          stepId,
 						RecipeID:    recipeId,
 						Name:        "updated first step",
@@ -719,3 +907,108 @@ func TestRecipeStepService_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestRecipeStepService_Reorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recipeStepRepo := mocks.NewMockIRecipeStepRepository(ctrl)
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	svc := services.NewRecipeStepService(recipeStepRepo, newTxManagerMock(ctrl), logger)
+
+	recipeId := uuid.UUID{10}
+	currentSteps := func() []*domain.RecipeStep {
+		return []*domain.RecipeStep{
+			{ID: uuid.UUID{1}, RecipeID: recipeId, StepNum: 1},
+			{ID: uuid.UUID{2}, RecipeID: recipeId, StepNum: 2},
+			{ID: uuid.UUID{3}, RecipeID: recipeId, StepNum: 3},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		stepIds    []uuid.UUID
+		beforeTest func(recipeStepRepo mocks.MockIRecipeStepRepository)
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:    "успешное изменение порядка",
+			stepIds: []uuid.UUID{{3}, {1}, {2}},
+			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return(currentSteps(), nil)
+				gomock.InOrder(
+					recipeStepRepo.EXPECT().
+						Update(gomock.Any(), &domain.RecipeStep{ID: uuid.UUID{3}, RecipeID: recipeId, StepNum: -3}).
+						Return(nil),
+					recipeStepRepo.EXPECT().
+						Update(gomock.Any(), &domain.RecipeStep{ID: uuid.UUID{2}, RecipeID: recipeId, StepNum: 3}).
+						Return(nil),
+					recipeStepRepo.EXPECT().
+						Update(gomock.Any(), &domain.RecipeStep{ID: uuid.UUID{1}, RecipeID: recipeId, StepNum: 2}).
+						Return(nil),
+					recipeStepRepo.EXPECT().
+						Update(gomock.Any(), &domain.RecipeStep{ID: uuid.UUID{3}, RecipeID: recipeId, StepNum: 1}).
+						Return(nil),
+				)
+			},
+			wantErr: false,
+		}, // успешное изменение порядка
+		{
+			name:    "шаги изменились (не хватает шага)",
+			stepIds: []uuid.UUID{{2}, {1}},
+			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return(currentSteps(), nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("reordering steps of recipe: recipe steps were changed concurrently"),
+		}, // шаги изменились (не хватает шага)
+		{
+			name:    "шаги изменились (неизвестный шаг)",
+			stepIds: []uuid.UUID{{2}, {1}, {4}},
+			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return(currentSteps(), nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("reordering steps of recipe: recipe steps were changed concurrently"),
+		}, // шаги изменились (неизвестный шаг)
+		{
+			name:    "повторяющийся шаг",
+			stepIds: []uuid.UUID{{1}, {1}, {2}},
+			beforeTest: func(recipeStepRepo mocks.MockIRecipeStepRepository) {
+				recipeStepRepo.EXPECT().
+					GetAllByRecipeID(gomock.Any(), recipeId).
+					Return(currentSteps(), nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("reordering steps of recipe: recipe steps were changed concurrently"),
+		}, // повторяющийся шаг
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*recipeStepRepo)
+			}
+
+			err := svc.Reorder(context.Background(), recipeId, tt.stepIds)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+				require.ErrorIs(t, err, domain.ErrStepsChanged)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/Mx1q/ppo_services/config"
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/services"
 	"github.com/Mx1q/ppo_services/tests/mocks"
@@ -31,7 +32,7 @@ func TestRecipeService_Create(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewRecipeService(recipeRepo, logger)
+	svc := services.NewRecipeService(recipeRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	recipeId := uuid.New()
 	saladId := uuid.New()
@@ -171,7 +172,7 @@ func TestRecipeService_DeleteById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewRecipeService(recipeRepo, logger)
+	svc := services.NewRecipeService(recipeRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	recipeId := uuid.New()
 
@@ -238,19 +239,19 @@ func TestRecipeService_GetAll(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewRecipeService(recipeRepo, logger)
+	svc := services.NewRecipeService(recipeRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	filter := &domain.RecipeFilter{
 		AvailableIngredients: nil,
 		MinRate:              0,
 		SaladTypes:           nil,
 	}
-	page := 1
+	page := &domain.PageRequest{Size: 10}
 
 	tests := []struct {
 		name       string
 		filter     *domain.RecipeFilter
-		page       int
+		page       *domain.PageRequest
 		beforeTest func(recipeRepo mocks.MockIRecipeRepository)
 		expected   []*domain.Recipe
 		wantErr    bool
@@ -292,7 +293,7 @@ func TestRecipeService_GetAll(t *testing.T) {
 							NumberOfServings: 4,
 							TimeToCook:       4,
 						},
-					}, nil)
+					}, &domain.PageInfo{Size: 10, Total: 4}, nil)
 			},
 			expected: []*domain.Recipe{
 				{
@@ -333,11 +334,24 @@ func TestRecipeService_GetAll(t *testing.T) {
 			beforeTest: func(recipeRepo mocks.MockIRecipeRepository) {
 				recipeRepo.EXPECT().
 					GetAll(context.Background(), filter, page).
-					Return(nil, fmt.Errorf("getting recipes err"))
+					Return(nil, nil, fmt.Errorf("getting recipes err"))
 			},
 			wantErr: true,
 			errStr:  errors.New("getting all recipes: getting recipes err"),
 		}, // ошибка выполнения запроса в репозитории
+		{
+			name:    "неподдерживаемая сортировка",
+			page:    &domain.PageRequest{Sort: "username"},
+			wantErr: true,
+			errStr:  errors.New("getting all recipes: unsupported sort username"),
+		}, // неподдерживаемая сортировка
+		{
+			name:    "некорректный фильтр",
+			filter:  &domain.RecipeFilter{MinServings: 4, MaxServings: 2},
+			page:    page,
+			wantErr: true,
+			errStr:  errors.New("getting all recipes: min servings greater than max servings"),
+		}, // некорректный фильтр
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -345,7 +359,7 @@ func TestRecipeService_GetAll(t *testing.T) {
 				tt.beforeTest(*recipeRepo)
 			}
 
-			recipes, err := svc.GetAll(context.Background(), tt.filter, tt.page)
+			recipes, _, err := svc.GetAll(context.Background(), tt.filter, tt.page)
 			if tt.wantErr {
 				require.Equal(t, tt.errStr.Error(), err.Error())
 			} else {
@@ -374,7 +388,7 @@ func TestRecipeService_GetById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewRecipeService(recipeRepo, logger)
+	svc := services.NewRecipeService(recipeRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	recipeId := uuid.New()
 
@@ -456,7 +470,7 @@ func TestRecipeService_GetBySaladId(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewRecipeService(recipeRepo, logger)
+	svc := services.NewRecipeService(recipeRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	saladId := uuid.New()
 
@@ -538,7 +552,7 @@ func TestRecipeService_Update(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewRecipeService(recipeRepo, logger)
+	svc := services.NewRecipeService(recipeRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	recipeId := uuid.New()
 	saladId := uuid.New()
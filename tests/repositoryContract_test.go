@@ -0,0 +1,480 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/app"
+	"github.com/Mx1q/ppo_services/config"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/logger"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+// repositoryBackends opens every backend keeping the repositories, the contract
+// tests run the same cases over each of them
+func repositoryBackends(t *testing.T) []struct {
+	name  string
+	repos *app.Repositories
+} {
+	open := func(storage config.StorageConfig) *app.Repositories {
+		repos, err := app.OpenBackend(storage)
+		require.NoError(t, err)
+		if repos.Close != nil {
+			t.Cleanup(func() { require.NoError(t, repos.Close(false)) })
+		}
+		return repos
+	}
+
+	return []struct {
+		name  string
+		repos *app.Repositories
+	}{
+		{
+			name:  "в памяти",
+			repos: open(config.StorageConfig{Backend: app.BackendMemory}),
+		}, // в памяти
+		{
+			name:  "SQLite",
+			repos: open(config.StorageConfig{Backend: app.BackendSQLite, DSN: filepath.Join(t.TempDir(), "salads.db")}),
+		}, // SQLite
+	}
+}
+
+// createSaladWithRecipe creates a salad of the author with a recipe of the status and rating
+func createSaladWithRecipe(t *testing.T, repos *app.Repositories, authorId uuid.UUID, name string, status int, rating float32) (*domain.Salad, *domain.Recipe) {
+	ctx := context.Background()
+	salad := &domain.Salad{AuthorID: authorId, Name: name}
+	_, err := repos.Salads.Create(ctx, salad)
+	require.NoError(t, err)
+
+	recipe := &domain.Recipe{SaladID: salad.ID, Status: status, NumberOfServings: 2, TimeToCook: 20, Rating: rating}
+	_, err = repos.Recipes.Create(ctx, recipe)
+	require.NoError(t, err)
+	return salad, recipe
+}
+
+func TestRepositoryContract_Versions(t *testing.T) {
+	for _, tt := range repositoryBackends(t) {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			salad := &domain.Salad{AuthorID: uuid.New(), Name: "caesar"}
+			id, err := tt.repos.Salads.Create(ctx, salad)
+			require.NoError(t, err)
+			require.Equal(t, 1, salad.Version)
+
+			stale := *salad
+			salad.Description = "classic"
+			require.NoError(t, tt.repos.Salads.Update(ctx, salad))
+			require.Equal(t, 2, salad.Version)
+
+			err = tt.repos.Salads.Update(ctx, &stale)
+			var conflict *domain.VersionConflictError
+			require.True(t, errors.As(err, &conflict))
+			require.Equal(t, id, conflict.ID)
+
+			stored, err := tt.repos.Salads.GetById(ctx, id)
+			require.NoError(t, err)
+			require.Equal(t, salad, stored)
+
+			missing := uuid.New()
+			_, err = tt.repos.Salads.GetById(ctx, missing)
+			require.EqualError(t, err, fmt.Sprintf("salad %s not found", missing))
+			err = tt.repos.Salads.Update(ctx, &domain.Salad{ID: missing, Version: 1})
+			require.EqualError(t, err, fmt.Sprintf("salad %s not found", missing))
+			err = tt.repos.Salads.DeleteById(ctx, missing)
+			require.EqualError(t, err, fmt.Sprintf("salad %s not found", missing))
+		})
+	}
+}
+
+func TestRepositoryContract_SaladDelete(t *testing.T) {
+	for _, tt := range repositoryBackends(t) {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			salad, recipe := createSaladWithRecipe(t, tt.repos, uuid.New(), "greek", domain.PublishedSaladStatus, 4)
+
+			_, err := tt.repos.Recipes.Create(ctx, &domain.Recipe{SaladID: salad.ID})
+			require.EqualError(t, err, fmt.Sprintf("salad %s already has a recipe", salad.ID))
+
+			require.NoError(t, tt.repos.RecipeSteps.Create(ctx, &domain.RecipeStep{RecipeID: recipe.ID, Name: "cut", StepNum: 1}))
+			require.NoError(t, tt.repos.Comments.Create(ctx, &domain.Comment{AuthorID: uuid.New(), SaladID: salad.ID, Rating: 4}))
+
+			require.NoError(t, tt.repos.Salads.DeleteById(ctx, salad.ID))
+
+			_, err = tt.repos.Recipes.GetById(ctx, recipe.ID)
+			require.EqualError(t, err, fmt.Sprintf("recipe %s not found", recipe.ID))
+			steps, err := tt.repos.RecipeSteps.GetAllByRecipeID(ctx, recipe.ID)
+			require.NoError(t, err)
+			require.Empty(t, steps)
+			comments, _, err := tt.repos.Comments.GetAllBySaladID(ctx, salad.ID, nil)
+			require.NoError(t, err)
+			require.Empty(t, comments)
+		})
+	}
+}
+
+func TestRepositoryContract_RecipeQuery(t *testing.T) {
+	for _, tt := range repositoryBackends(t) {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			authorId := uuid.New()
+			_, best := createSaladWithRecipe(t, tt.repos, authorId, "caesar", domain.PublishedSaladStatus, 5)
+			_, good := createSaladWithRecipe(t, tt.repos, uuid.New(), "greek", domain.PublishedSaladStatus, 4)
+			_, poor := createSaladWithRecipe(t, tt.repos, authorId, "olivier", domain.PublishedSaladStatus, 2)
+			createSaladWithRecipe(t, tt.repos, authorId, "draft", domain.EditingSaladStatus, 5)
+
+			gram := &domain.Measurement{Name: "gram", Unit: domain.UnitGram, Grams: 1}
+			require.NoError(t, tt.repos.Measurements.Create(ctx, gram))
+			lettuce := &domain.Ingredient{Name: "lettuce"}
+			anchovy := &domain.Ingredient{Name: "anchovy"}
+			olives := &domain.Ingredient{Name: "olives"}
+			for _, ingredient := range []*domain.Ingredient{lettuce, anchovy, olives} {
+				require.NoError(t, tt.repos.Ingredients.Create(ctx, ingredient))
+			}
+			for _, link := range []*domain.RecipeIngredient{
+				{RecipeID: best.ID, IngredientID: lettuce.ID, MeasurementID: gram.ID, Amount: 100, Order: 1},
+				{RecipeID: best.ID, IngredientID: anchovy.ID, MeasurementID: gram.ID, Amount: 20, Optional: true, Order: 2},
+				{RecipeID: good.ID, IngredientID: lettuce.ID, MeasurementID: gram.ID, Amount: 100, Order: 1},
+				{RecipeID: good.ID, IngredientID: olives.ID, MeasurementID: gram.ID, Amount: 50, Order: 2},
+			} {
+				_, err := tt.repos.RecipeIngredients.Create(ctx, link)
+				require.NoError(t, err)
+			}
+
+			tests := []struct {
+				name   string
+				filter *domain.RecipeFilter
+				want   []uuid.UUID
+			}{
+				{
+					name:   "по статусу",
+					filter: &domain.RecipeFilter{Status: domain.PublishedSaladStatus},
+					want:   []uuid.UUID{best.ID, good.ID, poor.ID},
+				}, // по статусу
+				{
+					name:   "по рейтингу",
+					filter: &domain.RecipeFilter{Status: domain.PublishedSaladStatus, MinRate: 4},
+					want:   []uuid.UUID{best.ID, good.ID},
+				}, // по рейтингу
+				{
+					name:   "по автору",
+					filter: &domain.RecipeFilter{Status: domain.PublishedSaladStatus, AuthorID: authorId},
+					want:   []uuid.UUID{best.ID, poor.ID},
+				}, // по автору
+				{
+					name:   "необязательные ингредиенты не нужны в наличии",
+					filter: &domain.RecipeFilter{Status: domain.PublishedSaladStatus, AvailableIngredients: []uuid.UUID{lettuce.ID}},
+					want:   []uuid.UUID{best.ID, poor.ID},
+				}, // необязательные ингредиенты не нужны в наличии
+			}
+
+			for _, query := range tests {
+				t.Run(query.name, func(t *testing.T) {
+					page := &domain.PageRequest{Size: 2, Sort: domain.SortByRating, Desc: true}
+					ids := make([]uuid.UUID, 0)
+					total := 0
+					for {
+						recipes, info, err := tt.repos.Recipes.GetAll(ctx, query.filter, page)
+						require.NoError(t, err)
+						for _, recipe := range recipes {
+							ids = append(ids, recipe.ID)
+						}
+						total = info.Total
+						if info.NextCursor == "" {
+							break
+						}
+						page.Cursor = info.NextCursor
+					}
+					require.Equal(t, query.want, ids)
+					require.Equal(t, len(query.want), total)
+				})
+			}
+		})
+	}
+}
+
+func TestRepositoryContract_SaladPaging(t *testing.T) {
+	for _, tt := range repositoryBackends(t) {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			createSaladWithRecipe(t, tt.repos, uuid.New(), "caesar", domain.PublishedSaladStatus, 4)
+			createSaladWithRecipe(t, tt.repos, uuid.New(), "caesar", domain.PublishedSaladStatus, 4.5)
+			createSaladWithRecipe(t, tt.repos, uuid.New(), "greek", domain.PublishedSaladStatus, 4)
+			createSaladWithRecipe(t, tt.repos, uuid.New(), "olivier", domain.EditingSaladStatus, 2)
+			_, err := tt.repos.Salads.Create(ctx, &domain.Salad{AuthorID: uuid.New(), Name: "mimosa"})
+			require.NoError(t, err)
+
+			all, _, err := tt.repos.Salads.GetAll(ctx, nil, &domain.PageRequest{Size: 100})
+			require.NoError(t, err)
+			require.Len(t, all, 5)
+			candidates := make([]*domain.RecipeCandidate, 0, len(all))
+			for _, salad := range all {
+				recipe, err := tt.repos.Recipes.GetBySaladId(ctx, salad.ID)
+				if err != nil {
+					recipe = &domain.Recipe{ID: salad.ID, SaladID: salad.ID}
+				}
+				candidates = append(candidates, &domain.RecipeCandidate{Salad: salad, Recipe: recipe})
+			}
+
+			sorts := []string{"", domain.SortByName, domain.SortByRating, domain.SortByTimeToCook, domain.SortByCreatedAt}
+			for _, sort := range sorts {
+				for _, desc := range []bool{false, true} {
+					expected, _, err := domain.QueryRecipes(candidates, nil, &domain.PageRequest{Size: 100, Sort: sort, Desc: desc})
+					require.NoError(t, err)
+					want := make([]uuid.UUID, 0, len(expected))
+					for _, candidate := range expected {
+						want = append(want, candidate.Salad.ID)
+					}
+
+					page := &domain.PageRequest{Size: 2, Sort: sort, Desc: desc}
+					ids := make([]uuid.UUID, 0)
+					for {
+						salads, info, err := tt.repos.Salads.GetAll(ctx, nil, page)
+						require.NoError(t, err)
+						require.Equal(t, 5, info.Total)
+						for _, salad := range salads {
+							ids = append(ids, salad.ID)
+						}
+						if info.NextCursor == "" {
+							break
+						}
+						page.Cursor = info.NextCursor
+					}
+					require.Equal(t, want, ids, "sort %q desc %v", sort, desc)
+				}
+			}
+		})
+	}
+}
+
+func TestRepositoryContract_AuthorSaladPaging(t *testing.T) {
+	for _, tt := range repositoryBackends(t) {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			authorId := uuid.New()
+			for _, name := range []string{"Olivier", "caesar", "greek"} {
+				_, err := tt.repos.Salads.Create(ctx, &domain.Salad{AuthorID: authorId, Name: name})
+				require.NoError(t, err)
+			}
+			_, err := tt.repos.Salads.Create(ctx, &domain.Salad{AuthorID: uuid.New(), Name: "mimosa"})
+			require.NoError(t, err)
+
+			page := &domain.PageRequest{Size: 2}
+			names := make([]string, 0)
+			for {
+				salads, info, err := tt.repos.Salads.GetAllByUserId(ctx, authorId, page)
+				require.NoError(t, err)
+				require.Equal(t, 3, info.Total)
+				for _, salad := range salads {
+					names = append(names, salad.Name)
+				}
+				if info.NextCursor == "" {
+					break
+				}
+				page.Cursor = info.NextCursor
+			}
+			require.Equal(t, []string{"caesar", "greek", "Olivier"}, names)
+		})
+	}
+}
+
+func TestRepositoryContract_MeasurementGrams(t *testing.T) {
+	for _, tt := range repositoryBackends(t) {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ounce := &domain.Measurement{Name: "ounce", Unit: domain.UnitOunce, Grams: 28.349523125}
+			require.NoError(t, tt.repos.Measurements.Create(ctx, ounce))
+
+			got, err := tt.repos.Measurements.GetById(ctx, ounce.ID)
+			require.NoError(t, err)
+			require.Equal(t, 28.349523125, got.Grams)
+		})
+	}
+}
+
+func TestRepositoryContract_Ordering(t *testing.T) {
+	for _, tt := range repositoryBackends(t) {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			_, recipe := createSaladWithRecipe(t, tt.repos, uuid.New(), "caesar", domain.EditingSaladStatus, 0)
+
+			second := &domain.RecipeStep{RecipeID: recipe.ID, Name: "mix", StepNum: 2}
+			first := &domain.RecipeStep{RecipeID: recipe.ID, Name: "cut", StepNum: 1}
+			require.NoError(t, tt.repos.RecipeSteps.Create(ctx, second))
+			require.NoError(t, tt.repos.RecipeSteps.Create(ctx, first))
+
+			steps, err := tt.repos.RecipeSteps.GetAllByRecipeID(ctx, recipe.ID)
+			require.NoError(t, err)
+			require.Equal(t, []*domain.RecipeStep{first, second}, steps)
+
+			lettuce := &domain.Ingredient{Name: "lettuce"}
+			croutons := &domain.Ingredient{Name: "croutons"}
+			require.NoError(t, tt.repos.Ingredients.Create(ctx, lettuce))
+			require.NoError(t, tt.repos.Ingredients.Create(ctx, croutons))
+			_, err = tt.repos.Ingredients.Link(ctx, recipe.ID, lettuce.ID)
+			require.NoError(t, err)
+			_, err = tt.repos.Ingredients.Link(ctx, recipe.ID, croutons.ID)
+			require.NoError(t, err)
+
+			ingredients, err := tt.repos.Ingredients.GetAllByRecipeId(ctx, recipe.ID)
+			require.NoError(t, err)
+			require.Equal(t, []*domain.Ingredient{lettuce, croutons}, ingredients)
+
+			require.NoError(t, tt.repos.Ingredients.Unlink(ctx, recipe.ID, lettuce.ID))
+			ingredients, err = tt.repos.Ingredients.GetAllByRecipeId(ctx, recipe.ID)
+			require.NoError(t, err)
+			require.Equal(t, []*domain.Ingredient{croutons}, ingredients)
+		})
+	}
+}
+
+func TestRepositoryContract_Uniqueness(t *testing.T) {
+	for _, tt := range repositoryBackends(t) {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			require.NoError(t, tt.repos.Users.Create(ctx, &domain.User{Name: "Ivan", Username: "ivan", Password: "hash"}))
+			err := tt.repos.Users.Create(ctx, &domain.User{Name: "Other", Username: "ivan", Password: "hash"})
+			require.EqualError(t, err, "user ivan already exists")
+
+			require.NoError(t, tt.repos.Keywords.Create(ctx, &domain.KeyWord{Word: "spam"}))
+			err = tt.repos.Keywords.Create(ctx, &domain.KeyWord{Word: "spam"})
+			require.EqualError(t, err, "keyword spam already exists")
+
+			salad, _ := createSaladWithRecipe(t, tt.repos, uuid.New(), "caesar", domain.PublishedSaladStatus, 0)
+			authorId := uuid.New()
+			require.NoError(t, tt.repos.Comments.Create(ctx, &domain.Comment{AuthorID: authorId, SaladID: salad.ID, Rating: 5}))
+			err = tt.repos.Comments.Create(ctx, &domain.Comment{AuthorID: authorId, SaladID: salad.ID, Rating: 1})
+			require.EqualError(t, err, fmt.Sprintf("comment of user %s to salad %s already exists", authorId, salad.ID))
+
+			_, recipe := createSaladWithRecipe(t, tt.repos, uuid.New(), "greek", domain.PublishedSaladStatus, 0)
+			first := &domain.RecipeStep{RecipeID: recipe.ID, Name: "cut", StepNum: 1}
+			second := &domain.RecipeStep{RecipeID: recipe.ID, Name: "mix", StepNum: 2}
+			require.NoError(t, tt.repos.RecipeSteps.Create(ctx, first))
+			require.NoError(t, tt.repos.RecipeSteps.Create(ctx, second))
+			err = tt.repos.RecipeSteps.Create(ctx, &domain.RecipeStep{RecipeID: recipe.ID, Name: "serve", StepNum: 2})
+			require.EqualError(t, err, fmt.Sprintf("step 2 of recipe %s already exists", recipe.ID))
+			second.StepNum = 1
+			err = tt.repos.RecipeSteps.Update(ctx, second)
+			require.EqualError(t, err, fmt.Sprintf("step 1 of recipe %s already exists", recipe.ID))
+		})
+	}
+}
+
+func TestRepositoryContract_IngredientTypes(t *testing.T) {
+	for _, tt := range repositoryBackends(t) {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			missing := uuid.New()
+			err := tt.repos.Ingredients.Create(ctx, &domain.Ingredient{TypeID: missing, Name: "tomato"})
+			require.EqualError(t, err, fmt.Sprintf("ingredient type %s not found", missing))
+
+			vegetables := &domain.IngredientType{Name: "Овощи"}
+			require.NoError(t, tt.repos.IngredientTypes.Create(ctx, vegetables))
+			tomato := &domain.Ingredient{TypeID: vegetables.ID, Name: "tomato"}
+			require.NoError(t, tt.repos.Ingredients.Create(ctx, tomato))
+
+			require.NoError(t, tt.repos.IngredientTypes.DeleteById(ctx, vegetables.ID))
+			stored, err := tt.repos.Ingredients.GetById(ctx, tomato.ID)
+			require.NoError(t, err)
+			require.Equal(t, uuid.Nil, stored.TypeID)
+		})
+	}
+}
+
+func TestRepositoryContract_StepRenumbering(t *testing.T) {
+	for _, tt := range repositoryBackends(t) {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			_, recipe := createSaladWithRecipe(t, tt.repos, uuid.New(), "caesar", domain.EditingSaladStatus, 0)
+			steps := services.NewRecipeStepService(tt.repos.RecipeSteps, tt.repos.TxManager, logger.NewLogger("error", io.Discard))
+
+			names := func() []string {
+				stored, err := steps.GetAllByRecipeID(ctx, recipe.ID)
+				require.NoError(t, err)
+				result := make([]string, 0, len(stored))
+				for i, step := range stored {
+					require.Equal(t, i+1, step.StepNum)
+					result = append(result, step.Name)
+				}
+				return result
+			}
+
+			mix := &domain.RecipeStep{RecipeID: recipe.ID, Name: "mix", Description: "d", StepNum: 1}
+			cut := &domain.RecipeStep{RecipeID: recipe.ID, Name: "cut", Description: "d", StepNum: 1}
+			wash := &domain.RecipeStep{RecipeID: recipe.ID, Name: "wash", Description: "d", StepNum: 1}
+			require.NoError(t, steps.Create(ctx, mix))
+			require.NoError(t, steps.Create(ctx, cut))
+			require.NoError(t, steps.Create(ctx, wash))
+			require.Equal(t, []string{"wash", "cut", "mix"}, names())
+
+			moved, err := steps.GetById(ctx, wash.ID)
+			require.NoError(t, err)
+			moved.StepNum = 3
+			require.NoError(t, steps.Update(ctx, moved))
+			require.Equal(t, []string{"cut", "mix", "wash"}, names())
+
+			require.NoError(t, steps.Reorder(ctx, recipe.ID, []uuid.UUID{wash.ID, mix.ID, cut.ID}))
+			require.Equal(t, []string{"wash", "mix", "cut"}, names())
+
+			require.NoError(t, steps.DeleteById(ctx, wash.ID))
+			require.Equal(t, []string{"mix", "cut"}, names())
+		})
+	}
+}
+
+func TestRepositoryContract_SaladTypes(t *testing.T) {
+	for _, tt := range repositoryBackends(t) {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			salad, _ := createSaladWithRecipe(t, tt.repos, uuid.New(), "caesar", domain.PublishedSaladStatus, 0)
+			parent := &domain.SaladType{Name: "Мясные"}
+			require.NoError(t, tt.repos.SaladTypes.Create(ctx, parent))
+			child := &domain.SaladType{ParentID: parent.ID, Name: "С курицей"}
+			require.NoError(t, tt.repos.SaladTypes.Create(ctx, child))
+
+			children, err := tt.repos.SaladTypes.GetAllByParentId(ctx, parent.ID)
+			require.NoError(t, err)
+			require.Equal(t, []*domain.SaladType{child}, children)
+
+			require.NoError(t, tt.repos.SaladTypes.Link(ctx, salad.ID, child.ID))
+			types, err := tt.repos.SaladTypes.GetAllBySaladId(ctx, salad.ID)
+			require.NoError(t, err)
+			require.Equal(t, []*domain.SaladType{child}, types)
+
+			require.NoError(t, tt.repos.SaladTypes.DeleteById(ctx, child.ID))
+			types, err = tt.repos.SaladTypes.GetAllBySaladId(ctx, salad.ID)
+			require.NoError(t, err)
+			require.Empty(t, types)
+		})
+	}
+}
+
+func TestRepositoryContract_Transaction(t *testing.T) {
+	for _, tt := range repositoryBackends(t) {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			salad := &domain.Salad{AuthorID: uuid.New(), Name: "caesar"}
+			failed := errors.New("failed")
+
+			err := domain.RunInTransaction(ctx, tt.repos.TxManager, func(ctx context.Context) error {
+				if _, err := tt.repos.Salads.Create(ctx, salad); err != nil {
+					return err
+				}
+				if _, err := tt.repos.Salads.GetById(ctx, salad.ID); err != nil {
+					return err
+				}
+				return failed
+			})
+			require.ErrorIs(t, err, failed)
+
+			_, err = tt.repos.Salads.GetById(ctx, salad.ID)
+			require.EqualError(t, err, fmt.Sprintf("salad %s not found", salad.ID))
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type saladDetailsMocks struct {
+	saladService            *mocks.MockISaladService
+	userService             *mocks.MockIUserService
+	recipeService           *mocks.MockIRecipeService
+	recipeStepService       *mocks.MockIRecipeStepService
+	ingredientService       *mocks.MockIIngredientService
+	recipeIngredientService *mocks.MockIRecipeIngredientService
+	measurementService      *mocks.MockIMeasurementService
+	saladTypeService        *mocks.MockISaladTypeService
+	commentService          *mocks.MockICommentService
+}
+
+func TestSaladDetailsService_GetById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := &saladDetailsMocks{
+		saladService:            mocks.NewMockISaladService(ctrl),
+		userService:             mocks.NewMockIUserService(ctrl),
+		recipeService:           mocks.NewMockIRecipeService(ctrl),
+		recipeStepService:       mocks.NewMockIRecipeStepService(ctrl),
+		ingredientService:       mocks.NewMockIIngredientService(ctrl),
+		recipeIngredientService: mocks.NewMockIRecipeIngredientService(ctrl),
+		measurementService:      mocks.NewMockIMeasurementService(ctrl),
+		saladTypeService:        mocks.NewMockISaladTypeService(ctrl),
+		commentService:          mocks.NewMockICommentService(ctrl),
+	}
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	svc := services.NewSaladDetailsService(m.saladService, m.userService, m.recipeService, m.recipeStepService,
+		m.ingredientService, m.recipeIngredientService, m.measurementService, m.saladTypeService, m.commentService, logger)
+
+	saladId := uuid.UUID{1}
+	authorId := uuid.UUID{2}
+	recipeId := uuid.UUID{3}
+	tomatoId := uuid.UUID{4}
+	oilId := uuid.UUID{5}
+	pieceId := uuid.UUID{6}
+
+	expectRecipeParts := func(m *saladDetailsMocks) {
+		m.recipeStepService.EXPECT().
+			GetAllByRecipeID(gomock.Any(), recipeId).
+			Return([]*domain.RecipeStep{
+				{ID: uuid.UUID{12}, RecipeID: recipeId, Name: "mix", Description: "mix", StepNum: 2},
+				{ID: uuid.UUID{11}, RecipeID: recipeId, Name: "cut", Description: "cut", StepNum: 1},
+			}, nil)
+		m.ingredientService.EXPECT().
+			GetAllByRecipeId(gomock.Any(), recipeId).
+			Return([]*domain.Ingredient{
+				{ID: tomatoId, Name: "tomato"},
+				{ID: oilId, Name: "oil"},
+			}, nil)
+		m.recipeIngredientService.EXPECT().
+			GetAllByRecipeId(gomock.Any(), recipeId).
+			Return([]*domain.RecipeIngredient{
+				{RecipeID: recipeId, IngredientID: tomatoId, MeasurementID: pieceId, Amount: 1.5, Note: "sliced", Order: 1},
+			}, nil)
+		m.measurementService.EXPECT().
+			GetAllByRecipeId(gomock.Any(), recipeId).
+			Return([]*domain.RecipeMeasurement{
+				{IngredientID: tomatoId, Measurement: &domain.Measurement{ID: pieceId, Name: "piece", Grams: 100}},
+			}, nil)
+	}
+
+	tests := []struct {
+		name         string
+		commentsPage *domain.PageRequest
+		beforeTest   func(m *saladDetailsMocks)
+		check        func(t *testing.T, details *domain.SaladDetails)
+		wantErr      bool
+		errStr       error
+	}{
+		{
+			name:         "успешное получение с комментариями",
+			commentsPage: &domain.PageRequest{Size: 10},
+			beforeTest: func(m *saladDetailsMocks) {
+				m.saladService.EXPECT().
+					GetById(gomock.Any(), saladId).
+					Return(&domain.Salad{ID: saladId, AuthorID: authorId, Name: "salad"}, nil)
+				m.userService.EXPECT().
+					GetById(gomock.Any(), authorId).
+					Return(&domain.User{ID: authorId, Name: "author"}, nil)
+				m.recipeService.EXPECT().
+					GetBySaladId(gomock.Any(), saladId).
+					Return(&domain.Recipe{ID: recipeId, SaladID: saladId}, nil)
+				expectRecipeParts(m)
+				m.saladTypeService.EXPECT().
+					GetAllBySaladId(gomock.Any(), saladId).
+					Return([]*domain.SaladType{{Name: "vegan"}}, nil)
+				m.commentService.EXPECT().
+					GetAllBySaladID(gomock.Any(), saladId, &domain.PageRequest{Size: 10}).
+					Return([]*domain.Comment{{Text: "nice", Rating: 5}}, &domain.PageInfo{Size: 10, Total: 21}, nil)
+			},
+			check: func(t *testing.T, details *domain.SaladDetails) {
+				require.Equal(t, "salad", details.Salad.Name)
+				require.Equal(t, "author", details.Author.Name)
+				require.Equal(t, recipeId, details.Recipe.ID)
+				require.Equal(t, "cut", details.Steps[0].Name)
+				require.Equal(t, "mix", details.Steps[1].Name)
+				require.Len(t, details.Ingredients, 2)
+				require.Equal(t, "tomato", details.Ingredients[0].Ingredient.Name)
+				require.Equal(t, 1.5, details.Ingredients[0].Amount)
+				require.Equal(t, "sliced", details.Ingredients[0].Note)
+				require.Equal(t, "piece", details.Ingredients[0].Measurement.Name)
+				require.Nil(t, details.Ingredients[1].Measurement)
+				require.Len(t, details.Types, 1)
+				require.Len(t, details.Comments, 1)
+				require.Equal(t, 21, details.CommentsPage.Total)
+			},
+			wantErr: false,
+		}, // успешное получение с комментариями
+		{
+			name:         "успешное получение без комментариев",
+			commentsPage: nil,
+			beforeTest: func(m *saladDetailsMocks) {
+				m.saladService.EXPECT().
+					GetById(gomock.Any(), saladId).
+					Return(&domain.Salad{ID: saladId, AuthorID: authorId, Name: "salad"}, nil)
+				m.userService.EXPECT().
+					GetById(gomock.Any(), authorId).
+					Return(&domain.User{ID: authorId}, nil)
+				m.recipeService.EXPECT().
+					GetBySaladId(gomock.Any(), saladId).
+					Return(&domain.Recipe{ID: recipeId, SaladID: saladId}, nil)
+				expectRecipeParts(m)
+				m.saladTypeService.EXPECT().
+					GetAllBySaladId(gomock.Any(), saladId).
+					Return(nil, nil)
+			},
+			check: func(t *testing.T, details *domain.SaladDetails) {
+				require.Nil(t, details.Comments)
+				require.Len(t, details.Ingredients, 2)
+			},
+			wantErr: false,
+		}, // успешное получение без комментариев
+		{
+			name:         "ошибка получения рецепта",
+			commentsPage: nil,
+			beforeTest: func(m *saladDetailsMocks) {
+				m.saladService.EXPECT().
+					GetById(gomock.Any(), saladId).
+					Return(&domain.Salad{ID: saladId, AuthorID: authorId, Name: "salad"}, nil).
+					AnyTimes()
+				m.userService.EXPECT().
+					GetById(gomock.Any(), authorId).
+					Return(&domain.User{ID: authorId}, nil).
+					AnyTimes()
+				m.recipeService.EXPECT().
+					GetBySaladId(gomock.Any(), saladId).
+					Return(nil, fmt.Errorf("recipe not found"))
+				m.saladTypeService.EXPECT().
+					GetAllBySaladId(gomock.Any(), saladId).
+					Return(nil, nil).
+					AnyTimes()
+			},
+			wantErr: true,
+			errStr:  errors.New("getting salad details: recipe not found"),
+		}, // ошибка получения рецепта
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(m)
+			}
+
+			details, err := svc.GetById(context.Background(), saladId, tt.commentsPage)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				tt.check(t, details)
+			}
+		})
+	}
+}
@@ -213,11 +213,11 @@ func TestSaladInteractor_GetAll(t *testing.T) {
 		MinRate:              0,
 		SaladTypes:           nil,
 	}
-	page := 1
+	page := &domain.PageRequest{Size: 10}
 
 	tests := []struct {
 		name       string
-		page       int
+		page       *domain.PageRequest
 		beforeTest func(saladService mocks.MockISaladService, validatorServices []mocks.MockIValidatorService)
 		expected   []*domain.Salad
 		wantErr    bool
@@ -254,7 +254,7 @@ func TestSaladInteractor_GetAll(t *testing.T) {
 							Name:        "salad1",
 							Description: "",
 						},
-					}, 4, nil)
+					}, &domain.PageInfo{Size: 10, Total: 4}, nil)
 			},
 			expected: []*domain.Salad{
 				{
@@ -290,7 +290,7 @@ func TestSaladInteractor_GetAll(t *testing.T) {
 			beforeTest: func(saladService mocks.MockISaladService, validatorServices []mocks.MockIValidatorService) {
 				saladService.EXPECT().
 					GetAll(context.Background(), filter, page).
-					Return(nil, 0, fmt.Errorf("getting salads err"))
+					Return(nil, nil, fmt.Errorf("getting salads err"))
 			},
 			wantErr: true,
 			errStr:  errors.New("getting salads err"),
@@ -323,12 +323,12 @@ func TestSaladInteractor_GetAllRatedByUser(t *testing.T) {
 	svc := services.NewSaladInteractor(saladService, []domain.IValidatorService{validatorService})
 
 	userId := uuid.New()
-	page := 1
+	page := &domain.PageRequest{Size: 10}
 
 	tests := []struct {
 		name       string
 		userId     uuid.UUID
-		page       int
+		page       *domain.PageRequest
 		beforeTest func(saladService mocks.MockISaladService, validatorServices []mocks.MockIValidatorService)
 		expected   []*domain.Salad
 		wantErr    bool
@@ -366,7 +366,7 @@ func TestSaladInteractor_GetAllRatedByUser(t *testing.T) {
 							Name:        "salad1",
 							Description: "",
 						},
-					}, 1, nil)
+					}, &domain.PageInfo{Size: 10, Total: 4}, nil)
 			},
 			expected: []*domain.Salad{
 				{
@@ -403,7 +403,7 @@ func TestSaladInteractor_GetAllRatedByUser(t *testing.T) {
 			beforeTest: func(saladService mocks.MockISaladService, validatorServices []mocks.MockIValidatorService) {
 				saladService.EXPECT().
 					GetAllRatedByUser(context.Background(), userId, page).
-					Return(nil, 0, fmt.Errorf("getting salads err"))
+					Return(nil, nil, fmt.Errorf("getting salads err"))
 			},
 			wantErr: true,
 			errStr:  errors.New("getting salads err"),
@@ -436,6 +436,7 @@ func TestSaladInteractor_GetAllByUserId(t *testing.T) {
 	svc := services.NewSaladInteractor(saladService, []domain.IValidatorService{validatorService})
 
 	userId := uuid.New()
+	page := &domain.PageRequest{Size: 10}
 
 	tests := []struct {
 		name       string
@@ -450,7 +451,7 @@ func TestSaladInteractor_GetAllByUserId(t *testing.T) {
 			userId: userId,
 			beforeTest: func(saladService mocks.MockISaladService, validatorServices []mocks.MockIValidatorService) {
 				saladService.EXPECT().
-					GetAllByUserId(context.Background(), userId).
+					GetAllByUserId(context.Background(), userId, page).
 					Return([]*domain.Salad{
 						{
 							ID:          uuid.UUID{1},
@@ -476,7 +477,7 @@ func TestSaladInteractor_GetAllByUserId(t *testing.T) {
 							Name:        "salad1",
 							Description: "",
 						},
-					}, nil)
+					}, &domain.PageInfo{Size: 10, Total: 4}, nil)
 			},
 			expected: []*domain.Salad{
 				{
@@ -511,8 +512,8 @@ func TestSaladInteractor_GetAllByUserId(t *testing.T) {
 			userId: userId,
 			beforeTest: func(saladService mocks.MockISaladService, validatorServices []mocks.MockIValidatorService) {
 				saladService.EXPECT().
-					GetAllByUserId(context.Background(), userId).
-					Return(nil, fmt.Errorf("getting salads err"))
+					GetAllByUserId(context.Background(), userId, page).
+					Return(nil, nil, fmt.Errorf("getting salads err"))
 			},
 			wantErr: true,
 			errStr:  errors.New("getting salads err"),
@@ -524,7 +525,7 @@ func TestSaladInteractor_GetAllByUserId(t *testing.T) {
 				tt.beforeTest(*saladService, []mocks.MockIValidatorService{*validatorService})
 			}
 
-			salads, err := svc.GetAllByUserId(context.Background(), tt.userId)
+			salads, _, err := svc.GetAllByUserId(context.Background(), tt.userId, page)
 			if tt.wantErr {
 				require.Equal(t, tt.errStr.Error(), err.Error())
 			} else {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/Mx1q/ppo_services/config"
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/services"
 	"github.com/Mx1q/ppo_services/tests/mocks"
@@ -31,7 +32,7 @@ func TestSaladTypeService_Create(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewSaladTypeService(saladTypeRepo, logger)
+	svc := services.NewSaladTypeService(saladTypeRepo, newTxManagerMock(ctrl), logger, config.Default().Pages)
 
 	saladTypeId := uuid.New()
 
@@ -51,7 +52,7 @@ func TestSaladTypeService_Create(t *testing.T) {
 			},
 			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
 				saladTypeRepo.EXPECT().
-					Create(context.Background(), &domain.SaladType{
+					Create(gomock.Any(), &domain.SaladType{
 						ID:          saladTypeId,
 						Name:        "meat",
 						Description: "",
@@ -79,7 +80,7 @@ func TestSaladTypeService_Create(t *testing.T) {
 			},
 			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
 				saladTypeRepo.EXPECT().
-					Create(context.Background(), &domain.SaladType{
+					Create(gomock.Any(), &domain.SaladType{
 						ID:          saladTypeId,
 						Name:        "meat",
 						Description: "",
@@ -124,7 +125,7 @@ func TestSaladTypeService_DeleteById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewSaladTypeService(saladTypeRepo, logger)
+	svc := services.NewSaladTypeService(saladTypeRepo, newTxManagerMock(ctrl), logger, config.Default().Pages)
 
 	saladTypeId := uuid.New()
 
@@ -140,22 +141,52 @@ func TestSaladTypeService_DeleteById(t *testing.T) {
 			saladTypeId: saladTypeId,
 			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
 				saladTypeRepo.EXPECT().
-					DeleteById(context.Background(), saladTypeId).
+					GetAllByParentId(gomock.Any(), saladTypeId).
+					Return(nil, nil)
+				saladTypeRepo.EXPECT().
+					DeleteById(gomock.Any(), saladTypeId).
 					Return(nil)
 			},
 			wantErr: false,
 		}, // успешное удаление
+		{
+			name:        "проверка подтипов и удаление в транзакции",
+			saladTypeId: saladTypeId,
+			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
+				saladTypeRepo.EXPECT().
+					GetAllByParentId(inTransaction{}, saladTypeId).
+					Return(nil, nil)
+				saladTypeRepo.EXPECT().
+					DeleteById(inTransaction{}, saladTypeId).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // проверка подтипов и удаление в транзакции
 		{
 			name:        "ошибка выполнения запроса в репозитории",
 			saladTypeId: saladTypeId,
 			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
 				saladTypeRepo.EXPECT().
-					DeleteById(context.Background(), saladTypeId).
+					GetAllByParentId(gomock.Any(), saladTypeId).
+					Return(nil, nil)
+				saladTypeRepo.EXPECT().
+					DeleteById(gomock.Any(), saladTypeId).
 					Return(fmt.Errorf("deleting err"))
 			},
 			wantErr: true,
 			errStr:  errors.New("deleting salad type by id: deleting err"),
 		}, // ошибка выполнения запроса в репозитории
+		{
+			name:        "есть подтипы",
+			saladTypeId: saladTypeId,
+			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
+				saladTypeRepo.EXPECT().
+					GetAllByParentId(gomock.Any(), saladTypeId).
+					Return([]*domain.SaladType{{ID: uuid.New(), ParentID: saladTypeId}}, nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("deleting salad type by id: type has subtypes"),
+		}, // есть подтипы
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -191,7 +222,7 @@ func TestSaladTypeService_Link(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewSaladTypeService(saladTypeRepo, logger)
+	svc := services.NewSaladTypeService(saladTypeRepo, newTxManagerMock(ctrl), logger, config.Default().Pages)
 
 	saladId := uuid.New()
 	saladTypeId := uuid.New()
@@ -262,7 +293,7 @@ func TestSaladTypeService_Unlink(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewSaladTypeService(saladTypeRepo, logger)
+	svc := services.NewSaladTypeService(saladTypeRepo, newTxManagerMock(ctrl), logger, config.Default().Pages)
 
 	saladId := uuid.New()
 	saladTypeId := uuid.New()
@@ -333,13 +364,13 @@ func TestSaladTypeService_GetAll(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewSaladTypeService(saladTypeRepo, logger)
+	svc := services.NewSaladTypeService(saladTypeRepo, newTxManagerMock(ctrl), logger, config.Default().Pages)
 
-	page := 1
+	page := &domain.PageRequest{Size: 10}
 
 	tests := []struct {
 		name       string
-		page       int
+		page       *domain.PageRequest
 		beforeTest func(saladTypeRepo mocks.MockISaladTypeRepository)
 		expected   []*domain.SaladType
 		wantErr    bool
@@ -372,7 +403,7 @@ func TestSaladTypeService_GetAll(t *testing.T) {
 							Name:        "fourth",
 							Description: "",
 						},
-					}, 1, nil)
+					}, &domain.PageInfo{Size: 10, Total: 4}, nil)
 			},
 			expected: []*domain.SaladType{
 				{
@@ -400,14 +431,21 @@ func TestSaladTypeService_GetAll(t *testing.T) {
 		}, // успешное получение
 		{
 			name: "ошибка выполнения запроса в репозитории",
+			page: page,
 			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
 				saladTypeRepo.EXPECT().
 					GetAll(context.Background(), page).
-					Return(nil, 0, fmt.Errorf("getting all err"))
+					Return(nil, nil, fmt.Errorf("getting all err"))
 			},
 			wantErr: true,
 			errStr:  errors.New("getting all salad types: getting all err"),
 		}, // ошибка выполнения запроса в репозитории
+		{
+			name:    "неподдерживаемая сортировка",
+			page:    &domain.PageRequest{Sort: "rating"},
+			wantErr: true,
+			errStr:  errors.New("getting all salad types: unsupported sort rating"),
+		}, // неподдерживаемая сортировка
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -415,7 +453,7 @@ func TestSaladTypeService_GetAll(t *testing.T) {
 				tt.beforeTest(*saladTypeRepo)
 			}
 
-			types, _, err := svc.GetAll(context.Background(), page)
+			types, _, err := svc.GetAll(context.Background(), tt.page)
 			if tt.wantErr {
 				require.Equal(t, tt.errStr.Error(), err.Error())
 			} else {
@@ -444,7 +482,7 @@ func TestSaladTypeService_GetAllBySaladId(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewSaladTypeService(saladTypeRepo, logger)
+	svc := services.NewSaladTypeService(saladTypeRepo, newTxManagerMock(ctrl), logger, config.Default().Pages)
 
 	saladId := uuid.New()
 
@@ -556,7 +594,7 @@ func TestSaladTypeService_GetById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewSaladTypeService(saladTypeRepo, logger)
+	svc := services.NewSaladTypeService(saladTypeRepo, newTxManagerMock(ctrl), logger, config.Default().Pages)
 
 	typeId := uuid.New()
 
@@ -634,7 +672,7 @@ func TestSaladTypeService_Update(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewSaladTypeService(saladTypeRepo, logger)
+	svc := services.NewSaladTypeService(saladTypeRepo, newTxManagerMock(ctrl), logger, config.Default().Pages)
 
 	saladTypeId := uuid.New()
 
@@ -654,7 +692,7 @@ func TestSaladTypeService_Update(t *testing.T) {
 			},
 			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
 				saladTypeRepo.EXPECT().
-					Update(context.Background(), &domain.SaladType{
+					Update(gomock.Any(), &domain.SaladType{
 						ID:          saladTypeId,
 						Name:        "meat",
 						Description: "",
@@ -682,7 +720,7 @@ func TestSaladTypeService_Update(t *testing.T) {
 			},
 			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
 				saladTypeRepo.EXPECT().
-					Update(context.Background(), &domain.SaladType{
+					Update(gomock.Any(), &domain.SaladType{
 						ID:          saladTypeId,
 						Name:        "meat",
 						Description: "",
@@ -708,3 +746,206 @@ func TestSaladTypeService_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestSaladTypeService_Move(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	saladTypeRepo := mocks.NewMockISaladTypeRepository(ctrl)
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Warnf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	svc := services.NewSaladTypeService(saladTypeRepo, newTxManagerMock(ctrl), logger, config.Default().Pages)
+
+	// Mediterranean -> Greek -> Cretan, Asian
+	mediterranean := &domain.SaladType{ID: uuid.UUID{1}, Name: "Mediterranean"}
+	greek := &domain.SaladType{ID: uuid.UUID{2}, ParentID: mediterranean.ID, Name: "Greek"}
+	cretan := &domain.SaladType{ID: uuid.UUID{3}, ParentID: greek.ID, Name: "Cretan"}
+	asian := &domain.SaladType{ID: uuid.UUID{4}, Name: "Asian"}
+	byId := map[uuid.UUID]*domain.SaladType{
+		mediterranean.ID: mediterranean, greek.ID: greek, cretan.ID: cretan, asian.ID: asian,
+	}
+	getById := func(ctx context.Context, id uuid.UUID) (*domain.SaladType, error) {
+		saladType, ok := byId[id]
+		if !ok {
+			return nil, fmt.Errorf("salad type not found")
+		}
+		copied := *saladType
+		return &copied, nil
+	}
+
+	tests := []struct {
+		name       string
+		id         uuid.UUID
+		parentId   uuid.UUID
+		beforeTest func(saladTypeRepo mocks.MockISaladTypeRepository)
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:     "перенос в другую ветку",
+			id:       greek.ID,
+			parentId: asian.ID,
+			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
+				saladTypeRepo.EXPECT().
+					GetById(gomock.Any(), gomock.Any()).
+					DoAndReturn(getById).
+					Times(2)
+				saladTypeRepo.EXPECT().
+					Update(gomock.Any(), &domain.SaladType{ID: greek.ID, ParentID: asian.ID, Name: "Greek"}).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // перенос в другую ветку
+		{
+			name:     "перенос в корень",
+			id:       greek.ID,
+			parentId: uuid.Nil,
+			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
+				saladTypeRepo.EXPECT().
+					GetById(gomock.Any(), greek.ID).
+					DoAndReturn(getById)
+				saladTypeRepo.EXPECT().
+					Update(gomock.Any(), &domain.SaladType{ID: greek.ID, Name: "Greek"}).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // перенос в корень
+		{
+			name:     "перенос в своего потомка",
+			id:       mediterranean.ID,
+			parentId: cretan.ID,
+			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
+				saladTypeRepo.EXPECT().
+					GetById(gomock.Any(), gomock.Any()).
+					DoAndReturn(getById).
+					Times(3)
+			},
+			wantErr: true,
+			errStr:  errors.New("moving salad type: type can't be moved under itself or its descendant"),
+		}, // перенос в своего потомка
+		{
+			name:     "перенос в себя",
+			id:       greek.ID,
+			parentId: greek.ID,
+			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
+				saladTypeRepo.EXPECT().
+					GetById(gomock.Any(), greek.ID).
+					DoAndReturn(getById)
+			},
+			wantErr: true,
+			errStr:  errors.New("moving salad type: type can't be moved under itself or its descendant"),
+		}, // перенос в себя
+		{
+			name:     "родитель не найден",
+			id:       greek.ID,
+			parentId: uuid.UUID{9},
+			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
+				saladTypeRepo.EXPECT().
+					GetById(gomock.Any(), gomock.Any()).
+					DoAndReturn(getById).
+					Times(2)
+			},
+			wantErr: true,
+			errStr:  errors.New("moving salad type: salad type not found"),
+		}, // родитель не найден
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*saladTypeRepo)
+			}
+
+			err := svc.Move(context.Background(), tt.id, tt.parentId)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestSaladTypeService_GetSubtree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	saladTypeRepo := mocks.NewMockISaladTypeRepository(ctrl)
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	svc := services.NewSaladTypeService(saladTypeRepo, newTxManagerMock(ctrl), logger, config.Default().Pages)
+
+	mediterranean := &domain.SaladType{ID: uuid.UUID{1}, Name: "Mediterranean"}
+	greek := &domain.SaladType{ID: uuid.UUID{2}, ParentID: mediterranean.ID, Name: "Greek"}
+	italian := &domain.SaladType{ID: uuid.UUID{3}, ParentID: mediterranean.ID, Name: "Italian"}
+	cretan := &domain.SaladType{ID: uuid.UUID{4}, ParentID: greek.ID, Name: "Cretan"}
+
+	tests := []struct {
+		name       string
+		beforeTest func(saladTypeRepo mocks.MockISaladTypeRepository)
+		want       []*domain.SaladType
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "поддерево в ширину",
+			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
+				saladTypeRepo.EXPECT().
+					GetById(gomock.Any(), mediterranean.ID).
+					Return(mediterranean, nil)
+				saladTypeRepo.EXPECT().
+					GetAllByParentId(gomock.Any(), mediterranean.ID).
+					Return([]*domain.SaladType{greek, italian}, nil)
+				saladTypeRepo.EXPECT().
+					GetAllByParentId(gomock.Any(), greek.ID).
+					Return([]*domain.SaladType{cretan}, nil)
+				saladTypeRepo.EXPECT().
+					GetAllByParentId(gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(2)
+			},
+			want:    []*domain.SaladType{mediterranean, greek, italian, cretan},
+			wantErr: false,
+		}, // поддерево в ширину
+		{
+			name: "ошибка выполнения запроса в репозитории",
+			beforeTest: func(saladTypeRepo mocks.MockISaladTypeRepository) {
+				saladTypeRepo.EXPECT().
+					GetById(gomock.Any(), mediterranean.ID).
+					Return(mediterranean, nil)
+				saladTypeRepo.EXPECT().
+					GetAllByParentId(gomock.Any(), mediterranean.ID).
+					Return(nil, fmt.Errorf("getting subtypes err"))
+			},
+			wantErr: true,
+			errStr:  errors.New("getting subtree of salad type: getting subtypes err"),
+		}, // ошибка выполнения запроса в репозитории
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*saladTypeRepo)
+			}
+
+			subtree, err := svc.GetSubtree(context.Background(), mediterranean.ID)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tt.want, subtree)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/Mx1q/ppo_services/config"
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/services"
 	"github.com/Mx1q/ppo_services/tests/mocks"
@@ -31,7 +32,7 @@ func TestSaladService_Create(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewSaladService(saladRepo, logger)
+	svc := services.NewSaladService(saladRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	saladId := uuid.New()
 	userId := uuid.New()
@@ -141,7 +142,7 @@ func TestSaladService_DeleteById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewSaladService(saladRepo, logger)
+	svc := services.NewSaladService(saladRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	saladId := uuid.New()
 
@@ -208,26 +209,28 @@ func TestSaladService_GetAll(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewSaladService(saladRepo, logger)
+	svc := services.NewSaladService(saladRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	filter := &domain.RecipeFilter{
 		AvailableIngredients: nil,
 		MinRate:              0,
 		SaladTypes:           nil,
 	}
-	page := 1
+	page := &domain.PageRequest{Size: 10}
 
 	tests := []struct {
 		name       string
-		page       int
+		filter     *domain.RecipeFilter
+		page       *domain.PageRequest
 		beforeTest func(saladRepo mocks.MockISaladRepository)
 		expected   []*domain.Salad
 		wantErr    bool
 		errStr     error
 	}{
 		{
-			name: "успешное получение всех салатов",
-			page: page,
+			name:   "успешное получение всех салатов",
+			filter: filter,
+			page:   page,
 			beforeTest: func(saladRepo mocks.MockISaladRepository) {
 				saladRepo.EXPECT().
 					GetAll(context.Background(), filter, page).
@@ -256,7 +259,7 @@ func TestSaladService_GetAll(t *testing.T) {
 							Name:        "salad1",
 							Description: "",
 						},
-					}, 4, nil)
+					}, &domain.PageInfo{Size: 10, Total: 4}, nil)
 			},
 			expected: []*domain.Salad{
 				{
@@ -287,16 +290,30 @@ func TestSaladService_GetAll(t *testing.T) {
 			wantErr: false,
 		}, // успешное получение всех салатов
 		{
-			name: "ошибка выполнения запроса в репозитории",
-			page: page,
+			name:   "ошибка выполнения запроса в репозитории",
+			filter: filter,
+			page:   page,
 			beforeTest: func(saladRepo mocks.MockISaladRepository) {
 				saladRepo.EXPECT().
 					GetAll(context.Background(), filter, page).
-					Return(nil, 0, fmt.Errorf("getting all salads err"))
+					Return(nil, nil, fmt.Errorf("getting all salads err"))
 			},
 			wantErr: true,
 			errStr:  errors.New("getting all salads: getting all salads err"),
 		}, // ошибка выполнения запроса в репозитории
+		{
+			name:    "неподдерживаемая сортировка",
+			page:    &domain.PageRequest{Sort: "username"},
+			wantErr: true,
+			errStr:  errors.New("getting all salads: unsupported sort username"),
+		}, // неподдерживаемая сортировка
+		{
+			name:    "некорректный фильтр",
+			filter:  &domain.RecipeFilter{MinServings: 4, MaxServings: 2},
+			page:    page,
+			wantErr: true,
+			errStr:  errors.New("getting all salads: min servings greater than max servings"),
+		}, // некорректный фильтр
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -304,7 +321,7 @@ func TestSaladService_GetAll(t *testing.T) {
 				tt.beforeTest(*saladRepo)
 			}
 
-			salads, _, err := svc.GetAll(context.Background(), filter, tt.page)
+			salads, _, err := svc.GetAll(context.Background(), tt.filter, tt.page)
 			if tt.wantErr {
 				require.Equal(t, tt.errStr.Error(), err.Error())
 			} else {
@@ -333,15 +350,15 @@ func TestSaladService_GetAllRatedByUser(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewSaladService(saladRepo, logger)
+	svc := services.NewSaladService(saladRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	userId := uuid.New()
-	page := 1
+	page := &domain.PageRequest{Size: 10}
 
 	tests := []struct {
 		name       string
 		userId     uuid.UUID
-		page       int
+		page       *domain.PageRequest
 		beforeTest func(saladRepo mocks.MockISaladRepository)
 		expected   []*domain.Salad
 		wantErr    bool
@@ -379,7 +396,7 @@ func TestSaladService_GetAllRatedByUser(t *testing.T) {
 							Name:        "salad1",
 							Description: "",
 						},
-					}, 1, nil)
+					}, &domain.PageInfo{Size: 10, Total: 4}, nil)
 			},
 			expected: []*domain.Salad{
 				{
@@ -416,11 +433,17 @@ func TestSaladService_GetAllRatedByUser(t *testing.T) {
 			beforeTest: func(saladRepo mocks.MockISaladRepository) {
 				saladRepo.EXPECT().
 					GetAllRatedByUser(context.Background(), userId, page).
-					Return(nil, 0, fmt.Errorf("getting all salads err"))
+					Return(nil, nil, fmt.Errorf("getting all salads err"))
 			},
 			wantErr: true,
 			errStr:  errors.New("getting all salads rated by user: getting all salads err"),
 		}, // ошибка выполнения запроса в репозитории
+		{
+			name:    "неподдерживаемая сортировка",
+			page:    &domain.PageRequest{Sort: "username"},
+			wantErr: true,
+			errStr:  errors.New("getting all salads rated by user: unsupported sort username"),
+		}, // неподдерживаемая сортировка
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -457,14 +480,16 @@ func TestSaladService_GetAllByUserId(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewSaladService(saladRepo, logger)
+	svc := services.NewSaladService(saladRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	userId := uuid.New()
+	page := &domain.PageRequest{Size: 10}
 
 	tests := []struct {
 		name       string
 		beforeTest func(saladRepo mocks.MockISaladRepository)
 		userId     uuid.UUID
+		page       *domain.PageRequest
 		expected   []*domain.Salad
 		wantErr    bool
 		errStr     error
@@ -473,7 +498,7 @@ func TestSaladService_GetAllByUserId(t *testing.T) {
 			name: "успешное получение всех салатов",
 			beforeTest: func(saladRepo mocks.MockISaladRepository) {
 				saladRepo.EXPECT().
-					GetAllByUserId(context.Background(), userId).
+					GetAllByUserId(context.Background(), userId, page).
 					Return([]*domain.Salad{
 						{
 							ID:          uuid.UUID{1},
@@ -499,9 +524,10 @@ func TestSaladService_GetAllByUserId(t *testing.T) {
 							Name:        "salad1",
 							Description: "",
 						},
-					}, nil)
+					}, &domain.PageInfo{Size: 10, Total: 4}, nil)
 			},
 			userId: userId,
+			page:   page,
 			expected: []*domain.Salad{
 				{
 					ID:          uuid.UUID{1},
@@ -534,13 +560,21 @@ func TestSaladService_GetAllByUserId(t *testing.T) {
 			name: "ошибка выполнения запроса в репозитории",
 			beforeTest: func(saladRepo mocks.MockISaladRepository) {
 				saladRepo.EXPECT().
-					GetAllByUserId(context.Background(), userId).
-					Return(nil, fmt.Errorf("getting all salads by uid err"))
+					GetAllByUserId(context.Background(), userId, page).
+					Return(nil, nil, fmt.Errorf("getting all salads by uid err"))
 			},
 			userId:  userId,
+			page:    page,
 			wantErr: true,
 			errStr:  errors.New("getting all salads by author id: getting all salads by uid err"),
 		}, // ошибка выполнения запроса в репозитории
+		{
+			name:    "слишком большая страница",
+			userId:  userId,
+			page:    &domain.PageRequest{Size: domain.MaxPageSize + 1},
+			wantErr: true,
+			errStr:  fmt.Errorf("getting all salads by author id: page size must be from 1 to %d", domain.MaxPageSize),
+		}, // слишком большая страница
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -548,7 +582,7 @@ func TestSaladService_GetAllByUserId(t *testing.T) {
 				tt.beforeTest(*saladRepo)
 			}
 
-			salads, err := svc.GetAllByUserId(context.Background(), tt.userId)
+			salads, _, err := svc.GetAllByUserId(context.Background(), tt.userId, tt.page)
 			if tt.wantErr {
 				require.Equal(t, tt.errStr.Error(), err.Error())
 			} else {
@@ -577,7 +611,7 @@ func TestSaladService_GetById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewSaladService(saladRepo, logger)
+	svc := services.NewSaladService(saladRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	saladId := uuid.New()
 
@@ -657,7 +691,7 @@ func TestSaladService_Update(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewSaladService(saladRepo, logger)
+	svc := services.NewSaladService(saladRepo, logger, config.Default().Rating, config.Default().Pages)
 
 	saladId := uuid.New()
 	authorId := uuid.New()
@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/sqliterepo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openSQLite(t *testing.T, path string) *sqliterepo.DB {
+	db, err := sqliterepo.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestSQLite_Reopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "salads.db")
+
+	db := openSQLite(t, path)
+	salad := &domain.Salad{AuthorID: uuid.New(), Name: "caesar"}
+	_, err := sqliterepo.NewSaladRepository(db).Create(ctx, salad)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	reopened := openSQLite(t, path)
+	version, err := reopened.SchemaVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, version)
+
+	stored, err := sqliterepo.NewSaladRepository(reopened).GetById(ctx, salad.ID)
+	require.NoError(t, err)
+	require.Equal(t, salad, stored)
+
+	_, err = sqliterepo.Open("")
+	require.EqualError(t, err, "empty file name")
+}
+
+func TestSQLite_ForeignKeys(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t, filepath.Join(t.TempDir(), "salads.db"))
+
+	missing := uuid.New()
+	_, err := sqliterepo.NewRecipeRepository(db).Create(ctx, &domain.Recipe{SaladID: missing})
+	require.EqualError(t, err, fmt.Sprintf("salad %s not found", missing))
+
+	err = sqliterepo.NewRecipeStepRepository(db).Create(ctx, &domain.RecipeStep{RecipeID: missing, StepNum: 1})
+	require.EqualError(t, err, fmt.Sprintf("recipe %s not found", missing))
+
+	types := sqliterepo.NewIngredientTypeRepository(db)
+	parent := &domain.IngredientType{Name: "Овощи"}
+	require.NoError(t, types.Create(ctx, parent))
+	require.NoError(t, types.Create(ctx, &domain.IngredientType{ParentID: parent.ID, Name: "Корнеплоды"}))
+	require.ErrorIs(t, types.DeleteById(ctx, parent.ID), domain.ErrTypeHasChildren)
+
+	saladTypes := sqliterepo.NewSaladTypeRepository(db)
+	saladParent := &domain.SaladType{Name: "Мясные"}
+	require.NoError(t, saladTypes.Create(ctx, saladParent))
+	require.NoError(t, saladTypes.Create(ctx, &domain.SaladType{ParentID: saladParent.ID, Name: "С курицей"}))
+	require.ErrorIs(t, saladTypes.DeleteById(ctx, saladParent.ID), domain.ErrTypeHasChildren)
+}
+
+func TestSQLite_Outbox(t *testing.T) {
+	ctx := context.Background()
+	outbox := sqliterepo.NewEventOutbox(openSQLite(t, filepath.Join(t.TempDir(), "salads.db")))
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	entries := []*domain.OutboxEntry{
+		{
+			ID:          uuid.New(),
+			EventName:   domain.SaladCreatedEvent,
+			AggregateID: uuid.New(),
+			Payload:     json.RawMessage(`{"Name":"caesar"}`),
+			CreatedAt:   createdAt,
+		},
+		{
+			ID:          uuid.New(),
+			EventName:   domain.SaladCreatedEvent,
+			AggregateID: uuid.New(),
+			Payload:     json.RawMessage(`{"Name":"greek"}`),
+			CreatedAt:   createdAt.Add(time.Second),
+		},
+	}
+	require.NoError(t, outbox.Save(ctx, entries...))
+
+	pending, err := outbox.GetPending(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, entries[:1], pending)
+
+	require.NoError(t, outbox.MarkPublished(ctx, []uuid.UUID{entries[0].ID}, createdAt.Add(time.Minute)))
+	pending, err = outbox.GetPending(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, entries[1:], pending)
+
+	require.NoError(t, outbox.MarkFailed(ctx, entries[1].ID, "unknown event", createdAt.Add(time.Minute)))
+	pending, err = outbox.GetPending(ctx, 0)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
+
+func TestSQLite_MissingTranslations(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t, filepath.Join(t.TempDir(), "salads.db"))
+	translations := sqliterepo.NewTranslationRepository(db)
+
+	salad := &domain.Salad{AuthorID: uuid.New(), Name: "Цезарь"}
+	_, err := sqliterepo.NewSaladRepository(db).Create(ctx, salad)
+	require.NoError(t, err)
+
+	missing, err := translations.GetMissing(ctx, domain.TranslationEntitySalad, "en")
+	require.NoError(t, err)
+	require.Equal(t, []*domain.MissingTranslation{
+		{EntityType: domain.TranslationEntitySalad, EntityID: salad.ID, Field: domain.TranslationFieldName, Source: "Цезарь"},
+	}, missing)
+
+	_, err = translations.Create(ctx, &domain.Translation{EntityType: domain.TranslationEntitySalad, EntityID: salad.ID,
+		Locale: "en", Field: domain.TranslationFieldName, Text: "Caesar"})
+	require.NoError(t, err)
+	missing, err = translations.GetMissing(ctx, domain.TranslationEntitySalad, "en")
+	require.NoError(t, err)
+	require.Empty(t, missing)
+
+	_, err = translations.GetMissing(ctx, "unknown", "en")
+	require.EqualError(t, err, "unknown entity type unknown")
+}
+
+func TestSQLite_Webhooks(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t, filepath.Join(t.TempDir(), "salads.db"))
+	subscriptions := sqliterepo.NewWebhookSubscriptionRepository(db)
+	deliveries := sqliterepo.NewWebhookDeliveryRepository(db)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	subscription := &domain.WebhookSubscription{
+		ID:         uuid.New(),
+		URL:        "https://partner.example/hook",
+		EventTypes: []string{domain.WebhookRecipePublished},
+		Secret:     "secret",
+		Active:     true,
+		CreatedAt:  now,
+	}
+	require.NoError(t, subscriptions.Create(ctx, subscription))
+
+	subscribed, err := subscriptions.GetAllByEventType(ctx, domain.WebhookRecipePublished)
+	require.NoError(t, err)
+	require.Equal(t, []*domain.WebhookSubscription{subscription}, subscribed)
+	subscribed, err = subscriptions.GetAllByEventType(ctx, domain.WebhookCommentAdded)
+	require.NoError(t, err)
+	require.Empty(t, subscribed)
+
+	due := &domain.WebhookDelivery{ID: uuid.New(), SubscriptionID: subscription.ID, EventType: domain.WebhookRecipePublished,
+		Payload: json.RawMessage(`{}`), Status: domain.WebhookDeliveryPending, NextAttemptAt: now, CreatedAt: now}
+	later := &domain.WebhookDelivery{ID: uuid.New(), SubscriptionID: subscription.ID, EventType: domain.WebhookRecipePublished,
+		Payload: json.RawMessage(`{}`), Status: domain.WebhookDeliveryPending, NextAttemptAt: now.Add(time.Hour), CreatedAt: now}
+	require.NoError(t, deliveries.Create(ctx, due))
+	require.NoError(t, deliveries.Create(ctx, later))
+
+	found, err := deliveries.GetDue(ctx, now, 0)
+	require.NoError(t, err)
+	require.Equal(t, []*domain.WebhookDelivery{due}, found)
+
+	attempt := &domain.WebhookAttempt{ID: uuid.New(), DeliveryID: due.ID, Number: 1, StatusCode: 500,
+		Duration: 150 * time.Millisecond, Timestamp: now}
+	require.NoError(t, deliveries.CreateAttempt(ctx, attempt))
+	attempts, err := deliveries.GetAttempts(ctx, due.ID)
+	require.NoError(t, err)
+	require.Equal(t, []*domain.WebhookAttempt{attempt}, attempts)
+}
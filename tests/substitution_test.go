@@ -0,0 +1,322 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type substitutionMocks struct {
+	substitutionRepo     *mocks.MockISubstitutionRepository
+	ingredientRepo       *mocks.MockIIngredientRepository
+	recipeIngredientRepo *mocks.MockIRecipeIngredientRepository
+	saladDetailsService  *mocks.MockISaladDetailsService
+}
+
+func newSubstitutionService(ctrl *gomock.Controller) (domain.ISubstitutionService, substitutionMocks) {
+	m := substitutionMocks{
+		substitutionRepo:     mocks.NewMockISubstitutionRepository(ctrl),
+		ingredientRepo:       mocks.NewMockIIngredientRepository(ctrl),
+		recipeIngredientRepo: mocks.NewMockIRecipeIngredientRepository(ctrl),
+		saladDetailsService:  mocks.NewMockISaladDetailsService(ctrl),
+	}
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Warnf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	return services.NewSubstitutionService(m.substitutionRepo, m.ingredientRepo, m.recipeIngredientRepo,
+		m.saladDetailsService, newTxManagerMock(ctrl), logger), m
+}
+
+func TestSubstitutionService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newSubstitutionService(ctrl)
+
+	sourCreamId := uuid.UUID{1}
+	yogurtId := uuid.UUID{2}
+	substitutionId := uuid.UUID{3}
+
+	tests := []struct {
+		name         string
+		substitution *domain.Substitution
+		beforeTest   func(m substitutionMocks)
+		wantErr      bool
+		errStr       error
+	}{
+		{
+			name: "успешное добавление",
+			substitution: &domain.Substitution{
+				IngredientID: sourCreamId,
+				SubstituteID: yogurtId,
+				Ratio:        1,
+				Context:      "dressings",
+			},
+			beforeTest: func(m substitutionMocks) {
+				m.ingredientRepo.EXPECT().
+					GetById(gomock.Any(), sourCreamId).
+					Return(&domain.Ingredient{ID: sourCreamId, Name: "sour cream"}, nil)
+				m.ingredientRepo.EXPECT().
+					GetById(gomock.Any(), yogurtId).
+					Return(&domain.Ingredient{ID: yogurtId, Name: "greek yogurt"}, nil)
+				m.substitutionRepo.EXPECT().
+					GetAllByIngredientId(gomock.Any(), sourCreamId).
+					Return([]*domain.Substitution{
+						{ID: uuid.UUID{4}, IngredientID: sourCreamId, SubstituteID: yogurtId, Ratio: 1, Context: "baking"},
+					}, nil)
+				m.substitutionRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(substitutionId, nil)
+			},
+			wantErr: false,
+		}, // успешное добавление
+		{
+			name: "замена уже существует",
+			substitution: &domain.Substitution{
+				IngredientID: sourCreamId,
+				SubstituteID: yogurtId,
+				Ratio:        1,
+			},
+			beforeTest: func(m substitutionMocks) {
+				m.ingredientRepo.EXPECT().
+					GetById(gomock.Any(), gomock.Any()).
+					Return(&domain.Ingredient{}, nil).
+					Times(2)
+				m.substitutionRepo.EXPECT().
+					GetAllByIngredientId(gomock.Any(), sourCreamId).
+					Return([]*domain.Substitution{
+						{ID: uuid.UUID{4}, IngredientID: sourCreamId, SubstituteID: yogurtId, Ratio: 2},
+					}, nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("creating substitution: substitution already exists"),
+		}, // замена уже существует
+		{
+			name: "замена самим собой",
+			substitution: &domain.Substitution{
+				IngredientID: sourCreamId,
+				SubstituteID: sourCreamId,
+				Ratio:        1,
+			},
+			wantErr: true,
+			errStr:  errors.New("creating substitution: ingredient substitutes itself"),
+		}, // замена самим собой
+		{
+			name: "нулевая пропорция",
+			substitution: &domain.Substitution{
+				IngredientID: sourCreamId,
+				SubstituteID: yogurtId,
+			},
+			wantErr: true,
+			errStr:  errors.New("creating substitution: negative or zero ratio"),
+		}, // нулевая пропорция
+		{
+			name: "ингредиент не найден",
+			substitution: &domain.Substitution{
+				IngredientID: sourCreamId,
+				SubstituteID: yogurtId,
+				Ratio:        1,
+			},
+			beforeTest: func(m substitutionMocks) {
+				m.ingredientRepo.EXPECT().
+					GetById(gomock.Any(), sourCreamId).
+					Return(nil, fmt.Errorf("ingredient not found"))
+			},
+			wantErr: true,
+			errStr:  errors.New("creating substitution: ingredient not found"),
+		}, // ингредиент не найден
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(m)
+			}
+
+			id, err := svc.Create(context.Background(), tt.substitution)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, substitutionId, id)
+				require.Equal(t, substitutionId, tt.substitution.ID)
+			}
+		})
+	}
+}
+
+func TestSubstitutionService_GetForRecipeIngredient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newSubstitutionService(ctrl)
+
+	recipeIngredientId := uuid.UUID{1}
+	butterId := uuid.UUID{2}
+	oilId := uuid.UUID{3}
+	oil := &domain.Ingredient{ID: oilId, Name: "olive oil"}
+	substitution := &domain.Substitution{ID: uuid.UUID{4}, IngredientID: butterId, SubstituteID: oilId, Ratio: 0.75}
+
+	tests := []struct {
+		name       string
+		beforeTest func(m substitutionMocks)
+		want       []*domain.SubstituteOption
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "количество масштабируется пропорцией",
+			beforeTest: func(m substitutionMocks) {
+				m.recipeIngredientRepo.EXPECT().
+					GetById(gomock.Any(), recipeIngredientId).
+					Return(&domain.RecipeIngredient{ID: recipeIngredientId, IngredientID: butterId, Amount: 2}, nil)
+				m.substitutionRepo.EXPECT().
+					GetAllByIngredientId(gomock.Any(), butterId).
+					Return([]*domain.Substitution{substitution}, nil)
+				m.ingredientRepo.EXPECT().
+					GetById(gomock.Any(), oilId).
+					Return(oil, nil)
+			},
+			want: []*domain.SubstituteOption{
+				{Substitution: substitution, Ingredient: oil, Amount: 1.5},
+			},
+			wantErr: false,
+		}, // количество масштабируется пропорцией
+		{
+			name: "ошибка выполнения запроса в репозитории",
+			beforeTest: func(m substitutionMocks) {
+				m.recipeIngredientRepo.EXPECT().
+					GetById(gomock.Any(), recipeIngredientId).
+					Return(nil, fmt.Errorf("getting recipe ingredient err"))
+			},
+			wantErr: true,
+			errStr:  errors.New("getting substitutes of recipe ingredient: getting recipe ingredient err"),
+		}, // ошибка выполнения запроса в репозитории
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(m)
+			}
+
+			options, err := svc.GetForRecipeIngredient(context.Background(), recipeIngredientId)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tt.want, options)
+			}
+		})
+	}
+}
+
+func TestSubstitutionService_Apply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newSubstitutionService(ctrl)
+
+	saladId := uuid.UUID{1}
+	sourCream := &domain.Ingredient{ID: uuid.UUID{2}, Name: "sour cream", Calories: 200}
+	yogurt := &domain.Ingredient{ID: uuid.UUID{3}, Name: "greek yogurt", Calories: 60}
+	cucumber := &domain.Ingredient{ID: uuid.UUID{4}, Name: "cucumber", Calories: 15}
+	spoon := &domain.Measurement{ID: uuid.UUID{5}, Name: "spoon", Grams: 20}
+	substitution := &domain.Substitution{ID: uuid.UUID{6}, IngredientID: sourCream.ID, SubstituteID: yogurt.ID, Ratio: 1}
+	details := func() *domain.SaladDetails {
+		return &domain.SaladDetails{
+			Salad: &domain.Salad{ID: saladId},
+			Ingredients: []*domain.SaladIngredient{
+				{Ingredient: cucumber, Measurement: spoon, Amount: 5},
+				{Ingredient: sourCream, Measurement: spoon, Amount: 5},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		choices    []*domain.SubstitutionChoice
+		beforeTest func(m substitutionMocks)
+		want       *domain.RecipeExport
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name:    "замена пересчитывает калории",
+			choices: []*domain.SubstitutionChoice{{IngredientID: sourCream.ID, SubstitutionID: substitution.ID}},
+			beforeTest: func(m substitutionMocks) {
+				m.saladDetailsService.EXPECT().
+					GetById(gomock.Any(), saladId, nil).
+					Return(details(), nil)
+				m.substitutionRepo.EXPECT().
+					GetById(gomock.Any(), substitution.ID).
+					Return(substitution, nil)
+				m.ingredientRepo.EXPECT().
+					GetById(gomock.Any(), yogurt.ID).
+					Return(yogurt, nil)
+			},
+			want: &domain.RecipeExport{
+				SaladDetails: &domain.SaladDetails{
+					Salad: &domain.Salad{ID: saladId},
+					Ingredients: []*domain.SaladIngredient{
+						{Ingredient: cucumber, Measurement: spoon, Amount: 5},
+						{Ingredient: yogurt, Measurement: spoon, Amount: 5, Note: "instead of sour cream"},
+					},
+				},
+				Calories: 75,
+			},
+			wantErr: false,
+		}, // замена пересчитывает калории
+		{
+			name:    "ингредиента нет в рецепте",
+			choices: []*domain.SubstitutionChoice{{IngredientID: uuid.UUID{9}, SubstitutionID: substitution.ID}},
+			beforeTest: func(m substitutionMocks) {
+				m.saladDetailsService.EXPECT().
+					GetById(gomock.Any(), saladId, nil).
+					Return(details(), nil)
+			},
+			wantErr: true,
+			errStr: fmt.Errorf("applying substitutions: ingredient %s is not in recipe",
+				uuid.UUID{9}.String()),
+		}, // ингредиента нет в рецепте
+		{
+			name:    "замена для другого ингредиента",
+			choices: []*domain.SubstitutionChoice{{IngredientID: cucumber.ID, SubstitutionID: substitution.ID}},
+			beforeTest: func(m substitutionMocks) {
+				m.saladDetailsService.EXPECT().
+					GetById(gomock.Any(), saladId, nil).
+					Return(details(), nil)
+				m.substitutionRepo.EXPECT().
+					GetById(gomock.Any(), substitution.ID).
+					Return(substitution, nil)
+			},
+			wantErr: true,
+			errStr: fmt.Errorf("applying substitutions: substitution %s is not for ingredient %s",
+				substitution.ID.String(), cucumber.ID.String()),
+		}, // замена для другого ингредиента
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(m)
+			}
+
+			export, err := svc.Apply(context.Background(), saladId, tt.choices)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tt.want, export)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Mx1q/ppo_services/config"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/telemetry"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type exportedTestSpan struct {
+	Name         string            `json:"name"`
+	TraceID      string            `json:"traceId"`
+	SpanID       string            `json:"spanId"`
+	ParentSpanID string            `json:"parentSpanId"`
+	Attributes   map[string]string `json:"attributes"`
+	Status       struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"status"`
+}
+
+func decodeSpans(t *testing.T, buf *bytes.Buffer) []exportedTestSpan {
+	spans := make([]exportedTestSpan, 0)
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var span exportedTestSpan
+		require.NoError(t, json.Unmarshal([]byte(line), &span))
+		spans = append(spans, span)
+	}
+	return spans
+}
+
+func TestInstrumentedSaladService_GetById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	saladRepo := mocks.NewMockISaladRepository(ctrl)
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+
+	registry := telemetry.NewRegistry()
+	spansBuf := new(bytes.Buffer)
+	tel := telemetry.New(registry, telemetry.NewTracer(telemetry.NewStdoutExporter(spansBuf)))
+	svc := telemetry.NewInstrumentedSaladService(
+		services.NewSaladService(telemetry.NewInstrumentedSaladRepository(saladRepo, tel), logger, config.Default().Rating, config.Default().Pages),
+		tel)
+
+	saladId := uuid.New()
+	saladRepo.EXPECT().
+		GetById(gomock.Any(), saladId).
+		Return(&domain.Salad{ID: saladId}, nil)
+	saladRepo.EXPECT().
+		GetById(gomock.Any(), saladId).
+		Return(nil, fmt.Errorf("repo error"))
+
+	_, err := svc.GetById(context.Background(), saladId)
+	require.Nil(t, err)
+	_, err = svc.GetById(context.Background(), saladId)
+	require.NotNil(t, err)
+
+	spans := decodeSpans(t, spansBuf)
+	require.Len(t, spans, 4)
+	repoSpan, serviceSpan := spans[0], spans[1]
+	require.Equal(t, "ISaladRepository.GetById", repoSpan.Name)
+	require.Equal(t, "ISaladService.GetById", serviceSpan.Name)
+	require.Equal(t, serviceSpan.TraceID, repoSpan.TraceID)
+	require.Equal(t, serviceSpan.SpanID, repoSpan.ParentSpanID)
+	require.Empty(t, serviceSpan.ParentSpanID)
+	require.Equal(t, saladId.String(), serviceSpan.Attributes["id"])
+	require.Equal(t, telemetry.StatusOk, serviceSpan.Status.Code)
+	require.Equal(t, telemetry.StatusError, spans[3].Status.Code)
+	require.Equal(t, "getting salad by id: repo error", spans[3].Status.Message)
+	require.NotEqual(t, serviceSpan.TraceID, spans[3].TraceID)
+
+	server := httptest.NewServer(registry.Handler())
+	defer server.Close()
+	response, err := server.Client().Get(server.URL)
+	require.Nil(t, err)
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	require.Nil(t, err)
+
+	require.Contains(t, response.Header.Get("Content-Type"), "text/plain; version=0.0.4")
+	metrics := string(body)
+	require.Contains(t, metrics, "# TYPE ppo_calls_total counter\n")
+	require.Contains(t, metrics, `ppo_calls_total{interface="ISaladService",method="GetById"} 2`)
+	require.Contains(t, metrics, `ppo_call_errors_total{interface="ISaladRepository",method="GetById"} 1`)
+	require.Contains(t, metrics, "# TYPE ppo_call_duration_seconds histogram\n")
+	require.Contains(t, metrics, `ppo_call_duration_seconds_bucket{interface="ISaladService",method="GetById",le="+Inf"} 2`)
+	require.Contains(t, metrics, `ppo_call_duration_seconds_count{interface="ISaladRepository",method="GetById"} 2`)
+}
+
+func TestEntityAttr(t *testing.T) {
+	saladId := uuid.New()
+
+	tests := []struct {
+		name   string
+		entity interface{}
+		want   telemetry.Attribute
+	}{
+		{
+			name:   "указатель на сущность",
+			entity: &domain.Salad{ID: saladId},
+			want:   telemetry.Attribute{Key: "salad.id", Value: saladId.String()},
+		}, // указатель на сущность
+		{
+			name:   "nil указатель",
+			entity: (*domain.Salad)(nil),
+			want:   telemetry.Attribute{Key: "salad.id"},
+		}, // nil указатель
+		{
+			name:   "значение без ID",
+			entity: "salad",
+			want:   telemetry.Attribute{Key: "salad.id"},
+		}, // значение без ID
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, telemetry.EntityAttr("salad", tt.entity))
+		})
+	}
+}
+
+// TestInstrumentedDecorators_UpToDate fails when go generate ./telemetry would change
+// the generated decorators, so a new domain interface can't be left without them
+func TestInstrumentedDecorators_UpToDate(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "instrumented.go")
+	cmd := exec.Command("go", "run", "../telemetry/gen", "-domain", "../domain", "-out", out)
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	generated, err := os.ReadFile(out)
+	require.NoError(t, err)
+	committed, err := os.ReadFile("../telemetry/instrumented.go")
+	require.NoError(t, err)
+	require.Equal(t, string(generated), string(committed), "telemetry/instrumented.go is stale, run go generate ./telemetry")
+}
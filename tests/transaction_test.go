@@ -0,0 +1,246 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/memrepo"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newTxManagerMock(ctrl *gomock.Controller) *mocks.MockITransactionManager {
+	tx := mocks.NewMockITransaction(ctrl)
+	tx.EXPECT().
+		Commit(gomock.Any()).
+		Return(nil).
+		AnyTimes()
+	tx.EXPECT().
+		Rollback(gomock.Any()).
+		Return(nil).
+		AnyTimes()
+
+	txManager := mocks.NewMockITransactionManager(ctrl)
+	txManager.EXPECT().
+		Begin(gomock.Any()).
+		Return(tx, nil).
+		AnyTimes()
+	return txManager
+}
+
+// inTransaction matches contexts carrying a transaction
+type inTransaction struct{}
+
+func (inTransaction) Matches(x interface{}) bool {
+	ctx, ok := x.(context.Context)
+	if !ok {
+		return false
+	}
+	_, ok = domain.TransactionFromContext(ctx)
+	return ok
+}
+
+func (inTransaction) String() string {
+	return "is a context with a transaction"
+}
+
+func TestRunInTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	txManager := mocks.NewMockITransactionManager(ctrl)
+	tx := mocks.NewMockITransaction(ctrl)
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		fn         func(ctx context.Context) error
+		beforeTest func(txManager *mocks.MockITransactionManager, tx *mocks.MockITransaction)
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "успешное выполнение с фиксацией",
+			ctx:  context.Background(),
+			fn: func(ctx context.Context) error {
+				_, ok := domain.TransactionFromContext(ctx)
+				require.True(t, ok)
+				return nil
+			},
+			beforeTest: func(txManager *mocks.MockITransactionManager, tx *mocks.MockITransaction) {
+				txManager.EXPECT().
+					Begin(context.Background()).
+					Return(tx, nil)
+				tx.EXPECT().
+					Commit(context.Background()).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // успешное выполнение с фиксацией
+		{
+			name: "откат при ошибке",
+			ctx:  context.Background(),
+			fn: func(ctx context.Context) error {
+				return fmt.Errorf("fn err")
+			},
+			beforeTest: func(txManager *mocks.MockITransactionManager, tx *mocks.MockITransaction) {
+				txManager.EXPECT().
+					Begin(context.Background()).
+					Return(tx, nil)
+				tx.EXPECT().
+					Rollback(context.Background()).
+					Return(nil)
+			},
+			wantErr: true,
+			errStr:  errors.New("fn err"),
+		}, // откат при ошибке
+		{
+			name: "ошибка открытия транзакции",
+			ctx:  context.Background(),
+			fn: func(ctx context.Context) error {
+				return nil
+			},
+			beforeTest: func(txManager *mocks.MockITransactionManager, tx *mocks.MockITransaction) {
+				txManager.EXPECT().
+					Begin(context.Background()).
+					Return(nil, fmt.Errorf("begin err"))
+			},
+			wantErr: true,
+			errStr:  errors.New("beginning transaction: begin err"),
+		}, // ошибка открытия транзакции
+		{
+			name: "присоединение к внешней транзакции",
+			ctx:  domain.ContextWithTransaction(context.Background(), tx),
+			fn: func(ctx context.Context) error {
+				return nil
+			},
+			wantErr: false,
+		}, // присоединение к внешней транзакции
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(txManager, tx)
+			}
+
+			err := domain.RunInTransaction(tt.ctx, txManager, tt.fn)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestAfterTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name         string
+		fnErr        error
+		wantCommit   bool
+		wantRollback bool
+		wantErr      bool
+	}{
+		{
+			name:         "после фиксации",
+			wantCommit:   true,
+			wantRollback: false,
+			wantErr:      false,
+		}, // после фиксации
+		{
+			name:         "после отката",
+			fnErr:        fmt.Errorf("fn err"),
+			wantCommit:   false,
+			wantRollback: true,
+			wantErr:      true,
+		}, // после отката
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ran := 0
+			committed, rolledBack := false, false
+			err := domain.RunInTransaction(context.Background(), newTxManagerMock(ctrl), func(ctx context.Context) error {
+				domain.AfterTransaction(ctx, func() { ran++ })
+				err := domain.RunInTransaction(ctx, nil, func(ctx context.Context) error {
+					domain.AfterTransaction(ctx, func() { ran++ })
+					domain.AfterCommit(ctx, func() { committed = true })
+					domain.AfterRollback(ctx, func() { rolledBack = true })
+					return nil
+				})
+				require.Nil(t, err)
+				require.Zero(t, ran)
+				require.False(t, committed || rolledBack)
+				return tt.fnErr
+			})
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, 2, ran)
+			require.Equal(t, tt.wantCommit, committed)
+			require.Equal(t, tt.wantRollback, rolledBack)
+		})
+	}
+
+	ran, committed, rolledBack := false, false, false
+	domain.AfterTransaction(context.Background(), func() { ran = true })
+	domain.AfterCommit(context.Background(), func() { committed = true })
+	domain.AfterRollback(context.Background(), func() { rolledBack = true })
+	require.True(t, ran)
+	require.True(t, committed)
+	require.False(t, rolledBack)
+}
+
+func TestMemoryTransaction_RollbackKeepsOtherWrites(t *testing.T) {
+	ctx := context.Background()
+	store := memrepo.NewStore()
+	salads := memrepo.NewSaladRepository(store)
+	saladTypes := memrepo.NewSaladTypeRepository(store)
+
+	shared := &domain.Salad{AuthorID: uuid.New(), Name: "caesar"}
+	_, err := salads.Create(ctx, shared)
+	require.NoError(t, err)
+	saladType := &domain.SaladType{Name: "Мясные"}
+	require.NoError(t, saladTypes.Create(ctx, saladType))
+
+	failed := errors.New("failed")
+	var other *domain.Salad
+	err = domain.RunInTransaction(ctx, memrepo.NewTransactionManager(store), func(txCtx context.Context) error {
+		updated := *shared
+		updated.Description = "in transaction"
+		if err := salads.Update(txCtx, &updated); err != nil {
+			return err
+		}
+		if _, err := salads.Create(txCtx, &domain.Salad{AuthorID: uuid.New(), Name: "greek"}); err != nil {
+			return err
+		}
+		if err := saladTypes.Link(txCtx, shared.ID, saladType.ID); err != nil {
+			return err
+		}
+
+		// another caller writes while the transaction is open
+		other = &domain.Salad{AuthorID: uuid.New(), Name: "olivier"}
+		if _, err := salads.Create(ctx, other); err != nil {
+			return err
+		}
+		return failed
+	})
+	require.ErrorIs(t, err, failed)
+
+	stored, err := salads.GetById(ctx, shared.ID)
+	require.NoError(t, err)
+	require.Equal(t, shared, stored)
+	_, err = salads.GetById(ctx, other.ID)
+	require.NoError(t, err)
+
+	all, _, err := salads.GetAll(ctx, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	linked, err := saladTypes.GetAllBySaladId(ctx, shared.ID)
+	require.NoError(t, err)
+	require.Empty(t, linked)
+}
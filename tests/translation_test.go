@@ -0,0 +1,241 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newTranslationService(ctrl *gomock.Controller) (domain.ITranslationService, *mocks.MockITranslationRepository) {
+	translationRepo := mocks.NewMockITranslationRepository(ctrl)
+	return services.NewTranslationService(translationRepo, newTxManagerMock(ctrl), newLoggerMock(ctrl)), translationRepo
+}
+
+func TestLocaleChain(t *testing.T) {
+	require.Equal(t, []string{"en-GB", "en", "ru"}, domain.LocaleChain("en-GB", "ru", "en"))
+	require.Equal(t, []string{"zh-Hant-TW", "zh-Hant", "zh"}, domain.LocaleChain("zh-Hant-TW"))
+
+	_, ok := domain.LocalesFromContext(context.Background())
+	require.False(t, ok)
+
+	locales, ok := domain.LocalesFromContext(domain.ContextWithLocale(context.Background(), "ru-RU", "en"))
+	require.True(t, ok)
+	require.Equal(t, []string{"ru-RU", "ru", "en"}, locales)
+
+	require.Nil(t, domain.VerifyLocale("en-GB"))
+	require.Equal(t, `invalid locale "English"`, domain.VerifyLocale("English").Error())
+}
+
+func TestTranslationService_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, translationRepo := newTranslationService(ctrl)
+
+	ingredientId := uuid.UUID{1}
+	existing := &domain.Translation{ID: uuid.UUID{2}, EntityType: domain.TranslationEntityIngredient,
+		EntityID: ingredientId, Locale: "ru", Field: domain.TranslationFieldName, Text: "томат", Version: 3}
+
+	tests := []struct {
+		name        string
+		translation *domain.Translation
+		beforeTest  func(translationRepo mocks.MockITranslationRepository)
+		wantErr     bool
+		errStr      error
+	}{
+		{
+			name: "новый перевод",
+			translation: &domain.Translation{EntityType: domain.TranslationEntityIngredient,
+				EntityID: ingredientId, Locale: "en", Field: domain.TranslationFieldName, Text: "tomato"},
+			beforeTest: func(translationRepo mocks.MockITranslationRepository) {
+				translationRepo.EXPECT().
+					GetAllByEntity(gomock.Any(), domain.TranslationEntityIngredient, ingredientId).
+					Return([]*domain.Translation{existing}, nil)
+				translationRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(uuid.UUID{3}, nil)
+			},
+			wantErr: false,
+		}, // новый перевод
+		{
+			name: "замена перевода",
+			translation: &domain.Translation{EntityType: domain.TranslationEntityIngredient,
+				EntityID: ingredientId, Locale: "ru", Field: domain.TranslationFieldName, Text: "помидор"},
+			beforeTest: func(translationRepo mocks.MockITranslationRepository) {
+				translationRepo.EXPECT().
+					GetAllByEntity(gomock.Any(), domain.TranslationEntityIngredient, ingredientId).
+					Return([]*domain.Translation{existing}, nil)
+				translationRepo.EXPECT().
+					Update(gomock.Any(), &domain.Translation{ID: existing.ID, EntityType: domain.TranslationEntityIngredient,
+						EntityID: ingredientId, Locale: "ru", Field: domain.TranslationFieldName, Text: "помидор", Version: 3}).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // замена перевода
+		{
+			name: "поле без переводов",
+			translation: &domain.Translation{EntityType: domain.TranslationEntityIngredient,
+				EntityID: ingredientId, Locale: "en", Field: domain.TranslationFieldDescription, Text: "red"},
+			wantErr: true,
+			errStr:  errors.New("setting translation: field description of ingredient isn't translatable"),
+		}, // поле без переводов
+		{
+			name: "некорректная локаль",
+			translation: &domain.Translation{EntityType: domain.TranslationEntityIngredient,
+				EntityID: ingredientId, Locale: "EN", Field: domain.TranslationFieldName, Text: "tomato"},
+			wantErr: true,
+			errStr:  errors.New(`setting translation: invalid locale "EN"`),
+		}, // некорректная локаль
+		{
+			name: "неизвестная сущность",
+			translation: &domain.Translation{EntityType: "comment",
+				EntityID: ingredientId, Locale: "en", Field: domain.TranslationFieldName, Text: "text"},
+			wantErr: true,
+			errStr:  errors.New("setting translation: unknown entity type comment"),
+		}, // неизвестная сущность
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*translationRepo)
+			}
+
+			err := svc.Set(context.Background(), tt.translation)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+				require.NotEqual(t, uuid.Nil, tt.translation.ID)
+			}
+		})
+	}
+}
+
+func TestTranslationService_Localize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, translationRepo := newTranslationService(ctrl)
+
+	greek := &domain.SaladType{ID: uuid.UUID{1}, Name: "Greek", Description: "Feta and olives"}
+	caesar := &domain.SaladType{ID: uuid.UUID{2}, Name: "Caesar", Description: "Romaine"}
+	translations := []*domain.Translation{
+		{EntityID: greek.ID, Locale: "ru", Field: domain.TranslationFieldName, Text: "Греческий"},
+		{EntityID: greek.ID, Locale: "ru-RU", Field: domain.TranslationFieldDescription, Text: "Фета и оливки"},
+		{EntityID: greek.ID, Locale: "ru", Field: domain.TranslationFieldDescription, Text: "Брынза и оливки"},
+	}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		beforeTest func(translationRepo mocks.MockITranslationRepository)
+		want       []string
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "перевод по цепочке локалей",
+			ctx:  domain.ContextWithLocale(context.Background(), "ru-RU"),
+			beforeTest: func(translationRepo mocks.MockITranslationRepository) {
+				translationRepo.EXPECT().
+					GetAllByEntities(gomock.Any(), domain.TranslationEntitySaladType,
+						[]uuid.UUID{greek.ID, caesar.ID}, []string{"ru-RU", "ru"}).
+					Return(translations, nil)
+			},
+			want:    []string{"Греческий", "Фета и оливки", "Caesar", "Romaine"},
+			wantErr: false,
+		}, // перевод по цепочке локалей
+		{
+			name:    "без локали",
+			ctx:     context.Background(),
+			want:    []string{"Greek", "Feta and olives", "Caesar", "Romaine"},
+			wantErr: false,
+		}, // без локали
+		{
+			name: "ошибка выполнения запроса в репозитории",
+			ctx:  domain.ContextWithLocale(context.Background(), "ru"),
+			beforeTest: func(translationRepo mocks.MockITranslationRepository) {
+				translationRepo.EXPECT().
+					GetAllByEntities(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("getting translations err"))
+			},
+			want:    []string{"Greek", "Feta and olives", "Caesar", "Romaine"},
+			wantErr: true,
+			errStr:  errors.New("localizing salad_type: getting translations err"),
+		}, // ошибка выполнения запроса в репозитории
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*translationRepo)
+			}
+
+			saladTypes := []*domain.SaladType{{}, {}}
+			*saladTypes[0], *saladTypes[1] = *greek, *caesar
+			texts := []domain.TranslatableText{
+				{EntityID: greek.ID, Field: domain.TranslationFieldName, Text: &saladTypes[0].Name},
+				{EntityID: greek.ID, Field: domain.TranslationFieldDescription, Text: &saladTypes[0].Description},
+				{EntityID: caesar.ID, Field: domain.TranslationFieldName, Text: &saladTypes[1].Name},
+				{EntityID: caesar.ID, Field: domain.TranslationFieldDescription, Text: &saladTypes[1].Description},
+			}
+
+			err := svc.Localize(tt.ctx, domain.TranslationEntitySaladType, texts)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+			require.Equal(t, tt.want, []string{saladTypes[0].Name, saladTypes[0].Description,
+				saladTypes[1].Name, saladTypes[1].Description})
+		})
+	}
+}
+
+func TestTranslationService_GetMissing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, translationRepo := newTranslationService(ctrl)
+
+	missing := []*domain.MissingTranslation{
+		{EntityType: domain.TranslationEntityMeasurement, EntityID: uuid.UUID{1}, Field: domain.TranslationFieldName, Source: "cup"},
+	}
+	translationRepo.EXPECT().
+		GetMissing(gomock.Any(), domain.TranslationEntityMeasurement, "ru").
+		Return(missing, nil)
+
+	got, err := svc.GetMissing(context.Background(), domain.TranslationEntityMeasurement, "ru")
+	require.Nil(t, err)
+	require.Equal(t, missing, got)
+
+	_, err = svc.GetMissing(context.Background(), "user", "ru")
+	require.Equal(t, "getting missing translations: unknown entity type user", err.Error())
+}
+
+func TestLocalizedSaladService_GetById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	saladService := mocks.NewMockISaladService(ctrl)
+	translationService := mocks.NewMockITranslationService(ctrl)
+	svc := services.NewLocalizedSaladService(saladService, translationService)
+
+	saladId := uuid.UUID{1}
+	ctx := domain.ContextWithLocale(context.Background(), "en")
+	saladService.EXPECT().
+		GetById(ctx, saladId).
+		Return(&domain.Salad{ID: saladId, Name: "Цезарь", Description: "С курицей"}, nil)
+	translationService.EXPECT().
+		Localize(ctx, domain.TranslationEntitySalad, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, entityType string, texts []domain.TranslatableText) error {
+			*texts[0].Text = "Caesar"
+			return nil
+		})
+
+	salad, err := svc.GetById(ctx, saladId)
+	require.Nil(t, err)
+	require.Equal(t, &domain.Salad{ID: saladId, Name: "Caesar", Description: "С курицей"}, salad)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/Mx1q/ppo_services/config"
 	"github.com/Mx1q/ppo_services/domain"
 	"github.com/Mx1q/ppo_services/services"
 	"github.com/Mx1q/ppo_services/tests/mocks"
@@ -32,7 +33,7 @@ func TestUserService_Create(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewUserService(userRepo, logger)
+	svc := services.NewUserService(userRepo, logger, config.Default().Pages)
 
 	testId := uuid.New()
 
@@ -312,7 +313,7 @@ func TestUserService_DeleteById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewUserService(userRepo, logger)
+	svc := services.NewUserService(userRepo, logger, config.Default().Pages)
 
 	testId := uuid.New()
 
@@ -379,13 +380,13 @@ func TestUserService_GetAll(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewUserService(userRepo, logger)
+	svc := services.NewUserService(userRepo, logger, config.Default().Pages)
 
-	page := 1
+	page := &domain.PageRequest{Size: 10}
 
 	tests := []struct {
 		name       string
-		page       int
+		page       *domain.PageRequest
 		beforeTest func(userRepo mocks.MockIUserRepository)
 		expected   []*domain.User
 		wantErr    bool
@@ -434,7 +435,7 @@ func TestUserService_GetAll(t *testing.T) {
 								Address: "fourth@mail.ru",
 							},
 						},
-					}, nil)
+					}, &domain.PageInfo{Size: 10, Total: 4}, nil)
 			},
 			expected: []*domain.User{
 				{
@@ -482,11 +483,17 @@ func TestUserService_GetAll(t *testing.T) {
 			beforeTest: func(userRepo mocks.MockIUserRepository) {
 				userRepo.EXPECT().
 					GetAll(context.Background(), page).
-					Return(nil, fmt.Errorf("get all users err"))
+					Return(nil, nil, fmt.Errorf("get all users err"))
 			},
 			wantErr: true,
 			errStr:  errors.New("getting all users: get all users err"),
 		}, // ошибка получения всех пользователей в репозитории
+		{
+			name:    "неподдерживаемая сортировка",
+			page:    &domain.PageRequest{Sort: "rating"},
+			wantErr: true,
+			errStr:  errors.New("getting all users: unsupported sort rating"),
+		}, // неподдерживаемая сортировка
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -494,7 +501,7 @@ func TestUserService_GetAll(t *testing.T) {
 				tt.beforeTest(*userRepo)
 			}
 
-			users, err := svc.GetAll(context.Background(), tt.page)
+			users, _, err := svc.GetAll(context.Background(), tt.page)
 			if tt.wantErr {
 				require.Equal(t, err.Error(), tt.errStr.Error())
 			} else {
@@ -523,7 +530,7 @@ func TestUserService_GetById(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewUserService(userRepo, logger)
+	svc := services.NewUserService(userRepo, logger, config.Default().Pages)
 	testId := uuid.New()
 
 	tests := []struct {
@@ -608,7 +615,7 @@ func TestUserService_GetByUsername(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewUserService(userRepo, logger)
+	svc := services.NewUserService(userRepo, logger, config.Default().Pages)
 	testId := uuid.New()
 	testUsername := "username"
 
@@ -694,7 +701,7 @@ func TestUserService_Update(t *testing.T) {
 	logger.EXPECT().
 		Errorf(gomock.Any(), gomock.Any()).
 		AnyTimes()
-	svc := services.NewUserService(userRepo, logger)
+	svc := services.NewUserService(userRepo, logger, config.Default().Pages)
 	testId := uuid.New()
 
 	tests := []struct {
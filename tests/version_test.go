@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseETag(t *testing.T) {
+	tests := []struct {
+		name            string
+		tag             string
+		expectedVersion int
+		wantErr         bool
+	}{
+		{
+			name:            "сильный тег",
+			tag:             domain.ETag(3),
+			expectedVersion: 3,
+			wantErr:         false,
+		}, // сильный тег
+		{
+			name:            "слабый тег",
+			tag:             ` W/"12"`,
+			expectedVersion: 12,
+			wantErr:         false,
+		}, // слабый тег
+		{
+			name:    "тег без кавычек",
+			tag:     "3",
+			wantErr: true,
+		}, // тег без кавычек
+		{
+			name:    "нечисловая версия",
+			tag:     `"abc"`,
+			wantErr: true,
+		}, // нечисловая версия
+		{
+			name:    "нулевая версия",
+			tag:     `"0"`,
+			wantErr: true,
+		}, // нулевая версия
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := domain.ParseETag(tt.tag)
+			if tt.wantErr {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tt.expectedVersion, version)
+			}
+		})
+	}
+}
+
+func TestVersionConflictError(t *testing.T) {
+	var err error = &domain.VersionConflictError{Entity: "salad", ID: uuid.UUID{1}, Version: 2}
+	wrapped := fmt.Errorf("updating salad: %w", err)
+
+	require.True(t, errors.Is(wrapped, domain.ErrVersionConflict))
+
+	var conflict *domain.VersionConflictError
+	require.True(t, errors.As(wrapped, &conflict))
+	require.Equal(t, 2, conflict.Version)
+	require.Equal(t, "updating salad: salad 01000000-0000-0000-0000-000000000000: version 2 is stale", wrapped.Error())
+}
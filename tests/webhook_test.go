@@ -0,0 +1,472 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/Mx1q/ppo_services/services"
+	"github.com/Mx1q/ppo_services/tests/mocks"
+	"github.com/Mx1q/ppo_services/webhookrepo"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newWebhookLoggerMock(ctrl *gomock.Controller) *mocks.MockILogger {
+	logger := mocks.NewMockILogger(ctrl)
+	logger.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Warnf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	logger.EXPECT().
+		Errorf(gomock.Any(), gomock.Any()).
+		AnyTimes()
+	return logger
+}
+
+func TestWebhookService_CreateSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subscriptionRepo := mocks.NewMockIWebhookSubscriptionRepository(ctrl)
+	svc := services.NewWebhookService(subscriptionRepo, nil, nil, nil,
+		services.DefaultWebhookConfig, newWebhookLoggerMock(ctrl))
+
+	tests := []struct {
+		name         string
+		subscription *domain.WebhookSubscription
+		beforeTest   func(subscriptionRepo mocks.MockIWebhookSubscriptionRepository)
+		wantErr      bool
+		errStr       error
+	}{
+		{
+			name: "успешное создание с генерацией секрета",
+			subscription: &domain.WebhookSubscription{
+				URL:        "https://partner.example/hooks",
+				EventTypes: []string{domain.WebhookRecipePublished},
+				Active:     true,
+			},
+			beforeTest: func(subscriptionRepo mocks.MockIWebhookSubscriptionRepository) {
+				subscriptionRepo.EXPECT().
+					Create(context.Background(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, subscription *domain.WebhookSubscription) error {
+						require.NotEqual(t, uuid.Nil, subscription.ID)
+						require.Len(t, subscription.Secret, 64)
+						return nil
+					})
+			},
+			wantErr: false,
+		}, // успешное создание с генерацией секрета
+		{
+			name: "некорректный адрес",
+			subscription: &domain.WebhookSubscription{
+				URL:        "ftp://partner.example/hooks",
+				EventTypes: []string{domain.WebhookRecipePublished},
+			},
+			wantErr: true,
+			errStr:  fmt.Errorf("creating webhook subscription: invalid url"),
+		}, // некорректный адрес
+		{
+			name: "неизвестный тип события",
+			subscription: &domain.WebhookSubscription{
+				URL:        "https://partner.example/hooks",
+				EventTypes: []string{domain.SaladCreatedEvent},
+			},
+			wantErr: true,
+			errStr:  fmt.Errorf("creating webhook subscription: unknown event type salad.created"),
+		}, // неизвестный тип события
+		{
+			name: "ошибка репозитория",
+			subscription: &domain.WebhookSubscription{
+				URL:        "https://partner.example/hooks",
+				EventTypes: []string{domain.WebhookCommentAdded},
+				Secret:     "secret",
+			},
+			beforeTest: func(subscriptionRepo mocks.MockIWebhookSubscriptionRepository) {
+				subscriptionRepo.EXPECT().
+					Create(context.Background(), gomock.Any()).
+					Return(fmt.Errorf("repo error"))
+			},
+			wantErr: true,
+			errStr:  fmt.Errorf("creating webhook subscription: repo error"),
+		}, // ошибка репозитория
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*subscriptionRepo)
+			}
+
+			err := svc.CreateSubscription(context.Background(), tt.subscription)
+
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestWebhookService_UpdateSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subscriptionRepo := mocks.NewMockIWebhookSubscriptionRepository(ctrl)
+	svc := services.NewWebhookService(subscriptionRepo, nil, nil, nil,
+		services.DefaultWebhookConfig, newWebhookLoggerMock(ctrl))
+
+	subscriptionId := uuid.New()
+
+	tests := []struct {
+		name         string
+		subscription *domain.WebhookSubscription
+		beforeTest   func(subscriptionRepo mocks.MockIWebhookSubscriptionRepository)
+		wantErr      bool
+		errStr       error
+	}{
+		{
+			name: "сохранение секрета без нового",
+			subscription: &domain.WebhookSubscription{
+				ID:         subscriptionId,
+				URL:        "https://partner.example/hooks",
+				EventTypes: []string{domain.WebhookRecipePublished},
+				Version:    1,
+			},
+			beforeTest: func(subscriptionRepo mocks.MockIWebhookSubscriptionRepository) {
+				subscriptionRepo.EXPECT().
+					GetById(context.Background(), subscriptionId).
+					Return(&domain.WebhookSubscription{ID: subscriptionId, Secret: "stored", Version: 1}, nil)
+				subscriptionRepo.EXPECT().
+					Update(context.Background(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, subscription *domain.WebhookSubscription) error {
+						require.Equal(t, "stored", subscription.Secret)
+						return nil
+					})
+			},
+			wantErr: false,
+		}, // сохранение секрета без нового
+		{
+			name: "замена секрета",
+			subscription: &domain.WebhookSubscription{
+				ID:         subscriptionId,
+				URL:        "https://partner.example/hooks",
+				EventTypes: []string{domain.WebhookRecipePublished},
+				Secret:     "rotated",
+				Version:    1,
+			},
+			beforeTest: func(subscriptionRepo mocks.MockIWebhookSubscriptionRepository) {
+				subscriptionRepo.EXPECT().
+					Update(context.Background(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, subscription *domain.WebhookSubscription) error {
+						require.Equal(t, "rotated", subscription.Secret)
+						return nil
+					})
+			},
+			wantErr: false,
+		}, // замена секрета
+		{
+			name: "подписка не найдена",
+			subscription: &domain.WebhookSubscription{
+				ID:         subscriptionId,
+				URL:        "https://partner.example/hooks",
+				EventTypes: []string{domain.WebhookRecipePublished},
+			},
+			beforeTest: func(subscriptionRepo mocks.MockIWebhookSubscriptionRepository) {
+				subscriptionRepo.EXPECT().
+					GetById(context.Background(), subscriptionId).
+					Return(nil, fmt.Errorf("%w: %s", domain.ErrSubscriptionNotFound, subscriptionId))
+			},
+			wantErr: true,
+			errStr:  fmt.Errorf("updating webhook subscription: %w: %s", domain.ErrSubscriptionNotFound, subscriptionId),
+		}, // подписка не найдена
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest(*subscriptionRepo)
+			}
+
+			err := svc.UpdateSubscription(context.Background(), tt.subscription)
+
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+type webhookReceiver struct {
+	mu       sync.Mutex
+	secret   string
+	statuses []int
+	bodies   []map[string]interface{}
+	invalid  int
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !services.VerifyWebhookSignature(r.secret, req.Header.Get(domain.WebhookTimestampHeader), body,
+		req.Header.Get(domain.WebhookSignatureHeader)) {
+		r.invalid++
+	}
+	var decoded map[string]interface{}
+	_ = json.Unmarshal(body, &decoded)
+	r.bodies = append(r.bodies, decoded)
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status = r.statuses[0]
+		r.statuses = r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func TestWebhookService_Deliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := services.WebhookConfig{
+		MaxAttempts: 3,
+		BaseBackoff: time.Nanosecond,
+		MaxBackoff:  time.Nanosecond,
+		Timeout:     time.Second,
+	}
+	comment := &domain.Comment{ID: uuid.New(), SaladID: uuid.New(), Text: "tasty", Rating: 5}
+
+	tests := []struct {
+		name          string
+		statuses      []int
+		rounds        int
+		wantDelivered int
+		wantAttempts  int
+		wantStatus    string
+		wantDead      int
+	}{
+		{
+			name:          "доставка после повторов",
+			statuses:      []int{http.StatusInternalServerError, http.StatusBadGateway},
+			rounds:        4,
+			wantDelivered: 1,
+			wantAttempts:  3,
+			wantStatus:    domain.WebhookDeliveryDelivered,
+			wantDead:      0,
+		}, // доставка после повторов
+		{
+			name: "перенос в очередь недоставленных",
+			statuses: []int{http.StatusInternalServerError, http.StatusInternalServerError,
+				http.StatusInternalServerError},
+			rounds:        4,
+			wantDelivered: 0,
+			wantAttempts:  3,
+			wantStatus:    domain.WebhookDeliveryDead,
+			wantDead:      1,
+		}, // перенос в очередь недоставленных
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			receiver := &webhookReceiver{secret: "partner-secret", statuses: tt.statuses}
+			server := httptest.NewServer(receiver)
+			defer server.Close()
+
+			svc := services.NewWebhookService(
+				webhookrepo.NewMemorySubscriptionRepository(),
+				webhookrepo.NewMemoryDeliveryRepository(),
+				webhookrepo.NewMemoryDeadLetterRepository(),
+				server.Client(), config, newWebhookLoggerMock(ctrl))
+
+			subscription := &domain.WebhookSubscription{
+				URL:        server.URL,
+				EventTypes: []string{domain.WebhookCommentAdded},
+				Secret:     receiver.secret,
+				Active:     true,
+			}
+			require.Nil(t, svc.CreateSubscription(ctx, subscription))
+
+			require.Nil(t, svc.HandleEvent(ctx, &domain.SaladCreated{Salad: &domain.Salad{ID: comment.SaladID}}))
+			require.Nil(t, svc.HandleEvent(ctx, &domain.CommentAdded{Comment: comment}))
+
+			delivered := 0
+			for i := 0; i < tt.rounds; i++ {
+				n, err := svc.DeliverDue(ctx, 10)
+				require.Nil(t, err)
+				delivered += n
+			}
+			require.Equal(t, tt.wantDelivered, delivered)
+
+			deliveries, err := svc.GetDeliveries(ctx, subscription.ID)
+			require.Nil(t, err)
+			require.Len(t, deliveries, 1)
+			require.Equal(t, tt.wantStatus, deliveries[0].Status)
+
+			attempts, err := svc.GetAttempts(ctx, deliveries[0].ID)
+			require.Nil(t, err)
+			require.Len(t, attempts, tt.wantAttempts)
+			require.Equal(t, tt.statuses[0], attempts[0].StatusCode)
+
+			dead, err := svc.GetDeadLetters(ctx)
+			require.Nil(t, err)
+			require.Len(t, dead, tt.wantDead)
+
+			require.Zero(t, receiver.invalid)
+			require.Len(t, receiver.bodies, tt.wantAttempts)
+			require.Equal(t, domain.WebhookCommentAdded, receiver.bodies[0]["event"])
+			require.Equal(t, deliveries[0].ID.String(), receiver.bodies[0]["id"])
+		})
+	}
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	receiver := &webhookReceiver{secret: "secret", statuses: []int{http.StatusInternalServerError}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	svc := services.NewWebhookService(
+		webhookrepo.NewMemorySubscriptionRepository(),
+		webhookrepo.NewMemoryDeliveryRepository(),
+		webhookrepo.NewMemoryDeadLetterRepository(),
+		server.Client(),
+		services.WebhookConfig{MaxAttempts: 1, Timeout: time.Second},
+		newWebhookLoggerMock(ctrl))
+
+	subscription := &domain.WebhookSubscription{
+		URL:        server.URL,
+		EventTypes: []string{domain.WebhookRecipePublished},
+		Secret:     receiver.secret,
+		Active:     true,
+	}
+	require.Nil(t, svc.CreateSubscription(ctx, subscription))
+	require.Nil(t, svc.HandleEvent(ctx, &domain.RecipeStatusChanged{
+		RecipeID:  uuid.New(),
+		SaladID:   uuid.New(),
+		OldStatus: domain.ModerationSaladStatus,
+		NewStatus: domain.PublishedSaladStatus,
+	}))
+
+	n, err := svc.DeliverDue(ctx, 10)
+	require.Nil(t, err)
+	require.Zero(t, n)
+
+	dead, err := svc.GetDeadLetters(ctx)
+	require.Nil(t, err)
+	require.Len(t, dead, 1)
+
+	err = svc.Redeliver(ctx, uuid.New())
+	require.Error(t, err)
+
+	require.Nil(t, svc.Redeliver(ctx, dead[0].ID))
+	dead, err = svc.GetDeadLetters(ctx)
+	require.Nil(t, err)
+	require.Empty(t, dead)
+
+	n, err = svc.DeliverDue(ctx, 10)
+	require.Nil(t, err)
+	require.Equal(t, 1, n)
+	require.Zero(t, receiver.invalid)
+}
+
+func TestWebhookService_DeliverSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subscriptionRepo := mocks.NewMockIWebhookSubscriptionRepository(ctrl)
+	deliveryRepo := mocks.NewMockIWebhookDeliveryRepository(ctrl)
+	deadLetterRepo := mocks.NewMockIWebhookDeadLetterRepository(ctrl)
+	svc := services.NewWebhookService(subscriptionRepo, deliveryRepo, deadLetterRepo, nil,
+		services.DefaultWebhookConfig, newWebhookLoggerMock(ctrl))
+
+	subscriptionId := uuid.UUID{1}
+	dead := &domain.WebhookDelivery{ID: uuid.UUID{2}, SubscriptionID: subscriptionId, Status: domain.WebhookDeliveryDead}
+
+	tests := []struct {
+		name       string
+		beforeTest func()
+		wantErr    bool
+		errStr     error
+	}{
+		{
+			name: "подписка удалена",
+			beforeTest: func() {
+				subscriptionRepo.EXPECT().
+					GetById(gomock.Any(), subscriptionId).
+					Return(nil, fmt.Errorf("%w: %s", domain.ErrSubscriptionNotFound, subscriptionId))
+				deliveryRepo.EXPECT().
+					Update(gomock.Any(), dead).
+					Return(nil)
+				deadLetterRepo.EXPECT().
+					Create(gomock.Any(), dead).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // подписка удалена
+		{
+			name: "подписка отключена",
+			beforeTest: func() {
+				subscriptionRepo.EXPECT().
+					GetById(gomock.Any(), subscriptionId).
+					Return(&domain.WebhookSubscription{ID: subscriptionId}, nil)
+				deliveryRepo.EXPECT().
+					Update(gomock.Any(), dead).
+					Return(nil)
+				deadLetterRepo.EXPECT().
+					Create(gomock.Any(), dead).
+					Return(nil)
+			},
+			wantErr: false,
+		}, // подписка отключена
+		{
+			name: "ошибка чтения подписки",
+			beforeTest: func() {
+				subscriptionRepo.EXPECT().
+					GetById(gomock.Any(), subscriptionId).
+					Return(nil, fmt.Errorf("database is locked"))
+			},
+			wantErr: true,
+			errStr:  errors.New("delivering webhooks: database is locked"),
+		}, // ошибка чтения подписки
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deliveryRepo.EXPECT().
+				GetDue(gomock.Any(), gomock.Any(), 10).
+				Return([]*domain.WebhookDelivery{{
+					ID:             uuid.UUID{2},
+					SubscriptionID: subscriptionId,
+					Status:         domain.WebhookDeliveryPending,
+				}}, nil)
+			tt.beforeTest()
+
+			n, err := svc.DeliverDue(context.Background(), 10)
+			if tt.wantErr {
+				require.Equal(t, tt.errStr.Error(), err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+			require.Zero(t, n)
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package webhookrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/Mx1q/ppo_services/domain"
+	"github.com/google/uuid"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Memory repositories store copies of the entities, so callers can't change
+// the stored state without Update
+
+type MemorySubscriptionRepository struct {
+	mu            sync.RWMutex
+	subscriptions map[uuid.UUID]domain.WebhookSubscription
+}
+
+func NewMemorySubscriptionRepository() domain.IWebhookSubscriptionRepository {
+	return &MemorySubscriptionRepository{subscriptions: make(map[uuid.UUID]domain.WebhookSubscription)}
+}
+
+func copySubscription(subscription domain.WebhookSubscription) *domain.WebhookSubscription {
+	subscription.EventTypes = append([]string(nil), subscription.EventTypes...)
+	return &subscription
+}
+
+func (r *MemorySubscriptionRepository) Create(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subscriptions[subscription.ID]; ok {
+		return fmt.Errorf("webhook subscription %s already exists", subscription.ID.String())
+	}
+	subscription.Version = 1
+	r.subscriptions[subscription.ID] = *copySubscription(*subscription)
+	return nil
+}
+
+func (r *MemorySubscriptionRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscription, ok := r.subscriptions[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrSubscriptionNotFound, id.String())
+	}
+	return copySubscription(subscription), nil
+}
+
+func (r *MemorySubscriptionRepository) GetAll(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	return r.filter(func(*domain.WebhookSubscription) bool { return true }), nil
+}
+
+func (r *MemorySubscriptionRepository) GetAllByEventType(ctx context.Context, eventType string) ([]*domain.WebhookSubscription, error) {
+	return r.filter(func(subscription *domain.WebhookSubscription) bool {
+		for _, subscribed := range subscription.EventTypes {
+			if subscribed == eventType {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+func (r *MemorySubscriptionRepository) filter(match func(*domain.WebhookSubscription) bool) []*domain.WebhookSubscription {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscriptions := make([]*domain.WebhookSubscription, 0)
+	for _, stored := range r.subscriptions {
+		subscription := copySubscription(stored)
+		if match(subscription) {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+	})
+	return subscriptions
+}
+
+func (r *MemorySubscriptionRepository) Update(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.subscriptions[subscription.ID]
+	if !ok {
+		return fmt.Errorf("webhook subscription %s not found", subscription.ID.String())
+	}
+	if stored.Version != subscription.Version {
+		return &domain.VersionConflictError{Entity: "webhook subscription", ID: subscription.ID, Version: subscription.Version}
+	}
+	subscription.Version++
+	subscription.CreatedAt = stored.CreatedAt
+	r.subscriptions[subscription.ID] = *copySubscription(*subscription)
+	return nil
+}
+
+func (r *MemorySubscriptionRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subscriptions[id]; !ok {
+		return fmt.Errorf("webhook subscription %s not found", id.String())
+	}
+	delete(r.subscriptions, id)
+	return nil
+}
+
+type MemoryDeliveryRepository struct {
+	mu         sync.RWMutex
+	deliveries []*domain.WebhookDelivery
+	attempts   []*domain.WebhookAttempt
+}
+
+func NewMemoryDeliveryRepository() domain.IWebhookDeliveryRepository {
+	return &MemoryDeliveryRepository{}
+}
+
+func copyDelivery(delivery *domain.WebhookDelivery) *domain.WebhookDelivery {
+	copied := *delivery
+	return &copied
+}
+
+func (r *MemoryDeliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deliveries = append(r.deliveries, copyDelivery(delivery))
+	return nil
+}
+
+func (r *MemoryDeliveryRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, delivery := range r.deliveries {
+		if delivery.ID == id {
+			return copyDelivery(delivery), nil
+		}
+	}
+	return nil, fmt.Errorf("webhook delivery %s not found", id.String())
+}
+
+func (r *MemoryDeliveryRepository) GetAllBySubscriptionId(ctx context.Context, subscriptionId uuid.UUID) ([]*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := make([]*domain.WebhookDelivery, 0)
+	for _, delivery := range r.deliveries {
+		if delivery.SubscriptionID == subscriptionId {
+			deliveries = append(deliveries, copyDelivery(delivery))
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *MemoryDeliveryRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := make([]*domain.WebhookDelivery, 0)
+	for _, delivery := range r.deliveries {
+		if limit > 0 && len(deliveries) == limit {
+			break
+		}
+		if delivery.Status == domain.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) {
+			deliveries = append(deliveries, copyDelivery(delivery))
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *MemoryDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, stored := range r.deliveries {
+		if stored.ID == delivery.ID {
+			r.deliveries[i] = copyDelivery(delivery)
+			return nil
+		}
+	}
+	return fmt.Errorf("webhook delivery %s not found", delivery.ID.String())
+}
+
+func (r *MemoryDeliveryRepository) CreateAttempt(ctx context.Context, attempt *domain.WebhookAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *attempt
+	r.attempts = append(r.attempts, &copied)
+	return nil
+}
+
+func (r *MemoryDeliveryRepository) GetAttempts(ctx context.Context, deliveryId uuid.UUID) ([]*domain.WebhookAttempt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	attempts := make([]*domain.WebhookAttempt, 0)
+	for _, attempt := range r.attempts {
+		if attempt.DeliveryID == deliveryId {
+			copied := *attempt
+			attempts = append(attempts, &copied)
+		}
+	}
+	return attempts, nil
+}
+
+type MemoryDeadLetterRepository struct {
+	mu         sync.RWMutex
+	deliveries []*domain.WebhookDelivery
+}
+
+func NewMemoryDeadLetterRepository() domain.IWebhookDeadLetterRepository {
+	return &MemoryDeadLetterRepository{}
+}
+
+func (r *MemoryDeadLetterRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deliveries = append(r.deliveries, copyDelivery(delivery))
+	return nil
+}
+
+func (r *MemoryDeadLetterRepository) GetAll(ctx context.Context) ([]*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := make([]*domain.WebhookDelivery, 0, len(r.deliveries))
+	for _, delivery := range r.deliveries {
+		deliveries = append(deliveries, copyDelivery(delivery))
+	}
+	return deliveries, nil
+}
+
+func (r *MemoryDeadLetterRepository) DeleteById(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, delivery := range r.deliveries {
+		if delivery.ID == id {
+			r.deliveries = append(r.deliveries[:i], r.deliveries[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("webhook dead letter %s not found", id.String())
+}